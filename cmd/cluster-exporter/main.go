@@ -0,0 +1,161 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"go.uber.org/zap"
+
+	"k8c.io/kubermatic/v2/pkg/cluster/export"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
+
+	"k8s.io/client-go/tools/clientcmd"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const usage = `Usage: cluster-exporter export|import [flags]
+
+Exports a cluster together with its certificate authorities and addons from a seed,
+or imports such an export into another seed. The secrets of the export are encrypted
+with the AES-256 key in the key file, which can be created with
+"head -c 32 /dev/urandom | base64".
+
+Flags:
+`
+
+func main() {
+	var (
+		kubeconfig  string
+		keyFile     string
+		clusterName string
+		file        string
+	)
+
+	flags := flag.NewFlagSet("cluster-exporter", flag.ExitOnError)
+	flags.StringVar(&kubeconfig, "kubeconfig", "", "Path to the kubeconfig of the seed.")
+	flags.StringVar(&keyFile, "encryption-key-file", "", "File containing the base64 encoded AES-256 key the secrets of the export are encrypted with.")
+	flags.StringVar(&clusterName, "cluster", "", "Name of the cluster to export.")
+	flags.StringVar(&file, "file", "", "File the export is written to or read from. Defaults to stdout or stdin.")
+	flags.Usage = func() {
+		fmt.Fprint(flags.Output(), usage)
+		flags.PrintDefaults()
+	}
+
+	if len(os.Args) < 2 {
+		flags.Usage()
+		os.Exit(2)
+	}
+	command := os.Args[1]
+	if err := flags.Parse(os.Args[2:]); err != nil {
+		os.Exit(2)
+	}
+
+	log := kubermaticlog.New(false, kubermaticlog.FormatConsole).Sugar()
+
+	if keyFile == "" {
+		log.Fatal("-encryption-key-file is required, the export contains the private keys of the cluster")
+	}
+	rawKey, err := ioutil.ReadFile(keyFile)
+	if err != nil {
+		log.Fatalw("Failed to read the encryption key", zap.Error(err))
+	}
+	key, err := export.ParseKey(rawKey)
+	if err != nil {
+		log.Fatalw("Invalid encryption key", zap.Error(err))
+	}
+
+	config, err := clientcmd.BuildConfigFromFlags("", kubeconfig)
+	if err != nil {
+		log.Fatalw("Failed to load the kubeconfig", zap.Error(err))
+	}
+	client, err := ctrlruntimeclient.New(config, ctrlruntimeclient.Options{})
+	if err != nil {
+		log.Fatalw("Failed to create the client", zap.Error(err))
+	}
+
+	ctx := context.Background()
+	switch command {
+	case "export":
+		if clusterName == "" {
+			log.Fatal("-cluster is required")
+		}
+		if err := exportCluster(ctx, client, clusterName, key, file); err != nil {
+			log.Fatalw("Failed to export the cluster", zap.Error(err))
+		}
+	case "import":
+		cluster, err := importCluster(ctx, client, key, file)
+		if err != nil {
+			log.Fatalw("Failed to import the cluster", zap.Error(err))
+		}
+		log.Infow("Imported the cluster", "cluster", cluster.Name)
+	default:
+		flags.Usage()
+		os.Exit(2)
+	}
+}
+
+func exportCluster(ctx context.Context, client ctrlruntimeclient.Client, clusterName string, key []byte, file string) error {
+	clusterExport, err := export.New(ctx, client, clusterName)
+	if err != nil {
+		return err
+	}
+	if err := clusterExport.Encrypt(key); err != nil {
+		return fmt.Errorf("failed to encrypt the export: %v", err)
+	}
+	data, err := clusterExport.Marshal()
+	if err != nil {
+		return fmt.Errorf("failed to marshal the export: %v", err)
+	}
+
+	if file == "" {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	return ioutil.WriteFile(file, data, 0600)
+}
+
+func importCluster(ctx context.Context, client ctrlruntimeclient.Client, key []byte, file string) (*kubermaticv1.Cluster, error) {
+	var (
+		data []byte
+		err  error
+	)
+	if file == "" {
+		data, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		data, err = ioutil.ReadFile(file)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the export: %v", err)
+	}
+
+	clusterExport, err := export.Unmarshal(data)
+	if err != nil {
+		return nil, err
+	}
+	if clusterExport.Encrypted {
+		if err := clusterExport.Decrypt(key); err != nil {
+			return nil, fmt.Errorf("failed to decrypt the export: %v", err)
+		}
+	}
+	return export.Import(ctx, client, clusterExport)
+}
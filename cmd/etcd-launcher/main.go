@@ -84,7 +84,9 @@ func main() {
 		log.Panicw("get in-cluster client", zap.Error(err))
 	}
 
-	k8cCluster, err := getK8cCluster(clusterClient, strings.ReplaceAll(e.namespace, "cluster-", ""), log)
+	// the token is the name of the cluster, which cannot be derived from the namespace as its
+	// prefix is configurable
+	k8cCluster, err := getK8cCluster(clusterClient, e.token, log)
 	if err != nil {
 		log.Panicw("get user cluster", zap.Error(err))
 	}
@@ -307,7 +309,7 @@ func (e *etcdCluster) parseConfigFlags() error {
 	flag.StringVar(&e.podName, "pod-name", "", "name of this etcd pod")
 	flag.StringVar(&e.podIP, "pod-ip", "", "IP address of this etcd pod")
 	flag.StringVar(&e.etcdctlAPIVersion, "api-version", defaultEtcdctlAPIVersion, "etcdctl API version")
-	flag.StringVar(&e.token, "token", "", "etcd database token")
+	flag.StringVar(&e.token, "token", "", "etcd database token, which is the name of the user cluster")
 	flag.BoolVar(&e.enableCorruptionCheck, "enable-corruption-check", false, "enable etcd experimental corruption check")
 	flag.Parse()
 
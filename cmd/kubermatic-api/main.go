@@ -39,6 +39,7 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
 	"time"
@@ -59,6 +60,7 @@ import (
 	"k8c.io/kubermatic/v2/pkg/features"
 	"k8c.io/kubermatic/v2/pkg/handler"
 	"k8c.io/kubermatic/v2/pkg/handler/auth"
+	providercommon "k8c.io/kubermatic/v2/pkg/handler/common/provider"
 	"k8c.io/kubermatic/v2/pkg/handler/v1/common"
 	v2 "k8c.io/kubermatic/v2/pkg/handler/v2"
 	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
@@ -66,6 +68,7 @@ import (
 	"k8c.io/kubermatic/v2/pkg/pprof"
 	"k8c.io/kubermatic/v2/pkg/provider"
 	kubernetesprovider "k8c.io/kubermatic/v2/pkg/provider/kubernetes"
+	"k8c.io/kubermatic/v2/pkg/provider/vault"
 	"k8c.io/kubermatic/v2/pkg/serviceaccount"
 	"k8c.io/kubermatic/v2/pkg/util/cli"
 	"k8c.io/kubermatic/v2/pkg/version"
@@ -104,7 +107,17 @@ func main() {
 	}()
 	kubermaticlog.Logger = log
 
-	ctx := context.Background()
+	providercommon.SetHetznerRequestTimeout(options.hetznerRequestTimeout)
+
+	var secretStore provider.SecretStore
+	if options.vault.Address != "" {
+		secretStore, err = vault.New(options.vault)
+		if err != nil {
+			log.Fatalw("Failed to create the Vault secret store", zap.Error(err))
+		}
+	}
+
+	ctx := provider.WithSecretStore(context.Background(), secretStore)
 	cli.Hello(log, "API", options.log.Debug, &options.versions)
 
 	if err := clusterv1alpha1.AddToScheme(scheme.Scheme); err != nil {
@@ -146,7 +159,13 @@ func main() {
 
 	go metricspkg.ServeForever(options.internalAddr, "/metrics")
 	log.Infow("the API server listening", "listenAddress", options.listenAddress)
-	log.Fatalw("failed to start API server", "error", http.ListenAndServe(options.listenAddress, handlers.CombinedLoggingHandler(os.Stdout, apiHandler)))
+	server := &http.Server{
+		Addr:    options.listenAddress,
+		Handler: handlers.CombinedLoggingHandler(os.Stdout, apiHandler),
+		// requests inherit the secret store from the base context
+		BaseContext: func(net.Listener) context.Context { return ctx },
+	}
+	log.Fatalw("failed to start API server", "error", server.ListenAndServe())
 }
 
 func createInitProviders(ctx context.Context, options serverRunOptions) (providers, error) {
@@ -422,6 +441,7 @@ func createAPIHandler(options serverRunOptions, prov providers, oidcIssuerVerifi
 		ConstraintTemplateProvider:            prov.constraintTemplateProvider,
 		ConstraintProvider:                    prov.constraintProvider,
 		PrivilegedConstraintProvider:          prov.privilegedConstraintProvider,
+		HetznerSizeCache:                      providercommon.NewHetznerSizeCache(options.hetznerSizeCacheTTL),
 		Versions:                              options.versions,
 	}
 
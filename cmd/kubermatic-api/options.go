@@ -21,11 +21,14 @@ import (
 	"flag"
 	"fmt"
 	"strings"
+	"time"
 
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
 	"k8c.io/kubermatic/v2/pkg/features"
+	providercommon "k8c.io/kubermatic/v2/pkg/handler/common/provider"
 	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
 	"k8c.io/kubermatic/v2/pkg/provider"
+	"k8c.io/kubermatic/v2/pkg/provider/vault"
 	"k8c.io/kubermatic/v2/pkg/resources/certificates"
 	"k8c.io/kubermatic/v2/pkg/serviceaccount"
 	"k8c.io/kubermatic/v2/pkg/version/kubermatic"
@@ -66,6 +69,13 @@ type serverRunOptions struct {
 	//service account configuration
 	serviceAccountSigningKey string
 
+	// hetznerSizeCacheTTL is the duration for which the Hetzner sizes are cached
+	hetznerSizeCacheTTL time.Duration
+	// hetznerRequestTimeout bounds every request to the Hetzner API
+	hetznerRequestTimeout time.Duration
+	// vault configures the Vault server credentials secrets can reference
+	vault vault.Options
+
 	featureGates features.FeatureGate
 	versions     kubermatic.Versions
 }
@@ -107,6 +117,9 @@ func newServerRunOptions() (serverRunOptions, error) {
 	flag.StringVar(&rawExposeStrategy, "expose-strategy", "NodePort", "The strategy to expose the controlplane with, either \"NodePort\" which creates NodePorts with a \"nodeport-proxy.k8s.io/expose: true\" annotation or \"LoadBalancer\", which creates a LoadBalancer")
 	flag.BoolVar(&s.dynamicPresets, "dynamic-presets", false, "Whether to enable dynamic presets")
 	flag.StringVar(&s.namespace, "namespace", "kubermatic", "The namespace kubermatic runs in, uses to determine where to look for datacenter custom resources")
+	flag.DurationVar(&s.hetznerSizeCacheTTL, "hetzner-size-cache-ttl", providercommon.DefaultHetznerSizeCacheTTL, "The duration for which the Hetzner sizes are cached per token, 0 disables the cache")
+	flag.DurationVar(&s.hetznerRequestTimeout, "hetzner-request-timeout", providercommon.DefaultHetznerRequestTimeout, "The timeout of requests to the Hetzner API, 0 disables the timeout")
+	s.vault.AddFlags(flag.CommandLine)
 	addFlags(flag.CommandLine)
 	flag.Parse()
 
@@ -138,6 +151,12 @@ func (o serverRunOptions) validate() error {
 	if err := serviceaccount.ValidateKey([]byte(o.serviceAccountSigningKey)); err != nil {
 		return fmt.Errorf("the service-account-signing-key is incorrect due to error: %v", err)
 	}
+	if o.hetznerSizeCacheTTL < 0 {
+		return fmt.Errorf("the hetzner-size-cache-ttl must not be negative, got %v", o.hetznerSizeCacheTTL)
+	}
+	if o.hetznerRequestTimeout < 0 {
+		return fmt.Errorf("the hetzner-request-timeout must not be negative, got %v", o.hetznerRequestTimeout)
+	}
 
 	return nil
 }
@@ -22,6 +22,10 @@ import (
 	"io/ioutil"
 	"time"
 
+	"github.com/Masterminds/semver/v3"
+
+	addonutils "k8c.io/kubermatic/v2/pkg/addon"
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
 	"k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/addon"
 	"k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/addoninstaller"
 	backupcontroller "k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/backup"
@@ -39,6 +43,7 @@ import (
 	updatecontroller "k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/update"
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
 	"k8c.io/kubermatic/v2/pkg/features"
+	"k8c.io/kubermatic/v2/pkg/resources/resourcequota"
 	"k8c.io/kubermatic/v2/pkg/version"
 
 	corev1 "k8s.io/api/core/v1"
@@ -100,9 +105,11 @@ func createClusterComponentDefaulter(ctrlCtx *controllerContext) error {
 				Replicas: utilpointer.Int32Ptr(int32(ctrlCtx.runOptions.controllerManagerDefaultReplicas)),
 			},
 		},
-		Scheduler: kubermaticv1.ControllerSettings{
-			DeploymentSettings: kubermaticv1.DeploymentSettings{
-				Replicas: utilpointer.Int32Ptr(int32(ctrlCtx.runOptions.schedulerDefaultReplicas)),
+		Scheduler: kubermaticv1.SchedulerSettings{
+			ControllerSettings: kubermaticv1.ControllerSettings{
+				DeploymentSettings: kubermaticv1.DeploymentSettings{
+					Replicas: utilpointer.Int32Ptr(int32(ctrlCtx.runOptions.schedulerDefaultReplicas)),
+				},
 			},
 		},
 	}
@@ -137,6 +144,24 @@ func createKubernetesController(ctrlCtx *controllerContext) error {
 		return fmt.Errorf("failed to parse %s as duration: %v", ctrlCtx.runOptions.backupInterval, err)
 	}
 
+	updateManager, err := version.NewFromFiles(ctrlCtx.runOptions.versionsFile, ctrlCtx.runOptions.updatesFile)
+	if err != nil {
+		return fmt.Errorf("failed to create update manager: %v", err)
+	}
+
+	var resourceQuotaPlans resourcequota.Plans
+	if ctrlCtx.runOptions.clusterResourceQuotaPlansFile != "" {
+		resourceQuotaPlans, err = resourcequota.LoadPlans(ctrlCtx.runOptions.clusterResourceQuotaPlansFile)
+		if err != nil {
+			return fmt.Errorf("failed to load cluster resource quota plans: %v", err)
+		}
+	}
+
+	var phaseNotifier *kubernetescontroller.PhaseNotifier
+	if ctrlCtx.runOptions.clusterPhaseWebhookURL != "" {
+		phaseNotifier = kubernetescontroller.NewPhaseNotifier(ctrlCtx.log, ctrlCtx.runOptions.clusterPhaseWebhookURL, ctrlCtx.runOptions.clusterPhaseWebhookTimeout)
+	}
+
 	return kubernetescontroller.Add(
 		ctrlCtx.mgr,
 		ctrlCtx.log,
@@ -147,6 +172,7 @@ func createKubernetesController(ctrlCtx *controllerContext) error {
 		ctrlCtx.clientProvider,
 		ctrlCtx.runOptions.overwriteRegistry,
 		ctrlCtx.runOptions.nodePortRange,
+		ctrlCtx.runOptions.seedNodePortRange,
 		ctrlCtx.runOptions.nodeAccessNetwork,
 		ctrlCtx.runOptions.etcdDiskSize,
 		ctrlCtx.runOptions.monitoringScrapeAnnotationPrefix,
@@ -157,8 +183,17 @@ func createKubernetesController(ctrlCtx *controllerContext) error {
 		ctrlCtx.dockerPullConfigJSON,
 		ctrlCtx.runOptions.nodeLocalDNSCacheEnabled(),
 		ctrlCtx.runOptions.concurrentClusterUpdate,
+		ctrlCtx.runOptions.concurrentClusterLaunches,
 		ctrlCtx.runOptions.enableEtcdBackupRestoreController,
 		backupInterval,
+		ctrlCtx.runOptions.clusterLaunchTimeout,
+		ctrlCtx.runOptions.addonsReadyTimeout,
+		updateManager,
+		ctrlCtx.runOptions.clusterControllerDryRun,
+		ctrlCtx.runOptions.apiserverURLTemplate,
+		resourceQuotaPlans,
+		ctrlCtx.runOptions.namespacePrefix,
+		ctrlCtx.runOptions.clusterResyncPeriods,
 		ctrlCtx.runOptions.oidcIssuerURL,
 		ctrlCtx.runOptions.oidcIssuerClientID,
 		ctrlCtx.runOptions.kubermaticImage,
@@ -166,11 +201,16 @@ func createKubernetesController(ctrlCtx *controllerContext) error {
 		ctrlCtx.runOptions.dnatControllerImage,
 		ctrlCtx.runOptions.tunnelingAgentIP.String(),
 		ctrlCtx.runOptions.caBundle,
+		ctrlCtx.runOptions.rootCASigningCA,
+		phaseNotifier,
 		kubernetescontroller.Features{
 			VPA:                          ctrlCtx.runOptions.featureGates.Enabled(features.VerticalPodAutoscaler),
 			EtcdDataCorruptionChecks:     ctrlCtx.runOptions.featureGates.Enabled(features.EtcdDataCorruptionChecks),
 			KubernetesOIDCAuthentication: ctrlCtx.runOptions.featureGates.Enabled(features.OpenIDAuthPlugin),
 			EtcdLauncher:                 ctrlCtx.runOptions.featureGates.Enabled(features.EtcdLauncher),
+			EtcdBackupRestore:            ctrlCtx.runOptions.enableEtcdBackupRestoreController,
+			DriftDetection:               ctrlCtx.runOptions.clusterDriftDetection,
+			NodePortAllocation:           ctrlCtx.runOptions.clusterNodePortAllocation,
 		},
 		ctrlCtx.versions,
 	)
@@ -328,26 +368,69 @@ func createUpdateController(ctrlCtx *controllerContext) error {
 }
 
 func createAddonController(ctrlCtx *controllerContext) error {
+	addonVariables := map[string]interface{}{
+		"openvpn": map[string]interface{}{
+			"NodeAccessNetwork": ctrlCtx.runOptions.nodeAccessNetwork,
+		},
+	}
+
+	// Broken addon templates would otherwise only surface once the addons
+	// get installed into the first cluster
+	if err := validateAddonTemplates(ctrlCtx, addonVariables); err != nil {
+		return err
+	}
+
 	return addon.Add(
 		ctrlCtx.mgr,
 		ctrlCtx.log,
 		ctrlCtx.runOptions.workerCount,
 		ctrlCtx.runOptions.workerName,
 		ctrlCtx.runOptions.addonEnforceInterval,
-		map[string]interface{}{ // addonVariables
-			"openvpn": map[string]interface{}{
-				"NodeAccessNetwork": ctrlCtx.runOptions.nodeAccessNetwork,
-			},
-		},
+		addonVariables,
 		ctrlCtx.runOptions.kubernetesAddonsPath,
 		ctrlCtx.runOptions.overwriteRegistry,
 		ctrlCtx.runOptions.nodeLocalDNSCacheEnabled(),
 		ctrlCtx.clientProvider,
 		ctrlCtx.versions,
+		ctrlCtx.runOptions.addonManifestsAllowedHosts,
 	)
 }
 
+// validateAddonTemplates renders all configured addons for every configured version.
+func validateAddonTemplates(ctrlCtx *controllerContext, addonVariables map[string]interface{}) error {
+	updateManager, err := version.NewFromFiles(ctrlCtx.runOptions.versionsFile, ctrlCtx.runOptions.updatesFile)
+	if err != nil {
+		return fmt.Errorf("failed to create update manager: %v", err)
+	}
+	versions, err := updateManager.GetVersions(apiv1.KubernetesClusterType)
+	if err != nil {
+		return fmt.Errorf("failed to get versions: %v", err)
+	}
+
+	var semvers []*semver.Version
+	for _, v := range versions {
+		semvers = append(semvers, v.Version)
+	}
+
+	if err := addonutils.ValidateTemplates(
+		ctrlCtx.runOptions.overwriteRegistry,
+		ctrlCtx.runOptions.kubernetesAddonsPath,
+		ctrlCtx.runOptions.kubernetesAddons.Items,
+		semvers,
+		addonVariables,
+	); err != nil {
+		return fmt.Errorf("invalid addon templates: %v", err)
+	}
+
+	return nil
+}
+
 func createAddonInstallerController(ctrlCtx *controllerContext) error {
+	updateManager, err := version.NewFromFiles(ctrlCtx.runOptions.versionsFile, ctrlCtx.runOptions.updatesFile)
+	if err != nil {
+		return fmt.Errorf("failed to create update manager: %v", err)
+	}
+
 	return addoninstaller.Add(
 		ctrlCtx.log,
 		ctrlCtx.mgr,
@@ -355,6 +438,7 @@ func createAddonInstallerController(ctrlCtx *controllerContext) error {
 		ctrlCtx.runOptions.workerName,
 		ctrlCtx.runOptions.kubernetesAddons,
 		ctrlCtx.versions,
+		updateManager,
 	)
 }
 
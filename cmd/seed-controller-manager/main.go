@@ -36,6 +36,8 @@ import (
 	"k8c.io/kubermatic/v2/pkg/metrics"
 	metricserver "k8c.io/kubermatic/v2/pkg/metrics/server"
 	"k8c.io/kubermatic/v2/pkg/pprof"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	"k8c.io/kubermatic/v2/pkg/provider/vault"
 	"k8c.io/kubermatic/v2/pkg/util/cli"
 	"k8c.io/kubermatic/v2/pkg/version/kubermatic"
 	clustermutation "k8c.io/kubermatic/v2/pkg/webhook/cluster/mutation"
@@ -83,6 +85,14 @@ func main() {
 	versions := kubermatic.NewDefaultVersions()
 	cli.Hello(log, "Seed Controller-Manager", logOpts.Debug, &versions)
 
+	var secretStore provider.SecretStore
+	if options.vault.Address != "" {
+		secretStore, err = vault.New(options.vault)
+		if err != nil {
+			log.Fatalw("Failed to create the Vault secret store", zap.Error(err))
+		}
+	}
+
 	// Set the logger used by sigs.k8s.io/controller-runtime
 	ctrlruntimelog.Log = ctrlruntimelog.NewDelegatingLogger(zapr.NewLogger(rawLog).WithName("controller_runtime"))
 
@@ -143,7 +153,7 @@ Please install the VerticalPodAutoscaler according to the documentation: https:/
 		}
 	}
 
-	rootCtx := context.Background()
+	rootCtx := provider.WithSecretStore(context.Background(), secretStore)
 	seedGetter, err := seedGetterFactory(rootCtx, mgr.GetClient(), options)
 	if err != nil {
 		log.Fatalw("Unable to create the seed factory", zap.Error(err))
@@ -172,7 +182,7 @@ Please install the VerticalPodAutoscaler according to the documentation: https:/
 		// Setup the admission handler for kubermatic Seed CRDs
 		h.SetupWebhookWithManager(mgr)
 		// Setup the validation admission handler for kubermatic Cluster CRDs
-		clustervalidation.NewAdmissionHandler(mgr.GetClient(), options.featureGates).SetupWebhookWithManager(mgr)
+		clustervalidation.NewAdmissionHandler(mgr.GetClient(), options.featureGates, options.defaultAddonNames()).SetupWebhookWithManager(mgr)
 		// Setup the mutation admission handler for kubermatic Cluster CRDs
 		clustermutation.NewAdmissionHandler().SetupWebhookWithManager(mgr)
 	}
@@ -200,13 +210,15 @@ Please install the VerticalPodAutoscaler according to the documentation: https:/
 	collectors.MustRegisterClusterCollector(prometheus.DefaultRegisterer, ctrlCtx.mgr.GetAPIReader())
 	log.Debug("Starting addons collector")
 	collectors.MustRegisterAddonCollector(prometheus.DefaultRegisterer, ctrlCtx.mgr.GetAPIReader())
+	log.Debug("Starting NodePort collector")
+	collectors.MustRegisterNodePortCollector(prometheus.DefaultRegisterer, ctrlCtx.mgr.GetAPIReader(), options.seedNodePortRange)
 
 	if err := mgr.Add(metricserver.New(options.internalAddr)); err != nil {
 		log.Fatalw("failed to add metrics server", zap.Error(err))
 	}
 
 	log.Info("starting the seed-controller-manager...")
-	if err := mgr.Start(ctrlruntime.SetupSignalHandler()); err != nil {
+	if err := mgr.Start(provider.WithSecretStore(ctrlruntime.SetupSignalHandler(), secretStore)); err != nil {
 		log.Fatalw("problem running manager", zap.Error(err))
 	}
 }
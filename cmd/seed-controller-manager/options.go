@@ -26,17 +26,24 @@ import (
 	"net/url"
 	"path"
 	"strings"
+	"time"
 
 	"go.uber.org/zap"
 
+	addonutils "k8c.io/kubermatic/v2/pkg/addon"
 	"k8c.io/kubermatic/v2/pkg/cluster/client"
 	"k8c.io/kubermatic/v2/pkg/controller/operator/common"
 	backupcontroller "k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/backup"
+	kubernetescontroller "k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/kubernetes"
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
 	"k8c.io/kubermatic/v2/pkg/features"
 	"k8c.io/kubermatic/v2/pkg/provider"
+	kubernetesprovider "k8c.io/kubermatic/v2/pkg/provider/kubernetes"
+	"k8c.io/kubermatic/v2/pkg/provider/vault"
 	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/resources/address"
 	"k8c.io/kubermatic/v2/pkg/resources/certificates"
+	"k8c.io/kubermatic/v2/pkg/resources/certificates/triple"
 	"k8c.io/kubermatic/v2/pkg/util/flagopts"
 	"k8c.io/kubermatic/v2/pkg/version/kubermatic"
 	"k8c.io/kubermatic/v2/pkg/webhook"
@@ -44,6 +51,8 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	knet "k8s.io/apimachinery/pkg/util/net"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/yaml"
 )
@@ -61,6 +70,7 @@ type controllerRunOptions struct {
 	workerCount                                      int
 	overwriteRegistry                                string
 	nodePortRange                                    string
+	seedNodePortRange                                knet.PortRange
 	nodeAccessNetwork                                string
 	kubernetesAddonsPath                             string
 	kubernetesAddons                                 kubermaticv1.AddonList
@@ -87,8 +97,23 @@ type controllerRunOptions struct {
 	schedulerDefaultReplicas                         int
 	admissionWebhook                                 webhook.Options
 	concurrentClusterUpdate                          int
+	concurrentClusterLaunches                        int
 	addonEnforceInterval                             int
+	addonManifestsAllowedHosts                       sets.String
+	clusterLaunchTimeout                             time.Duration
+	addonsReadyTimeout                               time.Duration
+	clusterPhaseWebhookURL                           string
+	clusterPhaseWebhookTimeout                       time.Duration
+	clusterControllerDryRun                          bool
+	clusterDriftDetection                            kubernetescontroller.DriftDetectionMode
+	clusterNodePortAllocation                        kubernetescontroller.NodePortAllocationStrategy
+	apiserverURLTemplate                             string
+	clusterResourceQuotaPlansFile                    string
+	namespacePrefix                                  string
+	clusterResyncPeriods                             kubernetescontroller.ResyncPeriods
+	vault                                            vault.Options
 	caBundle                                         *certificates.CABundle
+	rootCASigningCA                                  *triple.SigningCA
 
 	// OIDC configuration
 	oidcIssuerURL          string
@@ -110,7 +135,14 @@ func newControllerRunOptions() (controllerRunOptions, error) {
 
 	var (
 		rawEtcdDiskSize             string
+		rawClusterResyncPeriods     string
+		rawClusterDriftDetection    string
+		rawClusterNodePortAlloc     string
+		rawSeedNodePortRange        string
+		rawAddonManifestsHosts      string
 		caBundleFile                string
+		rootCASigningCertFile       string
+		rootCASigningKeyFile        string
 		defaultKubernetesAddonsList string
 		defaultKubernetesAddonsFile string
 	)
@@ -127,6 +159,7 @@ func newControllerRunOptions() (controllerRunOptions, error) {
 	flag.IntVar(&c.workerCount, "worker-count", 4, "Number of workers which process the clusters in parallel.")
 	flag.StringVar(&c.overwriteRegistry, "overwrite-registry", "", "registry to use for all images")
 	flag.StringVar(&c.nodePortRange, "nodeport-range", "30000-32767", "NodePort range to use for new clusters. It must be within the NodePort range of the seed-cluster")
+	flag.StringVar(&rawSeedNodePortRange, "seed-nodeport-range", "30000-32767", "NodePort range of the seed cluster (the --service-node-port-range of its kube-apiserver). The apiserver services of clusters allocate their NodePorts from it, unless their datacenter configures a NodePort range within it.")
 	flag.StringVar(&c.nodeAccessNetwork, "node-access-network", kubermaticv1.DefaultNodeAccessNetwork, "A network which allows direct access to nodes via VPN. Uses CIDR notation.")
 	flag.StringVar(&c.kubernetesAddonsPath, "kubernetes-addons-path", "/opt/addons/kubernetes", "Path to addon manifests. Should contain sub-folders for each addon")
 	flag.StringVar(&defaultKubernetesAddonsList, "kubernetes-addons-list", "", "Comma separated list of Addons to install into every user-cluster. Mutually exclusive with `--kubernetes-addons-file`")
@@ -157,8 +190,24 @@ func newControllerRunOptions() (controllerRunOptions, error) {
 	flag.IntVar(&c.controllerManagerDefaultReplicas, "controller-manager-default-replicas", 1, "The default number of replicas for usercluster controller managers")
 	flag.IntVar(&c.schedulerDefaultReplicas, "scheduler-default-replicas", 1, "The default number of replicas for usercluster schedulers")
 	flag.IntVar(&c.concurrentClusterUpdate, "max-parallel-reconcile", 10, "The default number of resources updates per cluster")
+	flag.IntVar(&c.concurrentClusterLaunches, "max-parallel-cluster-launches", 0, "The maximum number of clusters launching at the same time, further new clusters wait until a launch finished. Set to 0 to disable.")
 	flag.IntVar(&c.addonEnforceInterval, "addon-enforce-interval", 5, "Check and ensure default usercluster addons are deployed every interval in minutes. Set to 0 to disable.")
+	flag.StringVar(&rawAddonManifestsHosts, "addon-manifests-allowed-hosts", "", "Comma-separated list of hosts, optionally with a port, the manifests of custom addons may be downloaded from via their manifestsURL. Leave empty to not allow downloading manifests.")
+	flag.DurationVar(&c.clusterLaunchTimeout, "cluster-launch-timeout", 0, "Time after which clusters that did not become healthy are marked as failed and not reconciled anymore. Set to 0 to disable.")
+	flag.DurationVar(&c.addonsReadyTimeout, "addons-ready-timeout", 10*time.Minute, "Time after the cluster creation for which the initialization of a cluster waits for its default addons to be installed. Set to 0 to not wait for the addons.")
+	flag.StringVar(&c.clusterPhaseWebhookURL, "cluster-phase-webhook-url", "", "URL the phase transitions of clusters are posted to as JSON. Leave empty to disable the notifications.")
+	flag.DurationVar(&c.clusterPhaseWebhookTimeout, "cluster-phase-webhook-timeout", 10*time.Second, "Timeout of a single request to the cluster phase webhook, failed requests are retried with backoff.")
+	flag.BoolVar(&c.clusterControllerDryRun, "cluster-controller-dry-run", false, "Only log the changes the cluster controller would make to the control plane of clusters instead of applying them. Useful for debugging, must not be used in production.")
+	flag.StringVar(&rawClusterDriftDetection, "cluster-drift-detection", "", "Detect deployments and services of running clusters which differ from what the cluster spec produces and report them with the ControlPlaneInSync condition. \"reconcile\" reconciles them right away, \"report\" leaves them untouched until the kubermatic.io/reapply-control-plane annotation is set on the cluster. Leave empty to disable.")
+	flag.StringVar(&rawClusterNodePortAlloc, "cluster-nodeport-allocation", "", "How the NodePort of the apiserver service of new clusters is picked. \"lowest\" takes the lowest free port of the NodePort range, \"random\" a random free one. Leave empty to let Kubernetes allocate it.")
+	flag.StringVar(&c.apiserverURLTemplate, "apiserver-url-template", address.DefaultURLTemplate, "Go template for the apiserver URL of clusters. Available variables are .Name, .DC, .ExternalURL, .ExternalName and .Port, the result must be a https URL.")
+	flag.StringVar(&c.clusterResourceQuotaPlansFile, "cluster-resource-quota-plans", "", "YAML file mapping plan names to the ResourceQuota and LimitRange created in the namespace of clusters. Clusters select a plan with the \"plan\" label and use the \"default\" plan otherwise. Leave empty to not limit clusters.")
+	flag.StringVar(&c.namespacePrefix, "cluster-namespace-prefix", kubernetesprovider.NamespacePrefix, "Prefix of the namespaces the control planes of clusters are deployed in, followed by the cluster name. Only applies to new clusters, existing clusters keep their namespace.")
+	flag.StringVar(&rawClusterResyncPeriods, "cluster-resync-periods", "", "Comma-separated list of phase=duration pairs configuring after which time clusters in the phase are reconciled again, e.g. \"Pending=10s,Launching=10s,Running=10m\". Clusters in phases without a period are only reconciled on changes.")
+	c.vault.AddFlags(flag.CommandLine)
 	flag.StringVar(&caBundleFile, "ca-bundle", "", "File containing the PEM-encoded CA bundle for all userclusters")
+	flag.StringVar(&rootCASigningCertFile, "root-ca-signing-cert", "", "File containing the PEM-encoded certificate of the CA which issues the root CAs of clusters, followed by the certificates of the CAs which issued it. Leave empty to create self-signed root CAs.")
+	flag.StringVar(&rootCASigningKeyFile, "root-ca-signing-key", "", "File containing the PEM-encoded private key of the CA which issues the root CAs of clusters")
 	flag.Var(&c.tunnelingAgentIP, "tunneling-agent-ip", "The address used by the tunneling agents.")
 	c.admissionWebhook.AddFlags(flag.CommandLine, true)
 	addFlags(flag.CommandLine)
@@ -178,17 +227,52 @@ func newControllerRunOptions() (controllerRunOptions, error) {
 		c.overwriteRegistry = path.Clean(strings.TrimSpace(c.overwriteRegistry))
 	}
 
+	c.addonManifestsAllowedHosts = sets.NewString()
+	for _, host := range strings.Split(rawAddonManifestsHosts, ",") {
+		if host = strings.ToLower(strings.TrimSpace(host)); host != "" {
+			c.addonManifestsAllowedHosts.Insert(host)
+		}
+	}
+
 	c.kubernetesAddons, err = loadAddons(defaultKubernetesAddonsList, defaultKubernetesAddonsFile)
 	if err != nil {
 		return c, err
 	}
 
+	c.clusterResyncPeriods, err = kubernetescontroller.ParseResyncPeriods(rawClusterResyncPeriods)
+	if err != nil {
+		return c, fmt.Errorf("invalid value of flag cluster-resync-periods (%q): %v", rawClusterResyncPeriods, err)
+	}
+
+	c.clusterDriftDetection, err = kubernetescontroller.ParseDriftDetectionMode(rawClusterDriftDetection)
+	if err != nil {
+		return c, fmt.Errorf("invalid value of flag cluster-drift-detection: %v", err)
+	}
+
+	c.clusterNodePortAllocation, err = kubernetescontroller.ParseNodePortAllocationStrategy(rawClusterNodePortAlloc)
+	if err != nil {
+		return c, fmt.Errorf("invalid value of flag cluster-nodeport-allocation: %v", err)
+	}
+
+	seedNodePortRange, err := knet.ParsePortRange(rawSeedNodePortRange)
+	if err != nil {
+		return c, fmt.Errorf("invalid value of flag seed-nodeport-range (%q): %v", rawSeedNodePortRange, err)
+	}
+	c.seedNodePortRange = *seedNodePortRange
+
 	caBundle, err := certificates.NewCABundleFromFile(caBundleFile)
 	if err != nil {
 		return c, fmt.Errorf("invalid CA bundle file (%q): %v", caBundleFile, err)
 	}
 	c.caBundle = caBundle
 
+	if rootCASigningCertFile != "" || rootCASigningKeyFile != "" {
+		c.rootCASigningCA, err = loadRootCASigningCA(rootCASigningCertFile, rootCASigningKeyFile)
+		if err != nil {
+			return c, err
+		}
+	}
+
 	return c, nil
 }
 
@@ -219,6 +303,16 @@ func (o controllerRunOptions) validate() error {
 		return fmt.Errorf("datacenter-name is undefined")
 	}
 
+	if _, err := address.RenderURL(o.apiserverURLTemplate, address.URLTemplateData{
+		Name:         "cluster",
+		DC:           o.dc,
+		ExternalURL:  o.externalURL,
+		ExternalName: fmt.Sprintf("cluster.%s.%s", o.dc, o.externalURL),
+		Port:         6443,
+	}); err != nil {
+		return fmt.Errorf("invalid apiserver-url-template: %v", err)
+	}
+
 	if o.backupContainerFile == "" {
 		return fmt.Errorf("backup-container is undefined")
 	}
@@ -239,6 +333,28 @@ func (o controllerRunOptions) validate() error {
 	if o.concurrentClusterUpdate < 1 {
 		return fmt.Errorf("--max-parallel-reconcile must be > 0 (was %d)", o.concurrentClusterUpdate)
 	}
+	if o.concurrentClusterLaunches < 0 {
+		return fmt.Errorf("--max-parallel-cluster-launches must be >= 0 (was %d)", o.concurrentClusterLaunches)
+	}
+	if o.clusterLaunchTimeout < 0 {
+		return fmt.Errorf("--cluster-launch-timeout must not be negative (was %v)", o.clusterLaunchTimeout)
+	}
+	if o.addonsReadyTimeout < 0 {
+		return fmt.Errorf("--addons-ready-timeout must not be negative (was %v)", o.addonsReadyTimeout)
+	}
+	if o.clusterPhaseWebhookURL != "" {
+		if u, err := url.Parse(o.clusterPhaseWebhookURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			return fmt.Errorf("--cluster-phase-webhook-url must be a http or https URL (was %q)", o.clusterPhaseWebhookURL)
+		}
+	}
+	if o.clusterPhaseWebhookTimeout <= 0 {
+		return fmt.Errorf("--cluster-phase-webhook-timeout must be positive (was %v)", o.clusterPhaseWebhookTimeout)
+	}
+
+	// The prefix is followed by the cluster name, which may start with a digit
+	if errs := validation.IsDNS1123Label(o.namespacePrefix + "0"); len(errs) > 0 {
+		return fmt.Errorf("--cluster-namespace-prefix must be a valid prefix for namespace names (was %q): %s", o.namespacePrefix, strings.Join(errs, ", "))
+	}
 
 	// Validate node-port range
 	if _, err := knet.ParsePortRange(o.nodePortRange); err != nil {
@@ -251,6 +367,19 @@ func (o controllerRunOptions) validate() error {
 		if addon.Name == "metrics-server" {
 			return errors.New("the metrics-server addon must be disabled, it is now deployed inside the seed cluster")
 		}
+		if addon.Spec.Manifests != "" && addon.Spec.ManifestsURL != "" {
+			return fmt.Errorf("addon %s must not set both manifests and manifestsURL", addon.Name)
+		}
+		if addon.Spec.Manifests != "" {
+			if _, err := addonutils.ParseManifests(addon.Spec.Manifests); err != nil {
+				return fmt.Errorf("invalid manifests for addon %s: %v", addon.Name, err)
+			}
+		}
+		if addon.Spec.ManifestsURL != "" {
+			if err := addonutils.ValidateManifestsURL(addon.Spec.ManifestsURL, o.addonManifestsAllowedHosts); err != nil {
+				return fmt.Errorf("invalid manifestsURL for addon %s: %v", addon.Name, err)
+			}
+		}
 	}
 
 	return nil
@@ -265,6 +394,15 @@ func (o controllerRunOptions) nodeLocalDNSCacheEnabled() bool {
 	return false
 }
 
+// defaultAddonNames returns the names of the configured default addons.
+func (o controllerRunOptions) defaultAddonNames() sets.String {
+	names := sets.NewString()
+	for _, addon := range o.kubernetesAddons.Items {
+		names.Insert(addon.Name)
+	}
+	return names
+}
+
 // controllerContext holds all controllerRunOptions plus everything that
 // needs to be initialized first
 type controllerContext struct {
@@ -278,6 +416,28 @@ type controllerContext struct {
 	versions             kubermatic.Versions
 }
 
+// loadRootCASigningCA loads the CA which issues the root CAs of clusters as intermediate CAs
+func loadRootCASigningCA(certFile, keyFile string) (*triple.SigningCA, error) {
+	if certFile == "" || keyFile == "" {
+		return nil, errors.New("-root-ca-signing-cert and -root-ca-signing-key must be set together")
+	}
+
+	certPEM, err := ioutil.ReadFile(certFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read root CA signing certificate: %v", err)
+	}
+	keyPEM, err := ioutil.ReadFile(keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read root CA signing key: %v", err)
+	}
+
+	signer, err := triple.ParseSigningCA(certPEM, keyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("invalid root CA signing CA: %v", err)
+	}
+	return signer, nil
+}
+
 func loadAddons(listOpt, fileOpt string) (kubermaticv1.AddonList, error) {
 	addonList := kubermaticv1.AddonList{}
 	if listOpt != "" && fileOpt != "" {
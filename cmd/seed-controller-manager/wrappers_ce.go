@@ -43,5 +43,6 @@ func seedValidationHandler(ctx context.Context, client ctrlruntimeclient.Client,
 		WorkerName(options.workerName).
 		AllowedSeed(options.namespace, provider.DefaultSeedName).
 		FeatureGates(options.featureGates).
+		SeedNodePortRange(options.seedNodePortRange).
 		Build(ctx)
 }
@@ -43,5 +43,6 @@ func seedValidationHandler(ctx context.Context, client ctrlruntimeclient.Client,
 		SeedName(options.dc).
 		WorkerName(options.workerName).
 		FeatureGates(options.featureGates).
+		SeedNodePortRange(options.seedNodePortRange).
 		Build(ctx)
 }
@@ -60,6 +60,16 @@ func main() {
 				ImportAlias:  "corev1",
 				// Don't specify ResourceImportPath so this block does not create a new import line in the generated code
 			},
+			{
+				ResourceName: "ResourceQuota",
+				ImportAlias:  "corev1",
+				// Don't specify ResourceImportPath so this block does not create a new import line in the generated code
+			},
+			{
+				ResourceName: "LimitRange",
+				ImportAlias:  "corev1",
+				// Don't specify ResourceImportPath so this block does not create a new import line in the generated code
+			},
 			{
 				ResourceName:       "StatefulSet",
 				ImportAlias:        "appsv1",
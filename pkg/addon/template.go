@@ -19,8 +19,12 @@ package addon
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"fmt"
+	"io"
 	"io/ioutil"
+	"net/http"
+	"net/url"
 	"path"
 	"strings"
 	"text/template"
@@ -34,12 +38,19 @@ import (
 	"k8c.io/kubermatic/v2/pkg/resources"
 	"k8c.io/kubermatic/v2/pkg/util/yaml"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
+	k8syaml "sigs.k8s.io/yaml"
 )
 
 const (
 	ClusterTypeKubernetes = "kubernetes"
+
+	// maxManifestsSize is the maximum size of the manifests downloaded for a custom addon.
+	maxManifestsSize = 1 << 20
+	// maxManifestsRedirects is the maximum number of redirects followed to download the manifests.
+	maxManifestsRedirects = 10
 )
 
 func txtFuncMap(overwriteRegistry string) template.FuncMap {
@@ -112,6 +123,7 @@ func NewTemplateData(
 				PodCIDRBlocks:     cluster.Spec.ClusterNetwork.Pods.CIDRBlocks,
 				ServiceCIDRBlocks: cluster.Spec.ClusterNetwork.Services.CIDRBlocks,
 				ProxyMode:         cluster.Spec.ClusterNetwork.ProxyMode,
+				DualStack:         cluster.Spec.ClusterNetwork.IsDualStack(),
 			},
 		},
 	}, nil
@@ -173,6 +185,8 @@ type ClusterNetwork struct {
 	PodCIDRBlocks     []string
 	ServiceCIDRBlocks []string
 	ProxyMode         string
+	// DualStack is true if the pod and service CIDR blocks contain an IPv4 and an IPv6 block.
+	DualStack bool
 }
 
 func ParseFromFolder(log *zap.SugaredLogger, overwriteRegistry string, manifestPath string, data *TemplateData) ([]runtime.RawExtension, error) {
@@ -197,6 +211,10 @@ func ParseFromFolder(log *zap.SugaredLogger, overwriteRegistry string, manifestP
 			continue
 		}
 
+		if info.Name() == VariablesSchemaFile {
+			continue
+		}
+
 		infoLog.Debug("Processing file")
 
 		fbytes, err := ioutil.ReadFile(filename)
@@ -229,3 +247,88 @@ func ParseFromFolder(log *zap.SugaredLogger, overwriteRegistry string, manifestP
 
 	return allManifests, nil
 }
+
+// ParseManifests parses the manifests of a custom addon. Every document must be a
+// Kubernetes object with an apiVersion and kind.
+func ParseManifests(manifests string) ([]runtime.RawExtension, error) {
+	allManifests, err := yaml.ParseMultipleDocuments(strings.NewReader(manifests))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode manifests: %v", err)
+	}
+	if len(allManifests) == 0 {
+		return nil, fmt.Errorf("no manifests found")
+	}
+
+	for i, manifest := range allManifests {
+		typeMeta := metav1.TypeMeta{}
+		if err := k8syaml.Unmarshal(manifest.Raw, &typeMeta); err != nil {
+			return nil, fmt.Errorf("manifest %d is not a Kubernetes object: %v", i, err)
+		}
+		if typeMeta.APIVersion == "" || typeMeta.Kind == "" {
+			return nil, fmt.Errorf("manifest %d is not a Kubernetes object: apiVersion and kind must be set", i)
+		}
+	}
+
+	return allManifests, nil
+}
+
+// ValidateManifestsURL returns an error if the manifests of a custom addon can not be
+// downloaded from the given URL. Only HTTPS URLs on one of the allowed hosts are allowed, the
+// hosts are lower-case hostnames, optionally followed by a port. No URL is allowed if the set
+// of allowed hosts is empty.
+func ValidateManifestsURL(manifestsURL string, allowedHosts sets.String) error {
+	u, err := url.Parse(manifestsURL)
+	if err != nil {
+		return fmt.Errorf("invalid URL: %v", err)
+	}
+	if u.Scheme != "https" || u.Host == "" {
+		return fmt.Errorf("%q is not an HTTPS URL", manifestsURL)
+	}
+	if host := strings.ToLower(u.Host); !allowedHosts.Has(host) && !allowedHosts.Has(strings.ToLower(u.Hostname())) {
+		return fmt.Errorf("host %q of %q is not allowed to serve addon manifests", u.Host, manifestsURL)
+	}
+	return nil
+}
+
+// FetchManifests downloads the manifests of a custom addon from the given URL and parses
+// them like ParseManifests. The URL and all redirects must be allowed by ValidateManifestsURL,
+// manifests larger than 1 MiB are rejected.
+func FetchManifests(ctx context.Context, client *http.Client, manifestsURL string, allowedHosts sets.String) ([]runtime.RawExtension, error) {
+	if err := ValidateManifestsURL(manifestsURL, allowedHosts); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, manifestsURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %v", err)
+	}
+
+	// Redirects must not lead to hosts which are not allowed
+	restricted := *client
+	restricted.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if len(via) >= maxManifestsRedirects {
+			return fmt.Errorf("stopped after %d redirects", maxManifestsRedirects)
+		}
+		return ValidateManifestsURL(req.URL.String(), allowedHosts)
+	}
+
+	resp, err := restricted.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download manifests: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download manifests: unexpected response %d", resp.StatusCode)
+	}
+
+	manifests, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxManifestsSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to download manifests: %v", err)
+	}
+	if len(manifests) > maxManifestsSize {
+		return nil, fmt.Errorf("manifests are larger than %d bytes", maxManifestsSize)
+	}
+
+	return ParseManifests(string(manifests))
+}
@@ -17,10 +17,14 @@ limitations under the License.
 package addon
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"go.uber.org/zap"
@@ -30,6 +34,7 @@ import (
 	"k8c.io/kubermatic/v2/pkg/semver"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/yaml"
 )
 
@@ -126,3 +131,166 @@ func TestNewTemplateData(t *testing.T) {
 		t.Fatalf("Expected cluster features to contain %q, but does not.", feature)
 	}
 }
+
+func TestParseManifests(t *testing.T) {
+	testCases := []struct {
+		name          string
+		manifests     string
+		expectedCount int
+		expectErr     bool
+	}{
+		{
+			name: "valid manifests",
+			manifests: `apiVersion: v1
+kind: Namespace
+metadata:
+  name: monitoring
+---
+# only a comment
+---
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: agent
+  namespace: monitoring
+`,
+			expectedCount: 2,
+		},
+		{
+			name:      "no manifests",
+			manifests: "# nothing here",
+			expectErr: true,
+		},
+		{
+			name: "missing kind",
+			manifests: `apiVersion: v1
+metadata:
+  name: monitoring
+`,
+			expectErr: true,
+		},
+		{
+			name:      "invalid yaml",
+			manifests: "apiVersion: [v1",
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			manifests, err := ParseManifests(tc.manifests)
+			if (err != nil) != tc.expectErr {
+				t.Fatalf("expected error: %v, got %v", tc.expectErr, err)
+			}
+			if len(manifests) != tc.expectedCount {
+				t.Errorf("expected %d manifests, got %d", tc.expectedCount, len(manifests))
+			}
+		})
+	}
+}
+
+func TestFetchManifests(t *testing.T) {
+	const manifests = `apiVersion: v1
+kind: Namespace
+metadata:
+  name: monitoring
+`
+
+	other := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, manifests)
+	}))
+	defer other.Close()
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/addon.yaml":
+			fmt.Fprint(w, manifests)
+		case "/redirect.yaml":
+			http.Redirect(w, r, "/addon.yaml", http.StatusFound)
+		case "/other.yaml":
+			http.Redirect(w, r, other.URL+"/addon.yaml", http.StatusFound)
+		case "/large.yaml":
+			fmt.Fprint(w, manifests+"# "+strings.Repeat("x", maxManifestsSize))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	serverHost := strings.TrimPrefix(server.URL, "https://")
+	allowedHosts := sets.NewString(serverHost)
+
+	testCases := []struct {
+		name          string
+		url           string
+		allowedHosts  sets.String
+		expectedCount int
+		expectErr     bool
+	}{
+		{
+			name:          "valid manifests",
+			url:           server.URL + "/addon.yaml",
+			allowedHosts:  allowedHosts,
+			expectedCount: 1,
+		},
+		{
+			name:          "host allowed without the port",
+			url:           server.URL + "/addon.yaml",
+			allowedHosts:  sets.NewString(strings.Split(serverHost, ":")[0]),
+			expectedCount: 1,
+		},
+		{
+			name:         "host not allowed",
+			url:          server.URL + "/addon.yaml",
+			allowedHosts: sets.NewString("addons.example.com"),
+			expectErr:    true,
+		},
+		{
+			name:      "no hosts allowed",
+			url:       server.URL + "/addon.yaml",
+			expectErr: true,
+		},
+		{
+			name:          "redirect to an allowed host",
+			url:           server.URL + "/redirect.yaml",
+			allowedHosts:  allowedHosts,
+			expectedCount: 1,
+		},
+		{
+			name:         "redirect to a host which is not allowed",
+			url:          server.URL + "/other.yaml",
+			allowedHosts: allowedHosts,
+			expectErr:    true,
+		},
+		{
+			name:         "missing manifests",
+			url:          server.URL + "/missing.yaml",
+			allowedHosts: allowedHosts,
+			expectErr:    true,
+		},
+		{
+			name:         "manifests too large",
+			url:          server.URL + "/large.yaml",
+			allowedHosts: allowedHosts,
+			expectErr:    true,
+		},
+		{
+			name:         "plain HTTP",
+			url:          strings.Replace(server.URL, "https://", "http://", 1) + "/addon.yaml",
+			allowedHosts: allowedHosts,
+			expectErr:    true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			manifests, err := FetchManifests(context.Background(), server.Client(), tc.url, tc.allowedHosts)
+			if (err != nil) != tc.expectErr {
+				t.Fatalf("expected error: %v, got %v", tc.expectErr, err)
+			}
+			if len(manifests) != tc.expectedCount {
+				t.Errorf("expected %d manifests, got %d", tc.expectedCount, len(manifests))
+			}
+		})
+	}
+}
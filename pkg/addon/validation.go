@@ -0,0 +1,160 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package addon
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path"
+
+	"github.com/Masterminds/semver/v3"
+	"go.uber.org/zap"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	ksemver "k8c.io/kubermatic/v2/pkg/semver"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// missingValue is what text/template renders for map keys which do not exist,
+// e.g. addon variables which are not configured.
+const missingValue = "<no value>"
+
+// syntheticCloudSpecs contains one cloud spec per cloud provider, so that the
+// provider specific parts of the addon templates get rendered as well.
+var syntheticCloudSpecs = []kubermaticv1.CloudSpec{
+	{Alibaba: &kubermaticv1.AlibabaCloudSpec{}},
+	{Anexia: &kubermaticv1.AnexiaCloudSpec{}},
+	{AWS: &kubermaticv1.AWSCloudSpec{}},
+	{Azure: &kubermaticv1.AzureCloudSpec{}},
+	{BringYourOwn: &kubermaticv1.BringYourOwnCloudSpec{}},
+	{Digitalocean: &kubermaticv1.DigitaloceanCloudSpec{}},
+	{GCP: &kubermaticv1.GCPCloudSpec{}},
+	{Hetzner: &kubermaticv1.HetznerCloudSpec{}},
+	{Kubevirt: &kubermaticv1.KubevirtCloudSpec{}},
+	{Openstack: &kubermaticv1.OpenstackCloudSpec{}},
+	{Packet: &kubermaticv1.PacketCloudSpec{}},
+	{VSphere: &kubermaticv1.VSphereCloudSpec{}},
+}
+
+// ValidateTemplates renders the templates of all given addons for every version and cloud
+// provider against a synthetic cluster. This allows to detect broken templates on startup
+// instead of when the addon gets installed into the first cluster. The variables are the
+// addon variables configured for the controller, keyed by addon name. All errors are
+// returned aggregated.
+func ValidateTemplates(overwriteRegistry string, addonsPath string, addons []kubermaticv1.Addon, versions []*semver.Version, variables map[string]interface{}) error {
+	log := zap.NewNop().Sugar()
+
+	var errs []error
+	for _, addon := range addons {
+		// custom manifests are not templated and validated separately
+		if addon.Spec.Manifests != "" || addon.Spec.ManifestsURL != "" {
+			continue
+		}
+
+		addonErrs := sets.NewString()
+		for _, version := range versions {
+			for _, cloud := range syntheticCloudSpecs {
+				if err := validateTemplate(log, overwriteRegistry, addonsPath, addon, syntheticCluster(version, cloud), variables); err != nil {
+					addonErrs.Insert(err.Error())
+				}
+			}
+		}
+		for _, err := range addonErrs.List() {
+			errs = append(errs, fmt.Errorf("addon %s: %s", addon.Name, err))
+		}
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
+
+func validateTemplate(log *zap.SugaredLogger, overwriteRegistry string, addonsPath string, addon kubermaticv1.Addon, cluster *kubermaticv1.Cluster, variables map[string]interface{}) error {
+	// Use the same variables as the addon controller
+	addonVariables := make(map[string]interface{})
+	if sub, ok := variables[addon.Name].(map[string]interface{}); ok {
+		for k, v := range sub {
+			addonVariables[k] = v
+		}
+	}
+	if len(addon.Spec.Variables.Raw) > 0 {
+		specVariables := make(map[string]interface{})
+		if err := json.Unmarshal(addon.Spec.Variables.Raw, &specVariables); err != nil {
+			return fmt.Errorf("invalid variables: %v", err)
+		}
+		schema, err := LoadVariablesSchema(path.Join(addonsPath, addon.Name))
+		if err != nil {
+			return err
+		}
+		if err := schema.Validate(specVariables); err != nil {
+			return fmt.Errorf("invalid variables: %v", err)
+		}
+		for k, v := range specVariables {
+			addonVariables[k] = v
+		}
+	}
+
+	data, err := NewTemplateData(cluster, resources.Credentials{}, "kubeconfig", "10.240.16.10", "10.240.16.10", addonVariables)
+	if err != nil {
+		return fmt.Errorf("failed to create template data: %v", err)
+	}
+
+	manifests, err := ParseFromFolder(log, overwriteRegistry, path.Join(addonsPath, addon.Name), data)
+	if err != nil {
+		return err
+	}
+
+	for _, manifest := range manifests {
+		if bytes.Contains(manifest.Raw, []byte(missingValue)) {
+			return fmt.Errorf("manifests reference a variable which is not configured for %s clusters", data.Cluster.CloudProviderName)
+		}
+	}
+
+	return nil
+}
+
+func syntheticCluster(version *semver.Version, cloud kubermaticv1.CloudSpec) *kubermaticv1.Cluster {
+	cloud.DatacenterName = "synthetic"
+
+	return &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "synthetic",
+		},
+		Address: kubermaticv1.ClusterAddress{
+			ExternalName: "synthetic.kubermatic.io",
+			InternalName: "apiserver-external.cluster-synthetic.svc.cluster.local.",
+			URL:          "https://synthetic.kubermatic.io:30000",
+			Port:         30000,
+		},
+		Spec: kubermaticv1.ClusterSpec{
+			Version: *ksemver.NewSemverOrDie(version.String()),
+			Cloud:   cloud,
+			ClusterNetwork: kubermaticv1.ClusterNetworkingConfig{
+				DNSDomain: "cluster.local",
+				Pods:      kubermaticv1.NetworkRanges{CIDRBlocks: []string{"172.25.0.0/16"}},
+				Services:  kubermaticv1.NetworkRanges{CIDRBlocks: []string{"10.240.16.0/20"}},
+				ProxyMode: resources.IPVSProxyMode,
+			},
+		},
+		Status: kubermaticv1.ClusterStatus{
+			NamespaceName: "cluster-synthetic",
+		},
+	}
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package addon
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestValidateTemplates(t *testing.T) {
+	testCases := []struct {
+		name      string
+		template  string
+		variables map[string]interface{}
+		expectErr bool
+	}{
+		{
+			name: "valid template",
+			template: `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{ .Cluster.Name }}
+data:
+  version: "{{ .Cluster.MajorMinorVersion }}"`,
+		},
+		{
+			name: "configured variable",
+			template: `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{ .Variables.Name }}`,
+			variables: map[string]interface{}{
+				"test": map[string]interface{}{"Name": "configured"},
+			},
+		},
+		{
+			name: "missing variable",
+			template: `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{ .Variables.Name }}`,
+			expectErr: true,
+		},
+		{
+			name: "unknown field",
+			template: `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{ .Cluster.Unknown }}`,
+			expectErr: true,
+		},
+		{
+			name:      "malformed template",
+			template:  `{{ if .Cluster.Name }}`,
+			expectErr: true,
+		},
+	}
+
+	versions := []*semver.Version{semver.MustParse("1.18.10"), semver.MustParse("1.19.2")}
+	addons := []kubermaticv1.Addon{{ObjectMeta: metav1.ObjectMeta{Name: "test"}}}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			addonsPath, err := ioutil.TempDir("", "addons")
+			if err != nil {
+				t.Fatalf("failed to create addons directory: %v", err)
+			}
+			defer os.RemoveAll(addonsPath)
+
+			if err := os.Mkdir(filepath.Join(addonsPath, "test"), 0755); err != nil {
+				t.Fatalf("failed to create addon directory: %v", err)
+			}
+			if err := ioutil.WriteFile(filepath.Join(addonsPath, "test", "manifest.yaml"), []byte(tc.template), 0644); err != nil {
+				t.Fatalf("failed to write template: %v", err)
+			}
+
+			err = ValidateTemplates("", addonsPath, addons, versions, tc.variables)
+			if (err != nil) != tc.expectErr {
+				t.Fatalf("expected error: %v, got: %v", tc.expectErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateDefaultAddonTemplates(t *testing.T) {
+	addonPaths, _ := filepath.Glob("../../addons/*")
+
+	var addons []kubermaticv1.Addon
+	for _, addonPath := range addonPaths {
+		if stat, err := os.Stat(addonPath); err != nil || !stat.IsDir() {
+			continue
+		}
+		addons = append(addons, kubermaticv1.Addon{ObjectMeta: metav1.ObjectMeta{Name: filepath.Base(addonPath)}})
+	}
+
+	variables := map[string]interface{}{
+		"openvpn": map[string]interface{}{"NodeAccessNetwork": "10.254.0.0/16"},
+	}
+	versions := []*semver.Version{semver.MustParse("1.18.10"), semver.MustParse("1.19.2"), semver.MustParse("1.20.2")}
+
+	if err := ValidateTemplates("", "../../addons", addons, versions, variables); err != nil {
+		t.Fatalf("default addons are not valid: %v", err)
+	}
+}
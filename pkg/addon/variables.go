@@ -0,0 +1,142 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package addon
+
+import (
+	"fmt"
+	"io/ioutil"
+	"math"
+	"os"
+	"path"
+	"sort"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	k8syaml "sigs.k8s.io/yaml"
+)
+
+// VariablesSchemaFile is the file in the directory of an addon which describes the variables
+// the addon can be configured with. It is not rendered as a manifest.
+const VariablesSchemaFile = "variables.schema.yaml"
+
+// VariableType is the type of an addon variable.
+type VariableType string
+
+const (
+	VariableTypeString  VariableType = "string"
+	VariableTypeInteger VariableType = "integer"
+	VariableTypeNumber  VariableType = "number"
+	VariableTypeBoolean VariableType = "boolean"
+	VariableTypeList    VariableType = "list"
+	VariableTypeObject  VariableType = "object"
+)
+
+// VariablesSchema maps the names of the variables an addon supports to their type, e.g.
+//
+//	Replicas: integer
+//	UpstreamNameservers: list
+type VariablesSchema map[string]VariableType
+
+// LoadVariablesSchema reads the variables schema from the manifest directory of an addon. It
+// returns nil if the addon has no schema, in which case any variables are accepted.
+func LoadVariablesSchema(manifestPath string) (VariablesSchema, error) {
+	filename := path.Join(manifestPath, VariablesSchemaFile)
+
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read file %s: %v", filename, err)
+	}
+
+	schema := VariablesSchema{}
+	if err := k8syaml.UnmarshalStrict(data, &schema); err != nil {
+		return nil, fmt.Errorf("failed to decode file %s: %v", filename, err)
+	}
+	for name, variableType := range schema {
+		switch variableType {
+		case VariableTypeString, VariableTypeInteger, VariableTypeNumber, VariableTypeBoolean, VariableTypeList, VariableTypeObject:
+		default:
+			return nil, fmt.Errorf("invalid schema in %s: variable %s has unknown type %q", filename, name, variableType)
+		}
+	}
+
+	return schema, nil
+}
+
+// Validate checks that all variables are known to the schema and have the type it
+// declares. Variables which are not set are not an error, as templates usually fall
+// back to a default. All errors are returned aggregated.
+func (s VariablesSchema) Validate(variables map[string]interface{}) error {
+	if s == nil {
+		return nil
+	}
+
+	names := make([]string, 0, len(variables))
+	for name := range variables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var errs []error
+	for _, name := range names {
+		variableType, ok := s[name]
+		if !ok {
+			errs = append(errs, fmt.Errorf("unknown variable %s", name))
+			continue
+		}
+		if !hasVariableType(variables[name], variableType) {
+			errs = append(errs, fmt.Errorf("variable %s must be of type %s", name, variableType))
+		}
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
+
+// hasVariableType reports whether the value has the type. Values are usually decoded from
+// JSON, so integers are float64 without a fractional part.
+func hasVariableType(value interface{}, variableType VariableType) bool {
+	switch variableType {
+	case VariableTypeString:
+		_, ok := value.(string)
+		return ok
+	case VariableTypeBoolean:
+		_, ok := value.(bool)
+		return ok
+	case VariableTypeList:
+		_, ok := value.([]interface{})
+		return ok
+	case VariableTypeObject:
+		_, ok := value.(map[string]interface{})
+		return ok
+	case VariableTypeInteger:
+		switch v := value.(type) {
+		case int, int32, int64:
+			return true
+		case float64:
+			return v == math.Trunc(v)
+		}
+		return false
+	case VariableTypeNumber:
+		switch value.(type) {
+		case int, int32, int64, float64:
+			return true
+		}
+		return false
+	}
+	return false
+}
@@ -0,0 +1,187 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package addon
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+)
+
+func TestLoadVariablesSchema(t *testing.T) {
+	testCases := []struct {
+		name           string
+		schema         string
+		expectedSchema VariablesSchema
+		expectErr      bool
+	}{
+		{
+			name: "no schema",
+		},
+		{
+			name: "valid schema",
+			schema: `Replicas: integer
+UpstreamNameservers: list`,
+			expectedSchema: VariablesSchema{"Replicas": VariableTypeInteger, "UpstreamNameservers": VariableTypeList},
+		},
+		{
+			name:      "unknown type",
+			schema:    `Replicas: int`,
+			expectErr: true,
+		},
+		{
+			name:      "malformed schema",
+			schema:    `- Replicas`,
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			manifestPath, err := ioutil.TempDir("", "addon")
+			if err != nil {
+				t.Fatalf("failed to create addon directory: %v", err)
+			}
+			defer os.RemoveAll(manifestPath)
+
+			if tc.schema != "" {
+				if err := ioutil.WriteFile(filepath.Join(manifestPath, VariablesSchemaFile), []byte(tc.schema), 0644); err != nil {
+					t.Fatalf("failed to write schema: %v", err)
+				}
+			}
+
+			schema, err := LoadVariablesSchema(manifestPath)
+			if (err != nil) != tc.expectErr {
+				t.Fatalf("expected error: %v, got: %v", tc.expectErr, err)
+			}
+			if len(schema) != len(tc.expectedSchema) {
+				t.Fatalf("expected schema %v, got %v", tc.expectedSchema, schema)
+			}
+			for name, variableType := range tc.expectedSchema {
+				if schema[name] != variableType {
+					t.Errorf("expected variable %s to be of type %s, got %q", name, variableType, schema[name])
+				}
+			}
+		})
+	}
+}
+
+func TestVariablesSchemaValidate(t *testing.T) {
+	schema := VariablesSchema{
+		"Replicas":            VariableTypeInteger,
+		"CPU":                 VariableTypeNumber,
+		"Image":               VariableTypeString,
+		"Debug":               VariableTypeBoolean,
+		"UpstreamNameservers": VariableTypeList,
+		"Resources":           VariableTypeObject,
+	}
+
+	testCases := []struct {
+		name      string
+		schema    VariablesSchema
+		variables string
+		expectErr bool
+	}{
+		{
+			name:      "no schema accepts anything",
+			variables: `{"Anything": "goes"}`,
+		},
+		{
+			name:      "no variables",
+			schema:    schema,
+			variables: `{}`,
+		},
+		{
+			name:      "all types",
+			schema:    schema,
+			variables: `{"Replicas": 2, "CPU": 0.5, "Image": "coredns", "Debug": true, "UpstreamNameservers": ["8.8.8.8"], "Resources": {"memory": "64Mi"}}`,
+		},
+		{
+			name:      "unknown variable",
+			schema:    schema,
+			variables: `{"Replica": 2}`,
+			expectErr: true,
+		},
+		{
+			name:      "fractional integer",
+			schema:    schema,
+			variables: `{"Replicas": 1.5}`,
+			expectErr: true,
+		},
+		{
+			name:      "string instead of list",
+			schema:    schema,
+			variables: `{"UpstreamNameservers": "8.8.8.8"}`,
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			variables := map[string]interface{}{}
+			if err := json.Unmarshal([]byte(tc.variables), &variables); err != nil {
+				t.Fatalf("failed to decode variables: %v", err)
+			}
+
+			err := tc.schema.Validate(variables)
+			if (err != nil) != tc.expectErr {
+				t.Fatalf("expected error: %v, got: %v", tc.expectErr, err)
+			}
+		})
+	}
+}
+
+var variableReference = regexp.MustCompile(`\.Variables\.([A-Za-z0-9_]+)`)
+
+func TestDefaultAddonVariablesSchemas(t *testing.T) {
+	addonPaths, _ := filepath.Glob("../../addons/*")
+
+	for _, addonPath := range addonPaths {
+		if stat, err := os.Stat(addonPath); err != nil || !stat.IsDir() {
+			continue
+		}
+
+		manifests, _ := filepath.Glob(filepath.Join(addonPath, "*.yaml"))
+		referenced := map[string]string{}
+		for _, manifest := range manifests {
+			if filepath.Base(manifest) == VariablesSchemaFile {
+				continue
+			}
+			data, err := ioutil.ReadFile(manifest)
+			if err != nil {
+				t.Fatalf("failed to read %s: %v", manifest, err)
+			}
+			for _, match := range variableReference.FindAllStringSubmatch(string(data), -1) {
+				referenced[match[1]] = manifest
+			}
+		}
+
+		schema, err := LoadVariablesSchema(addonPath)
+		if err != nil {
+			t.Errorf("invalid variables schema of addon %s: %v", filepath.Base(addonPath), err)
+			continue
+		}
+		for name, manifest := range referenced {
+			if _, ok := schema[name]; !ok {
+				t.Errorf("variable %s used in %s is not declared in the %s of addon %s", name, manifest, VariablesSchemaFile, filepath.Base(addonPath))
+			}
+		}
+	}
+}
@@ -103,6 +103,17 @@ type DatacenterSpec struct {
 // swagger:model DatacenterList
 type DatacenterList []Datacenter
 
+// DatacenterListPaginated is a page of datacenters
+// swagger:response DatacenterListPaginated
+type DatacenterListPaginated struct {
+	// The total number of datacenters
+	// in: header
+	// name: X-Total-Count
+	TotalCount int `json:"X-Total-Count"`
+	// in: body
+	Body DatacenterList
+}
+
 // Datacenter is the object representing a Kubernetes infra datacenter.
 // swagger:model Datacenter
 type Datacenter struct {
@@ -371,6 +382,29 @@ type AzureSize struct {
 	ResourceDiskSizeInMB int32  `json:"resourceDiskSizeInMB"`
 	MemoryInMB           int32  `json:"memoryInMB"`
 	MaxDataDiskCount     int32  `json:"maxDataDiskCount"`
+	// PremiumIO is set if the size supports premium storage disks.
+	PremiumIO bool `json:"premiumIO"`
+	// RestrictedZones are the availability zones of the region the size can not be
+	// used in. Sizes which can not be used in the region at all are not listed.
+	RestrictedZones []string `json:"restrictedZones,omitempty"`
+}
+
+// SizeList represents an array of sizes of any cloud provider.
+// swagger:model SizeList
+type SizeList []Size
+
+// Size is the provider agnostic representation of a node size.
+// swagger:model Size
+type Size struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	// Category groups the sizes of a provider, e.g. "standard" or "dedicated".
+	Category string  `json:"category,omitempty"`
+	Cores    int     `json:"cores"`
+	Memory   float32 `json:"memory"`
+	Disk     int     `json:"disk"`
+	// Architecture is the CPU architecture of the size, e.g. "amd64" or "arm64".
+	Architecture string `json:"architecture,omitempty"`
 }
 
 // HetznerSizeList represents an array of Hetzner sizes.
@@ -378,6 +412,7 @@ type AzureSize struct {
 type HetznerSizeList struct {
 	Standard  []HetznerSize `json:"standard"`
 	Dedicated []HetznerSize `json:"dedicated"`
+	ARM64     []HetznerSize `json:"arm64"`
 }
 
 // HetznerSize is the object representing Hetzner sizes.
@@ -389,6 +424,27 @@ type HetznerSize struct {
 	Cores       int     `json:"cores"`
 	Memory      float32 `json:"memory"`
 	Disk        int     `json:"disk"`
+	// Architecture is the CPU architecture of the size, either "amd64" or "arm64".
+	Architecture string `json:"architecture"`
+	// Prices contains the prices of the size per location. Locations without
+	// pricing information are omitted.
+	Prices []HetznerSizePrice `json:"prices,omitempty"`
+	// Features lists the features supported by the size, out of "dedicated-cpu" and
+	// "local-storage".
+	Features []string `json:"features,omitempty"`
+}
+
+// HetznerSizePrice is the object representing the price of a Hetzner size in a location.
+// The amounts are decimal strings as returned by the Hetzner API. Amounts which are
+// not known are left empty.
+// swagger:model HetznerSizePrice
+type HetznerSizePrice struct {
+	Location     string `json:"location"`
+	Currency     string `json:"currency,omitempty"`
+	HourlyNet    string `json:"hourlyNet,omitempty"`
+	HourlyGross  string `json:"hourlyGross,omitempty"`
+	MonthlyNet   string `json:"monthlyNet,omitempty"`
+	MonthlyGross string `json:"monthlyGross,omitempty"`
 }
 
 // PacketSizeList represents an array of Packet VM sizes.
@@ -402,6 +458,18 @@ type PacketSize struct {
 	CPUs   []PacketCPU   `json:"cpus,omitempty"`
 	Memory string        `json:"memory,omitempty"`
 	Drives []PacketDrive `json:"drives,omitempty"`
+	// Price is the price of the size, it is omitted if the plan has no pricing.
+	Price *PacketSizePrice `json:"price,omitempty"`
+	// Available is false if the size is sold out in all facilities of the datacenter.
+	// Sizes listed without facilities are always available.
+	Available bool `json:"available"`
+}
+
+// PacketSizePrice is the object representing the price of a Packet size in USD.
+// swagger:model PacketSizePrice
+type PacketSizePrice struct {
+	Hourly  float64 `json:"hourly,omitempty"`
+	Monthly float64 `json:"monthly,omitempty"`
 }
 
 // PacketCPU represents an array of Packet CPUs. It is a part of PacketSize.
@@ -556,6 +624,13 @@ type Kubeconfig struct {
 	Config []byte
 }
 
+// RootCA is the PEM encoded root CA certificate of a cluster
+// swagger:response RootCA
+type RootCA struct {
+	// in: body
+	Certificate []byte
+}
+
 // OpenstackSize is the object representing openstack's sizes.
 // swagger:model OpenstackSize
 type OpenstackSize struct {
@@ -678,6 +753,23 @@ type MasterVersion struct {
 	// If true, then given version control plane version is not compatible
 	// with one of the kubelets inside cluster and shouldn't be used.
 	RestrictedByKubeletVersion bool `json:"restrictedByKubeletVersion,omitempty"`
+
+	// If true, the version is still available, but should not be chosen for new clusters.
+	Deprecated bool `json:"deprecated,omitempty"`
+}
+
+// MasterVersionOverview lists the available versions of the master components and the updates between them
+// swagger:model MasterVersionOverview
+type MasterVersionOverview struct {
+	Versions []*MasterVersion       `json:"versions"`
+	Updates  []*MasterVersionUpdate `json:"updates"`
+}
+
+// MasterVersionUpdate describes an allowed update of the master components
+// swagger:model MasterVersionUpdate
+type MasterVersionUpdate struct {
+	From *semver.Version `json:"from"`
+	To   *semver.Version `json:"to"`
 }
 
 // CreateClusterSpec is the structure that is used to create cluster with its initial node deployment
@@ -717,6 +809,11 @@ type ClusterSpec struct {
 	// MachineNetworks optionally specifies the parameters for IPAM.
 	MachineNetworks []kubermaticv1.MachineNetworkingConfig `json:"machineNetworks,omitempty"`
 
+	// ClusterNetwork optionally specifies the pod and service networks of the cluster.
+	// They must not overlap each other or the reserved networks of the datacenter and
+	// cannot be changed after the cluster has been created.
+	ClusterNetwork *kubermaticv1.ClusterNetworkingConfig `json:"clusterNetwork,omitempty"`
+
 	// Version desired version of the kubernetes master components
 	Version ksemver.Semver `json:"version"`
 
@@ -770,6 +867,7 @@ func (cs *ClusterSpec) MarshalJSON() ([]byte, error) {
 	ret, err := json.Marshal(struct {
 		Cloud                                PublicCloudSpec                        `json:"cloud"`
 		MachineNetworks                      []kubermaticv1.MachineNetworkingConfig `json:"machineNetworks,omitempty"`
+		ClusterNetwork                       *kubermaticv1.ClusterNetworkingConfig  `json:"clusterNetwork,omitempty"`
 		Version                              ksemver.Semver                         `json:"version"`
 		OIDC                                 kubermaticv1.OIDCSettings              `json:"oidc"`
 		UpdateWindow                         *kubermaticv1.UpdateWindow             `json:"updateWindow,omitempty"`
@@ -800,6 +898,7 @@ func (cs *ClusterSpec) MarshalJSON() ([]byte, error) {
 		},
 		Version:                              cs.Version,
 		MachineNetworks:                      cs.MachineNetworks,
+		ClusterNetwork:                       cs.ClusterNetwork,
 		OIDC:                                 cs.OIDC,
 		UpdateWindow:                         cs.UpdateWindow,
 		UsePodSecurityPolicyAdmissionPlugin:  cs.UsePodSecurityPolicyAdmissionPlugin,
@@ -1004,6 +1103,38 @@ type ClusterStatus struct {
 	URL string `json:"url"`
 }
 
+// ClusterStatusSummary is a compact status of a cluster, e.g. for dashboards
+// swagger:model ClusterStatusSummary
+type ClusterStatusSummary struct {
+	// ID is the unique ID of the cluster
+	ID string `json:"id"`
+	// Name is the human readable name of the cluster
+	Name string `json:"name"`
+	// Phase is the phase of the cluster as last observed by the cluster controller
+	Phase kubermaticv1.ClusterPhase `json:"phase,omitempty"`
+	// Version is the version of the kubernetes master components
+	Version ksemver.Semver `json:"version"`
+	// URL specifies the address at which the cluster is available
+	URL string `json:"url"`
+	// Ready is set if all components of the cluster are healthy
+	Ready bool `json:"ready"`
+}
+
+// ClusterStatusSummaryList is the status of all clusters of a project
+// swagger:response ClusterStatusSummaryList
+type ClusterStatusSummaryList struct {
+	// ETag identifies the state of the clusters, it changes whenever a cluster changes.
+	// Sending it in the If-None-Match header makes subsequent requests return 304 Not
+	// Modified without a body as long as no cluster changed.
+	// in: header
+	// name: ETag
+	ETag string `json:"ETag"`
+	// NotModified is set if the clusters did not change since the requested ETag
+	NotModified bool `json:"-"`
+	// in: body
+	Body []ClusterStatusSummary
+}
+
 // ClusterHealth stores health information about the cluster's components.
 // swagger:model ClusterHealth
 type ClusterHealth struct {
@@ -1902,6 +2033,9 @@ type Event struct {
 	// A human-readable description of the status of this operation.
 	Message string `json:"message,omitempty"`
 
+	// A short, machine understandable string that gives the reason for this event.
+	Reason string `json:"reason,omitempty"`
+
 	// Type of this event (i.e. normal or warning). New types could be added in the future.
 	Type string `json:"type,omitempty"`
 
@@ -2123,6 +2257,9 @@ const (
 	GatekeeperConstraintCleanupFinalizer = "kubermatic.io/cleanup-gatekeeper-constraints"
 	// KubermaticConstraintCleanupFinalizer indicates that Kubermatic constraints for the cluster need cleanup
 	KubermaticConstraintCleanupFinalizer = "kubermatic.io/cleanup-kubermatic-constraints"
+	// ApiserverServiceCleanupFinalizer indicates that the apiserver service of the cluster needs cleanup,
+	// so that its NodePort is released without waiting for the garbage collection of the namespace
+	ApiserverServiceCleanupFinalizer = "kubermatic.io/cleanup-apiserver-service"
 	// SeedProjectCleanupFinalizer indicates that Kubermatic Projects on the seed clusters need cleanup
 	SeedProjectCleanupFinalizer = "kubermatic.io/cleanup-seed-projects"
 )
@@ -0,0 +1,283 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package export serializes a cluster together with the secrets and addons which are needed
+// to rebuild it on another master, without regenerating its certificate authorities.
+package export
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+// Version is the version of the export format. It is increased on incompatible changes.
+const Version = "v1"
+
+// KeySize is the size of the AES-256 key the secrets of an export are encrypted with.
+const KeySize = 32
+
+// SecretNames are the secrets of the cluster namespace which are exported. They hold the
+// certificate authorities, keys and tokens which cannot be regenerated without invalidating
+// the credentials issued for the cluster, and the SSH keys of the cluster.
+var SecretNames = []string{
+	resources.CASecretName,
+	resources.FrontProxyCASecretName,
+	resources.OpenVPNCASecretName,
+	resources.ServiceAccountKeySecretName,
+	resources.ViewerTokenSecretName,
+	resources.UserSSHKeys,
+}
+
+// ClusterExport is the portable representation of a cluster.
+type ClusterExport struct {
+	// Version is the version of the export format.
+	Version string `json:"version"`
+	// Cluster is the cluster object, including its address and status.
+	Cluster kubermaticv1.Cluster `json:"cluster"`
+	// Secrets are the exported secrets of the cluster namespace.
+	Secrets []corev1.Secret `json:"secrets,omitempty"`
+	// Encrypted is true if the data of the secrets is encrypted, see Encrypt.
+	Encrypted bool `json:"encrypted,omitempty"`
+	// Addons are the addons installed in the cluster.
+	Addons []kubermaticv1.Addon `json:"addons,omitempty"`
+}
+
+// New exports the cluster with the given name together with its secrets and addons from the
+// seed. Secrets which do not exist yet are skipped.
+func New(ctx context.Context, client ctrlruntimeclient.Client, clusterName string) (*ClusterExport, error) {
+	cluster := &kubermaticv1.Cluster{}
+	if err := client.Get(ctx, types.NamespacedName{Name: clusterName}, cluster); err != nil {
+		return nil, fmt.Errorf("failed to get cluster: %v", err)
+	}
+
+	export := &ClusterExport{Version: Version, Cluster: *cluster}
+	resetObjectMeta(&export.Cluster.ObjectMeta)
+	// Conditions and health describe the old control plane, they are determined anew after the import
+	export.Cluster.Status.Conditions = nil
+	export.Cluster.Status.ExtendedHealth = kubermaticv1.ExtendedClusterHealth{}
+
+	if cluster.Status.NamespaceName == "" {
+		return export, nil
+	}
+
+	for _, name := range SecretNames {
+		secret := &corev1.Secret{}
+		if err := client.Get(ctx, types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: name}, secret); err != nil {
+			if kerrors.IsNotFound(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to get Secret %s: %v", name, err)
+		}
+		resetObjectMeta(&secret.ObjectMeta)
+		export.Secrets = append(export.Secrets, *secret)
+	}
+
+	addons := &kubermaticv1.AddonList{}
+	if err := client.List(ctx, addons, ctrlruntimeclient.InNamespace(cluster.Status.NamespaceName)); err != nil {
+		return nil, fmt.Errorf("failed to list addons: %v", err)
+	}
+	for _, addon := range addons.Items {
+		resetObjectMeta(&addon.ObjectMeta)
+		addon.Spec.Cluster.UID = ""
+		addon.Status = kubermaticv1.AddonStatus{}
+		export.Addons = append(export.Addons, addon)
+	}
+
+	return export, nil
+}
+
+// Import creates the exported cluster together with its namespace, secrets and addons. The
+// cluster controller adopts the existing namespace and keeps the imported certificate
+// authorities instead of generating new ones. Secrets and addons which already exist are kept.
+func Import(ctx context.Context, client ctrlruntimeclient.Client, export *ClusterExport) (*kubermaticv1.Cluster, error) {
+	if export.Version != Version {
+		return nil, fmt.Errorf("unsupported export version %q, expected %q", export.Version, Version)
+	}
+	if export.Encrypted {
+		return nil, errors.New("the secrets of the export are encrypted, decrypt them before importing")
+	}
+	if export.Cluster.Status.NamespaceName == "" && (len(export.Secrets) > 0 || len(export.Addons) > 0) {
+		return nil, fmt.Errorf("export of cluster %s contains namespaced objects but no namespace", export.Cluster.Name)
+	}
+
+	cluster := export.Cluster.DeepCopy()
+	if err := client.Create(ctx, cluster); err != nil {
+		return nil, fmt.Errorf("failed to create cluster: %v", err)
+	}
+
+	if cluster.Status.NamespaceName == "" {
+		return cluster, nil
+	}
+
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: cluster.Status.NamespaceName}}
+	if err := client.Create(ctx, ns); err != nil && !kerrors.IsAlreadyExists(err) {
+		return nil, fmt.Errorf("failed to create Namespace %s: %v", ns.Name, err)
+	}
+
+	ownerRef := resources.GetClusterRef(cluster)
+	for _, s := range export.Secrets {
+		secret := s.DeepCopy()
+		secret.Namespace = cluster.Status.NamespaceName
+		secret.OwnerReferences = []metav1.OwnerReference{ownerRef}
+		if err := client.Create(ctx, secret); err != nil && !kerrors.IsAlreadyExists(err) {
+			return nil, fmt.Errorf("failed to create Secret %s: %v", secret.Name, err)
+		}
+	}
+
+	for _, a := range export.Addons {
+		addon := a.DeepCopy()
+		addon.Namespace = cluster.Status.NamespaceName
+		addon.OwnerReferences = []metav1.OwnerReference{ownerRef}
+		addon.Spec.Cluster.UID = cluster.UID
+		if err := client.Create(ctx, addon); err != nil && !kerrors.IsAlreadyExists(err) {
+			return nil, fmt.Errorf("failed to create Addon %s: %v", addon.Name, err)
+		}
+	}
+
+	return cluster, nil
+}
+
+// Marshal serializes the export as YAML. The secrets hold the private keys of the certificate
+// authorities of the cluster, so they have to be encrypted before.
+func (e *ClusterExport) Marshal() ([]byte, error) {
+	if len(e.Secrets) > 0 && !e.Encrypted {
+		return nil, errors.New("the secrets of the export are not encrypted")
+	}
+	return yaml.Marshal(e)
+}
+
+// ParseKey parses a base64 encoded AES-256 key, as created by `head -c 32 /dev/urandom | base64`.
+func ParseKey(data []byte) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(data)))
+	if err != nil {
+		return nil, fmt.Errorf("key is not valid base64: %v", err)
+	}
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("key must be %d bytes long, got %d bytes", KeySize, len(key))
+	}
+	return key, nil
+}
+
+// Encrypt encrypts the data of all secrets with AES-256-GCM. Every value gets its own nonce,
+// which is stored in front of the ciphertext.
+func (e *ClusterExport) Encrypt(key []byte) error {
+	if e.Encrypted {
+		return errors.New("the export is encrypted already")
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return err
+	}
+
+	for i := range e.Secrets {
+		for k, v := range e.Secrets[i].Data {
+			nonce := make([]byte, aead.NonceSize())
+			if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+				return fmt.Errorf("failed to create nonce: %v", err)
+			}
+			e.Secrets[i].Data[k] = aead.Seal(nonce, nonce, v, []byte(e.Secrets[i].Name+"/"+k))
+		}
+	}
+	e.Encrypted = true
+	return nil
+}
+
+// Decrypt decrypts the data of all secrets encrypted by Encrypt.
+func (e *ClusterExport) Decrypt(key []byte) error {
+	if !e.Encrypted {
+		return errors.New("the export is not encrypted")
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return err
+	}
+
+	decrypted := make([]map[string][]byte, len(e.Secrets))
+	for i, secret := range e.Secrets {
+		decrypted[i] = map[string][]byte{}
+		for k, v := range secret.Data {
+			if len(v) < aead.NonceSize() {
+				return fmt.Errorf("value %s of Secret %s is too short", k, secret.Name)
+			}
+			plaintext, err := aead.Open(nil, v[:aead.NonceSize()], v[aead.NonceSize():], []byte(secret.Name+"/"+k))
+			if err != nil {
+				return fmt.Errorf("failed to decrypt value %s of Secret %s, the key may be wrong: %v", k, secret.Name, err)
+			}
+			decrypted[i][k] = plaintext
+		}
+	}
+
+	// the secrets are only changed once all values were decrypted
+	for i := range e.Secrets {
+		e.Secrets[i].Data = decrypted[i]
+	}
+	e.Encrypted = false
+	return nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("key must be %d bytes long, got %d bytes", KeySize, len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %v", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// Unmarshal parses an export from YAML or JSON and checks its version.
+func Unmarshal(data []byte) (*ClusterExport, error) {
+	export := &ClusterExport{}
+	if err := yaml.Unmarshal(data, export); err != nil {
+		return nil, fmt.Errorf("failed to parse export: %v", err)
+	}
+	if export.Version != Version {
+		return nil, fmt.Errorf("unsupported export version %q, expected %q", export.Version, Version)
+	}
+	return export, nil
+}
+
+// resetObjectMeta removes all metadata which is specific to the object in its current
+// cluster, so it can be created elsewhere.
+func resetObjectMeta(meta *metav1.ObjectMeta) {
+	meta.UID = ""
+	meta.ResourceVersion = ""
+	meta.Generation = 0
+	meta.SelfLink = ""
+	meta.CreationTimestamp = metav1.Time{}
+	meta.DeletionTimestamp = nil
+	meta.DeletionGracePeriodSeconds = nil
+	meta.OwnerReferences = nil
+	meta.ManagedFields = nil
+}
@@ -0,0 +1,217 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package export
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"testing"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func init() {
+	if err := kubermaticv1.SchemeBuilder.AddToScheme(scheme.Scheme); err != nil {
+		panic(fmt.Sprintf("failed to add kubermaticv1 to scheme: %v", err))
+	}
+}
+
+func TestExportImport(t *testing.T) {
+	ctx := context.Background()
+
+	cluster := &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", UID: "old-uid"},
+		Address:    kubermaticv1.ClusterAddress{ExternalName: "test.example.com", AdminToken: "token"},
+		Status: kubermaticv1.ClusterStatus{
+			NamespaceName: "cluster-test",
+			Conditions:    []kubermaticv1.ClusterCondition{{Type: kubermaticv1.ClusterConditionClusterInitialized, Status: corev1.ConditionTrue}},
+		},
+	}
+	caSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: resources.CASecretName, Namespace: "cluster-test", UID: "old-secret-uid"},
+		Data:       map[string][]byte{resources.CACertSecretKey: []byte("cert"), resources.CAKeySecretKey: []byte("private-key-material")},
+	}
+	otherSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: resources.ApiserverTLSSecretName, Namespace: "cluster-test"},
+	}
+	addon := &kubermaticv1.Addon{
+		ObjectMeta: metav1.ObjectMeta{Name: "dashboard", Namespace: "cluster-test"},
+		Spec: kubermaticv1.AddonSpec{
+			Name:    "dashboard",
+			Cluster: corev1.ObjectReference{Name: "test", UID: "old-uid"},
+		},
+	}
+
+	source := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(cluster, caSecret, otherSecret, addon).Build()
+	export, err := New(ctx, source, "test")
+	if err != nil {
+		t.Fatalf("failed to export cluster: %v", err)
+	}
+	if len(export.Secrets) != 1 || export.Secrets[0].Name != resources.CASecretName {
+		t.Fatalf("expected only the CA secret to be exported, got %v", export.Secrets)
+	}
+
+	key := newTestKey(t)
+	if err := export.Encrypt(key); err != nil {
+		t.Fatalf("failed to encrypt export: %v", err)
+	}
+	data, err := export.Marshal()
+	if err != nil {
+		t.Fatalf("failed to marshal export: %v", err)
+	}
+	if bytes.Contains(data, []byte(base64.StdEncoding.EncodeToString([]byte("private-key-material")))) {
+		t.Fatal("expected the CA key not to be exported in plain text")
+	}
+	parsed, err := Unmarshal(data)
+	if err != nil {
+		t.Fatalf("failed to unmarshal export: %v", err)
+	}
+	if _, err := Import(ctx, fake.NewClientBuilder().WithScheme(scheme.Scheme).Build(), parsed); err == nil {
+		t.Fatal("expected an error when importing an encrypted export")
+	}
+	if err := parsed.Decrypt(key); err != nil {
+		t.Fatalf("failed to decrypt export: %v", err)
+	}
+
+	target := fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+	imported, err := Import(ctx, target, parsed)
+	if err != nil {
+		t.Fatalf("failed to import cluster: %v", err)
+	}
+	if imported.UID == "old-uid" {
+		t.Error("expected the imported cluster to get a new UID")
+	}
+	if imported.Address.AdminToken != "token" || imported.Status.NamespaceName != "cluster-test" {
+		t.Errorf("expected address and namespace to be imported, got %v and %q", imported.Address, imported.Status.NamespaceName)
+	}
+	if len(imported.Status.Conditions) != 0 {
+		t.Errorf("expected conditions not to be imported, got %v", imported.Status.Conditions)
+	}
+
+	importedSecret := &corev1.Secret{}
+	if err := target.Get(ctx, types.NamespacedName{Namespace: "cluster-test", Name: resources.CASecretName}, importedSecret); err != nil {
+		t.Fatalf("failed to get imported CA secret: %v", err)
+	}
+	if !bytes.Equal(importedSecret.Data[resources.CAKeySecretKey], []byte("private-key-material")) {
+		t.Errorf("expected the CA key to be imported, got %q", importedSecret.Data[resources.CAKeySecretKey])
+	}
+	if len(importedSecret.OwnerReferences) != 1 || importedSecret.OwnerReferences[0].UID != imported.UID {
+		t.Errorf("expected the CA secret to be owned by the imported cluster, got %v", importedSecret.OwnerReferences)
+	}
+
+	importedAddon := &kubermaticv1.Addon{}
+	if err := target.Get(ctx, types.NamespacedName{Namespace: "cluster-test", Name: "dashboard"}, importedAddon); err != nil {
+		t.Fatalf("failed to get imported addon: %v", err)
+	}
+	if importedAddon.Spec.Cluster.UID != imported.UID {
+		t.Errorf("expected the addon to reference the imported cluster, got UID %q", importedAddon.Spec.Cluster.UID)
+	}
+}
+
+func newTestKey(t *testing.T) []byte {
+	key := make([]byte, KeySize)
+	if _, err := rand.Read(key); err != nil {
+		t.Fatalf("failed to create key: %v", err)
+	}
+	return key
+}
+
+func TestMarshalRequiresEncryptedSecrets(t *testing.T) {
+	export := &ClusterExport{
+		Version: Version,
+		Secrets: []corev1.Secret{{ObjectMeta: metav1.ObjectMeta{Name: resources.CASecretName}, Data: map[string][]byte{resources.CAKeySecretKey: []byte("key")}}},
+	}
+	if _, err := export.Marshal(); err == nil {
+		t.Error("expected an error when marshaling unencrypted secrets")
+	}
+}
+
+func TestDecrypt(t *testing.T) {
+	tests := []struct {
+		name        string
+		decryptKey  func(key []byte) []byte
+		expectedErr bool
+	}{
+		{
+			name:       "matching key",
+			decryptKey: func(key []byte) []byte { return key },
+		},
+		{
+			name:        "wrong key",
+			decryptKey:  func([]byte) []byte { return bytes.Repeat([]byte{1}, KeySize) },
+			expectedErr: true,
+		},
+		{
+			name:        "key of wrong size",
+			decryptKey:  func(key []byte) []byte { return key[:16] },
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			export := &ClusterExport{
+				Version: Version,
+				Secrets: []corev1.Secret{{ObjectMeta: metav1.ObjectMeta{Name: resources.CASecretName}, Data: map[string][]byte{resources.CAKeySecretKey: []byte("key")}}},
+			}
+			key := newTestKey(t)
+			if err := export.Encrypt(key); err != nil {
+				t.Fatalf("failed to encrypt export: %v", err)
+			}
+
+			err := export.Decrypt(test.decryptKey(key))
+			if (err != nil) != test.expectedErr {
+				t.Fatalf("expected error: %v, got %v", test.expectedErr, err)
+			}
+			if err != nil {
+				if !export.Encrypted {
+					t.Error("expected the export to stay encrypted after a failed decryption")
+				}
+				return
+			}
+			if !bytes.Equal(export.Secrets[0].Data[resources.CAKeySecretKey], []byte("key")) {
+				t.Errorf("expected the decrypted key, got %q", export.Secrets[0].Data[resources.CAKeySecretKey])
+			}
+		})
+	}
+}
+
+func TestParseKey(t *testing.T) {
+	key := bytes.Repeat([]byte{1}, KeySize)
+	if parsed, err := ParseKey([]byte(base64.StdEncoding.EncodeToString(key) + "\n")); err != nil || !bytes.Equal(parsed, key) {
+		t.Errorf("failed to parse key: %v", err)
+	}
+	if _, err := ParseKey([]byte(base64.StdEncoding.EncodeToString(key[:16]))); err == nil {
+		t.Error("expected an error for a key of the wrong size")
+	}
+}
+
+func TestUnmarshalRejectsUnknownVersion(t *testing.T) {
+	if _, err := Unmarshal([]byte(`{"version": "v0", "cluster": {}}`)); err == nil {
+		t.Error("expected an error for an unknown export version")
+	}
+}
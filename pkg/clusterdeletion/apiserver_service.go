@@ -0,0 +1,56 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterdeletion
+
+import (
+	"context"
+	"fmt"
+
+	kubermaticapiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
+	"k8c.io/kubermatic/v2/pkg/resources"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	controllerruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// cleanupApiserverService deletes the apiserver service of the cluster, which releases its NodePort
+// right away instead of when the cluster namespace is garbage collected.
+func (d *Deletion) cleanupApiserverService(ctx context.Context, cluster *kubermaticv1.Cluster) error {
+	if !kuberneteshelper.HasFinalizer(cluster, kubermaticapiv1.ApiserverServiceCleanupFinalizer) {
+		return nil
+	}
+
+	if cluster.Status.NamespaceName != "" {
+		service := &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: cluster.Status.NamespaceName,
+				Name:      resources.ApiserverServiceName,
+			},
+		}
+		if err := d.seedClient.Delete(ctx, service); err != nil && !kerrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete apiserver service: %v", err)
+		}
+	}
+
+	oldCluster := cluster.DeepCopy()
+	kuberneteshelper.RemoveFinalizer(cluster, kubermaticapiv1.ApiserverServiceCleanupFinalizer)
+	return d.seedClient.Patch(ctx, cluster, controllerruntimeclient.MergeFrom(oldCluster))
+}
@@ -82,6 +82,11 @@ func (d *Deletion) CleanupCluster(ctx context.Context, log *zap.SugaredLogger, c
 		return nil
 	}
 
+	// The apiserver is not needed anymore once the nodes are gone
+	if err := d.cleanupApiserverService(ctx, cluster); err != nil {
+		return err
+	}
+
 	// We might need credentials for cloud provider cleanup. Since different cloud providers use different
 	// finalizers, we need to ensure that the credentials are not removed until the cloud provider is cleaned
 	// up, or in other words, all other finalizers have been removed from the cluster, and the
@@ -24,7 +24,9 @@ import (
 	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
 	kubermaticapiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
 	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
+	"k8c.io/kubermatic/v2/pkg/resources"
 
 	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
@@ -185,6 +187,48 @@ func TestNodesRemainUntilInClusterResourcesAreGone(t *testing.T) {
 	}
 }
 
+func TestCleanupApiserverService(t *testing.T) {
+	cluster := getClusterWithFinalizer("cluster", kubermaticapiv1.ApiserverServiceCleanupFinalizer, kubermaticapiv1.CredentialsSecretsCleanupFinalizer)
+	cluster.Status.NamespaceName = testNS
+
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNS,
+			Name:      resources.ApiserverServiceName,
+		},
+		Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeNodePort},
+	}
+
+	ctx := context.Background()
+	seedClient := fake.NewClientBuilder().WithObjects(cluster, service).Build()
+	deletion := &Deletion{seedClient: seedClient}
+
+	if err := deletion.cleanupApiserverService(ctx, cluster); err != nil {
+		t.Fatalf("cleanup failed: %v", err)
+	}
+
+	err := seedClient.Get(ctx, types.NamespacedName{Namespace: testNS, Name: resources.ApiserverServiceName}, &corev1.Service{})
+	if !kerrors.IsNotFound(err) {
+		t.Errorf("expected the apiserver service to be deleted, got %v", err)
+	}
+
+	result := &kubermaticv1.Cluster{}
+	if err := seedClient.Get(ctx, types.NamespacedName{Name: cluster.Name}, result); err != nil {
+		t.Fatalf("failed to get cluster: %v", err)
+	}
+	if kuberneteshelper.HasFinalizer(result, kubermaticapiv1.ApiserverServiceCleanupFinalizer) {
+		t.Error("expected the apiserver service finalizer to be removed")
+	}
+	if !kuberneteshelper.HasFinalizer(result, kubermaticapiv1.CredentialsSecretsCleanupFinalizer) {
+		t.Error("expected the other finalizers to be kept")
+	}
+
+	// a second cleanup must not fail on the missing service
+	if err := deletion.cleanupApiserverService(ctx, cluster); err != nil {
+		t.Fatalf("repeated cleanup failed: %v", err)
+	}
+}
+
 func getClusterWithFinalizer(name string, finalizers ...string) *kubermaticv1.Cluster {
 	return &kubermaticv1.Cluster{
 		ObjectMeta: metav1.ObjectMeta{
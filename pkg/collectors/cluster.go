@@ -19,10 +19,12 @@ package collectors
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	kubermaticv1helper "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1/helper"
 	"k8c.io/kubermatic/v2/pkg/provider"
 
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
@@ -33,6 +35,13 @@ const (
 	prefix = "kubermatic_cluster_"
 )
 
+var clusterPhases = []kubermaticv1.ClusterPhase{
+	kubermaticv1.ClusterPhasePending,
+	kubermaticv1.ClusterPhaseLaunching,
+	kubermaticv1.ClusterPhaseRunning,
+	kubermaticv1.ClusterPhaseFailed,
+}
+
 // ClusterCollector exports metrics for cluster resources
 type ClusterCollector struct {
 	client ctrlruntimeclient.Reader
@@ -40,6 +49,8 @@ type ClusterCollector struct {
 	clusterCreated *prometheus.Desc
 	clusterDeleted *prometheus.Desc
 	clusterInfo    *prometheus.Desc
+	clusterPhase   *prometheus.Desc
+	oldestPending  *prometheus.Desc
 }
 
 // MustRegisterClusterCollector registers the cluster collector at the given prometheus registry
@@ -73,6 +84,18 @@ func MustRegisterClusterCollector(registry prometheus.Registerer, client ctrlrun
 			},
 			nil,
 		),
+		clusterPhase: prometheus.NewDesc(
+			prefix+"phase_count",
+			"The number of clusters per phase, deleted clusters are not counted",
+			[]string{"phase"},
+			nil,
+		),
+		oldestPending: prometheus.NewDesc(
+			prefix+"oldest_pending_seconds",
+			"The age of the oldest cluster which is still pending, only reported if a cluster is pending",
+			[]string{"cluster"},
+			nil,
+		),
 	}
 
 	registry.MustRegister(cc)
@@ -83,6 +106,8 @@ func (cc ClusterCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- cc.clusterCreated
 	ch <- cc.clusterDeleted
 	ch <- cc.clusterInfo
+	ch <- cc.clusterPhase
+	ch <- cc.oldestPending
 }
 
 // Collect gets called by prometheus to collect the metrics
@@ -96,6 +121,47 @@ func (cc ClusterCollector) Collect(ch chan<- prometheus.Metric) {
 	for _, cluster := range clusters.Items {
 		cc.collectCluster(ch, &cluster)
 	}
+
+	cc.collectPhases(ch, clusters.Items, time.Now())
+}
+
+// collectPhases reports how many clusters are in each phase and the oldest pending cluster,
+// which tells whether the cluster controller keeps up.
+func (cc *ClusterCollector) collectPhases(ch chan<- prometheus.Metric, clusters []kubermaticv1.Cluster, now time.Time) {
+	counts := map[kubermaticv1.ClusterPhase]int{}
+	var oldestPending *kubermaticv1.Cluster
+
+	for i := range clusters {
+		c := &clusters[i]
+		if c.DeletionTimestamp != nil {
+			continue
+		}
+
+		phase := kubermaticv1helper.ClusterPhase(c)
+		counts[phase]++
+
+		if phase == kubermaticv1.ClusterPhasePending && (oldestPending == nil || c.CreationTimestamp.Before(&oldestPending.CreationTimestamp)) {
+			oldestPending = c
+		}
+	}
+
+	for _, phase := range clusterPhases {
+		ch <- prometheus.MustNewConstMetric(
+			cc.clusterPhase,
+			prometheus.GaugeValue,
+			float64(counts[phase]),
+			string(phase),
+		)
+	}
+
+	if oldestPending != nil {
+		ch <- prometheus.MustNewConstMetric(
+			cc.oldestPending,
+			prometheus.GaugeValue,
+			now.Sub(oldestPending.CreationTimestamp.Time).Seconds(),
+			oldestPending.Name,
+		)
+	}
 }
 
 func (cc *ClusterCollector) collectCluster(ch chan<- prometheus.Metric, c *kubermaticv1.Cluster) {
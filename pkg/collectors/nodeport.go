@@ -0,0 +1,111 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package collectors
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	corev1 "k8s.io/api/core/v1"
+	knet "k8s.io/apimachinery/pkg/util/net"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	nodePortPrefix = "kubermatic_nodeport_"
+)
+
+// NodePortCollector exports metrics for the NodePorts allocated in the seed cluster within
+// the NodePort range of the seed, so that operators can act before the range is exhausted.
+type NodePortCollector struct {
+	client    ctrlruntimeclient.Reader
+	portRange knet.PortRange
+
+	nodePortsAllocated *prometheus.Desc
+	nodePortsFree      *prometheus.Desc
+}
+
+// MustRegisterNodePortCollector registers the NodePort collector at the given prometheus registry.
+// The port range must be the NodePort range of the seed cluster.
+func MustRegisterNodePortCollector(registry prometheus.Registerer, client ctrlruntimeclient.Reader, portRange knet.PortRange) {
+	cc := &NodePortCollector{
+		client:    client,
+		portRange: portRange,
+		nodePortsAllocated: prometheus.NewDesc(
+			nodePortPrefix+"allocated",
+			"Number of allocated NodePorts within the NodePort range",
+			[]string{"range"},
+			nil,
+		),
+		nodePortsFree: prometheus.NewDesc(
+			nodePortPrefix+"free",
+			"Number of free NodePorts within the NodePort range",
+			[]string{"range"},
+			nil,
+		),
+	}
+
+	registry.MustRegister(cc)
+}
+
+// Describe returns the metrics descriptors
+func (cc NodePortCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- cc.nodePortsAllocated
+	ch <- cc.nodePortsFree
+}
+
+// Collect gets called by prometheus to collect the metrics
+func (cc NodePortCollector) Collect(ch chan<- prometheus.Metric) {
+	services := &corev1.ServiceList{}
+	if err := cc.client.List(context.Background(), services); err != nil {
+		utilruntime.HandleError(fmt.Errorf("failed to list services in NodePortCollector: %v", err))
+		return
+	}
+
+	allocated := allocatedNodePorts(services.Items, cc.portRange)
+
+	ch <- prometheus.MustNewConstMetric(
+		cc.nodePortsAllocated,
+		prometheus.GaugeValue,
+		float64(allocated),
+		cc.portRange.String(),
+	)
+	ch <- prometheus.MustNewConstMetric(
+		cc.nodePortsFree,
+		prometheus.GaugeValue,
+		float64(cc.portRange.Size-allocated),
+		cc.portRange.String(),
+	)
+}
+
+// allocatedNodePorts returns the number of distinct NodePorts within the given range
+// which are used by the given services.
+func allocatedNodePorts(services []corev1.Service, portRange knet.PortRange) int {
+	ports := sets.NewInt()
+	for _, service := range services {
+		for _, port := range service.Spec.Ports {
+			if port.NodePort != 0 && portRange.Contains(int(port.NodePort)) {
+				ports.Insert(int(port.NodePort))
+			}
+		}
+	}
+	return ports.Len()
+}
@@ -0,0 +1,50 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package collectors
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	knet "k8s.io/apimachinery/pkg/util/net"
+)
+
+func TestAllocatedNodePorts(t *testing.T) {
+	service := func(nodePorts ...int32) corev1.Service {
+		s := corev1.Service{}
+		for _, p := range nodePorts {
+			s.Spec.Ports = append(s.Spec.Ports, corev1.ServicePort{NodePort: p})
+		}
+		return s
+	}
+
+	services := []corev1.Service{
+		service(30000, 30001),
+		// NodePorts are shared between the TCP and UDP port of a service
+		service(30001),
+		// ClusterIP services
+		service(),
+		service(0),
+		// outside of the range
+		service(29999, 32000),
+	}
+
+	portRange := knet.PortRange{Base: 30000, Size: 2000}
+	if allocated := allocatedNodePorts(services, portRange); allocated != 2 {
+		t.Errorf("expected 2 allocated NodePorts, got %d", allocated)
+	}
+}
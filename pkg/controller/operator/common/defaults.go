@@ -878,20 +878,61 @@ func CreateVersionsYAML(config *operatorv1alpha1.KubermaticVersionsConfiguration
 		Versions: make([]*version.Version, 0),
 	}
 
-	appendOrchestrator := func(cfg *operatorv1alpha1.KubermaticVersioningConfiguration, kind string) {
+	appendOrchestrator := func(cfg *operatorv1alpha1.KubermaticVersioningConfiguration, kind string) error {
 		for _, v := range cfg.Versions {
+			addonVersions, err := pinnedAddonVersions(cfg.AddonVersions, v)
+			if err != nil {
+				return err
+			}
+
 			output.Versions = append(output.Versions, &version.Version{
-				Version: v,
-				Default: v.Equal(cfg.Default),
-				Type:    kind,
+				Version:       v,
+				Default:       v.Equal(cfg.Default),
+				Deprecated:    containsVersion(cfg.Deprecated, v),
+				Type:          kind,
+				AddonVersions: addonVersions,
 			})
 		}
+		return nil
 	}
 
-	appendOrchestrator(&config.Kubernetes, kubermaticapiv1.KubernetesClusterType)
+	if err := appendOrchestrator(&config.Kubernetes, kubermaticapiv1.KubernetesClusterType); err != nil {
+		return "", err
+	}
 	return toYAML(output)
 }
 
+// pinnedAddonVersions returns the addon versions pinned for the given version, keyed by the addon name.
+func pinnedAddonVersions(addonVersions []operatorv1alpha1.AddonVersion, v *semver.Version) (map[string]string, error) {
+	var pinned map[string]string
+	for _, av := range addonVersions {
+		constraint, err := semver.NewConstraint(av.MasterVersions)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse master versions %q of addon %s: %v", av.MasterVersions, av.Name, err)
+		}
+		if !constraint.Check(v) {
+			continue
+		}
+		if pinned == nil {
+			pinned = map[string]string{}
+		}
+		if existing, ok := pinned[av.Name]; ok && existing != av.Version {
+			return nil, fmt.Errorf("conflicting versions %s and %s of addon %s are pinned for version %s", existing, av.Version, av.Name, v)
+		}
+		pinned[av.Name] = av.Version
+	}
+	return pinned, nil
+}
+
+func containsVersion(versions []*semver.Version, v *semver.Version) bool {
+	for _, version := range versions {
+		if version.Equal(v) {
+			return true
+		}
+	}
+	return false
+}
+
 type updatesYAML struct {
 	Updates []*version.Update `json:"updates"`
 }
@@ -21,6 +21,7 @@ import (
 	"context"
 	"fmt"
 	"io/ioutil"
+	"net/http"
 	"os"
 	"os/exec"
 	"path"
@@ -49,6 +50,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/json"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/record"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
@@ -66,6 +68,9 @@ const (
 	addonLabelKey        = "kubermatic-addon"
 	cleanupFinalizerName = "cleanup-manifests"
 	addonEnsureLabelKey  = "addons.kubermatic.io/ensure"
+
+	// manifestsDownloadTimeout bounds the download of the manifests of a custom addon
+	manifestsDownloadTimeout = 30 * time.Second
 )
 
 // KubeconfigProvider provides functionality to get a clusters admin kubeconfig
@@ -88,6 +93,10 @@ type Reconciler struct {
 	KubeconfigProvider       KubeconfigProvider
 	nodeLocalDNSCacheEnabled bool
 	versions                 kubermatic.Versions
+	// httpClient downloads the manifests of custom addons
+	httpClient *http.Client
+	// manifestsAllowedHosts are the hosts the manifests of custom addons may be downloaded from
+	manifestsAllowedHosts sets.String
 }
 
 // Add creates a new Addon controller that is responsible for
@@ -104,6 +113,7 @@ func Add(
 	nodeLocalDNSCacheEnabled bool,
 	kubeconfigProvider KubeconfigProvider,
 	versions kubermatic.Versions,
+	manifestsAllowedHosts sets.String,
 ) error {
 	log = log.Named(ControllerName)
 	client := mgr.GetClient()
@@ -121,6 +131,8 @@ func Add(
 		overwriteRegistry:        overwriteRegistey,
 		nodeLocalDNSCacheEnabled: nodeLocalDNSCacheEnabled,
 		versions:                 versions,
+		httpClient:               &http.Client{Timeout: manifestsDownloadTimeout},
+		manifestsAllowedHosts:    manifestsAllowedHosts,
 	}
 
 	ctrlOptions := controller.Options{
@@ -255,7 +267,7 @@ func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, addo
 	// This is true when the addon: 1) is fully deployed, 2) doesn't have a `addonEnsureLabelKey` set to true.
 	// we do this to allow users to "edit/delete" resources deployed by unlabeled addons,
 	// while we enfornce the labeled ones
-	if addonResourcesCreated(addon) && !hasEnsureResourcesLabel(addon) {
+	if kubermaticv1helper.AddonResourcesCreated(addon) && !hasEnsureResourcesLabel(addon) {
 		return nil, nil
 	}
 
@@ -285,6 +297,22 @@ func (r *Reconciler) removeCleanupFinalizer(ctx context.Context, log *zap.Sugare
 }
 
 func (r *Reconciler) getAddonManifests(ctx context.Context, log *zap.SugaredLogger, addon *kubermaticv1.Addon, cluster *kubermaticv1.Cluster) ([]runtime.RawExtension, error) {
+	if addon.Spec.Manifests != "" {
+		allManifests, err := addonutils.ParseManifests(addon.Spec.Manifests)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse custom manifests of addon %s: %v", addon.Name, err)
+		}
+		return allManifests, nil
+	}
+
+	if addon.Spec.ManifestsURL != "" {
+		allManifests, err := addonutils.FetchManifests(ctx, r.httpClient, addon.Spec.ManifestsURL, r.manifestsAllowedHosts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get custom manifests of addon %s: %v", addon.Name, err)
+		}
+		return allManifests, nil
+	}
+
 	addonDir := r.kubernetesAddonDir
 	clusterIP, err := resources.UserClusterDNSResolverIP(cluster)
 	if err != nil {
@@ -305,17 +333,32 @@ func (r *Reconciler) getAddonManifests(ctx context.Context, log *zap.SugaredLogg
 		return nil, fmt.Errorf("failed to get credentials: %v", err)
 	}
 
-	// Add addon variables if available.
+	manifestPath := addonManifestPath(addonDir, addon)
+
+	// Add addon variables if available, the variables of the addon itself take precedence.
 	variables := make(map[string]interface{})
 
-	if sub := r.addonVariables[addon.Spec.Name]; sub != nil {
-		variables = sub.(map[string]interface{})
+	if sub, ok := r.addonVariables[addon.Spec.Name].(map[string]interface{}); ok {
+		for k, v := range sub {
+			variables[k] = v
+		}
 	}
 
 	if len(addon.Spec.Variables.Raw) > 0 {
-		if err = json.Unmarshal(addon.Spec.Variables.Raw, &variables); err != nil {
+		specVariables := make(map[string]interface{})
+		if err = json.Unmarshal(addon.Spec.Variables.Raw, &specVariables); err != nil {
 			return nil, err
 		}
+		schema, err := addonutils.LoadVariablesSchema(manifestPath)
+		if err != nil {
+			return nil, err
+		}
+		if err := schema.Validate(specVariables); err != nil {
+			return nil, fmt.Errorf("invalid variables of addon %s: %v", addon.Name, err)
+		}
+		for k, v := range specVariables {
+			variables[k] = v
+		}
 	}
 
 	data, err := addonutils.NewTemplateData(
@@ -330,7 +373,6 @@ func (r *Reconciler) getAddonManifests(ctx context.Context, log *zap.SugaredLogg
 		return nil, fmt.Errorf("failed to create template data for addon manifests: %v", err)
 	}
 
-	manifestPath := path.Join(addonDir, addon.Spec.Name)
 	allManifests, err := addonutils.ParseFromFolder(log, r.overwriteRegistry, manifestPath, data)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse addon templates in %s: %v", manifestPath, err)
@@ -339,6 +381,16 @@ func (r *Reconciler) getAddonManifests(ctx context.Context, log *zap.SugaredLogg
 	return allManifests, nil
 }
 
+// addonManifestPath returns the directory containing the manifest templates of the addon. Pinned
+// versions are kept next to the addon directory, as manifests in subdirectories are always
+// parsed for the addon itself.
+func addonManifestPath(addonDir string, addon *kubermaticv1.Addon) string {
+	if addon.Spec.Version == "" {
+		return path.Join(addonDir, addon.Spec.Name)
+	}
+	return path.Join(addonDir, fmt.Sprintf("%s@%s", addon.Spec.Name, addon.Spec.Version))
+}
+
 // combineManifests returns all manifests combined into a multi document yaml
 func (r *Reconciler) combineManifests(manifests []*bytes.Buffer) *bytes.Buffer {
 	parts := make([]string, len(manifests))
@@ -375,6 +427,10 @@ func (r *Reconciler) ensureAddonLabelOnManifests(addon *kubermaticv1.Addon, mani
 		}
 		parsedUnstructuredObj.SetLabels(existingLabels)
 
+		if err := applySchedulingOverrides(addon, parsedUnstructuredObj); err != nil {
+			return nil, fmt.Errorf("failed to apply scheduling overrides to %s %s: %v", parsedUnstructuredObj.GetKind(), parsedUnstructuredObj.GetName(), err)
+		}
+
 		jsonBuffer := &bytes.Buffer{}
 		if err := metav1unstructured.UnstructuredJSONScheme.Encode(parsedUnstructuredObj, jsonBuffer); err != nil {
 			return nil, fmt.Errorf("encoding json failed: %v", err)
@@ -393,6 +449,73 @@ func (r *Reconciler) ensureAddonLabelOnManifests(addon *kubermaticv1.Addon, mani
 	return rawManifests, nil
 }
 
+// podSpecPaths are the paths of the pod specs within the workload kinds.
+var podSpecPaths = map[string][]string{
+	"Deployment":  {"spec", "template", "spec"},
+	"StatefulSet": {"spec", "template", "spec"},
+	"DaemonSet":   {"spec", "template", "spec"},
+	"ReplicaSet":  {"spec", "template", "spec"},
+	"Job":         {"spec", "template", "spec"},
+	"CronJob":     {"spec", "jobTemplate", "spec", "template", "spec"},
+}
+
+// applySchedulingOverrides merges the node selector and tolerations of the addon into the pod
+// spec of a workload. Other objects are left untouched.
+func applySchedulingOverrides(addon *kubermaticv1.Addon, obj *metav1unstructured.Unstructured) error {
+	podSpecPath, isWorkload := podSpecPaths[obj.GetKind()]
+	if !isWorkload || (len(addon.Spec.NodeSelector) == 0 && len(addon.Spec.Tolerations) == 0) {
+		return nil
+	}
+
+	if len(addon.Spec.NodeSelector) > 0 {
+		nodeSelectorPath := append(append([]string{}, podSpecPath...), "nodeSelector")
+		nodeSelector, _, err := metav1unstructured.NestedStringMap(obj.Object, nodeSelectorPath...)
+		if err != nil {
+			return fmt.Errorf("invalid node selector: %v", err)
+		}
+		if nodeSelector == nil {
+			nodeSelector = map[string]string{}
+		}
+		for k, v := range addon.Spec.NodeSelector {
+			nodeSelector[k] = v
+		}
+		if err := metav1unstructured.SetNestedStringMap(obj.Object, nodeSelector, nodeSelectorPath...); err != nil {
+			return err
+		}
+	}
+
+	if len(addon.Spec.Tolerations) > 0 {
+		tolerationsPath := append(append([]string{}, podSpecPath...), "tolerations")
+		tolerations, _, err := metav1unstructured.NestedSlice(obj.Object, tolerationsPath...)
+		if err != nil {
+			return fmt.Errorf("invalid tolerations: %v", err)
+		}
+		for i := range addon.Spec.Tolerations {
+			toleration, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&addon.Spec.Tolerations[i])
+			if err != nil {
+				return fmt.Errorf("failed to convert toleration: %v", err)
+			}
+			if !containsToleration(tolerations, toleration) {
+				tolerations = append(tolerations, toleration)
+			}
+		}
+		if err := metav1unstructured.SetNestedSlice(obj.Object, tolerations, tolerationsPath...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func containsToleration(tolerations []interface{}, toleration map[string]interface{}) bool {
+	for _, t := range tolerations {
+		if reflect.DeepEqual(t, toleration) {
+			return true
+		}
+	}
+	return false
+}
+
 func (r *Reconciler) getAddonLabel(addon *kubermaticv1.Addon) map[string]string {
 	return map[string]string{
 		addonLabelKey: addon.Spec.Name,
@@ -609,14 +732,6 @@ func getAddonCondition(a *kubermaticv1.Addon, condType kubermaticv1.AddonConditi
 	return -1, nil
 }
 
-func addonResourcesCreated(addon *kubermaticv1.Addon) bool {
-	_, cond := getAddonCondition(addon, kubermaticv1.AddonResourcesCreated)
-	if cond != nil && cond.Status == corev1.ConditionTrue {
-		return true
-	}
-	return false
-}
-
 func hasEnsureResourcesLabel(addon *kubermaticv1.Addon) bool {
 	return addon.Labels[addonEnsureLabelKey] == "true"
 }
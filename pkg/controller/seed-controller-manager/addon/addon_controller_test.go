@@ -22,8 +22,11 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -33,9 +36,12 @@ import (
 	"k8c.io/kubermatic/v2/pkg/resources"
 	"k8c.io/kubermatic/v2/pkg/semver"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1unstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
 	kyaml "k8s.io/apimachinery/pkg/util/yaml"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -320,6 +326,51 @@ func TestController_getAddonDeploymentManifestsDefault(t *testing.T) {
 	}
 }
 
+func TestController_getAddonDeploymentManifestsPinnedVersion(t *testing.T) {
+	cluster := setupTestCluster("10.240.16.0/20")
+	addon := setupTestAddon("test")
+	addon.Spec.Version = "v2"
+
+	addonDir, err := ioutil.TempDir("/tmp", "kubermatic-tests-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(addonDir)
+
+	manifests := map[string]string{
+		addon.Spec.Name:         testManifest1WithDeployment,
+		addon.Spec.Name + "@v2": strings.Replace(testManifest1WithDeployment, "1.2.3", "2.0.0", 1),
+	}
+	for dir, manifest := range manifests {
+		if err := os.Mkdir(path.Join(addonDir, dir), 0777); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path.Join(addonDir, dir, "testManifest.yaml"), []byte(manifest), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	log := kubermaticlog.New(true, kubermaticlog.FormatConsole).Sugar()
+
+	controller := &Reconciler{
+		kubernetesAddonDir: addonDir,
+		KubeconfigProvider: &fakeKubeconfigProvider{},
+	}
+	parsed, err := controller.getAddonManifests(context.Background(), log, addon, cluster)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(parsed) != 1 {
+		t.Fatalf("invalid number of manifests returned. Expected 1, Got %d", len(parsed))
+	}
+
+	expectedRegURL := "foo.io/test:2.0.0"
+	if !strings.Contains(string(parsed[0].Raw), expectedRegURL) {
+		t.Fatalf("invalid registryURI returned. Expected \n%s, Got \n%s", expectedRegURL, parsed[0].String())
+	}
+}
+
 func TestController_getAddonManifests(t *testing.T) {
 	cluster := setupTestCluster("10.240.16.0/20")
 	addon := setupTestAddon("test")
@@ -372,6 +423,63 @@ func TestController_getAddonManifests(t *testing.T) {
 
 }
 
+func TestController_getAddonManifestsURL(t *testing.T) {
+	requests := 0
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprint(w, testManifests[0])
+	}))
+	defer server.Close()
+
+	testCases := []struct {
+		name          string
+		allowedHosts  sets.String
+		expectedCount int
+		expectErr     bool
+	}{
+		{
+			name:          "host is allowed",
+			allowedHosts:  sets.NewString(strings.TrimPrefix(server.URL, "https://")),
+			expectedCount: 1,
+		},
+		{
+			name:         "host is not allowed",
+			allowedHosts: sets.NewString("addons.example.com"),
+			expectErr:    true,
+		},
+		{
+			name:      "no hosts are allowed",
+			expectErr: true,
+		},
+	}
+
+	log := kubermaticlog.New(true, kubermaticlog.FormatConsole).Sugar()
+	cluster := setupTestCluster("10.240.16.0/20")
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			requests = 0
+			addon := setupTestAddon("custom")
+			addon.Spec.ManifestsURL = server.URL + "/addon.yaml"
+
+			controller := &Reconciler{
+				httpClient:            server.Client(),
+				manifestsAllowedHosts: tc.allowedHosts,
+			}
+			manifests, err := controller.getAddonManifests(context.Background(), log, addon, cluster)
+			if (err != nil) != tc.expectErr {
+				t.Fatalf("expected error: %v, got %v", tc.expectErr, err)
+			}
+			if len(manifests) != tc.expectedCount {
+				t.Errorf("expected %d manifests, got %d", tc.expectedCount, len(manifests))
+			}
+			if tc.expectErr && requests != 0 {
+				t.Errorf("expected no request to a host which is not allowed, got %d", requests)
+			}
+		})
+	}
+}
+
 func TestController_ensureAddonLabelOnManifests(t *testing.T) {
 	controller := &Reconciler{
 		KubeconfigProvider: &fakeKubeconfigProvider{},
@@ -400,6 +508,99 @@ func TestController_ensureAddonLabelOnManifests(t *testing.T) {
 	}
 }
 
+func TestApplySchedulingOverrides(t *testing.T) {
+	addon := &kubermaticv1.Addon{
+		Spec: kubermaticv1.AddonSpec{
+			Name:         "test",
+			NodeSelector: map[string]string{"gpu": "false"},
+			Tolerations: []corev1.Toleration{
+				{Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "addons", Effect: corev1.TaintEffectNoSchedule},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name                 string
+		object               map[string]interface{}
+		podSpecPath          []string
+		expectedNodeSelector map[string]interface{}
+		expectedTolerations  []interface{}
+	}{
+		{
+			name: "deployment without scheduling settings",
+			object: map[string]interface{}{
+				"apiVersion": "apps/v1",
+				"kind":       "Deployment",
+			},
+			podSpecPath:          []string{"spec", "template", "spec"},
+			expectedNodeSelector: map[string]interface{}{"gpu": "false"},
+			expectedTolerations: []interface{}{
+				map[string]interface{}{"key": "dedicated", "operator": "Equal", "value": "addons", "effect": "NoSchedule"},
+			},
+		},
+		{
+			name: "cronjob with scheduling settings",
+			object: map[string]interface{}{
+				"apiVersion": "batch/v1beta1",
+				"kind":       "CronJob",
+				"spec": map[string]interface{}{
+					"jobTemplate": map[string]interface{}{
+						"spec": map[string]interface{}{
+							"template": map[string]interface{}{
+								"spec": map[string]interface{}{
+									"nodeSelector": map[string]interface{}{"gpu": "true", "zone": "a"},
+									"tolerations": []interface{}{
+										map[string]interface{}{"key": "dedicated", "operator": "Equal", "value": "addons", "effect": "NoSchedule"},
+										map[string]interface{}{"operator": "Exists"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			podSpecPath:          []string{"spec", "jobTemplate", "spec", "template", "spec"},
+			expectedNodeSelector: map[string]interface{}{"gpu": "false", "zone": "a"},
+			expectedTolerations: []interface{}{
+				map[string]interface{}{"key": "dedicated", "operator": "Equal", "value": "addons", "effect": "NoSchedule"},
+				map[string]interface{}{"operator": "Exists"},
+			},
+		},
+		{
+			name: "configmap is left untouched",
+			object: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "ConfigMap",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			obj := &metav1unstructured.Unstructured{Object: tc.object}
+			if err := applySchedulingOverrides(addon, obj); err != nil {
+				t.Fatalf("failed to apply scheduling overrides: %v", err)
+			}
+
+			if tc.podSpecPath == nil {
+				if _, found := obj.Object["spec"]; found {
+					t.Errorf("expected object to be left untouched, got %v", obj.Object)
+				}
+				return
+			}
+
+			nodeSelector, _, _ := metav1unstructured.NestedFieldNoCopy(obj.Object, append(tc.podSpecPath, "nodeSelector")...)
+			if !reflect.DeepEqual(nodeSelector, tc.expectedNodeSelector) {
+				t.Errorf("expected node selector %v, got %v", tc.expectedNodeSelector, nodeSelector)
+			}
+			tolerations, _, _ := metav1unstructured.NestedFieldNoCopy(obj.Object, append(tc.podSpecPath, "tolerations")...)
+			if !reflect.DeepEqual(tolerations, tc.expectedTolerations) {
+				t.Errorf("expected tolerations %v, got %v", tc.expectedTolerations, tolerations)
+			}
+		})
+	}
+}
+
 func TestController_getApplyCommand(t *testing.T) {
 	controller := &Reconciler{}
 	cmd := controller.getApplyCommand(context.Background(), "/opt/kubeconfig", "/opt/manifest.yaml", labels.SelectorFromSet(map[string]string{"foo": "bar"}))
@@ -20,13 +20,19 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"sort"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"go.uber.org/zap"
 
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	controllerutil "k8c.io/kubermatic/v2/pkg/controller/util"
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
 	kubermaticv1helper "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1/helper"
+	"k8c.io/kubermatic/v2/pkg/version"
 	"k8c.io/kubermatic/v2/pkg/version/kubermatic"
 
 	corev1 "k8s.io/api/core/v1"
@@ -34,8 +40,10 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -47,6 +55,8 @@ import (
 const (
 	ControllerName  = "kubermatic_addoninstaller_controller"
 	addonDefaultKey = ".spec.isDefault"
+
+	eventReasonAddonDependencyUnsatisfiable = "AddonDependencyUnsatisfiable"
 )
 
 type Reconciler struct {
@@ -57,6 +67,52 @@ type Reconciler struct {
 	workerName       string
 	recorder         record.EventRecorder
 	versions         kubermatic.Versions
+	updateManager    *version.Manager
+
+	// unsatisfiable holds the reported addons with unsatisfiable dependencies
+	unsatisfiable unsatisfiableDependencies
+}
+
+// unsatisfiableDependencies remembers the addons of each cluster whose dependencies were reported
+// as unsatisfiable, so that an event is only emitted when the state of an addon changes instead
+// of on every reconciliation.
+type unsatisfiableDependencies struct {
+	lock     sync.Mutex
+	reported map[string]map[string]string
+}
+
+// update stores the unsatisfiable dependencies of the cluster, keyed by addon name, and returns the
+// names of the addons which were not reported with the same reason before, in alphabetical order.
+func (u *unsatisfiableDependencies) update(cluster string, unsatisfiable map[string]string) []string {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+
+	if u.reported == nil {
+		u.reported = map[string]map[string]string{}
+	}
+
+	var changed []string
+	for name, reason := range unsatisfiable {
+		if u.reported[cluster][name] != reason {
+			changed = append(changed, name)
+		}
+	}
+	sort.Strings(changed)
+
+	if len(unsatisfiable) == 0 {
+		delete(u.reported, cluster)
+	} else {
+		u.reported[cluster] = unsatisfiable
+	}
+	return changed
+}
+
+// forget drops the state of the cluster, once it is deleted.
+func (u *unsatisfiableDependencies) forget(cluster string) {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+
+	delete(u.reported, cluster)
 }
 
 func Add(
@@ -66,6 +122,7 @@ func Add(
 	workerName string,
 	kubernetesAddons kubermaticv1.AddonList,
 	versions kubermatic.Versions,
+	updateManager *version.Manager,
 ) error {
 	log = log.Named(ControllerName)
 
@@ -76,6 +133,7 @@ func Add(
 		kubernetesAddons: kubernetesAddons,
 		recorder:         mgr.GetEventRecorderFor(ControllerName),
 		versions:         versions,
+		updateManager:    updateManager,
 	}
 
 	c, err := controller.New(ControllerName, mgr, controller.Options{
@@ -127,6 +185,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 	if err := r.Get(ctx, request.NamespacedName, cluster); err != nil {
 		if kerrors.IsNotFound(err) {
 			log.Debug("Skipping because the cluster is already gone")
+			r.unsatisfiable.forget(request.Name)
 			return reconcile.Result{}, nil
 		}
 		return reconcile.Result{}, err
@@ -163,13 +222,155 @@ func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, clus
 		return &reconcile.Result{RequeueAfter: 1 * time.Second}, nil
 	}
 
-	return nil, r.ensureAddons(ctx, log, cluster, *r.kubernetesAddons.DeepCopy())
+	addons, err := clusterDefaultAddons(cluster, cniAddons(cluster, *r.kubernetesAddons.DeepCopy()))
+	if err != nil {
+		return nil, err
+	}
+	addons = enabledAddons(cluster, addons)
+
+	return nil, r.ensureAddons(ctx, log, cluster, pinAddonVersions(r.updateManager, cluster, addons))
+}
+
+// pinAddonVersions sets the addon versions pinned for the version of the cluster on the addons.
+// Addons without a pinned version, as well as all addons of clusters with a version which is
+// not configured, are installed from the unversioned addon manifests.
+func pinAddonVersions(updateManager *version.Manager, cluster *kubermaticv1.Cluster, addons kubermaticv1.AddonList) kubermaticv1.AddonList {
+	if updateManager == nil {
+		return addons
+	}
+
+	v, err := updateManager.GetVersion(cluster.Spec.Version.String(), apiv1.KubernetesClusterType)
+	if err != nil {
+		return addons
+	}
+
+	for i := range addons.Items {
+		addons.Items[i].Spec.Version = v.AddonVersions[addons.Items[i].Name]
+	}
+	return addons
+}
+
+// cniAddons replaces the canal addon with the CNI plugin selected for the cluster. As the
+// replaced addon is not part of the returned list anymore, it gets removed from the cluster,
+// so two CNI plugins are never installed at the same time.
+func cniAddons(cluster *kubermaticv1.Cluster, addons kubermaticv1.AddonList) kubermaticv1.AddonList {
+	plugin := cluster.Spec.ClusterNetwork.CNIPlugin
+	if plugin == "" || plugin == kubermaticv1.CNIPluginTypeCanal {
+		return addons
+	}
+
+	for i := range addons.Items {
+		if addons.Items[i].Name == string(kubermaticv1.CNIPluginTypeCanal) {
+			addons.Items[i].Name = string(plugin)
+		}
+		for j, dependency := range addons.Items[i].Spec.DependsOn {
+			if dependency == string(kubermaticv1.CNIPluginTypeCanal) {
+				addons.Items[i].Spec.DependsOn[j] = string(plugin)
+			}
+		}
+	}
+	return addons
+}
+
+// clusterDefaultAddons returns the default addons which should be installed into the cluster. If the
+// cluster restricts its default addons, names which are not a configured default addon are rejected
+// before any addon gets created.
+func clusterDefaultAddons(cluster *kubermaticv1.Cluster, addons kubermaticv1.AddonList) (kubermaticv1.AddonList, error) {
+	if len(cluster.Spec.DefaultAddons) == 0 {
+		return addons, nil
+	}
+
+	available := map[string]kubermaticv1.Addon{}
+	for _, addon := range addons.Items {
+		available[addon.Name] = addon
+	}
+
+	selected := kubermaticv1.AddonList{}
+	for _, name := range cluster.Spec.DefaultAddons {
+		addon, ok := available[name]
+		if !ok {
+			return selected, fmt.Errorf("unknown default addon %q", name)
+		}
+		selected.Items = append(selected.Items, addon)
+	}
+
+	return selected, nil
+}
+
+// enabledAddons removes the addons disabled for the cluster. As disabled addons are not part of the
+// returned list, they get removed from the cluster and are not created again.
+func enabledAddons(cluster *kubermaticv1.Cluster, addons kubermaticv1.AddonList) kubermaticv1.AddonList {
+	if len(cluster.Spec.DisabledAddons) == 0 {
+		return addons
+	}
+
+	disabled := sets.NewString(cluster.Spec.DisabledAddons...)
+	enabled := kubermaticv1.AddonList{}
+	for _, addon := range addons.Items {
+		if !disabled.Has(addon.Name) {
+			enabled.Items = append(enabled.Items, addon)
+		}
+	}
+	return enabled
+}
+
+// orderAddons sorts the addons so that every addon comes after the addons it depends on, keeping
+// the configured order otherwise. Addons whose dependencies can not be installed into the cluster,
+// because they are not part of the given addons or are part of a dependency cycle, are not part of
+// the returned list. The reasons are returned keyed by the name of the skipped addon.
+func orderAddons(addons kubermaticv1.AddonList) (kubermaticv1.AddonList, map[string]string) {
+	names := sets.NewString()
+	for _, addon := range addons.Items {
+		names.Insert(addon.Name)
+	}
+
+	ordered := kubermaticv1.AddonList{}
+	unsatisfiable := map[string]string{}
+	placed := sets.NewString()
+	pending := addons.Items
+
+	for len(pending) > 0 {
+		var remaining []kubermaticv1.Addon
+		for _, addon := range pending {
+			if missing := sets.NewString(addon.Spec.DependsOn...).Difference(names); missing.Len() > 0 {
+				unsatisfiable[addon.Name] = fmt.Sprintf("depends on %s which is not installed into the cluster", strings.Join(missing.List(), ", "))
+				continue
+			}
+			if !placed.HasAll(addon.Spec.DependsOn...) {
+				remaining = append(remaining, addon)
+				continue
+			}
+			ordered.Items = append(ordered.Items, addon)
+			placed.Insert(addon.Name)
+		}
+
+		if len(remaining) == len(pending) {
+			for _, addon := range remaining {
+				unplaced := sets.NewString(addon.Spec.DependsOn...).Difference(placed)
+				unsatisfiable[addon.Name] = fmt.Sprintf("depends on %s which can not be installed, check for dependency cycles", strings.Join(unplaced.List(), ", "))
+			}
+			break
+		}
+		pending = remaining
+	}
+
+	return ordered, unsatisfiable
 }
 
 func (r *Reconciler) ensureAddons(ctx context.Context, log *zap.SugaredLogger, cluster *kubermaticv1.Cluster, addons kubermaticv1.AddonList) error {
 	ensuredAddonsMap := map[string]struct{}{}
 	for _, addon := range addons.Items {
 		ensuredAddonsMap[addon.Name] = struct{}{}
+	}
+
+	// Addons with unsatisfiable dependencies are not created, but kept if they were created before
+	ordered, unsatisfiable := orderAddons(addons)
+	for _, name := range r.unsatisfiable.update(cluster.Name, unsatisfiable) {
+		r.recorder.Eventf(cluster, corev1.EventTypeWarning, eventReasonAddonDependencyUnsatisfiable, "Addon %s %s", name, unsatisfiable[name])
+	}
+
+	createdAddons := sets.NewString()
+	for _, addon := range ordered.Items {
 		name := types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: addon.Name}
 		addonLog := log.With("addon", name)
 		existingAddon := &kubermaticv1.Addon{}
@@ -178,18 +379,33 @@ func (r *Reconciler) ensureAddons(ctx context.Context, log *zap.SugaredLogger, c
 			if !kerrors.IsNotFound(err) {
 				return fmt.Errorf("failed to get addon %q: %v", addon.Name, err)
 			}
+			// The addon gets created once its dependencies are ready, as the
+			// cluster is reconciled whenever the status of an addon changes
+			if waiting := sets.NewString(addon.Spec.DependsOn...).Difference(createdAddons); waiting.Len() > 0 {
+				addonLog.Debugw("Waiting for the dependencies of the addon", "dependencies", waiting.List())
+				continue
+			}
 			if err := r.createAddon(ctx, addonLog, addon, cluster); err != nil {
 				return fmt.Errorf("failed to create addon %q: %v", addon.Name, err)
 			}
 		} else {
 			addonLog.Debug("Addon already exists")
-			if !reflect.DeepEqual(addon.Labels, existingAddon.Labels) || !reflect.DeepEqual(addon.Annotations, existingAddon.Annotations) || !reflect.DeepEqual(addon.Spec.Variables, existingAddon.Spec.Variables) || !reflect.DeepEqual(addon.Spec.RequiredResourceTypes, existingAddon.Spec.RequiredResourceTypes) {
+			if kubermaticv1helper.AddonResourcesCreated(existingAddon) {
+				createdAddons.Insert(addon.Name)
+			}
+			if !reflect.DeepEqual(addon.Labels, existingAddon.Labels) || !reflect.DeepEqual(addon.Annotations, existingAddon.Annotations) || !reflect.DeepEqual(addon.Spec.Variables, existingAddon.Spec.Variables) || !reflect.DeepEqual(addon.Spec.RequiredResourceTypes, existingAddon.Spec.RequiredResourceTypes) || addon.Spec.Manifests != existingAddon.Spec.Manifests || addon.Spec.ManifestsURL != existingAddon.Spec.ManifestsURL || addon.Spec.Version != existingAddon.Spec.Version || !reflect.DeepEqual(addon.Spec.NodeSelector, existingAddon.Spec.NodeSelector) || !reflect.DeepEqual(addon.Spec.Tolerations, existingAddon.Spec.Tolerations) || !reflect.DeepEqual(addon.Spec.DependsOn, existingAddon.Spec.DependsOn) {
 				updatedAddon := existingAddon.DeepCopy()
 				updatedAddon.Labels = addon.Labels
 				updatedAddon.Annotations = addon.Annotations
 				updatedAddon.Spec.Name = addon.Name
 				updatedAddon.Spec.Variables = addon.Spec.Variables
 				updatedAddon.Spec.RequiredResourceTypes = addon.Spec.RequiredResourceTypes
+				updatedAddon.Spec.Manifests = addon.Spec.Manifests
+				updatedAddon.Spec.ManifestsURL = addon.Spec.ManifestsURL
+				updatedAddon.Spec.Version = addon.Spec.Version
+				updatedAddon.Spec.NodeSelector = addon.Spec.NodeSelector
+				updatedAddon.Spec.Tolerations = addon.Spec.Tolerations
+				updatedAddon.Spec.DependsOn = addon.Spec.DependsOn
 				updatedAddon.Spec.IsDefault = true
 				if err := r.Patch(ctx, updatedAddon, ctrlruntimeclient.MergeFrom(existingAddon)); err != nil {
 					return fmt.Errorf("failed to update addon %q: %v", addon.Name, err)
@@ -233,17 +449,24 @@ func (r *Reconciler) createAddon(ctx context.Context, log *zap.SugaredLogger, ad
 	addon.Spec.IsDefault = true
 
 	// Swallow IsAlreadyExists, we have predictable names and our cache may not be
-	// up to date, leading us to think the addon wasn't installed yet.
-	if err := r.Create(ctx, &addon); err != nil && !kerrors.IsAlreadyExists(err) {
+	// up to date, leading us to think the addon wasn't installed yet. This also
+	// covers creates which failed with a transient error but were persisted anyway.
+	err := retry.OnError(controllerutil.TransientErrorBackoff, controllerutil.IsTransientError, func() error {
+		if err := r.Create(ctx, addon.DeepCopy()); err != nil && !kerrors.IsAlreadyExists(err) {
+			return err
+		}
+		return nil
+	})
+	if err != nil {
 		return fmt.Errorf("failed to create addon %q: %v", addon.Name, err)
 	}
 
 	log.Info("Addon successfully created")
 
-	err := wait.Poll(10*time.Millisecond, 10*time.Second, func() (bool, error) {
+	err = wait.Poll(10*time.Millisecond, 10*time.Second, func() (bool, error) {
 		err := r.Get(ctx, types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: addon.Name}, &kubermaticv1.Addon{})
 		if err != nil {
-			if kerrors.IsNotFound(err) {
+			if kerrors.IsNotFound(err) || controllerutil.IsTransientError(err) {
 				return false, nil
 			}
 			return false, err
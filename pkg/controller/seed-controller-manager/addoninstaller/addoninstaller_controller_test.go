@@ -18,17 +18,24 @@ package addoninstaller
 
 import (
 	"context"
+	"sort"
 	"testing"
 
+	semverlib "github.com/Masterminds/semver/v3"
 	"github.com/go-test/deep"
 
 	"k8c.io/kubermatic/v2/pkg/crd/client/clientset/versioned/scheme"
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
 	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
+	"k8c.io/kubermatic/v2/pkg/semver"
+	"k8c.io/kubermatic/v2/pkg/version"
 
 	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 	ctrlruntimefakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
@@ -168,6 +175,92 @@ func TestCreateAddon(t *testing.T) {
 	}
 }
 
+// failingCreateClient fails the first creates with the given errors. If persist is set,
+// the object is created nonetheless, like a create which timed out on the client side.
+type failingCreateClient struct {
+	ctrlruntimeclient.Client
+	errs    []error
+	persist bool
+}
+
+func (c *failingCreateClient) Create(ctx context.Context, obj ctrlruntimeclient.Object, opts ...ctrlruntimeclient.CreateOption) error {
+	if len(c.errs) == 0 {
+		return c.Client.Create(ctx, obj, opts...)
+	}
+	err := c.errs[0]
+	c.errs = c.errs[1:]
+	if c.persist {
+		if createErr := c.Client.Create(ctx, obj, opts...); createErr != nil {
+			return createErr
+		}
+	}
+	return err
+}
+
+func TestCreateAddonRetries(t *testing.T) {
+	name := "test-cluster"
+	resource := schema.GroupResource{Group: kubermaticv1.GroupName, Resource: "addons"}
+	tests := []struct {
+		name    string
+		errs    []error
+		persist bool
+	}{
+		{
+			name: "transient errors are retried",
+			errs: []error{
+				kerrors.NewServerTimeout(resource, "create", 1),
+				kerrors.NewTooManyRequests("slow down", 1),
+			},
+		},
+		{
+			name:    "already existing addon after a timed out create is not an error",
+			errs:    []error{kerrors.NewTimeoutError("timed out", 1)},
+			persist: true,
+		},
+		{
+			name:    "already existing addon is not an error",
+			errs:    []error{kerrors.NewAlreadyExists(resource, "Foo")},
+			persist: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cluster := &kubermaticv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+				Status: kubermaticv1.ClusterStatus{
+					ExtendedHealth: kubermaticv1.ExtendedClusterHealth{
+						Apiserver: kubermaticv1.HealthStatusUp,
+					},
+					NamespaceName: "cluster-" + name,
+				},
+			}
+			client := ctrlruntimefakeclient.
+				NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithObjects(cluster).
+				Build()
+
+			reconciler := Reconciler{
+				log:    kubermaticlog.New(true, kubermaticlog.FormatConsole).Sugar(),
+				Client: &failingCreateClient{Client: client, errs: test.errs, persist: test.persist},
+				kubernetesAddons: kubermaticv1.AddonList{Items: []kubermaticv1.Addon{
+					{ObjectMeta: metav1.ObjectMeta{Name: "Foo"}},
+				}},
+			}
+
+			if _, err := reconciler.reconcile(context.Background(), reconciler.log, cluster); err != nil {
+				t.Fatalf("Reconciliation failed: %v", err)
+			}
+
+			addon := &kubermaticv1.Addon{}
+			if err := client.Get(context.Background(), types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: "Foo"}, addon); err != nil {
+				t.Fatalf("Did not find expected addon: %v", err)
+			}
+		})
+	}
+}
+
 func TestUpdateAddon(t *testing.T) {
 	name := "test-cluster"
 	tests := []struct {
@@ -430,3 +523,384 @@ func TestUpdateAddon(t *testing.T) {
 		})
 	}
 }
+
+func TestClusterDefaultAddons(t *testing.T) {
+	tests := []struct {
+		name           string
+		defaultAddons  []string
+		expectedAddons []string
+		expectErr      bool
+	}{
+		{
+			name:           "all default addons without restriction",
+			expectedAddons: []string{"Foo", "Bar"},
+		},
+		{
+			name:           "only selected default addons",
+			defaultAddons:  []string{"Bar"},
+			expectedAddons: []string{"Bar"},
+		},
+		{
+			name:          "unknown default addon",
+			defaultAddons: []string{"Bar", "Baz"},
+			expectErr:     true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cluster := &kubermaticv1.Cluster{
+				Spec: kubermaticv1.ClusterSpec{
+					DefaultAddons: test.defaultAddons,
+				},
+			}
+
+			result, err := clusterDefaultAddons(cluster, *addons.DeepCopy())
+			if (err != nil) != test.expectErr {
+				t.Fatalf("expected error: %v, got %v", test.expectErr, err)
+			}
+			if test.expectErr {
+				return
+			}
+
+			var names []string
+			for _, addon := range result.Items {
+				names = append(names, addon.Name)
+			}
+			if diff := deep.Equal(names, test.expectedAddons); diff != nil {
+				t.Errorf("got unexpected addons, diff: %v", diff)
+			}
+		})
+	}
+}
+
+func TestEnabledAddons(t *testing.T) {
+	tests := []struct {
+		name           string
+		disabledAddons []string
+		expectedAddons []string
+	}{
+		{
+			name:           "no disabled addons",
+			expectedAddons: []string{"Foo", "Bar"},
+		},
+		{
+			name:           "disabled addon",
+			disabledAddons: []string{"Foo"},
+			expectedAddons: []string{"Bar"},
+		},
+		{
+			name:           "disabled addon which is not a default addon",
+			disabledAddons: []string{"Baz"},
+			expectedAddons: []string{"Foo", "Bar"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cluster := &kubermaticv1.Cluster{
+				Spec: kubermaticv1.ClusterSpec{
+					DisabledAddons: test.disabledAddons,
+				},
+			}
+
+			var names []string
+			for _, addon := range enabledAddons(cluster, *addons.DeepCopy()).Items {
+				names = append(names, addon.Name)
+			}
+			if diff := deep.Equal(names, test.expectedAddons); diff != nil {
+				t.Errorf("got unexpected addons, diff: %v", diff)
+			}
+		})
+	}
+}
+
+func TestCNIAddons(t *testing.T) {
+	defaultAddons := kubermaticv1.AddonList{Items: []kubermaticv1.Addon{
+		{ObjectMeta: metav1.ObjectMeta{Name: "canal"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "kube-proxy"}},
+	}}
+
+	tests := []struct {
+		name           string
+		cniPlugin      kubermaticv1.CNIPluginType
+		expectedAddons []string
+	}{
+		{
+			name:           "default CNI plugin",
+			expectedAddons: []string{"canal", "kube-proxy"},
+		},
+		{
+			name:           "canal CNI plugin",
+			cniPlugin:      kubermaticv1.CNIPluginTypeCanal,
+			expectedAddons: []string{"canal", "kube-proxy"},
+		},
+		{
+			name:           "cilium replaces canal",
+			cniPlugin:      kubermaticv1.CNIPluginTypeCilium,
+			expectedAddons: []string{"cilium", "kube-proxy"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cluster := &kubermaticv1.Cluster{
+				Spec: kubermaticv1.ClusterSpec{
+					ClusterNetwork: kubermaticv1.ClusterNetworkingConfig{
+						CNIPlugin: test.cniPlugin,
+					},
+				},
+			}
+
+			var names []string
+			for _, addon := range cniAddons(cluster, *defaultAddons.DeepCopy()).Items {
+				names = append(names, addon.Name)
+			}
+			if diff := deep.Equal(names, test.expectedAddons); diff != nil {
+				t.Errorf("got unexpected addons, diff: %v", diff)
+			}
+		})
+	}
+}
+
+func TestPinAddonVersions(t *testing.T) {
+	updateManager := version.New(
+		[]*version.Version{
+			{Version: semverlib.MustParse("1.19.2"), Type: "kubernetes"},
+			{Version: semverlib.MustParse("1.20.2"), Type: "kubernetes", AddonVersions: map[string]string{"canal": "v3.17"}},
+		},
+		nil,
+	)
+	defaultAddons := kubermaticv1.AddonList{Items: []kubermaticv1.Addon{
+		{ObjectMeta: metav1.ObjectMeta{Name: "canal"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "kube-proxy"}},
+	}}
+
+	tests := []struct {
+		name             string
+		version          string
+		expectedVersions []string
+	}{
+		{
+			name:             "no pinned versions",
+			version:          "1.19.2",
+			expectedVersions: []string{"", ""},
+		},
+		{
+			name:             "pinned CNI addon",
+			version:          "1.20.2",
+			expectedVersions: []string{"v3.17", ""},
+		},
+		{
+			name:             "version not configured",
+			version:          "1.21.0",
+			expectedVersions: []string{"", ""},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cluster := &kubermaticv1.Cluster{
+				Spec: kubermaticv1.ClusterSpec{
+					Version: *semver.NewSemverOrDie(test.version),
+				},
+			}
+
+			var versions []string
+			for _, addon := range pinAddonVersions(updateManager, cluster, *defaultAddons.DeepCopy()).Items {
+				versions = append(versions, addon.Spec.Version)
+			}
+			if diff := deep.Equal(versions, test.expectedVersions); diff != nil {
+				t.Errorf("got unexpected addon versions, diff: %v", diff)
+			}
+		})
+	}
+}
+
+func TestOrderAddons(t *testing.T) {
+	addon := func(name string, dependsOn ...string) kubermaticv1.Addon {
+		return kubermaticv1.Addon{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       kubermaticv1.AddonSpec{DependsOn: dependsOn},
+		}
+	}
+
+	tests := []struct {
+		name                  string
+		addons                []kubermaticv1.Addon
+		expectedAddons        []string
+		expectedUnsatisfiable []string
+	}{
+		{
+			name:           "configured order is kept without dependencies",
+			addons:         []kubermaticv1.Addon{addon("kube-proxy"), addon("canal"), addon("rbac")},
+			expectedAddons: []string{"kube-proxy", "canal", "rbac"},
+		},
+		{
+			name:           "dependencies come first",
+			addons:         []kubermaticv1.Addon{addon("kube-dns", "canal"), addon("metrics", "kube-dns"), addon("canal")},
+			expectedAddons: []string{"canal", "kube-dns", "metrics"},
+		},
+		{
+			name:                  "dependency which is not installed",
+			addons:                []kubermaticv1.Addon{addon("kube-dns", "canal"), addon("metrics", "kube-dns"), addon("rbac")},
+			expectedAddons:        []string{"rbac"},
+			expectedUnsatisfiable: []string{"kube-dns", "metrics"},
+		},
+		{
+			name:                  "dependency cycle",
+			addons:                []kubermaticv1.Addon{addon("a", "b"), addon("b", "a"), addon("c")},
+			expectedAddons:        []string{"c"},
+			expectedUnsatisfiable: []string{"a", "b"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ordered, unsatisfiable := orderAddons(kubermaticv1.AddonList{Items: test.addons})
+
+			var names []string
+			for _, addon := range ordered.Items {
+				names = append(names, addon.Name)
+			}
+			if diff := deep.Equal(names, test.expectedAddons); diff != nil {
+				t.Errorf("got unexpected addons, diff: %v", diff)
+			}
+
+			var skipped []string
+			for name := range unsatisfiable {
+				skipped = append(skipped, name)
+			}
+			sort.Strings(skipped)
+			if diff := deep.Equal(skipped, test.expectedUnsatisfiable); diff != nil {
+				t.Errorf("got unexpected unsatisfiable addons, diff: %v", diff)
+			}
+		})
+	}
+}
+
+func TestCreateAddonWaitsForDependencies(t *testing.T) {
+	name := "test-cluster"
+	tests := []struct {
+		name              string
+		dependencyCreated bool
+	}{
+		{
+			name: "dependency did not create its resources yet",
+		},
+		{
+			name:              "dependency created its resources",
+			dependencyCreated: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cluster := &kubermaticv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+				Status: kubermaticv1.ClusterStatus{
+					ExtendedHealth: kubermaticv1.ExtendedClusterHealth{
+						Apiserver: kubermaticv1.HealthStatusUp,
+					},
+					NamespaceName: "cluster-" + name,
+				},
+			}
+			dependency := &kubermaticv1.Addon{
+				ObjectMeta: metav1.ObjectMeta{Name: "canal", Namespace: cluster.Status.NamespaceName},
+				Spec:       kubermaticv1.AddonSpec{Name: "canal", IsDefault: true},
+			}
+			if test.dependencyCreated {
+				dependency.Status.Conditions = []kubermaticv1.AddonCondition{
+					{Type: kubermaticv1.AddonResourcesCreated, Status: corev1.ConditionTrue},
+				}
+			}
+			client := ctrlruntimefakeclient.
+				NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithObjects(cluster, dependency).
+				Build()
+
+			reconciler := Reconciler{
+				log:    kubermaticlog.New(true, kubermaticlog.FormatConsole).Sugar(),
+				Client: client,
+				kubernetesAddons: kubermaticv1.AddonList{Items: []kubermaticv1.Addon{
+					{ObjectMeta: metav1.ObjectMeta{Name: "kube-dns"}, Spec: kubermaticv1.AddonSpec{DependsOn: []string{"canal"}}},
+					{ObjectMeta: metav1.ObjectMeta{Name: "canal"}},
+				}},
+			}
+
+			if _, err := reconciler.reconcile(context.Background(), reconciler.log, cluster); err != nil {
+				t.Fatalf("Reconciliation failed: %v", err)
+			}
+
+			err := client.Get(context.Background(), types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: "kube-dns"}, &kubermaticv1.Addon{})
+			if created := err == nil; created != test.dependencyCreated {
+				t.Errorf("expected the dependent addon to be created: %v, got error %v", test.dependencyCreated, err)
+			}
+		})
+	}
+}
+
+func TestDependencyUnsatisfiableEventOnStateChange(t *testing.T) {
+	cluster := &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+		Status: kubermaticv1.ClusterStatus{
+			ExtendedHealth: kubermaticv1.ExtendedClusterHealth{
+				Apiserver: kubermaticv1.HealthStatusUp,
+			},
+			NamespaceName: "cluster-test-cluster",
+		},
+	}
+	client := ctrlruntimefakeclient.
+		NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(cluster).
+		Build()
+
+	kubeDNS := kubermaticv1.Addon{ObjectMeta: metav1.ObjectMeta{Name: "kube-dns"}, Spec: kubermaticv1.AddonSpec{DependsOn: []string{"canal"}}}
+	canal := kubermaticv1.Addon{ObjectMeta: metav1.ObjectMeta{Name: "canal"}}
+	recorder := record.NewFakeRecorder(10)
+	reconciler := Reconciler{
+		log:      kubermaticlog.New(true, kubermaticlog.FormatConsole).Sugar(),
+		Client:   client,
+		recorder: recorder,
+	}
+
+	steps := []struct {
+		name           string
+		addons         []kubermaticv1.Addon
+		expectedEvents int
+	}{
+		{
+			name:           "dependency becomes unsatisfiable",
+			addons:         []kubermaticv1.Addon{kubeDNS},
+			expectedEvents: 1,
+		},
+		{
+			name:   "dependency stays unsatisfiable",
+			addons: []kubermaticv1.Addon{kubeDNS},
+		},
+		{
+			name:   "dependency becomes satisfiable",
+			addons: []kubermaticv1.Addon{kubeDNS, canal},
+		},
+		{
+			name:           "dependency becomes unsatisfiable again",
+			addons:         []kubermaticv1.Addon{kubeDNS},
+			expectedEvents: 1,
+		},
+	}
+
+	for _, step := range steps {
+		reconciler.kubernetesAddons = kubermaticv1.AddonList{Items: step.addons}
+		if _, err := reconciler.reconcile(context.Background(), reconciler.log, cluster); err != nil {
+			t.Fatalf("%s: reconciliation failed: %v", step.name, err)
+		}
+		if events := len(recorder.Events); events != step.expectedEvents {
+			t.Errorf("%s: expected %d events, got %d", step.name, step.expectedEvents, events)
+		}
+		for len(recorder.Events) > 0 {
+			<-recorder.Events
+		}
+	}
+}
@@ -24,7 +24,6 @@ import (
 
 	"go.uber.org/zap"
 
-	providerconfig "github.com/kubermatic/machine-controller/pkg/providerconfig/types"
 	kubermaticapiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
 	kubermaticv1helper "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1/helper"
@@ -143,7 +142,7 @@ func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, clus
 	if !found {
 		return nil, fmt.Errorf("couldn't find datacenter %q for cluster %q", cluster.Spec.Cloud.DatacenterName, cluster.Name)
 	}
-	prov, err := cloud.Provider(datacenter.DeepCopy(), r.getGlobalSecretKeySelectorValue)
+	prov, err := cloud.Provider(datacenter.DeepCopy(), provider.SecretKeySelectorValueFuncFactory(ctx, r.Client))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create cloud provider: %v", err)
 	}
@@ -251,7 +250,3 @@ func (r *Reconciler) updateCluster(name string, modify func(*kubermaticv1.Cluste
 	}
 	return cluster, r.Patch(context.Background(), cluster, ctrlruntimeclient.MergeFrom(oldCluster))
 }
-
-func (r *Reconciler) getGlobalSecretKeySelectorValue(configVar *providerconfig.GlobalSecretKeySelector, key string) (string, error) {
-	return provider.SecretKeySelectorValueFuncFactory(context.Background(), r.Client)(configVar, key)
-}
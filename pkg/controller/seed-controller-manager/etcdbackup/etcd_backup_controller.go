@@ -37,6 +37,7 @@ import (
 	"k8c.io/kubermatic/v2/pkg/resources/etcd"
 	"k8c.io/kubermatic/v2/pkg/resources/reconciling"
 	errors2 "k8c.io/kubermatic/v2/pkg/util/errors"
+	"k8c.io/kubermatic/v2/pkg/validation"
 	"k8c.io/kubermatic/v2/pkg/version/kubermatic"
 
 	batchv1 "k8s.io/api/batch/v1"
@@ -299,6 +300,10 @@ func (r *Reconciler) ensurePendingBackupIsScheduled(ctx context.Context, backupC
 		return nil, nil
 	}
 
+	if err := validation.ValidateEtcdBackupRetention(backupConfig.Spec); err != nil {
+		return nil, errors.Wrap(err, "invalid retention settings")
+	}
+
 	if len(backupConfig.Status.CurrentBackups) > 2*backupConfig.GetKeptBackupsCount() {
 		// keeping track of many backups already, don't schedule new ones.
 		if r.setBackupConfigCondition(
@@ -480,7 +485,8 @@ func (r *Reconciler) startPendingBackupJobs(ctx context.Context, backupConfig *k
 	return returnReconcile, nil
 }
 
-// create any backup delete jobs that can be created, i.e. for all completed backups older than the last backupConfig.GetKeptBackupsCount() ones.
+// create any backup delete jobs that can be created, i.e. for all completed backups older than the last backupConfig.GetKeptBackupsCount() ones
+// and for all completed backups older than backupConfig.GetBackupMaxAge(), except for the most recent one.
 func (r *Reconciler) startPendingBackupDeleteJobs(ctx context.Context, backupConfig *kubermaticv1.EtcdBackupConfig, cluster *kubermaticv1.Cluster) (*reconcile.Result, error) {
 	// one-shot backups are not deleted until their backupConfig is deleted
 	if backupConfig.Spec.Schedule == "" && backupConfig.DeletionTimestamp == nil {
@@ -492,6 +498,7 @@ func (r *Reconciler) startPendingBackupDeleteJobs(ctx context.Context, backupCon
 	if backupConfig.DeletionTimestamp != nil {
 		keepCount = 0
 	}
+	maxAge := backupConfig.GetBackupMaxAge()
 	kept := 0
 	for i := len(backupConfig.Status.CurrentBackups) - 1; i >= 0; i-- {
 		backup := &backupConfig.Status.CurrentBackups[i]
@@ -499,7 +506,8 @@ func (r *Reconciler) startPendingBackupDeleteJobs(ctx context.Context, backupCon
 			backupsToDelete = append(backupsToDelete, backup)
 		} else if backup.BackupPhase == kubermaticv1.BackupStatusPhaseCompleted {
 			kept++
-			if kept > keepCount && backup.DeletePhase == "" {
+			expired := maxAge > 0 && kept > 1 && r.clock.Since(backup.ScheduledTime.Time) > maxAge
+			if (kept > keepCount || expired) && backup.DeletePhase == "" {
 				backupsToDelete = append(backupsToDelete, backup)
 			}
 		}
@@ -643,6 +643,7 @@ func TestStartPendingBackupDeleteJobs(t *testing.T) {
 		name              string
 		currentTime       time.Time
 		keep              int
+		maxAge            *metav1.Duration
 		existingBackups   []kubermaticv1.BackupStatus
 		existingJobs      []batchv1.Job
 		expectedBackups   []kubermaticv1.BackupStatus
@@ -816,6 +817,58 @@ func TestStartPendingBackupDeleteJobs(t *testing.T) {
 				*genBackupDeleteJob("testbackup-1970-01-01t00-02-00", "testcluster-backup-testbackup-delete-bbbb"),
 			},
 		},
+		{
+			name:        "completed backups older than the max age are deleted except for the latest one",
+			currentTime: time.Unix(400, 0).UTC(),
+			keep:        10,
+			maxAge:      &metav1.Duration{Duration: 100 * time.Second},
+			existingBackups: []kubermaticv1.BackupStatus{
+				{
+					ScheduledTime:      &metav1.Time{Time: time.Unix(60, 0).UTC()},
+					BackupName:         "testbackup-1970-01-01t00-01-00",
+					JobName:            "testcluster-backup-testbackup-create-aaaa",
+					BackupFinishedTime: &metav1.Time{Time: time.Unix(90, 0).UTC()},
+					BackupPhase:        kubermaticv1.BackupStatusPhaseCompleted,
+					BackupMessage:      "job completed",
+					DeleteJobName:      "testcluster-backup-testbackup-delete-aaaa",
+				},
+				{
+					ScheduledTime:      &metav1.Time{Time: time.Unix(120, 0).UTC()},
+					BackupName:         "testbackup-1970-01-01t00-02-00",
+					JobName:            "testcluster-backup-testbackup-create-bbbb",
+					BackupFinishedTime: &metav1.Time{Time: time.Unix(150, 0).UTC()},
+					BackupPhase:        kubermaticv1.BackupStatusPhaseCompleted,
+					BackupMessage:      "job completed",
+					DeleteJobName:      "testcluster-backup-testbackup-delete-bbbb",
+				},
+			},
+			existingJobs: []batchv1.Job{},
+			expectedBackups: []kubermaticv1.BackupStatus{
+				{
+					ScheduledTime:      &metav1.Time{Time: time.Unix(60, 0).UTC()},
+					BackupName:         "testbackup-1970-01-01t00-01-00",
+					JobName:            "testcluster-backup-testbackup-create-aaaa",
+					BackupFinishedTime: &metav1.Time{Time: time.Unix(90, 0).UTC()},
+					BackupPhase:        kubermaticv1.BackupStatusPhaseCompleted,
+					BackupMessage:      "job completed",
+					DeleteJobName:      "testcluster-backup-testbackup-delete-aaaa",
+					DeletePhase:        kubermaticv1.BackupStatusPhaseRunning,
+				},
+				{
+					ScheduledTime:      &metav1.Time{Time: time.Unix(120, 0).UTC()},
+					BackupName:         "testbackup-1970-01-01t00-02-00",
+					JobName:            "testcluster-backup-testbackup-create-bbbb",
+					BackupFinishedTime: &metav1.Time{Time: time.Unix(150, 0).UTC()},
+					BackupPhase:        kubermaticv1.BackupStatusPhaseCompleted,
+					BackupMessage:      "job completed",
+					DeleteJobName:      "testcluster-backup-testbackup-delete-bbbb",
+				},
+			},
+			expectedReconcile: &reconcile.Result{RequeueAfter: assumedJobRuntime},
+			expectedJobs: []batchv1.Job{
+				*genBackupDeleteJob("testbackup-1970-01-01t00-01-00", "testcluster-backup-testbackup-delete-aaaa"),
+			},
+		},
 		{
 			name:        "already-finished deletion is not restarted",
 			currentTime: time.Unix(240, 0).UTC(),
@@ -865,6 +918,7 @@ func TestStartPendingBackupDeleteJobs(t *testing.T) {
 			backupConfig.SetCreationTimestamp(metav1.Time{Time: clock.Now()})
 			backupConfig.Spec.Schedule = "xxx" // must be non-empty
 			backupConfig.Spec.Keep = intPtr(tc.keep)
+			backupConfig.Spec.MaxAge = tc.maxAge
 			backupConfig.Status.CurrentBackups = tc.existingBackups
 
 			initObjs := []client.Object{
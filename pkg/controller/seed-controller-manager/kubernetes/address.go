@@ -50,6 +50,7 @@ func (r *Reconciler) syncAddress(ctx context.Context, log *zap.SugaredLogger, cl
 		Cluster(cluster).
 		Client(r.Client).
 		ExternalURL(r.externalURL).
+		URLTemplate(r.apiserverURLTemplate).
 		Seed(seed).
 		Build(ctx)
 	if err != nil {
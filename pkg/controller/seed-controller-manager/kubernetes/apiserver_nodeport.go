@@ -0,0 +1,77 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// requestedAPIServerNodePort returns the NodePort requested for the apiserver service of the
+// cluster, or 0 if the port is allocated by Kubernetes.
+func requestedAPIServerNodePort(cluster *kubermaticv1.Cluster) int32 {
+	if s := cluster.Spec.ComponentsOverride.Apiserver.Service; s != nil {
+		return s.NodePort
+	}
+	return 0
+}
+
+// apiserverNodePortConflict checks that the NodePort requested for the apiserver service is within
+// the NodePort range of the seed and not used by any other service of the seed. A non-empty
+// message describing the conflict is returned if the port can not be used. The apiserver service
+// of the cluster itself is ignored, so the port is kept when the cluster is reconciled again.
+func (r *Reconciler) apiserverNodePortConflict(ctx context.Context, cluster *kubermaticv1.Cluster) (string, error) {
+	nodePort := requestedAPIServerNodePort(cluster)
+	if nodePort == 0 {
+		return "", nil
+	}
+
+	if !r.seedNodePortRange.Contains(int(nodePort)) {
+		return fmt.Sprintf("NodePort %d is not within the NodePort range %s of the seed", nodePort, r.seedNodePortRange.String()), nil
+	}
+
+	owner, err := r.nodePortOwner(ctx, cluster, nodePort)
+	if err != nil || owner == "" {
+		return "", err
+	}
+	return fmt.Sprintf("NodePort %d is already allocated to service %s", nodePort, owner), nil
+}
+
+// nodePortOwner returns the namespaced name of the service which uses the given NodePort, or an
+// empty string if the port is free. The apiserver service of the cluster itself is ignored.
+func (r *Reconciler) nodePortOwner(ctx context.Context, cluster *kubermaticv1.Cluster, nodePort int32) (string, error) {
+	services := &corev1.ServiceList{}
+	if err := r.List(ctx, services); err != nil {
+		return "", fmt.Errorf("failed to list services: %v", err)
+	}
+	for _, service := range services.Items {
+		if service.Namespace == cluster.Status.NamespaceName && service.Name == resources.ApiserverServiceName {
+			continue
+		}
+		for _, port := range service.Spec.Ports {
+			if port.NodePort == nodePort {
+				return fmt.Sprintf("%s/%s", service.Namespace, service.Name), nil
+			}
+		}
+	}
+	return "", nil
+}
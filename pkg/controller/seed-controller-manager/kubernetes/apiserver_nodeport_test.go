@@ -0,0 +1,113 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"testing"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	knetutil "k8s.io/apimachinery/pkg/util/net"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestAPIServerNodePortConflict(t *testing.T) {
+	nodePortService := func(namespace, name string, nodePort int32) *corev1.Service {
+		return &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: corev1.ServiceSpec{
+				Type:  corev1.ServiceTypeNodePort,
+				Ports: []corev1.ServicePort{{Port: 443, NodePort: nodePort}},
+			},
+		}
+	}
+
+	tests := []struct {
+		name           string
+		nodePort       int32
+		objects        []ctrlruntimeclient.Object
+		expectConflict bool
+	}{
+		{
+			name: "No NodePort requested",
+			objects: []ctrlruntimeclient.Object{
+				nodePortService("cluster-other", resources.ApiserverServiceName, 30000),
+			},
+		},
+		{
+			name:     "Free NodePort",
+			nodePort: 30000,
+			objects: []ctrlruntimeclient.Object{
+				nodePortService("cluster-other", resources.ApiserverServiceName, 30001),
+			},
+		},
+		{
+			name:     "NodePort allocated to the apiserver of the cluster itself",
+			nodePort: 30000,
+			objects: []ctrlruntimeclient.Object{
+				nodePortService("cluster-test", resources.ApiserverServiceName, 30000),
+			},
+		},
+		{
+			name:     "NodePort allocated to another service",
+			nodePort: 30000,
+			objects: []ctrlruntimeclient.Object{
+				nodePortService("cluster-other", resources.ApiserverServiceName, 30000),
+			},
+			expectConflict: true,
+		},
+		{
+			name:           "NodePort outside of the seed range",
+			nodePort:       20000,
+			expectConflict: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cluster := &kubermaticv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: kubermaticv1.ClusterSpec{
+					ComponentsOverride: kubermaticv1.ComponentSettings{
+						Apiserver: kubermaticv1.APIServerSettings{
+							Service: &kubermaticv1.APIServerServiceSettings{NodePort: test.nodePort},
+						},
+					},
+				},
+				Status: kubermaticv1.ClusterStatus{NamespaceName: "cluster-test"},
+			}
+
+			r := &Reconciler{
+				Client:            fake.NewClientBuilder().WithObjects(test.objects...).Build(),
+				seedNodePortRange: knetutil.PortRange{Base: 30000, Size: 2768},
+			}
+
+			conflict, err := r.apiserverNodePortConflict(context.Background(), cluster)
+			if err != nil {
+				t.Fatalf("failed to check the NodePort: %v", err)
+			}
+			if (conflict != "") != test.expectConflict {
+				t.Errorf("expected conflict: %v, got %q", test.expectConflict, conflict)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// apiserverProbeAttempts is the number of times the apiserver is probed during a
+	// single reconciliation before the cluster is left for the next one.
+	apiserverProbeAttempts = 3
+	apiserverProbeInterval = 2 * time.Second
+	apiserverProbeTimeout  = 5 * time.Second
+)
+
+// apiserverReachable probes the /healthz endpoint of the apiserver through the external
+// URL of the cluster, which is the same way users and nodes access it. The result is
+// recorded as event on the cluster.
+func (r *Reconciler) apiserverReachable(ctx context.Context, cluster *kubermaticv1.Cluster) (bool, error) {
+	if cluster.Address.URL == "" {
+		return false, nil
+	}
+
+	caKeyPair, err := resources.GetClusterRootCA(ctx, cluster.Status.NamespaceName, r)
+	if err != nil {
+		return false, fmt.Errorf("failed to get the cluster root CA: %v", err)
+	}
+	pool := x509.NewCertPool()
+	pool.AddCert(caKeyPair.Cert)
+
+	client := &http.Client{
+		Timeout: apiserverProbeTimeout,
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{RootCAs: pool},
+		},
+	}
+
+	if err := probeApiserver(ctx, client, cluster.Address.URL, apiserverProbeAttempts, apiserverProbeInterval); err != nil {
+		r.recorder.Eventf(cluster, corev1.EventTypeWarning, EventReasonApiserverUnreachable, "Apiserver is not reachable at %s: %v", cluster.Address.URL, err)
+		return false, nil
+	}
+
+	r.recorder.Eventf(cluster, corev1.EventTypeNormal, EventReasonApiserverReachable, "Apiserver is reachable at %s", cluster.Address.URL)
+	return true, nil
+}
+
+// probeApiserver requests the /healthz endpoint below the given URL until it responds
+// with "ok" or the attempts are used up, in which case the last error is returned.
+func probeApiserver(ctx context.Context, client *http.Client, url string, attempts int, interval time.Duration) error {
+	var err error
+	for i := 0; i < attempts; i++ {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(interval):
+			}
+		}
+
+		if err = probeApiserverOnce(ctx, client, url); err == nil {
+			return nil
+		}
+	}
+
+	return err
+}
+
+func probeApiserverOnce(ctx context.Context, client *http.Client, url string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(url, "/")+"/healthz", nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %v", err)
+	}
+
+	if resp.StatusCode != http.StatusOK || strings.TrimSpace(string(body)) != "ok" {
+		return fmt.Errorf("unexpected response %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestProbeApiserver(t *testing.T) {
+	testCases := []struct {
+		name string
+		// responses are returned in order, the last one is repeated
+		responses []int
+		body      string
+		attempts  int
+		expectErr bool
+	}{
+		{
+			name:      "healthy apiserver",
+			responses: []int{http.StatusOK},
+			body:      "ok",
+			attempts:  1,
+		},
+		{
+			name:      "apiserver becomes healthy within the attempts",
+			responses: []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusOK},
+			body:      "ok",
+			attempts:  3,
+		},
+		{
+			name:      "apiserver does not become healthy within the attempts",
+			responses: []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusOK},
+			body:      "ok",
+			attempts:  2,
+			expectErr: true,
+		},
+		{
+			name:      "unexpected body",
+			responses: []int{http.StatusOK},
+			body:      "[-]etcd failed",
+			attempts:  1,
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			requests := 0
+			server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				if req.URL.Path != "/healthz" {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				status := tc.responses[len(tc.responses)-1]
+				if requests < len(tc.responses) {
+					status = tc.responses[requests]
+				}
+				requests++
+				w.WriteHeader(status)
+				_, _ = w.Write([]byte(tc.body))
+			}))
+			defer server.Close()
+
+			err := probeApiserver(context.Background(), server.Client(), server.URL, tc.attempts, 0)
+			if (err != nil) != tc.expectErr {
+				t.Fatalf("expected error: %t, got: %v", tc.expectErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+
+	corev1 "k8s.io/api/core/v1"
+	kubeapierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// ensureAPIServerExtraVolumesExist checks that the secrets and config maps mounted into the apiserver
+// exist. A non-nil result is returned as long as one is missing, as rolling out the apiserver would
+// leave it stuck in ContainerCreating. The objects are usually created by the user after the cluster.
+func (r *Reconciler) ensureAPIServerExtraVolumesExist(ctx context.Context, cluster *kubermaticv1.Cluster) (*reconcile.Result, error) {
+	for _, volume := range cluster.Spec.ComponentsOverride.Apiserver.ExtraVolumes {
+		var (
+			obj  ctrlruntimeclient.Object
+			kind string
+			name string
+		)
+		if volume.Secret != "" {
+			obj, kind, name = &corev1.Secret{}, "secret", volume.Secret
+		} else {
+			obj, kind, name = &corev1.ConfigMap{}, "config map", volume.ConfigMap
+		}
+
+		err := r.Get(ctx, types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: name}, obj)
+		if kubeapierrors.IsNotFound(err) {
+			r.recorder.Eventf(cluster, corev1.EventTypeWarning, EventReasonMissingExtraVolume, "Waiting for %s %q of apiserver volume %q to be created in namespace %s", kind, name, volume.Name, cluster.Status.NamespaceName)
+			return &reconcile.Result{RequeueAfter: 30 * time.Second}, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %s %q of apiserver volume %q: %v", kind, name, volume.Name, err)
+		}
+	}
+
+	return nil, nil
+}
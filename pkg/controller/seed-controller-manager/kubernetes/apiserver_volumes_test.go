@@ -0,0 +1,98 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"testing"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestEnsureAPIServerExtraVolumesExist(t *testing.T) {
+	tests := []struct {
+		name          string
+		volumes       []kubermaticv1.APIServerExtraVolume
+		objects       []ctrlruntimeclient.Object
+		expectRequeue bool
+	}{
+		{
+			name: "No extra volumes",
+		},
+		{
+			name: "Referenced objects exist",
+			volumes: []kubermaticv1.APIServerExtraVolume{
+				{Name: "webhook", MountPath: "/etc/webhook", Secret: "webhook-kubeconfig"},
+				{Name: "policies", MountPath: "/etc/policies", ConfigMap: "policies"},
+			},
+			objects: []ctrlruntimeclient.Object{
+				&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "webhook-kubeconfig", Namespace: "cluster-test"}},
+				&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "policies", Namespace: "cluster-test"}},
+			},
+		},
+		{
+			name: "Missing secret",
+			volumes: []kubermaticv1.APIServerExtraVolume{
+				{Name: "webhook", MountPath: "/etc/webhook", Secret: "webhook-kubeconfig"},
+			},
+			expectRequeue: true,
+		},
+		{
+			name: "Config map in another namespace",
+			volumes: []kubermaticv1.APIServerExtraVolume{
+				{Name: "policies", MountPath: "/etc/policies", ConfigMap: "policies"},
+			},
+			objects: []ctrlruntimeclient.Object{
+				&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "policies", Namespace: "kube-system"}},
+			},
+			expectRequeue: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cluster := &kubermaticv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: kubermaticv1.ClusterSpec{
+					ComponentsOverride: kubermaticv1.ComponentSettings{
+						Apiserver: kubermaticv1.APIServerSettings{ExtraVolumes: test.volumes},
+					},
+				},
+				Status: kubermaticv1.ClusterStatus{NamespaceName: "cluster-test"},
+			}
+
+			r := &Reconciler{
+				Client:   fake.NewClientBuilder().WithObjects(test.objects...).Build(),
+				recorder: record.NewFakeRecorder(10),
+			}
+
+			res, err := r.ensureAPIServerExtraVolumesExist(context.Background(), cluster)
+			if err != nil {
+				t.Fatalf("failed to check extra volumes: %v", err)
+			}
+			if (res != nil) != test.expectRequeue {
+				t.Errorf("expected requeue: %v, got result %v", test.expectRequeue, res)
+			}
+		})
+	}
+}
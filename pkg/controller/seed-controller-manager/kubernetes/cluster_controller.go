@@ -19,18 +19,26 @@ package kubernetes
 import (
 	"context"
 	"fmt"
+	"os"
 	"reflect"
+	"strings"
 	"time"
 
 	"go.uber.org/zap"
 
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
 	k8cuserclusterclient "k8c.io/kubermatic/v2/pkg/cluster/client"
 	"k8c.io/kubermatic/v2/pkg/clusterdeletion"
 	controllerutil "k8c.io/kubermatic/v2/pkg/controller/util"
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
 	kubermaticv1helper "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1/helper"
 	"k8c.io/kubermatic/v2/pkg/provider"
+	"k8c.io/kubermatic/v2/pkg/resources"
 	"k8c.io/kubermatic/v2/pkg/resources/certificates"
+	"k8c.io/kubermatic/v2/pkg/resources/certificates/triple"
+	"k8c.io/kubermatic/v2/pkg/resources/resourcequota"
+	"k8c.io/kubermatic/v2/pkg/validation"
+	"k8c.io/kubermatic/v2/pkg/version"
 	"k8c.io/kubermatic/v2/pkg/version/kubermatic"
 
 	appsv1 "k8s.io/api/apps/v1"
@@ -41,6 +49,9 @@ import (
 	kubeapierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	knetutil "k8s.io/apimachinery/pkg/util/net"
+	"k8s.io/apimachinery/pkg/util/sets"
 	autoscalingv1beta2 "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/apis/autoscaling.k8s.io/v1beta2"
 	"k8s.io/client-go/tools/record"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
@@ -55,6 +66,37 @@ const (
 	ControllerName = "kubermatic_kubernetes_controller"
 )
 
+// Reasons of the events emitted for clusters. They are part of the API, as consumers
+// match on them, so they must never change. Details belong into the event message.
+const (
+	EventReasonReconcilingError      = "ReconcilingError"
+	EventReasonInvalidRootCASettings = "InvalidRootCASettings"
+	EventReasonRootCACreated         = "RootCACreated"
+	EventReasonRootCARotationStarted = "RootCARotationStarted"
+	EventReasonRootCARotated         = "RootCARotated"
+	EventReasonLaunchTimeout         = "LaunchTimeout"
+	EventReasonAddonsNotReady        = "AddonsNotReady"
+	EventReasonDeploymentRecreated   = "DeploymentRecreated"
+	EventReasonVersionUpdateRejected = "VersionUpdateRejected"
+	EventReasonEtcdRestoreRejected   = "EtcdRestoreRejected"
+	EventReasonEtcdRestored          = "EtcdRestored"
+	EventReasonEtcdRestoreWaiting    = "EtcdRestoreWaiting"
+	EventReasonDryRun                = "DryRun"
+	EventReasonApiserverReachable    = "ApiserverReachable"
+	EventReasonApiserverUnreachable  = "ApiserverUnreachable"
+	EventReasonResourceQuotaCreated  = "ResourceQuotaCreated"
+	EventReasonWaitingForLaunchSlot  = "WaitingForLaunchSlot"
+	EventReasonOIDCIssuerUnreachable = "OIDCIssuerUnreachable"
+	EventReasonDeprecatedVersion     = "DeprecatedVersion"
+	EventReasonMissingResourceFile   = "MissingResourceFile"
+	EventReasonMissingExtraVolume    = "MissingExtraVolume"
+	EventReasonControlPlaneDrifted   = "ControlPlaneDrifted"
+	EventReasonNodePortUnavailable   = "NodePortUnavailable"
+	EventReasonNodePortsExhausted    = "NodePortsExhausted"
+	EventReasonPaused                = "Paused"
+	EventReasonResumed               = "Resumed"
+)
+
 // userClusterConnectionProvider offers functions to retrieve clients for the given user clusters
 type userClusterConnectionProvider interface {
 	GetClient(context.Context, *kubermaticv1.Cluster, ...k8cuserclusterclient.ConfigOption) (ctrlruntimeclient.Client, error)
@@ -65,6 +107,12 @@ type Features struct {
 	EtcdDataCorruptionChecks     bool
 	KubernetesOIDCAuthentication bool
 	EtcdLauncher                 bool
+	// EtcdBackupRestore is set when the etcd backup and restore controllers are running
+	EtcdBackupRestore bool
+	// DriftDetection configures the detection of drifted control plane resources
+	DriftDetection DriftDetectionMode
+	// NodePortAllocation configures how the NodePort of the apiserver service is picked
+	NodePortAllocation NodePortAllocationStrategy
 }
 
 // Reconciler is a controller which is responsible for managing clusters
@@ -81,6 +129,7 @@ type Reconciler struct {
 
 	overwriteRegistry                                string
 	nodePortRange                                    string
+	seedNodePortRange                                knetutil.PortRange
 	nodeAccessNetwork                                string
 	etcdDiskSize                                     resource.Quantity
 	inClusterPrometheusRulesFile                     string
@@ -94,8 +143,20 @@ type Reconciler struct {
 	etcdLauncherImage                                string
 	dnatControllerImage                              string
 	concurrentClusterUpdates                         int
+	concurrentClusterLaunches                        int
 	etcdBackupRestoreController                      bool
 	backupSchedule                                   time.Duration
+	clusterLaunchTimeout                             time.Duration
+	addonsReadyTimeout                               time.Duration
+	updateManager                                    *version.Manager
+	dryRun                                           bool
+	apiserverURLTemplate                             string
+	resourceQuotaPlans                               resourcequota.Plans
+	namespacePrefix                                  string
+	resyncPeriods                                    ResyncPeriods
+	reachableCheckBackoff                            *reachableCheckBackoff
+	launchSlots                                      *launchSlots
+	nodePortReservations                             *nodePortReservations
 
 	oidcIssuerURL      string
 	oidcIssuerClientID string
@@ -105,6 +166,8 @@ type Reconciler struct {
 
 	tunnelingAgentIP string
 	caBundle         *certificates.CABundle
+	rootCASigningCA  *triple.SigningCA
+	phaseNotifier    *PhaseNotifier
 }
 
 // NewController creates a cluster controller.
@@ -118,6 +181,7 @@ func Add(
 	userClusterConnProvider userClusterConnectionProvider,
 	overwriteRegistry string,
 	nodePortRange string,
+	seedNodePortRange knetutil.PortRange,
 	nodeAccessNetwork string,
 	etcdDiskSize resource.Quantity,
 	monitoringScrapeAnnotationPrefix string,
@@ -128,8 +192,17 @@ func Add(
 	dockerPullConfigJSON []byte,
 	nodeLocalDNSCacheEnabled bool,
 	concurrentClusterUpdates int,
+	concurrentClusterLaunches int,
 	etcdBackupRestoreController bool,
 	backupSchedule time.Duration,
+	clusterLaunchTimeout time.Duration,
+	addonsReadyTimeout time.Duration,
+	updateManager *version.Manager,
+	dryRun bool,
+	apiserverURLTemplate string,
+	resourceQuotaPlans resourcequota.Plans,
+	namespacePrefix string,
+	resyncPeriods ResyncPeriods,
 
 	oidcIssuerURL string,
 	oidcIssuerClientID string,
@@ -139,6 +212,8 @@ func Add(
 
 	tunnelingAgentIP string,
 	caBundle *certificates.CABundle,
+	rootCASigningCA *triple.SigningCA,
+	phaseNotifier *PhaseNotifier,
 
 	features Features,
 	versions kubermatic.Versions) error {
@@ -153,6 +228,7 @@ func Add(
 
 		overwriteRegistry:                      overwriteRegistry,
 		nodePortRange:                          nodePortRange,
+		seedNodePortRange:                      seedNodePortRange,
 		nodeAccessNetwork:                      nodeAccessNetwork,
 		etcdDiskSize:                           etcdDiskSize,
 		inClusterPrometheusRulesFile:           inClusterPrometheusRulesFile,
@@ -166,8 +242,20 @@ func Add(
 		etcdLauncherImage:                                etcdLauncherImage,
 		dnatControllerImage:                              dnatControllerImage,
 		concurrentClusterUpdates:                         concurrentClusterUpdates,
+		concurrentClusterLaunches:                        concurrentClusterLaunches,
 		etcdBackupRestoreController:                      etcdBackupRestoreController,
 		backupSchedule:                                   backupSchedule,
+		clusterLaunchTimeout:                             clusterLaunchTimeout,
+		addonsReadyTimeout:                               addonsReadyTimeout,
+		updateManager:                                    updateManager,
+		dryRun:                                           dryRun,
+		apiserverURLTemplate:                             apiserverURLTemplate,
+		resourceQuotaPlans:                               resourceQuotaPlans,
+		namespacePrefix:                                  namespacePrefix,
+		resyncPeriods:                                    resyncPeriods,
+		reachableCheckBackoff:                            newReachableCheckBackoff(),
+		launchSlots:                                      newLaunchSlots(),
+		nodePortReservations:                             newNodePortReservations(),
 
 		externalURL: externalURL,
 		seedGetter:  seedGetter,
@@ -177,11 +265,19 @@ func Add(
 
 		tunnelingAgentIP: tunnelingAgentIP,
 		caBundle:         caBundle,
+		rootCASigningCA:  rootCASigningCA,
+		phaseNotifier:    phaseNotifier,
 
 		features: features,
 		versions: versions,
 	}
 
+	if phaseNotifier != nil {
+		if err := mgr.Add(phaseNotifier); err != nil {
+			return fmt.Errorf("failed to add the phase notifier: %v", err)
+		}
+	}
+
 	c, err := controller.New(ControllerName, mgr, controller.Options{Reconciler: reconciler, MaxConcurrentReconciles: numWorkers})
 	if err != nil {
 		return err
@@ -190,6 +286,8 @@ func Add(
 	typesToWatch := []ctrlruntimeclient.Object{
 		&corev1.Service{},
 		&corev1.ServiceAccount{},
+		&corev1.ResourceQuota{},
+		&corev1.LimitRange{},
 		&corev1.ConfigMap{},
 		&corev1.Secret{},
 		&corev1.Namespace{},
@@ -200,6 +298,7 @@ func Add(
 		&autoscalingv1beta2.VerticalPodAutoscaler{},
 		&rbacv1.Role{},
 		&rbacv1.RoleBinding{},
+		&kubermaticv1.Addon{},
 	}
 
 	for _, t := range typesToWatch {
@@ -219,12 +318,32 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 	if err := r.Get(ctx, request.NamespacedName, cluster); err != nil {
 		if kubeapierrors.IsNotFound(err) {
 			log.Debug("Could not find cluster")
+			lastSuccessfulReconcile.DeleteLabelValues(request.Name)
 			return reconcile.Result{}, nil
 		}
 		return reconcile.Result{}, err
 	}
 	log = log.With("cluster", cluster.Name)
 
+	// In dry run mode all changes are only logged and summarized in an event,
+	// which allows to check what the controller would do to a cluster
+	var dryRun *dryRunClient
+	if r.dryRun {
+		dryRun = newDryRunClient(r.Client, log)
+		dryRunReconciler := *r
+		dryRunReconciler.Client = dryRun
+		r = &dryRunReconciler
+	}
+
+	// Paused clusters are skipped by the reconcile wrapper, record the pause so it is
+	// visible why the cluster does not progress
+	if cluster.Labels[kubermaticv1.WorkerNameLabelKey] == r.workerName {
+		paused, err := r.syncPause(ctx, cluster)
+		if err != nil || paused {
+			return reconcile.Result{}, err
+		}
+	}
+
 	// Add a wrapping here so we can emit an event on error
 	result, err := kubermaticv1helper.ClusterReconcileWrapper(
 		ctx,
@@ -242,21 +361,48 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 				}, err
 			}
 
-			return r.reconcile(ctx, log, cluster)
+			result, err := r.reconcile(ctx, log, cluster)
+			if phaseErr := r.syncPhase(ctx, cluster); phaseErr != nil {
+				err = utilerrors.NewAggregate([]error{err, phaseErr})
+			}
+			return result, err
 		},
 	)
 	if err != nil {
 		log.Errorw("Reconciling failed", zap.Error(err))
-		r.recorder.Event(cluster, corev1.EventTypeWarning, "ReconcilingError", err.Error())
+		r.recorder.Event(cluster, corev1.EventTypeWarning, EventReasonReconcilingError, err.Error())
+	} else if dryRun == nil {
+		lastSuccessfulReconcile.WithLabelValues(cluster.Name).SetToCurrentTime()
+	}
+
+	if dryRun != nil {
+		summary := dryRun.summary()
+		log.Infow("Dry run finished", "changes", summary)
+		r.recorder.Eventf(cluster, corev1.EventTypeNormal, EventReasonDryRun, "Dry run skipped changes: %s", summary)
+		// Requeueing would only repeat the same dry run
+		return reconcile.Result{}, err
 	}
 
 	if result == nil {
 		result = &reconcile.Result{}
 	}
 
+	// Failed reconciliations are retried with backoff anyway
+	if err == nil {
+		return r.resyncPeriods.apply(*result, cluster.Status.Phase), nil
+	}
+
 	return *result, err
 }
 
+// forgetCluster drops the in-memory state kept for the cluster once it is being deleted, so its
+// launch slot and the NodePort reserved for its apiserver service are free for other clusters.
+func (r *Reconciler) forgetCluster(cluster *kubermaticv1.Cluster) {
+	r.resetReachableCheckDelay(cluster)
+	r.launchSlots.release(cluster.Name)
+	r.nodePortReservations.release(cluster.Name)
+}
+
 func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, cluster *kubermaticv1.Cluster) (*reconcile.Result, error) {
 	// synchronize cluster.status.health for Kubernetes clusters
 	if err := r.syncHealth(ctx, cluster); err != nil {
@@ -264,7 +410,15 @@ func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, clus
 	}
 
 	if cluster.DeletionTimestamp != nil {
+		// The cleanup also deletes resources inside the user cluster, which
+		// can not be simulated
+		if r.dryRun {
+			log.Info("Skipping cleanup in dry run mode")
+			return nil, nil
+		}
+
 		log.Debug("Cleaning up cluster")
+		r.forgetCluster(cluster)
 
 		// Defer getting the client to make sure we only request it if we actually need it
 		userClusterClientGetter := func() (ctrlruntimeclient.Client, error) {
@@ -278,6 +432,72 @@ func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, clus
 		return &reconcile.Result{RequeueAfter: 10 * time.Second}, clusterdeletion.New(r.Client, userClusterClientGetter, r.etcdBackupRestoreController).CleanupCluster(ctx, log, cluster)
 	}
 
+	// Only start launching a new cluster once a launch slot is available, creating
+	// the control planes of many clusters at once overwhelms the seed
+	available, err := r.launchSlotAvailable(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+	if !available {
+		return &reconcile.Result{RequeueAfter: 30 * time.Second}, nil
+	}
+
+	if err := r.markLaunchStarted(ctx, cluster); err != nil {
+		return nil, err
+	}
+
+	// Give up on clusters which did not come up within the launch timeout,
+	// retrying would only keep the broken control plane around. Clusters which
+	// become healthy afterwards are reconciled again, which clears the error.
+	if r.launchTimeoutExceeded(cluster) {
+		if cluster.Status.ErrorReason != nil && *cluster.Status.ErrorReason == kubermaticv1.LaunchTimeoutClusterError {
+			return &reconcile.Result{}, nil
+		}
+
+		msg := fmt.Sprintf("cluster did not become healthy within %v, unhealthy components: %s", r.clusterLaunchTimeout, strings.Join(unhealthyComponents(cluster.Status.ExtendedHealth), ", "))
+		r.recorder.Event(cluster, corev1.EventTypeWarning, EventReasonLaunchTimeout, msg)
+		return &reconcile.Result{}, r.updateClusterError(ctx, cluster, kubermaticv1.LaunchTimeoutClusterError, msg)
+	}
+
+	// Refuse to create any resources for clusters with settings we can not
+	// satisfy instead of silently falling back to defaults
+	if err := validation.ValidateRootCASettings(cluster.Spec.RootCA); err != nil {
+		r.recorder.Eventf(cluster, corev1.EventTypeWarning, EventReasonInvalidRootCASettings, "Invalid root CA settings: %v", err)
+		return nil, r.updateClusterError(ctx, cluster, kubermaticv1.InvalidConfigurationClusterError, fmt.Sprintf("invalid root CA settings: %v", err))
+	}
+
+	// Files configured for the seed are read while creating the resources, report
+	// a missing file once instead of failing with a low-level error later on
+	if err := r.checkResourceFiles(); err != nil {
+		r.recorder.Event(cluster, corev1.EventTypeWarning, EventReasonMissingResourceFile, err.Error())
+		return nil, r.updateClusterError(ctx, cluster, kubermaticv1.ReconcileClusterError, err.Error())
+	}
+
+	// A requested apiserver NodePort must be free, Kubernetes would otherwise
+	// reject the service or the cluster would silently get a different port
+	conflict, err := r.apiserverNodePortConflict(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+	if conflict != "" {
+		r.recorder.Event(cluster, corev1.EventTypeWarning, EventReasonNodePortUnavailable, conflict)
+		return nil, r.updateClusterError(ctx, cluster, kubermaticv1.InvalidConfigurationClusterError, fmt.Sprintf("requested apiserver NodePort is not available: %s", conflict))
+	}
+
+	// New clusters must not be launched with a deprecated version, existing
+	// clusters keep running it until they are updated
+	if err := r.validateNewClusterVersion(ctx, cluster); err != nil {
+		r.recorder.Eventf(cluster, corev1.EventTypeWarning, EventReasonDeprecatedVersion, "Rejected cluster: %v", err)
+		return nil, r.updateClusterError(ctx, cluster, kubermaticv1.InvalidConfigurationClusterError, fmt.Sprintf("rejected cluster: %v", err))
+	}
+
+	// Do not roll out a version change which is not covered by the configured
+	// updates, the control plane keeps running the last deployed version
+	if err := r.validateVersionUpdate(cluster); err != nil {
+		r.recorder.Eventf(cluster, corev1.EventTypeWarning, EventReasonVersionUpdateRejected, "Rejected version update: %v", err)
+		return nil, r.updateClusterError(ctx, cluster, kubermaticv1.UnsupportedChangeClusterError, fmt.Sprintf("rejected version update: %v", err))
+	}
+
 	res, err := r.reconcileCluster(ctx, cluster)
 	if err != nil {
 		updateErr := r.updateClusterError(ctx, cluster, kubermaticv1.ReconcileClusterError, err.Error())
@@ -294,6 +514,188 @@ func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, clus
 	return res, nil
 }
 
+// launchSlotAvailable returns true if the cluster may start launching. Clusters which already
+// started launching or are initialized are always reconciled, new clusters have to wait until
+// less than concurrentClusterLaunches clusters are launching. An event is emitted once when a
+// cluster starts waiting.
+func (r *Reconciler) launchSlotAvailable(ctx context.Context, cluster *kubermaticv1.Cluster) (bool, error) {
+	if r.concurrentClusterLaunches <= 0 {
+		return true, nil
+	}
+	if _, condition := kubermaticv1helper.GetClusterCondition(cluster, kubermaticv1.ClusterConditionClusterInitialized); condition != nil {
+		return true, nil
+	}
+
+	clusters := &kubermaticv1.ClusterList{}
+	if err := r.List(ctx, clusters); err != nil {
+		return false, fmt.Errorf("failed to list clusters: %v", err)
+	}
+
+	launchingClusters := sets.NewString()
+	for i := range clusters.Items {
+		if launching(&clusters.Items[i]) {
+			launchingClusters.Insert(clusters.Items[i].Name)
+		}
+	}
+
+	granted, waitingStarted := r.launchSlots.acquire(cluster.Name, launchingClusters, r.concurrentClusterLaunches)
+	if waitingStarted {
+		r.recorder.Eventf(cluster, corev1.EventTypeNormal, EventReasonWaitingForLaunchSlot, "Waiting for a launch slot, %d clusters are launching already", r.concurrentClusterLaunches)
+	}
+	return granted, nil
+}
+
+// launching returns true if the control plane of the cluster is being created, but the
+// cluster did not get initialized yet. Paused clusters and clusters which failed with an
+// error that is not resolved by retrying do not make progress and are not counted.
+func launching(cluster *kubermaticv1.Cluster) bool {
+	if cluster.DeletionTimestamp != nil || cluster.Spec.Pause {
+		return false
+	}
+	if !cluster.Status.HasConditionValue(kubermaticv1.ClusterConditionClusterInitialized, corev1.ConditionFalse) {
+		return false
+	}
+	return cluster.Status.ErrorReason == nil || *cluster.Status.ErrorReason == kubermaticv1.ReconcileClusterError
+}
+
+// markLaunchStarted sets the ClusterInitialized condition of a cluster which has not started
+// launching yet to false. Its transition time is the start of the launch timeout.
+func (r *Reconciler) markLaunchStarted(ctx context.Context, cluster *kubermaticv1.Cluster) error {
+	if _, condition := kubermaticv1helper.GetClusterCondition(cluster, kubermaticv1.ClusterConditionClusterInitialized); condition != nil {
+		return nil
+	}
+
+	err := r.updateCluster(ctx, cluster, func(c *kubermaticv1.Cluster) {
+		kubermaticv1helper.SetClusterCondition(
+			c,
+			r.versions,
+			kubermaticv1.ClusterConditionClusterInitialized,
+			corev1.ConditionFalse,
+			kubermaticv1.ReasonClusterLaunching,
+			"Waiting for the control plane to become healthy",
+		)
+		// SetClusterCondition only records transitions of existing conditions
+		pos, _ := kubermaticv1helper.GetClusterCondition(c, kubermaticv1.ClusterConditionClusterInitialized)
+		c.Status.Conditions[pos].LastTransitionTime = metav1.Now()
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set cluster condition %s: %v", kubermaticv1.ClusterConditionClusterInitialized, err)
+	}
+	return nil
+}
+
+// launchTimeoutExceeded returns true if a launch timeout is configured and the cluster
+// neither got initialized nor became healthy within it.
+func (r *Reconciler) launchTimeoutExceeded(cluster *kubermaticv1.Cluster) bool {
+	if r.clusterLaunchTimeout <= 0 {
+		return false
+	}
+	if cluster.Status.HasConditionValue(kubermaticv1.ClusterConditionClusterInitialized, corev1.ConditionTrue) {
+		return false
+	}
+	if cluster.Status.ExtendedHealth.AllHealthy() {
+		return false
+	}
+	return time.Since(launchStartTime(cluster)) > r.clusterLaunchTimeout
+}
+
+// validateVersionUpdate returns an error if the version of the cluster differs from the
+// version of its control plane and the configured updates do not allow the transition.
+func (r *Reconciler) validateVersionUpdate(cluster *kubermaticv1.Cluster) error {
+	if r.updateManager == nil || cluster.Status.ControlPlaneVersion == "" {
+		return nil
+	}
+	return r.updateManager.ValidateUpdate(cluster.Status.ControlPlaneVersion, cluster.Spec.Version.String(), apiv1.KubernetesClusterType)
+}
+
+// checkResourceFiles returns an error naming the first configured resource file which cannot
+// be read, together with the component it is needed for.
+func (r *Reconciler) checkResourceFiles() error {
+	files := []struct {
+		component string
+		file      string
+	}{
+		{component: "Prometheus rules", file: r.inClusterPrometheusRulesFile},
+		{component: "Prometheus scraping configs", file: r.inClusterPrometheusScrapingConfigsFile},
+	}
+
+	for _, f := range files {
+		if f.file == "" {
+			continue
+		}
+		if _, err := os.Stat(f.file); err != nil {
+			if os.IsNotExist(err) {
+				return fmt.Errorf("the %s file %s does not exist, check the seed controller manager configuration", f.component, f.file)
+			}
+			return fmt.Errorf("failed to read the %s file %s: %v", f.component, f.file, err)
+		}
+	}
+
+	return nil
+}
+
+// validateNewClusterVersion returns an error if the control plane of the cluster was not
+// deployed yet and its version must not be used for new clusters.
+func (r *Reconciler) validateNewClusterVersion(ctx context.Context, cluster *kubermaticv1.Cluster) error {
+	if r.updateManager == nil {
+		return nil
+	}
+	launched, err := r.controlPlaneLaunched(ctx, cluster)
+	if err != nil || launched {
+		return err
+	}
+	return r.updateManager.ValidateNewCluster(cluster.Spec.Version.String(), apiv1.KubernetesClusterType)
+}
+
+// controlPlaneLaunched returns true if the control plane of the cluster was deployed before. Clusters
+// created before the deployed version was recorded in the status are recognized by their
+// ClusterInitialized condition or their apiserver deployment.
+func (r *Reconciler) controlPlaneLaunched(ctx context.Context, cluster *kubermaticv1.Cluster) (bool, error) {
+	if cluster.Status.ControlPlaneVersion != "" {
+		return true, nil
+	}
+	if _, condition := kubermaticv1helper.GetClusterCondition(cluster, kubermaticv1.ClusterConditionClusterInitialized); condition != nil {
+		return true, nil
+	}
+	if cluster.Status.NamespaceName == "" {
+		return false, nil
+	}
+
+	key := ctrlruntimeclient.ObjectKey{Namespace: cluster.Status.NamespaceName, Name: resources.ApiserverDeploymentName}
+	if err := r.Get(ctx, key, &appsv1.Deployment{}); err != nil {
+		if kubeapierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get the apiserver deployment: %v", err)
+	}
+	return true, nil
+}
+
+// unhealthyComponents returns the names of all components which are required for the
+// cluster to be considered healthy but are not up yet.
+func unhealthyComponents(h kubermaticv1.ExtendedClusterHealth) []string {
+	components := []struct {
+		name   string
+		status kubermaticv1.HealthStatus
+	}{
+		{name: "etcd", status: h.Etcd},
+		{name: "apiserver", status: h.Apiserver},
+		{name: "controller", status: h.Controller},
+		{name: "scheduler", status: h.Scheduler},
+		{name: "machineController", status: h.MachineController},
+		{name: "cloudProviderInfrastructure", status: h.CloudProviderInfrastructure},
+		{name: "userClusterControllerManager", status: h.UserClusterControllerManager},
+	}
+
+	var unhealthy []string
+	for _, c := range components {
+		if c.status != kubermaticv1.HealthStatusUp {
+			unhealthy = append(unhealthy, c.name)
+		}
+	}
+	return unhealthy
+}
+
 func (r *Reconciler) updateCluster(ctx context.Context, cluster *kubermaticv1.Cluster, modify func(*kubermaticv1.Cluster)) error {
 	oldCluster := cluster.DeepCopy()
 	modify(cluster)
@@ -0,0 +1,543 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	semverlib "github.com/Masterminds/semver/v3"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	kubermaticv1helper "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1/helper"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/semver"
+	"k8c.io/kubermatic/v2/pkg/version"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/net"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestLaunchTimeoutExceeded(t *testing.T) {
+	healthy := kubermaticv1.ExtendedClusterHealth{
+		Apiserver:                    kubermaticv1.HealthStatusUp,
+		Scheduler:                    kubermaticv1.HealthStatusUp,
+		Controller:                   kubermaticv1.HealthStatusUp,
+		MachineController:            kubermaticv1.HealthStatusUp,
+		Etcd:                         kubermaticv1.HealthStatusUp,
+		CloudProviderInfrastructure:  kubermaticv1.HealthStatusUp,
+		UserClusterControllerManager: kubermaticv1.HealthStatusUp,
+	}
+
+	tests := []struct {
+		name        string
+		timeout     time.Duration
+		age         time.Duration
+		launchAge   time.Duration
+		initialized bool
+		health      kubermaticv1.ExtendedClusterHealth
+		expected    bool
+	}{
+		{
+			name:      "Timeout disabled",
+			timeout:   0,
+			age:       time.Hour,
+			launchAge: time.Hour,
+			expected:  false,
+		},
+		{
+			name:      "Cluster within timeout",
+			timeout:   time.Hour,
+			age:       time.Minute,
+			launchAge: time.Minute,
+			expected:  false,
+		},
+		{
+			name:      "Cluster exceeded timeout",
+			timeout:   time.Hour,
+			age:       2 * time.Hour,
+			launchAge: 2 * time.Hour,
+			expected:  true,
+		},
+		{
+			name:      "Old cluster which started launching recently",
+			timeout:   time.Hour,
+			age:       2 * time.Hour,
+			launchAge: time.Minute,
+			expected:  false,
+		},
+		{
+			name:     "Cluster launched before the launch start was recorded",
+			timeout:  time.Hour,
+			age:      2 * time.Hour,
+			expected: true,
+		},
+		{
+			name:        "Initialized cluster exceeded timeout",
+			timeout:     time.Hour,
+			age:         2 * time.Hour,
+			initialized: true,
+			expected:    false,
+		},
+		{
+			name:      "Cluster became healthy after the timeout",
+			timeout:   time.Hour,
+			age:       2 * time.Hour,
+			launchAge: 2 * time.Hour,
+			health:    healthy,
+			expected:  false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cluster := &kubermaticv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "test-cluster",
+					CreationTimestamp: metav1.NewTime(time.Now().Add(-test.age)),
+				},
+			}
+			cluster.Status.ExtendedHealth = test.health
+			if test.initialized {
+				cluster.Status.Conditions = []kubermaticv1.ClusterCondition{{
+					Type:   kubermaticv1.ClusterConditionClusterInitialized,
+					Status: corev1.ConditionTrue,
+				}}
+			}
+			if test.launchAge > 0 {
+				cluster.Status.Conditions = []kubermaticv1.ClusterCondition{{
+					Type:               kubermaticv1.ClusterConditionClusterInitialized,
+					Status:             corev1.ConditionFalse,
+					LastTransitionTime: metav1.NewTime(time.Now().Add(-test.launchAge)),
+				}}
+			}
+
+			r := &Reconciler{clusterLaunchTimeout: test.timeout}
+			if got := r.launchTimeoutExceeded(cluster); got != test.expected {
+				t.Errorf("expected %v, got %v", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestMarkLaunchStarted(t *testing.T) {
+	cluster := &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "test-cluster",
+			CreationTimestamp: metav1.NewTime(time.Now().Add(-time.Hour)),
+		},
+	}
+	r := &Reconciler{
+		Client:               fake.NewClientBuilder().WithObjects(cluster).Build(),
+		clusterLaunchTimeout: 30 * time.Minute,
+	}
+
+	if err := r.markLaunchStarted(context.Background(), cluster); err != nil {
+		t.Fatalf("failed to mark the launch start: %v", err)
+	}
+
+	_, condition := kubermaticv1helper.GetClusterCondition(cluster, kubermaticv1.ClusterConditionClusterInitialized)
+	if condition == nil || condition.Status != corev1.ConditionFalse {
+		t.Fatalf("expected condition %s to be false, got %v", kubermaticv1.ClusterConditionClusterInitialized, condition)
+	}
+	started := condition.LastTransitionTime
+	if time.Since(started.Time) > time.Minute {
+		t.Errorf("expected the launch to start now, got %v", started)
+	}
+	if r.launchTimeoutExceeded(cluster) {
+		t.Error("expected the launch timeout to be measured from the launch start instead of the creation")
+	}
+
+	// the launch start must not move on subsequent reconciliations
+	if err := r.markLaunchStarted(context.Background(), cluster); err != nil {
+		t.Fatalf("failed to mark the launch start: %v", err)
+	}
+	if _, condition := kubermaticv1helper.GetClusterCondition(cluster, kubermaticv1.ClusterConditionClusterInitialized); !condition.LastTransitionTime.Equal(&started) {
+		t.Errorf("expected the launch start to stay at %v, got %v", started, condition.LastTransitionTime)
+	}
+}
+
+func TestValidateVersionUpdate(t *testing.T) {
+	updateManager := version.New(
+		[]*version.Version{
+			{Version: semverlib.MustParse("1.18.10"), Type: "kubernetes"},
+			{Version: semverlib.MustParse("1.19.2"), Type: "kubernetes"},
+			{Version: semverlib.MustParse("1.20.2"), Type: "kubernetes"},
+		},
+		[]*version.Update{
+			{From: "1.18.*", To: "1.19.*", Type: "kubernetes"},
+			{From: "1.19.*", To: "1.20.*", Type: "kubernetes"},
+		},
+	)
+
+	tests := []struct {
+		name                string
+		controlPlaneVersion string
+		version             string
+		expectError         bool
+	}{
+		{
+			name:    "Control plane not deployed yet",
+			version: "1.20.2",
+		},
+		{
+			name:                "Version unchanged",
+			controlPlaneVersion: "1.19.2",
+			version:             "1.19.2",
+		},
+		{
+			name:                "Configured update",
+			controlPlaneVersion: "1.18.10",
+			version:             "1.19.2",
+		},
+		{
+			name:                "Skipped minor version",
+			controlPlaneVersion: "1.18.10",
+			version:             "1.20.2",
+			expectError:         true,
+		},
+		{
+			name:                "Downgrade",
+			controlPlaneVersion: "1.20.2",
+			version:             "1.19.2",
+			expectError:         true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cluster := &kubermaticv1.Cluster{
+				Spec:   kubermaticv1.ClusterSpec{Version: *semver.NewSemverOrDie(test.version)},
+				Status: kubermaticv1.ClusterStatus{ControlPlaneVersion: test.controlPlaneVersion},
+			}
+
+			r := &Reconciler{updateManager: updateManager}
+			if err := r.validateVersionUpdate(cluster); (err != nil) != test.expectError {
+				t.Errorf("expected error: %v, got: %v", test.expectError, err)
+			}
+		})
+	}
+}
+
+func TestValidateNewClusterVersion(t *testing.T) {
+	updateManager := version.New(
+		[]*version.Version{
+			{Version: semverlib.MustParse("1.18.10"), Type: "kubernetes", Deprecated: true},
+			{Version: semverlib.MustParse("1.19.2"), Type: "kubernetes", Default: true},
+		},
+		[]*version.Update{
+			{From: "1.18.*", To: "1.19.*", Type: "kubernetes"},
+		},
+	)
+
+	tests := []struct {
+		name                string
+		controlPlaneVersion string
+		conditions          []kubermaticv1.ClusterCondition
+		apiserverDeployed   bool
+		version             string
+		expectError         bool
+	}{
+		{
+			name:    "New cluster",
+			version: "1.19.2",
+		},
+		{
+			name:        "New cluster with a deprecated version",
+			version:     "1.18.10",
+			expectError: true,
+		},
+		{
+			name:                "Existing cluster with a deprecated version",
+			controlPlaneVersion: "1.18.10",
+			version:             "1.18.10",
+		},
+		{
+			name:    "Initialized cluster created before the control plane version was recorded",
+			version: "1.18.10",
+			conditions: []kubermaticv1.ClusterCondition{
+				{Type: kubermaticv1.ClusterConditionClusterInitialized, Status: corev1.ConditionTrue},
+			},
+		},
+		{
+			name:              "Launching cluster created before the control plane version was recorded",
+			version:           "1.18.10",
+			apiserverDeployed: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cluster := &kubermaticv1.Cluster{
+				Spec: kubermaticv1.ClusterSpec{Version: *semver.NewSemverOrDie(test.version)},
+				Status: kubermaticv1.ClusterStatus{
+					ControlPlaneVersion: test.controlPlaneVersion,
+					Conditions:          test.conditions,
+					NamespaceName:       "cluster-test",
+				},
+			}
+
+			builder := fake.NewClientBuilder()
+			if test.apiserverDeployed {
+				builder = builder.WithObjects(&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: "cluster-test", Name: resources.ApiserverDeploymentName}})
+			}
+
+			r := &Reconciler{Client: builder.Build(), updateManager: updateManager}
+			if err := r.validateNewClusterVersion(context.Background(), cluster); (err != nil) != test.expectError {
+				t.Errorf("expected error: %v, got: %v", test.expectError, err)
+			}
+		})
+	}
+}
+
+func TestCheckResourceFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kubermatic-tests-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	rulesFile := path.Join(dir, "rules.yaml")
+	if err := ioutil.WriteFile(rulesFile, []byte("groups: []"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name                string
+		rulesFile           string
+		scrapingConfigsFile string
+		expectError         bool
+	}{
+		{
+			name: "no files configured",
+		},
+		{
+			name:      "existing file",
+			rulesFile: rulesFile,
+		},
+		{
+			name:                "missing file",
+			rulesFile:           rulesFile,
+			scrapingConfigsFile: path.Join(dir, "scraping-configs.yaml"),
+			expectError:         true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := &Reconciler{
+				inClusterPrometheusRulesFile:           test.rulesFile,
+				inClusterPrometheusScrapingConfigsFile: test.scrapingConfigsFile,
+			}
+			if err := r.checkResourceFiles(); (err != nil) != test.expectError {
+				t.Errorf("expected error: %v, got: %v", test.expectError, err)
+			}
+		})
+	}
+}
+
+func TestUnhealthyComponents(t *testing.T) {
+	health := kubermaticv1.ExtendedClusterHealth{
+		Apiserver:                    kubermaticv1.HealthStatusUp,
+		Scheduler:                    kubermaticv1.HealthStatusUp,
+		Controller:                   kubermaticv1.HealthStatusUp,
+		MachineController:            kubermaticv1.HealthStatusDown,
+		Etcd:                         kubermaticv1.HealthStatusUp,
+		CloudProviderInfrastructure:  kubermaticv1.HealthStatusUp,
+		UserClusterControllerManager: kubermaticv1.HealthStatusProvisioning,
+	}
+
+	got := unhealthyComponents(health)
+	expected := []string{"machineController", "userClusterControllerManager"}
+	if len(got) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Errorf("expected %v, got %v", expected, got)
+		}
+	}
+}
+
+func TestLaunchSlotAvailable(t *testing.T) {
+	launchingCluster := func(name string) *kubermaticv1.Cluster {
+		return &kubermaticv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: kubermaticv1.ClusterStatus{
+				NamespaceName: fmt.Sprintf("cluster-%s", name),
+				Conditions: []kubermaticv1.ClusterCondition{{
+					Type:   kubermaticv1.ClusterConditionClusterInitialized,
+					Status: corev1.ConditionFalse,
+				}},
+			},
+		}
+	}
+	failedCluster := func(name string, reason kubermaticv1.ClusterStatusError) *kubermaticv1.Cluster {
+		cluster := launchingCluster(name)
+		cluster.Status.ErrorReason = &reason
+		return cluster
+	}
+	initializedCluster := launchingCluster("initialized")
+	initializedCluster.Status.Conditions[0].Status = corev1.ConditionTrue
+	pausedCluster := launchingCluster("paused")
+	pausedCluster.Spec.Pause = true
+	// API-created clusters get their namespace name assigned before they are launched
+	newCluster := &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "new"},
+		Status:     kubermaticv1.ClusterStatus{NamespaceName: "cluster-new"},
+	}
+
+	tests := []struct {
+		name     string
+		limit    int
+		cluster  *kubermaticv1.Cluster
+		existing []ctrlruntimeclient.Object
+		expected bool
+	}{
+		{
+			name:     "Limit disabled",
+			limit:    0,
+			cluster:  newCluster,
+			existing: []ctrlruntimeclient.Object{launchingCluster("a"), launchingCluster("b")},
+			expected: true,
+		},
+		{
+			name:     "New cluster below the limit",
+			limit:    2,
+			cluster:  newCluster,
+			existing: []ctrlruntimeclient.Object{launchingCluster("a")},
+			expected: true,
+		},
+		{
+			name:     "New cluster at the limit",
+			limit:    2,
+			cluster:  newCluster,
+			existing: []ctrlruntimeclient.Object{launchingCluster("a"), failedCluster("b", kubermaticv1.ReconcileClusterError)},
+			expected: false,
+		},
+		{
+			name:    "Initialized, paused and failed clusters do not take a slot",
+			limit:   1,
+			cluster: newCluster,
+			existing: []ctrlruntimeclient.Object{
+				initializedCluster,
+				pausedCluster,
+				failedCluster("timed-out", kubermaticv1.LaunchTimeoutClusterError),
+				failedCluster("invalid", kubermaticv1.InvalidConfigurationClusterError),
+			},
+			expected: true,
+		},
+		{
+			name:     "Launching cluster keeps its slot",
+			limit:    1,
+			cluster:  launchingCluster("a"),
+			existing: []ctrlruntimeclient.Object{launchingCluster("b")},
+			expected: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := fake.NewClientBuilder().WithObjects(test.existing...).Build()
+			r := &Reconciler{
+				Client:                    client,
+				recorder:                  record.NewFakeRecorder(10),
+				concurrentClusterLaunches: test.limit,
+				launchSlots:               newLaunchSlots(),
+			}
+
+			available, err := r.launchSlotAvailable(context.Background(), test.cluster)
+			if err != nil {
+				t.Fatalf("failed to check for a launch slot: %v", err)
+			}
+			if available != test.expected {
+				t.Errorf("expected %v, got %v", test.expected, available)
+			}
+		})
+	}
+}
+
+func TestLaunchSlots(t *testing.T) {
+	now := time.Now()
+	slots := newLaunchSlots()
+	slots.now = func() time.Time { return now }
+
+	acquire := func(cluster string, launching sets.String, expectGranted, expectWaitingStarted bool) {
+		t.Helper()
+		granted, waitingStarted := slots.acquire(cluster, launching, 1)
+		if granted != expectGranted || waitingStarted != expectWaitingStarted {
+			t.Fatalf("expected cluster %s to be granted %v and start waiting %v, got %v and %v", cluster, expectGranted, expectWaitingStarted, granted, waitingStarted)
+		}
+	}
+
+	// a slot granted by another worker counts before the cache shows the cluster as launching
+	acquire("a", sets.NewString(), true, false)
+	acquire("b", sets.NewString(), false, true)
+	acquire("a", sets.NewString(), true, false)
+
+	// waiting is only reported on the transition
+	acquire("b", sets.NewString("a"), false, false)
+
+	// the slot is freed once the launch finished
+	acquire("b", sets.NewString(), true, false)
+
+	// grants of clusters which never showed up as launching expire
+	now = now.Add(launchSlotGrantTTL + time.Second)
+	acquire("c", sets.NewString(), true, false)
+}
+
+func TestForgetCluster(t *testing.T) {
+	r := &Reconciler{
+		launchSlots:          newLaunchSlots(),
+		nodePortReservations: newNodePortReservations(),
+	}
+	portRange := net.PortRange{Base: 30000, Size: 10}
+	lowest := func(unavailable sets.Int) (int, error) {
+		return freeNodePort(NodePortAllocationLowest, portRange, unavailable, nil)
+	}
+
+	if granted, _ := r.launchSlots.acquire("a", sets.NewString(), 1); !granted {
+		t.Fatal("expected a launch slot to be granted to cluster a")
+	}
+	if _, err := r.nodePortReservations.reserve("a", sets.NewInt(), lowest); err != nil {
+		t.Fatalf("failed to reserve a NodePort for cluster a: %v", err)
+	}
+
+	r.forgetCluster(&kubermaticv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "a"}})
+
+	if granted, _ := r.launchSlots.acquire("b", sets.NewString(), 1); !granted {
+		t.Error("expected the launch slot of the deleted cluster a to be granted to cluster b")
+	}
+	port, err := r.nodePortReservations.reserve("b", sets.NewInt(), lowest)
+	if err != nil {
+		t.Fatalf("failed to reserve a NodePort for cluster b: %v", err)
+	}
+	if port != 30000 {
+		t.Errorf("expected the NodePort 30000 of the deleted cluster a to be reserved for cluster b, got %d", port)
+	}
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/resources/reconciling"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// DriftDetectionMode configures how the cluster controller handles deployments and services of running
+// clusters which differ from what the cluster spec produces, e.g. because they were changed manually
+// or the templates changed with a Kubermatic update.
+type DriftDetectionMode string
+
+const (
+	// DriftDetectionDisabled applies all changes without checking for drift.
+	DriftDetectionDisabled DriftDetectionMode = ""
+	// DriftDetectionReport reports drifted resources and leaves them untouched until the
+	// ReapplyControlPlaneAnnotation is set on the cluster. This includes changes caused by
+	// updates of the cluster spec, e.g. a version update.
+	DriftDetectionReport DriftDetectionMode = "report"
+	// DriftDetectionReconcile reports drifted resources and reconciles them right away.
+	DriftDetectionReconcile DriftDetectionMode = "reconcile"
+)
+
+// ParseDriftDetectionMode parses the drift detection mode, an empty string disables drift detection.
+func ParseDriftDetectionMode(s string) (DriftDetectionMode, error) {
+	switch mode := DriftDetectionMode(s); mode {
+	case DriftDetectionDisabled, DriftDetectionReport, DriftDetectionReconcile:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("unknown drift detection mode %q, must be one of %q, %q or empty", s, DriftDetectionReport, DriftDetectionReconcile)
+	}
+}
+
+// checkControlPlaneDrift detects drifted deployments and services of running clusters and reports them
+// with the ControlPlaneInSync condition. It returns whether the deployments and services may be applied.
+func (r *Reconciler) checkControlPlaneDrift(ctx context.Context, cluster *kubermaticv1.Cluster, data *resources.TemplateData) (bool, error) {
+	if r.features.DriftDetection == DriftDetectionDisabled || cluster.Status.Phase != kubermaticv1.ClusterPhaseRunning {
+		return true, nil
+	}
+
+	drifted, err := r.detectControlPlaneDrift(ctx, cluster, data)
+	if err != nil {
+		return false, fmt.Errorf("failed to detect control plane drift: %v", err)
+	}
+
+	return r.handleControlPlaneDrift(ctx, cluster, drifted)
+}
+
+// detectControlPlaneDrift returns the kind and name of the deployments and services which differ from
+// what the cluster spec produces. They are rendered exactly like during reconciling, but the changes
+// are only recorded by a dry run client.
+func (r *Reconciler) detectControlPlaneDrift(ctx context.Context, cluster *kubermaticv1.Cluster, data *resources.TemplateData) ([]string, error) {
+	dryRun := newDryRunClient(r.Client, r.log.With("cluster", cluster.Name))
+
+	if err := reconciling.ReconcileServices(ctx, GetServiceCreators(data), cluster.Status.NamespaceName, dryRun, clusterObjectModifiers(cluster)...); err != nil {
+		return nil, err
+	}
+	if err := reconciling.ReconcileDeployments(ctx, GetDeploymentCreators(data, r.features.KubernetesOIDCAuthentication), cluster.Status.NamespaceName, dryRun, podObjectModifiers(cluster)...); err != nil {
+		return nil, err
+	}
+
+	return dryRun.changedObjects(), nil
+}
+
+// handleControlPlaneDrift updates the ControlPlaneInSync condition and decides whether drifted resources
+// are applied. Drift is only reported once, until the resources are in sync again.
+func (r *Reconciler) handleControlPlaneDrift(ctx context.Context, cluster *kubermaticv1.Cluster, drifted []string) (bool, error) {
+	if len(drifted) == 0 {
+		return true, r.setLaunchCheckConditions(ctx, cluster, corev1.ConditionTrue, "", "", kubermaticv1.ClusterConditionControlPlaneInSync)
+	}
+
+	msg := fmt.Sprintf("Control plane resources differ from the cluster spec: %s", strings.Join(drifted, ", "))
+	if !cluster.Status.HasConditionValue(kubermaticv1.ClusterConditionControlPlaneInSync, corev1.ConditionFalse) {
+		r.recorder.Event(cluster, corev1.EventTypeWarning, EventReasonControlPlaneDrifted, msg)
+	}
+	if err := r.setLaunchCheckConditions(ctx, cluster, corev1.ConditionFalse, kubermaticv1.ReasonControlPlaneDrifted, msg, kubermaticv1.ClusterConditionControlPlaneInSync); err != nil {
+		return false, err
+	}
+
+	if _, reapply := cluster.Annotations[kubermaticv1.ReapplyControlPlaneAnnotation]; reapply {
+		return true, nil
+	}
+	return r.features.DriftDetection == DriftDetectionReconcile, nil
+}
+
+// removeReapplyControlPlaneAnnotation removes the request to re-apply drifted resources once they were applied
+func (r *Reconciler) removeReapplyControlPlaneAnnotation(ctx context.Context, cluster *kubermaticv1.Cluster) error {
+	if _, ok := cluster.Annotations[kubermaticv1.ReapplyControlPlaneAnnotation]; !ok {
+		return nil
+	}
+
+	return r.updateCluster(ctx, cluster, func(c *kubermaticv1.Cluster) {
+		delete(c.Annotations, kubermaticv1.ReapplyControlPlaneAnnotation)
+	})
+}
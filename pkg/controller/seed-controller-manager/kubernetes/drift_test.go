@@ -0,0 +1,132 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"testing"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestParseDriftDetectionMode(t *testing.T) {
+	for _, s := range []string{"", "report", "reconcile"} {
+		if _, err := ParseDriftDetectionMode(s); err != nil {
+			t.Errorf("expected mode %q to be valid, got error %v", s, err)
+		}
+	}
+	if _, err := ParseDriftDetectionMode("ignore"); err == nil {
+		t.Error("expected an error for an unknown mode")
+	}
+}
+
+func TestHandleControlPlaneDrift(t *testing.T) {
+	tests := []struct {
+		name            string
+		mode            DriftDetectionMode
+		drifted         []string
+		reapply         bool
+		alreadyReported bool
+		expectApply     bool
+		expectInSync    corev1.ConditionStatus
+		expectEvent     bool
+	}{
+		{
+			name:         "Resources in sync",
+			mode:         DriftDetectionReport,
+			expectApply:  true,
+			expectInSync: corev1.ConditionTrue,
+		},
+		{
+			name:         "Drift is reconciled",
+			mode:         DriftDetectionReconcile,
+			drifted:      []string{"Deployment apiserver"},
+			expectApply:  true,
+			expectInSync: corev1.ConditionFalse,
+			expectEvent:  true,
+		},
+		{
+			name:         "Drift is only reported",
+			mode:         DriftDetectionReport,
+			drifted:      []string{"Deployment apiserver", "Service apiserver-external"},
+			expectApply:  false,
+			expectInSync: corev1.ConditionFalse,
+			expectEvent:  true,
+		},
+		{
+			name:            "Drift is reported once",
+			mode:            DriftDetectionReport,
+			drifted:         []string{"Deployment apiserver"},
+			alreadyReported: true,
+			expectApply:     false,
+			expectInSync:    corev1.ConditionFalse,
+		},
+		{
+			name:            "Drift is re-applied on request",
+			mode:            DriftDetectionReport,
+			drifted:         []string{"Deployment apiserver"},
+			reapply:         true,
+			alreadyReported: true,
+			expectApply:     true,
+			expectInSync:    corev1.ConditionFalse,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cluster := &kubermaticv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Annotations: map[string]string{}},
+				Status:     kubermaticv1.ClusterStatus{NamespaceName: "cluster-test", Phase: kubermaticv1.ClusterPhaseRunning},
+			}
+			if test.reapply {
+				cluster.Annotations[kubermaticv1.ReapplyControlPlaneAnnotation] = ""
+			}
+			if test.alreadyReported {
+				cluster.Status.Conditions = []kubermaticv1.ClusterCondition{{
+					Type:   kubermaticv1.ClusterConditionControlPlaneInSync,
+					Status: corev1.ConditionFalse,
+				}}
+			}
+
+			recorder := record.NewFakeRecorder(10)
+			r := &Reconciler{
+				Client:   fake.NewClientBuilder().WithObjects(cluster).Build(),
+				recorder: recorder,
+				features: Features{DriftDetection: test.mode},
+			}
+
+			apply, err := r.handleControlPlaneDrift(context.Background(), cluster, test.drifted)
+			if err != nil {
+				t.Fatalf("failed to handle drift: %v", err)
+			}
+			if apply != test.expectApply {
+				t.Errorf("expected apply: %v, got %v", test.expectApply, apply)
+			}
+			if !cluster.Status.HasConditionValue(kubermaticv1.ClusterConditionControlPlaneInSync, test.expectInSync) {
+				t.Errorf("expected condition %s to be %s, got conditions %v", kubermaticv1.ClusterConditionControlPlaneInSync, test.expectInSync, cluster.Status.Conditions)
+			}
+			if events := len(recorder.Events); (events > 0) != test.expectEvent {
+				t.Errorf("expected event: %v, got %d events", test.expectEvent, events)
+			}
+		})
+	}
+}
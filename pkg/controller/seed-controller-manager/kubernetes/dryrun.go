@@ -0,0 +1,177 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+
+	kubeapierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// dryRunClient is a client which logs and records all changes instead of applying them. Objects
+// which were created or changed are returned by subsequent Get calls, so reconciling continues as
+// if the changes had been applied. List calls are always served by the wrapped client.
+type dryRunClient struct {
+	ctrlruntimeclient.Client
+	log *zap.SugaredLogger
+
+	lock sync.Mutex
+	// objects contains all objects changed during the dry run, deleted objects are nil
+	objects map[string]ctrlruntimeclient.Object
+	actions []string
+	// changed contains the kind and name of all changed objects, e.g. "Deployment apiserver"
+	changed sets.String
+	// revision is used to give every change a new resource version
+	revision int
+}
+
+func newDryRunClient(client ctrlruntimeclient.Client, log *zap.SugaredLogger) *dryRunClient {
+	return &dryRunClient{
+		Client:  client,
+		log:     log,
+		objects: map[string]ctrlruntimeclient.Object{},
+		changed: sets.NewString(),
+	}
+}
+
+func dryRunObjectKey(obj ctrlruntimeclient.Object, key types.NamespacedName) string {
+	return fmt.Sprintf("%T/%s", obj, key)
+}
+
+func (c *dryRunClient) Get(ctx context.Context, key types.NamespacedName, obj ctrlruntimeclient.Object) error {
+	c.lock.Lock()
+	recorded, exists := c.objects[dryRunObjectKey(obj, key)]
+	c.lock.Unlock()
+
+	if !exists {
+		return c.Client.Get(ctx, key, obj)
+	}
+	if recorded == nil {
+		return kubeapierrors.NewNotFound(schema.GroupResource{}, key.Name)
+	}
+	reflect.ValueOf(obj).Elem().Set(reflect.ValueOf(recorded.DeepCopyObject()).Elem())
+	return nil
+}
+
+func (c *dryRunClient) Create(_ context.Context, obj ctrlruntimeclient.Object, _ ...ctrlruntimeclient.CreateOption) error {
+	c.record("create", obj, false)
+	return nil
+}
+
+func (c *dryRunClient) Update(_ context.Context, obj ctrlruntimeclient.Object, _ ...ctrlruntimeclient.UpdateOption) error {
+	c.record("update", obj, false)
+	return nil
+}
+
+// Patch records the object as passed in, as the callers of this controller always
+// apply their changes to the object before computing the patch from it.
+func (c *dryRunClient) Patch(_ context.Context, obj ctrlruntimeclient.Object, _ ctrlruntimeclient.Patch, _ ...ctrlruntimeclient.PatchOption) error {
+	c.record("patch", obj, false)
+	return nil
+}
+
+func (c *dryRunClient) Delete(_ context.Context, obj ctrlruntimeclient.Object, _ ...ctrlruntimeclient.DeleteOption) error {
+	c.record("delete", obj, true)
+	return nil
+}
+
+func (c *dryRunClient) DeleteAllOf(_ context.Context, obj ctrlruntimeclient.Object, _ ...ctrlruntimeclient.DeleteAllOfOption) error {
+	c.log.Infow("Dry run, skipping change", "action", "delete all", "type", fmt.Sprintf("%T", obj))
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.actions = append(c.actions, "delete all")
+	return nil
+}
+
+func (c *dryRunClient) Status() ctrlruntimeclient.StatusWriter {
+	return &dryRunStatusWriter{client: c}
+}
+
+func (c *dryRunClient) record(action string, obj ctrlruntimeclient.Object, deleted bool) {
+	key := ctrlruntimeclient.ObjectKeyFromObject(obj)
+	c.log.Infow("Dry run, skipping change", "action", action, "type", fmt.Sprintf("%T", obj), "object", key.String())
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.actions = append(c.actions, action)
+	c.changed.Insert(fmt.Sprintf("%s %s", reflect.TypeOf(obj).Elem().Name(), key.Name))
+	if deleted {
+		c.objects[dryRunObjectKey(obj, key)] = nil
+		return
+	}
+
+	// Waiting for changes to show up in the cache relies on the resource version
+	c.revision++
+	obj.SetResourceVersion(fmt.Sprintf("dry-run-%d", c.revision))
+	c.objects[dryRunObjectKey(obj, key)] = obj.DeepCopyObject().(ctrlruntimeclient.Object)
+}
+
+// summary returns the number of skipped changes per action, e.g. "create: 3, update: 1"
+func (c *dryRunClient) summary() string {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if len(c.actions) == 0 {
+		return "no changes"
+	}
+
+	counts := map[string]int{}
+	for _, action := range c.actions {
+		counts[action]++
+	}
+	var actions []string
+	for action, count := range counts {
+		actions = append(actions, fmt.Sprintf("%s: %d", action, count))
+	}
+	sort.Strings(actions)
+	return strings.Join(actions, ", ")
+}
+
+// changedObjects returns the kind and name of all objects changed during the dry run, sorted by kind and name
+func (c *dryRunClient) changedObjects() []string {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.changed.List()
+}
+
+type dryRunStatusWriter struct {
+	client *dryRunClient
+}
+
+func (w *dryRunStatusWriter) Update(_ context.Context, obj ctrlruntimeclient.Object, _ ...ctrlruntimeclient.UpdateOption) error {
+	w.client.record("update status", obj, false)
+	return nil
+}
+
+func (w *dryRunStatusWriter) Patch(_ context.Context, obj ctrlruntimeclient.Object, _ ctrlruntimeclient.Patch, _ ...ctrlruntimeclient.PatchOption) error {
+	w.client.record("patch status", obj, false)
+	return nil
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"go.uber.org/zap"
+
+	corev1 "k8s.io/api/core/v1"
+	kubeapierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestDryRunClient(t *testing.T) {
+	ctx := context.Background()
+	existing := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "existing", Namespace: "cluster-test"},
+		Data:       map[string]string{"key": "old"},
+	}
+	underlying := fake.NewClientBuilder().WithObjects(existing).Build()
+	client := newDryRunClient(underlying, zap.NewNop().Sugar())
+
+	created := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "created", Namespace: "cluster-test"}}
+	if err := client.Create(ctx, created); err != nil {
+		t.Fatalf("failed to create ConfigMap: %v", err)
+	}
+	if err := underlying.Get(ctx, types.NamespacedName{Namespace: "cluster-test", Name: "created"}, &corev1.ConfigMap{}); !kubeapierrors.IsNotFound(err) {
+		t.Errorf("expected created ConfigMap to not exist, got error %v", err)
+	}
+	if err := client.Get(ctx, types.NamespacedName{Namespace: "cluster-test", Name: "created"}, &corev1.ConfigMap{}); err != nil {
+		t.Errorf("expected created ConfigMap to be returned by the dry run client, got error %v", err)
+	}
+
+	updated := existing.DeepCopy()
+	updated.Data["key"] = "new"
+	if err := client.Update(ctx, updated); err != nil {
+		t.Fatalf("failed to update ConfigMap: %v", err)
+	}
+	got := &corev1.ConfigMap{}
+	if err := client.Get(ctx, types.NamespacedName{Namespace: "cluster-test", Name: "existing"}, got); err != nil {
+		t.Fatalf("failed to get ConfigMap: %v", err)
+	}
+	if got.Data["key"] != "new" || got.ResourceVersion == existing.ResourceVersion {
+		t.Errorf("expected updated ConfigMap with a new resource version, got %v", got)
+	}
+	if err := underlying.Get(ctx, types.NamespacedName{Namespace: "cluster-test", Name: "existing"}, got); err != nil || got.Data["key"] != "old" {
+		t.Errorf("expected ConfigMap to be unchanged, got %v, error %v", got, err)
+	}
+
+	if err := client.Delete(ctx, existing); err != nil {
+		t.Fatalf("failed to delete ConfigMap: %v", err)
+	}
+	if err := client.Get(ctx, types.NamespacedName{Namespace: "cluster-test", Name: "existing"}, &corev1.ConfigMap{}); !kubeapierrors.IsNotFound(err) {
+		t.Errorf("expected deleted ConfigMap to not be returned by the dry run client, got error %v", err)
+	}
+
+	if expected, summary := "create: 1, delete: 1, update: 1", client.summary(); summary != expected {
+		t.Errorf("expected summary %q, got %q", expected, summary)
+	}
+	if expected, changed := []string{"ConfigMap created", "ConfigMap existing"}, client.changedObjects(); !reflect.DeepEqual(expected, changed) {
+		t.Errorf("expected changed objects %v, got %v", expected, changed)
+	}
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	kubeapierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// syncEncryptionKeyRollout advances the rollout of the encryption key of the cluster by one
+// phase once all apiserver replicas run with the encryption configuration written for the
+// current phase. After the new key became the primary key, all secrets of the cluster are
+// rewritten, so they are encrypted with it and the previous keys can be pruned.
+func (r *Reconciler) syncEncryptionKeyRollout(ctx context.Context, cluster *kubermaticv1.Cluster) error {
+	if settings := cluster.Spec.EncryptionConfiguration; settings == nil || !settings.Enabled {
+		return nil
+	}
+
+	secret := &corev1.Secret{}
+	key := types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: resources.ApiserverEncryptionConfigurationSecretName}
+	if err := r.Get(ctx, key, secret); err != nil {
+		if kubeapierrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get the encryption configuration: %v", err)
+	}
+
+	target := secret.Annotations[resources.ApiserverEncryptionKeyAnnotation]
+	status := kubermaticv1.ClusterEncryptionStatus{}
+	if cluster.Status.Encryption != nil {
+		status = *cluster.Status.Encryption
+	}
+	if target == "" || status.ActiveKey == target {
+		return nil
+	}
+
+	if status.RolloutKey != target {
+		status.RolloutKey = target
+		status.Phase = ""
+		return r.updateEncryptionStatus(ctx, cluster, status)
+	}
+
+	rolledOut, err := r.encryptionConfigurationRolledOut(ctx, cluster, secret)
+	if err != nil || !rolledOut {
+		return err
+	}
+
+	switch status.Phase {
+	case "":
+		status.Phase = kubermaticv1.ClusterEncryptionPhaseKeyAdded
+	case kubermaticv1.ClusterEncryptionPhaseKeyAdded:
+		status.Phase = kubermaticv1.ClusterEncryptionPhaseKeyPrimary
+	case kubermaticv1.ClusterEncryptionPhaseKeyPrimary:
+		if err := r.rewriteSecrets(ctx, cluster); err != nil {
+			return err
+		}
+		status.Phase = kubermaticv1.ClusterEncryptionPhaseSecretsRewritten
+	default:
+		status = kubermaticv1.ClusterEncryptionStatus{ActiveKey: target}
+	}
+
+	r.log.Infow("Encryption key rollout progressed", "cluster", cluster.Name, "key", target, "phase", status.Phase)
+	return r.updateEncryptionStatus(ctx, cluster, status)
+}
+
+func (r *Reconciler) updateEncryptionStatus(ctx context.Context, cluster *kubermaticv1.Cluster, status kubermaticv1.ClusterEncryptionStatus) error {
+	err := r.updateCluster(ctx, cluster, func(c *kubermaticv1.Cluster) {
+		c.Status.Encryption = &status
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update the encryption status: %v", err)
+	}
+	return nil
+}
+
+// encryptionConfigurationRolledOut returns true if all replicas of the apiserver were updated
+// to mount the current revision of the encryption configuration and are ready.
+func (r *Reconciler) encryptionConfigurationRolledOut(ctx context.Context, cluster *kubermaticv1.Cluster, secret *corev1.Secret) (bool, error) {
+	deployment := &appsv1.Deployment{}
+	key := types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: resources.ApiserverDeploymentName}
+	if err := r.Get(ctx, key, deployment); err != nil {
+		return false, fmt.Errorf("failed to get the apiserver deployment: %v", err)
+	}
+
+	revisionLabel := fmt.Sprintf("%s-secret-revision", resources.ApiserverEncryptionConfigurationSecretName)
+	if deployment.Spec.Template.Labels[revisionLabel] != secret.ResourceVersion {
+		return false, nil
+	}
+
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+	status := deployment.Status
+	return status.ObservedGeneration >= deployment.Generation &&
+		status.UpdatedReplicas == replicas &&
+		status.ReadyReplicas == replicas &&
+		status.Replicas == replicas, nil
+}
+
+// rewriteSecrets updates all secrets of the cluster without changing them, which makes the
+// apiserver encrypt them with the current primary key.
+func (r *Reconciler) rewriteSecrets(ctx context.Context, cluster *kubermaticv1.Cluster) error {
+	client, err := r.userClusterConnProvider.GetClient(ctx, cluster)
+	if err != nil {
+		return fmt.Errorf("failed to get user cluster client: %v", err)
+	}
+
+	secrets := &corev1.SecretList{}
+	if err := client.List(ctx, secrets); err != nil {
+		return fmt.Errorf("failed to list secrets: %v", err)
+	}
+	for i := range secrets.Items {
+		// secrets which were deleted or updated concurrently do not need to be rewritten
+		if err := client.Update(ctx, &secrets.Items[i]); err != nil && !kubeapierrors.IsNotFound(err) && !kubeapierrors.IsConflict(err) {
+			return fmt.Errorf("failed to rewrite secret %s/%s: %v", secrets.Items[i].Namespace, secrets.Items[i].Name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"go.uber.org/zap"
+
+	k8cuserclusterclient "k8c.io/kubermatic/v2/pkg/cluster/client"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+type fakeUserClusterConnectionProvider struct {
+	client ctrlruntimeclient.Client
+}
+
+func (f *fakeUserClusterConnectionProvider) GetClient(context.Context, *kubermaticv1.Cluster, ...k8cuserclusterclient.ConfigOption) (ctrlruntimeclient.Client, error) {
+	return f.client, nil
+}
+
+func TestSyncEncryptionKeyRollout(t *testing.T) {
+	ctx := context.Background()
+	cluster := &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		Spec: kubermaticv1.ClusterSpec{
+			EncryptionConfiguration: &kubermaticv1.EncryptionConfiguration{Enabled: true, KeyRotation: 1},
+		},
+		Status: kubermaticv1.ClusterStatus{
+			NamespaceName: "cluster-test",
+			Encryption:    &kubermaticv1.ClusterEncryptionStatus{ActiveKey: "generated-0"},
+		},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        resources.ApiserverEncryptionConfigurationSecretName,
+			Namespace:   "cluster-test",
+			Annotations: map[string]string{resources.ApiserverEncryptionKeyAnnotation: "generated-1"},
+		},
+	}
+	seedClient := fake.NewClientBuilder().WithObjects(cluster.DeepCopy(), secret).Build()
+	if err := seedClient.Get(ctx, ctrlruntimeclient.ObjectKeyFromObject(secret), secret); err != nil {
+		t.Fatalf("failed to get secret: %v", err)
+	}
+
+	// the apiserver does not run with the current encryption configuration yet
+	revisionLabel := fmt.Sprintf("%s-secret-revision", resources.ApiserverEncryptionConfigurationSecretName)
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: resources.ApiserverDeploymentName, Namespace: "cluster-test"},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: resources.Int32(2),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{revisionLabel: "outdated"}},
+			},
+		},
+		Status: appsv1.DeploymentStatus{Replicas: 2, UpdatedReplicas: 2, ReadyReplicas: 2},
+	}
+	if err := seedClient.Create(ctx, deployment); err != nil {
+		t.Fatalf("failed to create deployment: %v", err)
+	}
+
+	userClusterSecret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "token", Namespace: "kube-system"}}
+	userClusterClient := fake.NewClientBuilder().WithObjects(userClusterSecret).Build()
+	if err := userClusterClient.Get(ctx, ctrlruntimeclient.ObjectKeyFromObject(userClusterSecret), userClusterSecret); err != nil {
+		t.Fatalf("failed to get user cluster secret: %v", err)
+	}
+
+	r := &Reconciler{
+		Client:                  seedClient,
+		log:                     zap.NewNop().Sugar(),
+		userClusterConnProvider: &fakeUserClusterConnectionProvider{client: userClusterClient},
+	}
+
+	sync := func(expected kubermaticv1.ClusterEncryptionStatus) {
+		t.Helper()
+		if err := r.syncEncryptionKeyRollout(ctx, cluster); err != nil {
+			t.Fatalf("failed to sync the encryption key rollout: %v", err)
+		}
+		if cluster.Status.Encryption == nil || *cluster.Status.Encryption != expected {
+			t.Fatalf("expected encryption status %+v, got %+v", expected, cluster.Status.Encryption)
+		}
+	}
+
+	sync(kubermaticv1.ClusterEncryptionStatus{ActiveKey: "generated-0", RolloutKey: "generated-1"})
+	sync(kubermaticv1.ClusterEncryptionStatus{ActiveKey: "generated-0", RolloutKey: "generated-1"})
+
+	deployment.Spec.Template.Labels[revisionLabel] = secret.ResourceVersion
+	if err := seedClient.Update(ctx, deployment); err != nil {
+		t.Fatalf("failed to update deployment: %v", err)
+	}
+
+	sync(kubermaticv1.ClusterEncryptionStatus{ActiveKey: "generated-0", RolloutKey: "generated-1", Phase: kubermaticv1.ClusterEncryptionPhaseKeyAdded})
+	sync(kubermaticv1.ClusterEncryptionStatus{ActiveKey: "generated-0", RolloutKey: "generated-1", Phase: kubermaticv1.ClusterEncryptionPhaseKeyPrimary})
+
+	rewritten := &corev1.Secret{}
+	if err := userClusterClient.Get(ctx, types.NamespacedName{Namespace: "kube-system", Name: "token"}, rewritten); err != nil {
+		t.Fatalf("failed to get user cluster secret: %v", err)
+	}
+	if rewritten.ResourceVersion != userClusterSecret.ResourceVersion {
+		t.Fatal("expected secrets not to be rewritten before the key is the primary key")
+	}
+
+	sync(kubermaticv1.ClusterEncryptionStatus{ActiveKey: "generated-0", RolloutKey: "generated-1", Phase: kubermaticv1.ClusterEncryptionPhaseSecretsRewritten})
+
+	if err := userClusterClient.Get(ctx, types.NamespacedName{Namespace: "kube-system", Name: "token"}, rewritten); err != nil {
+		t.Fatalf("failed to get user cluster secret: %v", err)
+	}
+	if rewritten.ResourceVersion == userClusterSecret.ResourceVersion {
+		t.Fatal("expected the secrets to be rewritten")
+	}
+
+	sync(kubermaticv1.ClusterEncryptionStatus{ActiveKey: "generated-1"})
+	sync(kubermaticv1.ClusterEncryptionStatus{ActiveKey: "generated-1"})
+}
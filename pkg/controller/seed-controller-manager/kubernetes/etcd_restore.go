@@ -0,0 +1,142 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	kubeapierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// etcdSnapshotRestoreName is the name of the EtcdRestore created for clusters launched from a snapshot
+const etcdSnapshotRestoreName = "restore-from-snapshot"
+
+// ensureEtcdRestoredFromSnapshot hands clusters which should be launched from an etcd snapshot over to
+// the etcd restore controller. A non-nil result is returned as long as the etcd must not be deployed yet,
+// as the restore controller would replace a freshly created etcd right away.
+func (r *Reconciler) ensureEtcdRestoredFromSnapshot(ctx context.Context, cluster *kubermaticv1.Cluster) (*reconcile.Result, error) {
+	snapshot := cluster.Spec.RestoreFromSnapshot
+	if snapshot == nil {
+		return nil, nil
+	}
+
+	restore := &kubermaticv1.EtcdRestore{}
+	err := r.Get(ctx, types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: etcdSnapshotRestoreName}, restore)
+	if meta.IsNoMatchError(err) {
+		return r.waitForEtcdRestoreController(cluster, "the EtcdRestore CRD is not installed")
+	}
+	if err != nil && !kubeapierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to get EtcdRestore: %v", err)
+	}
+
+	if kubeapierrors.IsNotFound(err) {
+		if !cluster.Spec.Features[kubermaticv1.ClusterFeatureEtcdLauncher] {
+			return nil, r.rejectEtcdSnapshotRestore(ctx, cluster, "restoring requires the etcd launcher")
+		}
+
+		// Never overwrite the data of a running etcd, a restore of an existing
+		// cluster must be requested explicitly by creating an EtcdRestore
+		exists, err := r.etcdStatefulSetExists(ctx, cluster)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			return nil, r.rejectEtcdSnapshotRestore(ctx, cluster, "an etcd cluster already exists")
+		}
+
+		if !r.features.EtcdBackupRestore {
+			return r.waitForEtcdRestoreController(cluster, "the etcd backup and restore controllers are not enabled")
+		}
+
+		restore = &kubermaticv1.EtcdRestore{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            etcdSnapshotRestoreName,
+				Namespace:       cluster.Status.NamespaceName,
+				OwnerReferences: []metav1.OwnerReference{r.getOwnerRefForCluster(cluster)},
+			},
+			Spec: kubermaticv1.EtcdRestoreSpec{
+				Name: etcdSnapshotRestoreName,
+				Cluster: corev1.ObjectReference{
+					APIVersion: kubermaticv1.SchemeGroupVersion.String(),
+					Kind:       kubermaticv1.ClusterKindName,
+					Name:       cluster.Name,
+					UID:        cluster.UID,
+				},
+				BackupName:                      snapshot.BackupName,
+				BackupDownloadCredentialsSecret: snapshot.BackupDownloadCredentialsSecret,
+			},
+		}
+		if err := r.Create(ctx, restore); err != nil {
+			if meta.IsNoMatchError(err) {
+				return r.waitForEtcdRestoreController(cluster, "the EtcdRestore CRD is not installed")
+			}
+			return nil, fmt.Errorf("failed to create EtcdRestore: %v", err)
+		}
+		return &reconcile.Result{RequeueAfter: 10 * time.Second}, nil
+	}
+
+	switch restore.Status.Phase {
+	case kubermaticv1.EtcdRestorePhaseCompleted:
+		r.recorder.Eventf(cluster, corev1.EventTypeNormal, EventReasonEtcdRestored, "Restored etcd from snapshot %q", snapshot.BackupName)
+		return nil, r.updateCluster(ctx, cluster, func(c *kubermaticv1.Cluster) {
+			c.Spec.RestoreFromSnapshot = nil
+		})
+	case kubermaticv1.EtcdRestorePhaseStsRebuilding:
+		// The etcd launcher restores the snapshot before starting the new members
+		return nil, nil
+	default:
+		return &reconcile.Result{RequeueAfter: 10 * time.Second}, nil
+	}
+}
+
+// waitForEtcdRestoreController requeues clusters which should be launched from a snapshot as long as the
+// etcd restore controller is not available, the etcd is not deployed in the meantime.
+func (r *Reconciler) waitForEtcdRestoreController(cluster *kubermaticv1.Cluster, reason string) (*reconcile.Result, error) {
+	r.recorder.Eventf(cluster, corev1.EventTypeNormal, EventReasonEtcdRestoreWaiting, "Waiting to restore etcd from snapshot %q: %s", cluster.Spec.RestoreFromSnapshot.BackupName, reason)
+	return &reconcile.Result{RequeueAfter: 30 * time.Second}, nil
+}
+
+// rejectEtcdSnapshotRestore reports why the cluster can not be launched from a snapshot and removes the
+// request, the cluster is then launched with an empty etcd.
+func (r *Reconciler) rejectEtcdSnapshotRestore(ctx context.Context, cluster *kubermaticv1.Cluster, reason string) error {
+	r.recorder.Eventf(cluster, corev1.EventTypeWarning, EventReasonEtcdRestoreRejected, "Refusing to restore etcd from snapshot %q: %s", cluster.Spec.RestoreFromSnapshot.BackupName, reason)
+	return r.updateCluster(ctx, cluster, func(c *kubermaticv1.Cluster) {
+		c.Spec.RestoreFromSnapshot = nil
+	})
+}
+
+func (r *Reconciler) etcdStatefulSetExists(ctx context.Context, cluster *kubermaticv1.Cluster) (bool, error) {
+	sts := &appsv1.StatefulSet{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: resources.EtcdStatefulSetName}, sts); err != nil {
+		if kubeapierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get etcd StatefulSet: %v", err)
+	}
+	return true, nil
+}
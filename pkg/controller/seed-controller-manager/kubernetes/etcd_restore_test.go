@@ -0,0 +1,143 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"testing"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestEnsureEtcdRestoredFromSnapshot(t *testing.T) {
+	tests := []struct {
+		name                  string
+		snapshot              *kubermaticv1.EtcdSnapshotReference
+		launcher              bool
+		restoreController     bool
+		objects               []ctrlruntimeclient.Object
+		expectRequeue         bool
+		expectRestore         bool
+		expectSnapshotCleared bool
+	}{
+		{
+			name:     "No snapshot requested",
+			launcher: true,
+		},
+		{
+			name:              "Restore is created for a new cluster",
+			snapshot:          &kubermaticv1.EtcdSnapshotReference{BackupName: "daily"},
+			launcher:          true,
+			restoreController: true,
+			expectRequeue:     true,
+			expectRestore:     true,
+		},
+		{
+			name:          "Restore waits for the restore controller",
+			snapshot:      &kubermaticv1.EtcdSnapshotReference{BackupName: "daily"},
+			launcher:      true,
+			expectRequeue: true,
+		},
+		{
+			name:                  "Restore is rejected without the etcd launcher",
+			snapshot:              &kubermaticv1.EtcdSnapshotReference{BackupName: "daily"},
+			expectSnapshotCleared: true,
+		},
+		{
+			name:     "Restore is rejected for an existing etcd",
+			snapshot: &kubermaticv1.EtcdSnapshotReference{BackupName: "daily"},
+			launcher: true,
+			objects: []ctrlruntimeclient.Object{
+				&appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: resources.EtcdStatefulSetName, Namespace: "cluster-test"}},
+			},
+			expectSnapshotCleared: true,
+		},
+		{
+			name:     "Etcd is deployed while the restore rebuilds it",
+			snapshot: &kubermaticv1.EtcdSnapshotReference{BackupName: "daily"},
+			launcher: true,
+			objects: []ctrlruntimeclient.Object{
+				&kubermaticv1.EtcdRestore{
+					ObjectMeta: metav1.ObjectMeta{Name: etcdSnapshotRestoreName, Namespace: "cluster-test"},
+					Status:     kubermaticv1.EtcdRestoreStatus{Phase: kubermaticv1.EtcdRestorePhaseStsRebuilding},
+				},
+			},
+			expectRestore: true,
+		},
+		{
+			name:     "Snapshot is cleared once the restore completed",
+			snapshot: &kubermaticv1.EtcdSnapshotReference{BackupName: "daily"},
+			launcher: true,
+			objects: []ctrlruntimeclient.Object{
+				&kubermaticv1.EtcdRestore{
+					ObjectMeta: metav1.ObjectMeta{Name: etcdSnapshotRestoreName, Namespace: "cluster-test"},
+					Status:     kubermaticv1.EtcdRestoreStatus{Phase: kubermaticv1.EtcdRestorePhaseCompleted},
+				},
+			},
+			expectRestore:         true,
+			expectSnapshotCleared: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+			cluster := &kubermaticv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: kubermaticv1.ClusterSpec{
+					Features:            map[string]bool{kubermaticv1.ClusterFeatureEtcdLauncher: test.launcher},
+					RestoreFromSnapshot: test.snapshot,
+				},
+				Status: kubermaticv1.ClusterStatus{NamespaceName: "cluster-test"},
+			}
+
+			client := fake.NewClientBuilder().WithObjects(append(test.objects, cluster)...).Build()
+			r := &Reconciler{
+				Client:   client,
+				recorder: record.NewFakeRecorder(10),
+				features: Features{EtcdBackupRestore: test.restoreController},
+			}
+
+			res, err := r.ensureEtcdRestoredFromSnapshot(ctx, cluster)
+			if err != nil {
+				t.Fatalf("failed to ensure etcd restore: %v", err)
+			}
+			if (res != nil) != test.expectRequeue {
+				t.Errorf("expected requeue: %v, got result %v", test.expectRequeue, res)
+			}
+
+			restore := &kubermaticv1.EtcdRestore{}
+			err = client.Get(ctx, types.NamespacedName{Namespace: "cluster-test", Name: etcdSnapshotRestoreName}, restore)
+			if (err == nil) != test.expectRestore {
+				t.Errorf("expected EtcdRestore to exist: %v, got error %v", test.expectRestore, err)
+			}
+
+			cleared := test.snapshot != nil && cluster.Spec.RestoreFromSnapshot == nil
+			if cleared != test.expectSnapshotCleared {
+				t.Errorf("expected snapshot to be cleared: %v, got %v", test.expectSnapshotCleared, cleared)
+			}
+		})
+	}
+}
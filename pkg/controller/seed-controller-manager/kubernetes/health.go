@@ -19,18 +19,21 @@ package kubernetes
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
 	kubermaticv1helper "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1/helper"
-	"k8c.io/kubermatic/v2/pkg/provider/kubernetes"
 	"k8c.io/kubermatic/v2/pkg/resources"
 )
 
 func (r *Reconciler) clusterHealth(ctx context.Context, cluster *kubermaticv1.Cluster) (*kubermaticv1.ExtendedClusterHealth, error) {
-	ns := kubernetes.NamespaceName(cluster.Name)
+	ns := cluster.Status.NamespaceName
 	extendedHealth := cluster.Status.ExtendedHealth.DeepCopy()
 
 	type depInfo struct {
@@ -87,6 +90,12 @@ func (r *Reconciler) syncHealth(ctx context.Context, cluster *kubermaticv1.Clust
 	if err != nil {
 		return err
 	}
+	// report the etcd step of the launch until etcd becomes healthy for the first time
+	if !cluster.Status.HasConditionValue(kubermaticv1.ClusterConditionEtcdClusterInitialized, corev1.ConditionTrue) && extendedHealth.Etcd != kubermaticv1.HealthStatusUp {
+		if err := r.setLaunchCheckConditions(ctx, cluster, corev1.ConditionFalse, kubermaticv1.ReasonWaitingForEtcd, "Waiting for etcd to become healthy", kubermaticv1.ClusterConditionEtcdClusterInitialized); err != nil {
+			return err
+		}
+	}
 	// set ClusterConditionEtcdClusterInitialized, this should be done only once
 	// when etcd becomes healthy for the first time.
 	if !cluster.Status.HasConditionValue(kubermaticv1.ClusterConditionEtcdClusterInitialized, corev1.ConditionTrue) && extendedHealth.Etcd == kubermaticv1.HealthStatusUp {
@@ -105,7 +114,19 @@ func (r *Reconciler) syncHealth(ctx context.Context, cluster *kubermaticv1.Clust
 	}
 
 	if !cluster.Status.HasConditionValue(kubermaticv1.ClusterConditionClusterInitialized, corev1.ConditionTrue) && kubermaticv1helper.IsClusterInitialized(cluster, r.versions) {
-		err = r.updateCluster(ctx, cluster, func(c *kubermaticv1.Cluster) {
+		ready, err := r.defaultAddonsReady(ctx, cluster)
+		if err != nil || !ready {
+			return err
+		}
+
+		// The healthy deployment does not guarantee that the apiserver can be
+		// reached through the address assigned to the cluster
+		reachable, err := r.apiserverReachable(ctx, cluster)
+		if err != nil || !reachable {
+			return err
+		}
+
+		if err := r.updateCluster(ctx, cluster, func(c *kubermaticv1.Cluster) {
 			kubermaticv1helper.SetClusterCondition(
 				c,
 				r.versions,
@@ -114,8 +135,59 @@ func (r *Reconciler) syncHealth(ctx context.Context, cluster *kubermaticv1.Clust
 				"",
 				"Cluster has been initialized successfully",
 			)
-		})
+		}); err != nil {
+			return err
+		}
+
+		// a dry run does not initialize the cluster
+		if !r.dryRun {
+			observeProvisioningDuration(cluster, time.Now())
+		}
+		return nil
 	}
 
 	return err
 }
+
+// defaultAddonsReady returns true once all default addons of the cluster have been installed. After
+// the addons ready timeout, the addons which are still not installed are reported as event and the
+// cluster is not waiting for them anymore.
+func (r *Reconciler) defaultAddonsReady(ctx context.Context, cluster *kubermaticv1.Cluster) (bool, error) {
+	if r.addonsReadyTimeout <= 0 {
+		return true, nil
+	}
+
+	addons := &kubermaticv1.AddonList{}
+	if err := r.List(ctx, addons, ctrlruntimeclient.InNamespace(cluster.Status.NamespaceName)); err != nil {
+		return false, fmt.Errorf("failed to list addons: %v", err)
+	}
+
+	pending := pendingDefaultAddons(addons.Items)
+	if len(pending) == 0 {
+		return true, r.setLaunchCheckConditions(ctx, cluster, corev1.ConditionTrue, "", "", kubermaticv1.ClusterConditionDefaultAddonsReady)
+	}
+
+	if time.Since(cluster.CreationTimestamp.Time) < r.addonsReadyTimeout {
+		msg := fmt.Sprintf("Waiting for the default addons to be installed: %s", strings.Join(pending, ", "))
+		return false, r.setLaunchCheckConditions(ctx, cluster, corev1.ConditionFalse, kubermaticv1.ReasonWaitingForAddons, msg, kubermaticv1.ClusterConditionDefaultAddonsReady)
+	}
+
+	msg := fmt.Sprintf("Default addons were not installed within %v: %s", r.addonsReadyTimeout, strings.Join(pending, ", "))
+	r.recorder.Event(cluster, corev1.EventTypeWarning, EventReasonAddonsNotReady, msg)
+	return true, r.setLaunchCheckConditions(ctx, cluster, corev1.ConditionFalse, kubermaticv1.ReasonAddonsNotReady, msg, kubermaticv1.ClusterConditionDefaultAddonsReady)
+}
+
+// pendingDefaultAddons returns the sorted names of all default addons whose resources
+// have not been created yet.
+func pendingDefaultAddons(addons []kubermaticv1.Addon) []string {
+	var pending []string
+	for i := range addons {
+		addon := &addons[i]
+		if !addon.Spec.IsDefault || kubermaticv1helper.AddonResourcesCreated(addon) {
+			continue
+		}
+		pending = append(pending, addon.Name)
+	}
+	sort.Strings(pending)
+	return pending
+}
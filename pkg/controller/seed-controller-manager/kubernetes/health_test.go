@@ -0,0 +1,170 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	kubermaticv1helper "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1/helper"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestDefaultAddonsReadyCondition(t *testing.T) {
+	addon := func(name string, created corev1.ConditionStatus) *kubermaticv1.Addon {
+		return &kubermaticv1.Addon{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "cluster-test"},
+			Spec:       kubermaticv1.AddonSpec{Name: name, IsDefault: true},
+			Status: kubermaticv1.AddonStatus{
+				Conditions: []kubermaticv1.AddonCondition{{
+					Type:   kubermaticv1.AddonResourcesCreated,
+					Status: created,
+				}},
+			},
+		}
+	}
+
+	tests := []struct {
+		name            string
+		age             time.Duration
+		addons          []ctrlruntimeclient.Object
+		expectedReady   bool
+		expectedStatus  corev1.ConditionStatus
+		expectedReason  string
+		expectedMessage string
+	}{
+		{
+			name:           "All default addons installed",
+			age:            time.Minute,
+			addons:         []ctrlruntimeclient.Object{addon("canal", corev1.ConditionTrue)},
+			expectedReady:  true,
+			expectedStatus: corev1.ConditionTrue,
+		},
+		{
+			name:            "Waiting for default addons",
+			age:             time.Minute,
+			addons:          []ctrlruntimeclient.Object{addon("canal", corev1.ConditionFalse), addon("rbac", corev1.ConditionTrue)},
+			expectedReady:   false,
+			expectedStatus:  corev1.ConditionFalse,
+			expectedReason:  kubermaticv1.ReasonWaitingForAddons,
+			expectedMessage: "Waiting for the default addons to be installed: canal",
+		},
+		{
+			name:            "Default addons not installed within the timeout",
+			age:             2 * time.Hour,
+			addons:          []ctrlruntimeclient.Object{addon("canal", corev1.ConditionFalse)},
+			expectedReady:   true,
+			expectedStatus:  corev1.ConditionFalse,
+			expectedReason:  kubermaticv1.ReasonAddonsNotReady,
+			expectedMessage: "Default addons were not installed within 1h0m0s: canal",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cluster := &kubermaticv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "test",
+					CreationTimestamp: metav1.NewTime(time.Now().Add(-test.age)),
+				},
+				Status: kubermaticv1.ClusterStatus{NamespaceName: "cluster-test"},
+			}
+			r := &Reconciler{
+				Client:             fake.NewClientBuilder().WithObjects(append(test.addons, cluster)...).Build(),
+				recorder:           record.NewFakeRecorder(10),
+				addonsReadyTimeout: time.Hour,
+			}
+
+			ready, err := r.defaultAddonsReady(context.Background(), cluster)
+			if err != nil {
+				t.Fatalf("failed to check the default addons: %v", err)
+			}
+			if ready != test.expectedReady {
+				t.Errorf("expected ready to be %v, got %v", test.expectedReady, ready)
+			}
+
+			_, condition := kubermaticv1helper.GetClusterCondition(cluster, kubermaticv1.ClusterConditionDefaultAddonsReady)
+			if condition == nil {
+				t.Fatalf("expected condition %s to be set", kubermaticv1.ClusterConditionDefaultAddonsReady)
+			}
+			if condition.Status != test.expectedStatus || condition.Reason != test.expectedReason || condition.Message != test.expectedMessage {
+				t.Errorf("expected condition %s/%s/%q, got %s/%s/%q", test.expectedStatus, test.expectedReason, test.expectedMessage, condition.Status, condition.Reason, condition.Message)
+			}
+		})
+	}
+}
+
+func TestPendingDefaultAddons(t *testing.T) {
+	addon := func(name string, isDefault bool, created corev1.ConditionStatus) kubermaticv1.Addon {
+		a := kubermaticv1.Addon{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       kubermaticv1.AddonSpec{Name: name, IsDefault: isDefault},
+		}
+		if created != "" {
+			a.Status.Conditions = []kubermaticv1.AddonCondition{{
+				Type:   kubermaticv1.AddonResourcesCreated,
+				Status: created,
+			}}
+		}
+		return a
+	}
+
+	tests := []struct {
+		name     string
+		addons   []kubermaticv1.Addon
+		expected []string
+	}{
+		{
+			name: "All default addons created",
+			addons: []kubermaticv1.Addon{
+				addon("canal", true, corev1.ConditionTrue),
+				addon("rbac", true, corev1.ConditionTrue),
+			},
+		},
+		{
+			name: "Default addons pending",
+			addons: []kubermaticv1.Addon{
+				addon("rbac", true, ""),
+				addon("canal", true, corev1.ConditionFalse),
+				addon("kube-proxy", true, corev1.ConditionTrue),
+			},
+			expected: []string{"canal", "rbac"},
+		},
+		{
+			name: "User addons are ignored",
+			addons: []kubermaticv1.Addon{
+				addon("dashboard", false, ""),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := pendingDefaultAddons(test.addons); !reflect.DeepEqual(got, test.expected) {
+				t.Errorf("expected %v, got %v", test.expected, got)
+			}
+		})
+	}
+}
@@ -18,14 +18,78 @@ package kubernetes
 
 import (
 	"context"
+	"sync"
+	"time"
 
 	"go.uber.org/zap"
 
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 )
 
+// launchSlotGrantTTL is how long a launch slot granted to a cluster is counted against the
+// limit while the cluster does not show up as launching in the cache.
+const launchSlotGrantTTL = time.Minute
+
+// launchSlots grants launch slots to new clusters. The slots granted recently are counted in
+// addition to the launching clusters in the cache, which does not contain the updates made
+// by other workers right away, so concurrent workers can not exceed the limit.
+type launchSlots struct {
+	lock    sync.Mutex
+	granted map[string]time.Time
+	waiting sets.String
+	now     func() time.Time
+}
+
+func newLaunchSlots() *launchSlots {
+	return &launchSlots{
+		granted: map[string]time.Time{},
+		waiting: sets.NewString(),
+		now:     time.Now,
+	}
+}
+
+// acquire returns true if the cluster gets a launch slot, given the clusters which are
+// launching according to the cache and the maximum number of launching clusters. The second
+// return value is true if the cluster has to wait and was not waiting before.
+func (s *launchSlots) acquire(cluster string, launching sets.String, limit int) (bool, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	now := s.now()
+	count := launching.Len()
+	for name, grantedAt := range s.granted {
+		if launching.Has(name) || now.Sub(grantedAt) > launchSlotGrantTTL {
+			delete(s.granted, name)
+			continue
+		}
+		if name != cluster {
+			count++
+		}
+	}
+
+	if _, ok := s.granted[cluster]; ok || count < limit {
+		s.granted[cluster] = now
+		s.waiting.Delete(cluster)
+		return true, false
+	}
+
+	waitingStarted := !s.waiting.Has(cluster)
+	s.waiting.Insert(cluster)
+	return false, waitingStarted
+}
+
+// release forgets the cluster, once it is deleted.
+func (s *launchSlots) release(cluster string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	delete(s.granted, cluster)
+	s.waiting.Delete(cluster)
+}
+
 // clusterIsReachable checks if the cluster is reachable via its external name
 func (r *Reconciler) clusterIsReachable(ctx context.Context, c *kubermaticv1.Cluster) (bool, error) {
 	client, err := r.userClusterConnProvider.GetClient(ctx, c)
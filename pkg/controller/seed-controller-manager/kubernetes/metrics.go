@@ -0,0 +1,76 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/provider"
+)
+
+var (
+	registerMetrics      sync.Once
+	provisioningDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Subsystem: "kubermatic_cluster",
+			Name:      "provisioning_duration_seconds",
+			Help:      "The time from the creation of a cluster until it got initialized",
+			Buckets:   prometheus.ExponentialBuckets(30, 2, 8),
+		},
+		[]string{"version", "provider"},
+	)
+	lastSuccessfulReconcile = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: "kubermatic_cluster_controller",
+			Name:      "last_successful_reconcile_timestamp_seconds",
+			Help:      "The Unix timestamp of the last successful reconciliation of a cluster",
+		},
+		[]string{"cluster"},
+	)
+	nodePortRangeExhausted = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: "kubermatic_cluster_controller",
+			Name:      "nodeport_range_exhausted_total",
+			Help:      "The number of times a service of a cluster could not be created because the NodePort range is exhausted",
+		},
+		[]string{"cluster", "range"},
+	)
+)
+
+func init() {
+	registerMetrics.Do(func() {
+		prometheus.MustRegister(provisioningDuration)
+		prometheus.MustRegister(lastSuccessfulReconcile)
+		prometheus.MustRegister(nodePortRangeExhausted)
+	})
+}
+
+// observeProvisioningDuration records the time it took to initialize the given cluster.
+func observeProvisioningDuration(cluster *kubermaticv1.Cluster, initializedAt time.Time) {
+	providerName, err := provider.ClusterCloudProviderName(cluster.Spec.Cloud)
+	if err != nil {
+		providerName = "unknown"
+	}
+
+	provisioningDuration.
+		WithLabelValues(cluster.Spec.Version.String(), providerName).
+		Observe(initializedAt.Sub(cluster.CreationTimestamp.Time).Seconds())
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+
+	corev1 "k8s.io/api/core/v1"
+	knetutil "k8s.io/apimachinery/pkg/util/net"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// nodePortRangeExhaustedError is returned when no NodePort is left in the NodePort range of the seed
+// or the datacenter of a cluster.
+type nodePortRangeExhaustedError struct {
+	portRange knetutil.PortRange
+}
+
+func (e *nodePortRangeExhaustedError) Error() string {
+	return fmt.Sprintf("no free NodePort left in the range %s", e.portRange.String())
+}
+
+// exhaustedNodePortRange returns the NodePort range and true if the error was caused by the range
+// being exhausted. Kubernetes reports a failed allocation as a generic internal error, so unless
+// the error is a nodePortRangeExhaustedError the NodePorts allocated in the seed are counted.
+func (r *Reconciler) exhaustedNodePortRange(ctx context.Context, err error) (knetutil.PortRange, bool) {
+	var exhausted *nodePortRangeExhaustedError
+	if errors.As(err, &exhausted) {
+		return exhausted.portRange, true
+	}
+
+	services := &corev1.ServiceList{}
+	if err := r.List(ctx, services); err != nil {
+		r.log.Errorw("Failed to list services to check the NodePort range", "error", err)
+		return knetutil.PortRange{}, false
+	}
+	return r.seedNodePortRange, usedNodePorts(services.Items, r.seedNodePortRange).Len() >= r.seedNodePortRange.Size
+}
+
+// usedNodePorts returns the NodePorts within the given range which are used by the given services.
+func usedNodePorts(services []corev1.Service, portRange knetutil.PortRange) sets.Int {
+	used := sets.NewInt()
+	for _, service := range services {
+		for _, port := range service.Spec.Ports {
+			if port.NodePort != 0 && portRange.Contains(int(port.NodePort)) {
+				used.Insert(int(port.NodePort))
+			}
+		}
+	}
+	return used
+}
+
+// reportNodePortRangeExhausted records an event and increments the exhaustion metric, so the
+// failing reconciliation can be attributed to the exhausted NodePort range.
+func (r *Reconciler) reportNodePortRangeExhausted(cluster *kubermaticv1.Cluster, portRange knetutil.PortRange) {
+	nodePortRange := portRange.String()
+	r.recorder.Eventf(cluster, corev1.EventTypeWarning, EventReasonNodePortsExhausted, "No free NodePort left in the range %s, services of the cluster can not be created", nodePortRange)
+	nodePortRangeExhausted.WithLabelValues(cluster.Name, nodePortRange).Inc()
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/resources/reconciling"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/net"
+	"k8s.io/apimachinery/pkg/util/sets"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// NodePortAllocationStrategy configures how the cluster controller picks the NodePort of the
+// apiserver service of new clusters.
+type NodePortAllocationStrategy string
+
+const (
+	// NodePortAllocationKubernetes leaves the allocation to Kubernetes.
+	NodePortAllocationKubernetes NodePortAllocationStrategy = ""
+	// NodePortAllocationLowest picks the lowest free NodePort of the range.
+	NodePortAllocationLowest NodePortAllocationStrategy = "lowest"
+	// NodePortAllocationRandom picks a random free NodePort of the range, so the port of a deleted
+	// cluster is not immediately reused.
+	NodePortAllocationRandom NodePortAllocationStrategy = "random"
+)
+
+// ParseNodePortAllocationStrategy parses the NodePort allocation strategy, an empty string leaves
+// the allocation to Kubernetes.
+func ParseNodePortAllocationStrategy(s string) (NodePortAllocationStrategy, error) {
+	switch strategy := NodePortAllocationStrategy(s); strategy {
+	case NodePortAllocationKubernetes, NodePortAllocationLowest, NodePortAllocationRandom:
+		return strategy, nil
+	default:
+		return "", fmt.Errorf("unknown NodePort allocation strategy %q, must be one of %q, %q or empty", s, NodePortAllocationLowest, NodePortAllocationRandom)
+	}
+}
+
+// freeNodePort returns a port of the range which is not used, according to the strategy. The
+// random strategy uses intn to pick among the free ports.
+func freeNodePort(strategy NodePortAllocationStrategy, portRange net.PortRange, used sets.Int, intn func(int) int) (int, error) {
+	var free []int
+	for port := portRange.Base; port < portRange.Base+portRange.Size; port++ {
+		if used.Has(port) {
+			continue
+		}
+		if strategy == NodePortAllocationLowest {
+			return port, nil
+		}
+		free = append(free, port)
+	}
+	if len(free) == 0 {
+		return 0, &nodePortRangeExhaustedError{portRange: portRange}
+	}
+	return free[intn(len(free))], nil
+}
+
+// maxNodePortAllocationAttempts is the number of NodePorts tried for the apiserver service within
+// a single reconciliation, in case the picked ones get allocated to other services meanwhile.
+const maxNodePortAllocationAttempts = 3
+
+// apiserverNodePortRange returns the range the NodePort of the apiserver service is allocated from,
+// which is the NodePort range of the datacenter if it configures one, or the range of the seed.
+func (r *Reconciler) apiserverNodePortRange(dc *kubermaticv1.Datacenter) (net.PortRange, error) {
+	if dc == nil || dc.Spec.NodePortRange == "" {
+		return r.seedNodePortRange, nil
+	}
+
+	portRange, err := net.ParsePortRange(dc.Spec.NodePortRange)
+	if err != nil {
+		return net.PortRange{}, fmt.Errorf("invalid NodePort range of the datacenter: %v", err)
+	}
+	if !r.seedNodePortRange.Contains(portRange.Base) || !r.seedNodePortRange.Contains(portRange.Base+portRange.Size-1) {
+		return net.PortRange{}, fmt.Errorf("NodePort range %s of the datacenter is not within the NodePort range %s of the seed", portRange.String(), r.seedNodePortRange.String())
+	}
+	return *portRange, nil
+}
+
+// allocateAPIServerNodePort picks the NodePort for the apiserver service of the cluster from the
+// NodePort range of its datacenter or the seed according to the configured strategy, skipping the
+// excluded ports. Kubernetes only allocates from the range of the seed, so the port is picked
+// randomly for datacenters with a NodePort range if the allocation is left to Kubernetes otherwise.
+// The port stays reserved for the cluster until its apiserver service shows up in the cache, and
+// is persisted in the cluster status before the service is created, so it is reused if the
+// creation fails or the controller restarts in between.
+// It returns 0 if Kubernetes allocates the port, the cluster requests a fixed port or its
+// apiserver service has a NodePort already.
+func (r *Reconciler) allocateAPIServerNodePort(ctx context.Context, cluster *kubermaticv1.Cluster, dc *kubermaticv1.Datacenter, excluded sets.Int) (int32, error) {
+	strategy := r.features.NodePortAllocation
+	if strategy == NodePortAllocationKubernetes && dc != nil && dc.Spec.NodePortRange != "" {
+		strategy = NodePortAllocationRandom
+	}
+	if strategy == NodePortAllocationKubernetes || requestedAPIServerNodePort(cluster) != 0 || cluster.Spec.ExposeStrategy == kubermaticv1.ExposeStrategyTunneling {
+		return 0, nil
+	}
+
+	portRange, err := r.apiserverNodePortRange(dc)
+	if err != nil {
+		return 0, err
+	}
+
+	services := &corev1.ServiceList{}
+	if err := r.List(ctx, services); err != nil {
+		return 0, fmt.Errorf("failed to list services: %v", err)
+	}
+	for _, service := range services.Items {
+		if service.Namespace != cluster.Status.NamespaceName || service.Name != resources.ApiserverServiceName {
+			continue
+		}
+		for _, port := range service.Spec.Ports {
+			if port.NodePort != 0 {
+				r.nodePortReservations.release(cluster.Name)
+				return 0, nil
+			}
+		}
+	}
+
+	used := usedNodePorts(services.Items, portRange).Union(excluded)
+	port, err := r.nodePortReservations.reserve(cluster.Name, used, func(unavailable sets.Int) (int, error) {
+		if persisted := int(cluster.Status.APIServerNodePort); persisted != 0 && portRange.Contains(persisted) && !unavailable.Has(persisted) {
+			return persisted, nil
+		}
+		return freeNodePort(strategy, portRange, unavailable, rand.Intn)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if err := r.updateCluster(ctx, cluster, func(c *kubermaticv1.Cluster) {
+		c.Status.APIServerNodePort = int32(port)
+	}); err != nil {
+		return 0, fmt.Errorf("failed to persist NodePort %d of the apiserver service: %v", port, err)
+	}
+	return int32(port), nil
+}
+
+// apiserverNodePortWrapper requests the given NodePort for the apiserver service as long as it
+// has none. The target port is left to the apiserver ServiceCreator, which makes it follow the
+// NodePort once the service has been created.
+func apiserverNodePortWrapper(nodePort int32) reconciling.ObjectModifier {
+	return func(create reconciling.ObjectCreator) reconciling.ObjectCreator {
+		return func(existing ctrlruntimeclient.Object) (ctrlruntimeclient.Object, error) {
+			obj, err := create(existing)
+			if err != nil {
+				return nil, err
+			}
+			service, ok := obj.(*corev1.Service)
+			if !ok || service.Name != resources.ApiserverServiceName || service.Spec.Type == corev1.ServiceTypeClusterIP ||
+				len(service.Spec.Ports) == 0 || service.Spec.Ports[0].NodePort != 0 {
+				return obj, nil
+			}
+			service.Spec.Ports[0].NodePort = nodePort
+			return service, nil
+		}
+	}
+}
@@ -0,0 +1,325 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"testing"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/net"
+	"k8s.io/apimachinery/pkg/util/sets"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestFreeNodePort(t *testing.T) {
+	portRange := net.PortRange{Base: 30000, Size: 10}
+	sparse := sets.NewInt(30000, 30001, 30003, 30006, 30007, 30009)
+
+	tests := []struct {
+		name        string
+		strategy    NodePortAllocationStrategy
+		used        sets.Int
+		expected    int
+		expectedErr bool
+	}{
+		{
+			name:     "Lowest on empty range",
+			strategy: NodePortAllocationLowest,
+			used:     sets.NewInt(),
+			expected: 30000,
+		},
+		{
+			name:     "Lowest skips used ports",
+			strategy: NodePortAllocationLowest,
+			used:     sparse,
+			expected: 30002,
+		},
+		{
+			name:     "Lowest ignores ports outside of the range",
+			strategy: NodePortAllocationLowest,
+			used:     sets.NewInt(29999, 30010),
+			expected: 30000,
+		},
+		{
+			name:        "Lowest on full range",
+			strategy:    NodePortAllocationLowest,
+			used:        sets.NewInt(30000, 30001, 30002, 30003, 30004, 30005, 30006, 30007, 30008, 30009),
+			expectedErr: true,
+		},
+		{
+			name:        "Random on full range",
+			strategy:    NodePortAllocationRandom,
+			used:        sets.NewInt(30000, 30001, 30002, 30003, 30004, 30005, 30006, 30007, 30008, 30009),
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			port, err := freeNodePort(test.strategy, portRange, test.used, rand.New(rand.NewSource(1)).Intn)
+			if test.expectedErr {
+				if err == nil {
+					t.Fatalf("expected an error, got port %d", port)
+				}
+				var exhausted *nodePortRangeExhaustedError
+				if !errors.As(err, &exhausted) {
+					t.Errorf("expected the error to report the range as exhausted, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed to find a free NodePort: %v", err)
+			}
+			if port != test.expected {
+				t.Errorf("expected port %d, got %d", test.expected, port)
+			}
+		})
+	}
+}
+
+func TestFreeNodePortRandom(t *testing.T) {
+	portRange := net.PortRange{Base: 30000, Size: 10}
+	used := sets.NewInt(30000, 30001, 30003, 30006, 30007, 30009)
+	free := sets.NewInt(30002, 30004, 30005, 30008)
+
+	intn := rand.New(rand.NewSource(1)).Intn
+	picked := sets.NewInt()
+	for i := 0; i < 100; i++ {
+		port, err := freeNodePort(NodePortAllocationRandom, portRange, used, intn)
+		if err != nil {
+			t.Fatalf("failed to find a free NodePort: %v", err)
+		}
+		if !free.Has(port) {
+			t.Fatalf("picked port %d which is not free", port)
+		}
+		picked.Insert(port)
+	}
+	if !picked.Equal(free) {
+		t.Errorf("expected all free ports %v to be picked eventually, got %v", free.List(), picked.List())
+	}
+}
+
+func TestAllocateAPIServerNodePort(t *testing.T) {
+	nodePortService := func(namespace, name string, nodePort int32) *corev1.Service {
+		return &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: corev1.ServiceSpec{
+				Type:  corev1.ServiceTypeNodePort,
+				Ports: []corev1.ServicePort{{Port: 443, NodePort: nodePort}},
+			},
+		}
+	}
+
+	datacenter := func(nodePortRange string) *kubermaticv1.Datacenter {
+		return &kubermaticv1.Datacenter{Spec: kubermaticv1.DatacenterSpec{NodePortRange: nodePortRange}}
+	}
+
+	tests := []struct {
+		name        string
+		objects     []ctrlruntimeclient.Object
+		dc          *kubermaticv1.Datacenter
+		kubernetes  bool
+		persisted   int32
+		excluded    sets.Int
+		expected    int32
+		expectedErr bool
+	}{
+		{
+			name:     "Lowest free port of the seed range",
+			objects:  []ctrlruntimeclient.Object{nodePortService("cluster-other", resources.ApiserverServiceName, 30000)},
+			excluded: sets.NewInt(),
+			expected: 30001,
+		},
+		{
+			name:     "Excluded ports are skipped",
+			objects:  []ctrlruntimeclient.Object{nodePortService("cluster-other", resources.ApiserverServiceName, 30000)},
+			excluded: sets.NewInt(30001),
+			expected: 30002,
+		},
+		{
+			name:      "Persisted port is reused",
+			objects:   []ctrlruntimeclient.Object{nodePortService("cluster-other", resources.ApiserverServiceName, 30000)},
+			persisted: 30007,
+			excluded:  sets.NewInt(),
+			expected:  30007,
+		},
+		{
+			name:      "Persisted port allocated to another service",
+			objects:   []ctrlruntimeclient.Object{nodePortService("cluster-other", resources.ApiserverServiceName, 30007)},
+			persisted: 30007,
+			excluded:  sets.NewInt(),
+			expected:  30000,
+		},
+		{
+			name:     "Lowest free port of the datacenter range",
+			objects:  []ctrlruntimeclient.Object{nodePortService("cluster-other", resources.ApiserverServiceName, 30000)},
+			dc:       datacenter("30005-30009"),
+			excluded: sets.NewInt(),
+			expected: 30005,
+		},
+		{
+			name: "Datacenters allocate from their own ranges",
+			objects: []ctrlruntimeclient.Object{
+				nodePortService("cluster-a", resources.ApiserverServiceName, 30000),
+				nodePortService("cluster-b", resources.ApiserverServiceName, 30005),
+			},
+			dc:       datacenter("30000-30004"),
+			excluded: sets.NewInt(),
+			expected: 30001,
+		},
+		{
+			name:      "Persisted port outside of the datacenter range is not reused",
+			persisted: 30001,
+			dc:        datacenter("30005-30009"),
+			excluded:  sets.NewInt(),
+			expected:  30005,
+		},
+		{
+			name: "Kubernetes strategy allocates from the datacenter range",
+			objects: []ctrlruntimeclient.Object{
+				nodePortService("cluster-a", resources.ApiserverServiceName, 30005),
+				nodePortService("cluster-b", resources.ApiserverServiceName, 30006),
+				nodePortService("cluster-c", resources.ApiserverServiceName, 30007),
+				nodePortService("cluster-d", resources.ApiserverServiceName, 30008),
+			},
+			dc:         datacenter("30005-30009"),
+			kubernetes: true,
+			excluded:   sets.NewInt(),
+			expected:   30009,
+		},
+		{
+			name:       "Kubernetes strategy without a datacenter range",
+			kubernetes: true,
+			excluded:   sets.NewInt(),
+		},
+		{
+			name:        "Datacenter range not within the seed range",
+			dc:          datacenter("30005-30014"),
+			excluded:    sets.NewInt(),
+			expectedErr: true,
+		},
+		{
+			name:     "Apiserver service has a NodePort already",
+			objects:  []ctrlruntimeclient.Object{nodePortService("cluster-test", resources.ApiserverServiceName, 30005)},
+			excluded: sets.NewInt(),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cluster := &kubermaticv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec:       kubermaticv1.ClusterSpec{ExposeStrategy: kubermaticv1.ExposeStrategyNodePort},
+				Status:     kubermaticv1.ClusterStatus{NamespaceName: "cluster-test", APIServerNodePort: test.persisted},
+			}
+			client := fake.NewClientBuilder().WithObjects(append(test.objects, cluster.DeepCopy())...).Build()
+			strategy := NodePortAllocationLowest
+			if test.kubernetes {
+				strategy = NodePortAllocationKubernetes
+			}
+			r := &Reconciler{
+				Client:               client,
+				seedNodePortRange:    net.PortRange{Base: 30000, Size: 10},
+				features:             Features{NodePortAllocation: strategy},
+				nodePortReservations: newNodePortReservations(),
+			}
+
+			port, err := r.allocateAPIServerNodePort(context.Background(), cluster, test.dc, test.excluded)
+			if test.expectedErr {
+				if err == nil {
+					t.Fatalf("expected an error, got port %d", port)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed to allocate a NodePort: %v", err)
+			}
+			if port != test.expected {
+				t.Errorf("expected port %d, got %d", test.expected, port)
+			}
+			if port == 0 {
+				return
+			}
+
+			persisted := &kubermaticv1.Cluster{}
+			if err := client.Get(context.Background(), ctrlruntimeclient.ObjectKeyFromObject(cluster), persisted); err != nil {
+				t.Fatalf("failed to get cluster: %v", err)
+			}
+			if persisted.Status.APIServerNodePort != port {
+				t.Errorf("expected port %d to be persisted in the cluster status, got %d", port, persisted.Status.APIServerNodePort)
+			}
+		})
+	}
+}
+
+func TestAPIServerNodePortWrapper(t *testing.T) {
+	creator := func(existing ctrlruntimeclient.Object) (ctrlruntimeclient.Object, error) {
+		service := existing.(*corev1.Service)
+		service.Spec.Type = corev1.ServiceTypeNodePort
+		if len(service.Spec.Ports) == 0 {
+			service.Spec.Ports = []corev1.ServicePort{{Port: 443, TargetPort: intstr.FromInt(resources.APIServerSecurePort)}}
+		}
+		return service, nil
+	}
+
+	tests := []struct {
+		name             string
+		existing         *corev1.Service
+		expectedNodePort int32
+	}{
+		{
+			name:             "New service gets the NodePort",
+			existing:         &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: resources.ApiserverServiceName}},
+			expectedNodePort: 30001,
+		},
+		{
+			name: "Existing NodePort is kept",
+			existing: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: resources.ApiserverServiceName},
+				Spec: corev1.ServiceSpec{
+					Ports: []corev1.ServicePort{{Port: 443, NodePort: 30005, TargetPort: intstr.FromInt(resources.APIServerSecurePort)}},
+				},
+			},
+			expectedNodePort: 30005,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			obj, err := apiserverNodePortWrapper(30001)(creator)(test.existing)
+			if err != nil {
+				t.Fatalf("failed to create the service: %v", err)
+			}
+			port := obj.(*corev1.Service).Spec.Ports[0]
+			if port.NodePort != test.expectedNodePort {
+				t.Errorf("expected NodePort %d, got %d", test.expectedNodePort, port.NodePort)
+			}
+			if port.TargetPort.IntValue() != resources.APIServerSecurePort {
+				t.Errorf("expected the target port to be left at %d, got %s", resources.APIServerSecurePort, port.TargetPort.String())
+			}
+		})
+	}
+}
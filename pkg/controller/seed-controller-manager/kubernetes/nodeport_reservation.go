@@ -0,0 +1,94 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// nodePortReservationTTL is how long a NodePort picked for the apiserver service of a cluster is
+// kept from other clusters, so reservations of crashed reconciliations are reclaimed eventually.
+const nodePortReservationTTL = 2 * time.Minute
+
+// nodePortReservations tracks the NodePorts picked for apiserver services which are not visible in
+// the cache yet, so that workers launching clusters concurrently do not pick the same port.
+type nodePortReservations struct {
+	lock  sync.Mutex
+	ports map[int]nodePortReservation
+	now   func() time.Time
+}
+
+type nodePortReservation struct {
+	cluster string
+	expires time.Time
+}
+
+func newNodePortReservations() *nodePortReservations {
+	return &nodePortReservations{
+		ports: map[int]nodePortReservation{},
+		now:   time.Now,
+	}
+}
+
+// reserve returns the port reserved for the cluster, or reserves the port returned by pick, which
+// gets all used ports and the ports reserved for other clusters. The reservations of used ports,
+// whose services are in the cache by now, and expired reservations are released.
+func (n *nodePortReservations) reserve(cluster string, used sets.Int, pick func(unavailable sets.Int) (int, error)) (int, error) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	now := n.now()
+	unavailable := sets.NewInt(used.List()...)
+	reserved := 0
+	for port, reservation := range n.ports {
+		if used.Has(port) || now.After(reservation.expires) {
+			delete(n.ports, port)
+			continue
+		}
+		if reservation.cluster == cluster {
+			reserved = port
+			continue
+		}
+		unavailable.Insert(port)
+	}
+	if reserved != 0 {
+		return reserved, nil
+	}
+
+	port, err := pick(unavailable)
+	if err != nil {
+		return 0, err
+	}
+	n.ports[port] = nodePortReservation{cluster: cluster, expires: now.Add(nodePortReservationTTL)}
+	return port, nil
+}
+
+// release drops the reservation of the cluster once its apiserver service is in the cache or the
+// cluster is deleted.
+func (n *nodePortReservations) release(cluster string) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	for port, reservation := range n.ports {
+		if reservation.cluster == cluster {
+			delete(n.ports, port)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/net"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+func TestNodePortReservations(t *testing.T) {
+	portRange := net.PortRange{Base: 30000, Size: 10}
+	lowest := func(unavailable sets.Int) (int, error) {
+		return freeNodePort(NodePortAllocationLowest, portRange, unavailable, nil)
+	}
+
+	now := time.Now()
+	reservations := newNodePortReservations()
+	reservations.now = func() time.Time { return now }
+
+	reserve := func(cluster string, used sets.Int, expected int) {
+		t.Helper()
+		port, err := reservations.reserve(cluster, used, lowest)
+		if err != nil {
+			t.Fatalf("failed to reserve a NodePort for cluster %s: %v", cluster, err)
+		}
+		if port != expected {
+			t.Fatalf("expected port %d to be reserved for cluster %s, got %d", expected, cluster, port)
+		}
+	}
+
+	// concurrent launches get distinct ports, retries of the same cluster keep theirs
+	reserve("a", sets.NewInt(30000), 30001)
+	reserve("b", sets.NewInt(30000), 30002)
+	reserve("a", sets.NewInt(30000), 30001)
+
+	// the reservation is released once the port shows up in the cache
+	reserve("c", sets.NewInt(30000, 30001), 30003)
+	reservations.release("c")
+	reserve("d", sets.NewInt(30000, 30001), 30003)
+
+	// expired reservations are reclaimed
+	now = now.Add(nodePortReservationTTL + time.Second)
+	reserve("e", sets.NewInt(30000), 30001)
+}
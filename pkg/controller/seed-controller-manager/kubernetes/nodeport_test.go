@@ -0,0 +1,116 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	knetutil "k8s.io/apimachinery/pkg/util/net"
+	"k8s.io/client-go/tools/record"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestReportNodePortRangeExhausted(t *testing.T) {
+	seedNodePortRange := knetutil.PortRange{Base: 30000, Size: 2}
+	internalError := errors.New("Internal error occurred: failed to allocate a nodePort: range is full")
+	nodePortService := func(namespace string, nodePorts ...int32) *corev1.Service {
+		service := &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: resources.ApiserverServiceName, Namespace: namespace},
+			Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeNodePort},
+		}
+		for _, nodePort := range nodePorts {
+			service.Spec.Ports = append(service.Spec.Ports, corev1.ServicePort{NodePort: nodePort})
+		}
+		return service
+	}
+
+	tests := []struct {
+		name        string
+		err         error
+		objects     []ctrlruntimeclient.Object
+		expectEvent bool
+	}{
+		{
+			name: "Unrelated error",
+			err:  errors.New("connection refused"),
+		},
+		{
+			name: "Internal error with free NodePorts in the seed range",
+			err:  internalError,
+			objects: []ctrlruntimeclient.Object{
+				nodePortService("cluster-a", 30000),
+				nodePortService("cluster-b", 29999, 30002),
+			},
+		},
+		{
+			name: "Internal error with an exhausted seed range",
+			err:  fmt.Errorf("failed to ensure Service cluster-test/apiserver-external: %v", internalError),
+			objects: []ctrlruntimeclient.Object{
+				nodePortService("cluster-a", 30000),
+				nodePortService("cluster-b", 30001),
+			},
+			expectEvent: true,
+		},
+		{
+			name:        "NodePort allocation by the controller failed",
+			err:         &nodePortRangeExhaustedError{portRange: seedNodePortRange},
+			expectEvent: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cluster := &kubermaticv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+			}
+
+			recorder := record.NewFakeRecorder(10)
+			r := &Reconciler{
+				Client:            fake.NewClientBuilder().WithObjects(test.objects...).Build(),
+				recorder:          recorder,
+				seedNodePortRange: seedNodePortRange,
+			}
+
+			if portRange, exhausted := r.exhaustedNodePortRange(context.Background(), test.err); exhausted {
+				r.reportNodePortRangeExhausted(cluster, portRange)
+			}
+
+			if !test.expectEvent {
+				if len(recorder.Events) > 0 {
+					t.Fatalf("expected no event, got %q", <-recorder.Events)
+				}
+				return
+			}
+			if len(recorder.Events) != 1 {
+				t.Fatalf("expected exactly one event, got %d", len(recorder.Events))
+			}
+			if event := <-recorder.Events; !strings.Contains(event, EventReasonNodePortsExhausted) || !strings.Contains(event, seedNodePortRange.String()) {
+				t.Errorf("expected event %s mentioning range %s, got %q", EventReasonNodePortsExhausted, seedNodePortRange.String(), event)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const oidcIssuerProbeTimeout = 5 * time.Second
+
+// checkOIDCIssuer verifies that the OIDC issuer configured for the apiserver of a launching
+// cluster serves its discovery document. The apiserver fails to authenticate OIDC tokens
+// without it, but the cluster itself works, so an unreachable issuer only results in a
+// warning event.
+func (r *Reconciler) checkOIDCIssuer(ctx context.Context, cluster *kubermaticv1.Cluster) {
+	issuerURL := cluster.Spec.OIDC.IssuerURL
+	if issuerURL == "" || cluster.Status.HasConditionValue(kubermaticv1.ClusterConditionClusterInitialized, corev1.ConditionTrue) {
+		return
+	}
+
+	transport := &http.Transport{}
+	if r.caBundle != nil {
+		transport.TLSClientConfig = &tls.Config{RootCAs: r.caBundle.CertPool()}
+	}
+	client := &http.Client{Timeout: oidcIssuerProbeTimeout, Transport: transport}
+
+	if err := probeOIDCIssuer(ctx, client, issuerURL); err != nil {
+		r.recorder.Eventf(cluster, corev1.EventTypeWarning, EventReasonOIDCIssuerUnreachable, "OIDC issuer %s is not reachable: %v", issuerURL, err)
+	}
+}
+
+// probeOIDCIssuer requests the OpenID discovery document of the given issuer.
+func probeOIDCIssuer(ctx context.Context, client *http.Client, issuerURL string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(issuerURL, "/")+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected response %d", resp.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestProbeOIDCIssuer(t *testing.T) {
+	testCases := []struct {
+		name      string
+		path      string
+		expectErr bool
+	}{
+		{
+			name: "discovery document is served",
+			path: "/dex/.well-known/openid-configuration",
+		},
+		{
+			name:      "discovery document is missing",
+			path:      "/other/.well-known/openid-configuration",
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != tc.path {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				_, _ = w.Write([]byte(`{"issuer":"https://example.com/dex"}`))
+			}))
+			defer server.Close()
+
+			err := probeOIDCIssuer(context.Background(), server.Client(), server.URL+"/dex/")
+			if (err != nil) != tc.expectErr {
+				t.Errorf("expected error: %v, got: %v", tc.expectErr, err)
+			}
+		})
+	}
+}
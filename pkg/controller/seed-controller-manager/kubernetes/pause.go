@@ -0,0 +1,72 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"time"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	kubermaticv1helper "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1/helper"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// syncPause records whether reconciling the cluster is paused in the ReconcilingEnabled
+// condition and emits an event whenever the cluster gets paused or resumed. It returns
+// true if the cluster is paused and must not be reconciled.
+func (r *Reconciler) syncPause(ctx context.Context, cluster *kubermaticv1.Cluster) (bool, error) {
+	if cluster.Spec.Pause {
+		if cluster.Status.HasConditionValue(kubermaticv1.ClusterConditionReconcilingEnabled, corev1.ConditionFalse) {
+			return true, nil
+		}
+		r.recorder.Eventf(cluster, corev1.EventTypeNormal, EventReasonPaused, "Reconciling is paused: %s", pauseReason(cluster))
+		return true, r.setLaunchCheckConditions(ctx, cluster, corev1.ConditionFalse, kubermaticv1.ReasonClusterPaused, pauseReason(cluster), kubermaticv1.ClusterConditionReconcilingEnabled)
+	}
+
+	// Clusters which were never paused do not get the condition
+	if !cluster.Status.HasConditionValue(kubermaticv1.ClusterConditionReconcilingEnabled, corev1.ConditionFalse) {
+		return false, nil
+	}
+	r.recorder.Event(cluster, corev1.EventTypeNormal, EventReasonResumed, "Reconciling is resumed")
+	return false, r.setLaunchCheckConditions(ctx, cluster, corev1.ConditionTrue, "", "", kubermaticv1.ClusterConditionReconcilingEnabled)
+}
+
+func pauseReason(cluster *kubermaticv1.Cluster) string {
+	if cluster.Spec.PauseReason == "" {
+		return "no reason given"
+	}
+	return cluster.Spec.PauseReason
+}
+
+// launchStartTime returns the time from which the launch timeout of the cluster is measured.
+// This is the transition of the ClusterInitialized condition into false when the launch
+// started, or the time the cluster was resumed if it was paused later on, as nothing happens
+// to a paused cluster and it would otherwise be failed right after resuming. Clusters which
+// started launching before the condition was recorded fall back to their creation time.
+func launchStartTime(cluster *kubermaticv1.Cluster) time.Time {
+	start := cluster.CreationTimestamp.Time
+	if _, condition := kubermaticv1helper.GetClusterCondition(cluster, kubermaticv1.ClusterConditionClusterInitialized); condition != nil &&
+		condition.Status == corev1.ConditionFalse && !condition.LastTransitionTime.IsZero() {
+		start = condition.LastTransitionTime.Time
+	}
+	if _, condition := kubermaticv1helper.GetClusterCondition(cluster, kubermaticv1.ClusterConditionReconcilingEnabled); condition != nil &&
+		condition.Status == corev1.ConditionTrue && condition.LastTransitionTime.After(start) {
+		start = condition.LastTransitionTime.Time
+	}
+	return start
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestSyncPause(t *testing.T) {
+	tests := []struct {
+		name            string
+		pause           bool
+		condition       corev1.ConditionStatus
+		expectPaused    bool
+		expectCondition corev1.ConditionStatus
+		expectEvent     bool
+	}{
+		{
+			name: "Cluster which was never paused",
+		},
+		{
+			name:            "Cluster gets paused",
+			pause:           true,
+			expectPaused:    true,
+			expectCondition: corev1.ConditionFalse,
+			expectEvent:     true,
+		},
+		{
+			name:            "Pause is recorded once",
+			pause:           true,
+			condition:       corev1.ConditionFalse,
+			expectPaused:    true,
+			expectCondition: corev1.ConditionFalse,
+		},
+		{
+			name:            "Cluster gets resumed",
+			condition:       corev1.ConditionFalse,
+			expectCondition: corev1.ConditionTrue,
+			expectEvent:     true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cluster := &kubermaticv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec:       kubermaticv1.ClusterSpec{Pause: test.pause, PauseReason: "maintenance"},
+			}
+			if test.condition != "" {
+				cluster.Status.Conditions = []kubermaticv1.ClusterCondition{{
+					Type:   kubermaticv1.ClusterConditionReconcilingEnabled,
+					Status: test.condition,
+				}}
+			}
+
+			recorder := record.NewFakeRecorder(10)
+			r := &Reconciler{
+				Client:   fake.NewClientBuilder().WithObjects(cluster).Build(),
+				recorder: recorder,
+			}
+
+			paused, err := r.syncPause(context.Background(), cluster)
+			if err != nil {
+				t.Fatalf("failed to sync the pause: %v", err)
+			}
+			if paused != test.expectPaused {
+				t.Errorf("expected paused: %v, got %v", test.expectPaused, paused)
+			}
+			if test.expectCondition != "" && !cluster.Status.HasConditionValue(kubermaticv1.ClusterConditionReconcilingEnabled, test.expectCondition) {
+				t.Errorf("expected condition %s to be %s, got conditions %v", kubermaticv1.ClusterConditionReconcilingEnabled, test.expectCondition, cluster.Status.Conditions)
+			}
+			if test.expectCondition == "" && len(cluster.Status.Conditions) > 0 {
+				t.Errorf("expected no conditions, got %v", cluster.Status.Conditions)
+			}
+			if events := len(recorder.Events); (events > 0) != test.expectEvent {
+				t.Errorf("expected event: %v, got %d events", test.expectEvent, events)
+			}
+		})
+	}
+}
+
+func TestLaunchTimeoutAfterResume(t *testing.T) {
+	created := metav1.NewTime(time.Now().Add(-time.Hour))
+	r := &Reconciler{clusterLaunchTimeout: 30 * time.Minute}
+
+	cluster := &kubermaticv1.Cluster{ObjectMeta: metav1.ObjectMeta{CreationTimestamp: created}}
+	if !r.launchTimeoutExceeded(cluster) {
+		t.Error("expected the launch timeout of a cluster created an hour ago to be exceeded")
+	}
+
+	cluster.Status.Conditions = []kubermaticv1.ClusterCondition{{
+		Type:               kubermaticv1.ClusterConditionReconcilingEnabled,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.NewTime(time.Now().Add(-time.Minute)),
+	}}
+	if r.launchTimeoutExceeded(cluster) {
+		t.Error("expected the launch timeout to be measured from the time the cluster was resumed")
+	}
+}
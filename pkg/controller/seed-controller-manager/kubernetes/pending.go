@@ -18,18 +18,24 @@ package kubernetes
 
 import (
 	"context"
+	"fmt"
+	"sync"
 	"time"
 
 	kubermaticapiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	kubermaticv1helper "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1/helper"
 	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
 	"k8c.io/kubermatic/v2/pkg/resources"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/util/workqueue"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 const (
-	reachableCheckPeriod = 5 * time.Second
+	reachableCheckPeriod    = 5 * time.Second
+	maxReachableCheckPeriod = 5 * time.Minute
 )
 
 func (r *Reconciler) reconcileCluster(ctx context.Context, cluster *kubermaticv1.Cluster) (*reconcile.Result, error) {
@@ -51,16 +57,44 @@ func (r *Reconciler) reconcileCluster(ctx context.Context, cluster *kubermaticv1
 		}
 	}
 
+	if !kuberneteshelper.HasFinalizer(cluster, kubermaticapiv1.ApiserverServiceCleanupFinalizer) {
+		if err := r.updateCluster(ctx, cluster, func(c *kubermaticv1.Cluster) {
+			kuberneteshelper.AddFinalizer(c, kubermaticapiv1.ApiserverServiceCleanupFinalizer)
+		}); err != nil {
+			return nil, err
+		}
+	}
+
 	// Apply etcdLauncher flag
 	if err := r.ensureEtcdLauncherFeatureFlag(ctx, cluster); err != nil {
 		return nil, err
 	}
 
+	// Launch the etcd from a snapshot if requested
+	if res, err := r.ensureEtcdRestoredFromSnapshot(ctx, cluster); err != nil || res != nil {
+		return res, err
+	}
+
+	// Warn about an OIDC issuer the apiserver will not be able to use
+	r.checkOIDCIssuer(ctx, cluster)
+
+	// Do not roll out an apiserver which could not mount its extra volumes
+	if res, err := r.ensureAPIServerExtraVolumesExist(ctx, cluster); err != nil || res != nil {
+		return res, err
+	}
+
 	// Deploy & Update master components for Kubernetes
 	if err := r.ensureResourcesAreDeployed(ctx, cluster); err != nil {
 		return nil, err
 	}
 
+	// Remember the deployed version, later version updates are validated against it
+	if err := r.updateCluster(ctx, cluster, func(c *kubermaticv1.Cluster) {
+		c.Status.ControlPlaneVersion = c.Spec.Version.String()
+	}); err != nil {
+		return nil, err
+	}
+
 	if cluster.Status.ExtendedHealth.Apiserver == kubermaticv1.HealthStatusUp {
 		// Controlling of user-cluster resources
 		reachable, err := r.clusterIsReachable(ctx, cluster)
@@ -69,7 +103,14 @@ func (r *Reconciler) reconcileCluster(ctx context.Context, cluster *kubermaticv1
 		}
 
 		if !reachable {
-			return &reconcile.Result{RequeueAfter: reachableCheckPeriod}, nil
+			return &reconcile.Result{RequeueAfter: r.reachableCheckDelay(cluster)}, nil
+		}
+		r.resetReachableCheckDelay(cluster)
+
+		// Roll out a changed encryption key in phases, so all apiserver replicas can
+		// read the secrets written by the others at any time
+		if err := r.syncEncryptionKeyRollout(ctx, cluster); err != nil {
+			return nil, err
 		}
 
 		// Only add the node deletion finalizer when the cluster is actually running
@@ -97,13 +138,102 @@ func (r *Reconciler) reconcileCluster(ctx context.Context, cluster *kubermaticv1
 	return &reconcile.Result{}, nil
 }
 
+// reachableCheckBackoff tracks the delays of the reachability checks of clusters whose
+// apiserver is not reachable yet, along with the time the next check is due.
+type reachableCheckBackoff struct {
+	lock    sync.Mutex
+	limiter workqueue.RateLimiter
+	due     map[string]time.Time
+}
+
+func newReachableCheckBackoff() *reachableCheckBackoff {
+	return &reachableCheckBackoff{
+		limiter: workqueue.NewItemExponentialFailureRateLimiter(reachableCheckPeriod, maxReachableCheckPeriod),
+		due:     map[string]time.Time{},
+	}
+}
+
+// reachableCheckDelay returns the delay until the apiserver of a cluster which is not
+// reachable yet is checked again. The delay doubles with every failed check up to
+// maxReachableCheckPeriod, so a persistently broken cluster does not hammer the seed.
+// Reconciliations triggered by watch events before the scheduled check do not count as
+// failed checks, they keep the remaining delay.
+func (r *Reconciler) reachableCheckDelay(cluster *kubermaticv1.Cluster) time.Duration {
+	b := r.reachableCheckBackoff
+	if b == nil {
+		return reachableCheckPeriod
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	now := time.Now()
+	if due, ok := b.due[cluster.Name]; ok && now.Before(due) {
+		return due.Sub(now)
+	}
+
+	delay := b.limiter.When(cluster.Name)
+	b.due[cluster.Name] = now.Add(delay)
+	return delay
+}
+
+// resetReachableCheckDelay resets the delay of the reachability check once the cluster
+// is reachable, so it is checked promptly if it breaks again.
+func (r *Reconciler) resetReachableCheckDelay(cluster *kubermaticv1.Cluster) {
+	b := r.reachableCheckBackoff
+	if b == nil {
+		return
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.limiter.Forget(cluster.Name)
+	delete(b.due, cluster.Name)
+}
+
+// launchCheck runs a single step of the control plane setup and records its outcome
+// in the given cluster condition. The error of the step is returned unchanged.
+func (r *Reconciler) launchCheck(ctx context.Context, cluster *kubermaticv1.Cluster, conditionType kubermaticv1.ClusterConditionType, check func() error) error {
+	checkErr := check()
+
+	status, reason, message := corev1.ConditionTrue, "", ""
+	if checkErr != nil {
+		status, reason, message = corev1.ConditionFalse, kubermaticv1.ReasonReconcilingFailed, checkErr.Error()
+	}
+
+	if err := r.setLaunchCheckConditions(ctx, cluster, status, reason, message, conditionType); err != nil && checkErr == nil {
+		return err
+	}
+	return checkErr
+}
+
+// setLaunchCheckConditions sets all given cluster conditions to the same state, this is
+// used to mark steps as pending which can not run yet.
+func (r *Reconciler) setLaunchCheckConditions(ctx context.Context, cluster *kubermaticv1.Cluster, status corev1.ConditionStatus, reason, message string, conditionTypes ...kubermaticv1.ClusterConditionType) error {
+	err := r.updateCluster(ctx, cluster, func(c *kubermaticv1.Cluster) {
+		for _, conditionType := range conditionTypes {
+			kubermaticv1helper.SetClusterCondition(c, r.versions, conditionType, status, reason, message)
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set cluster conditions %v: %v", conditionTypes, err)
+	}
+	return nil
+}
+
 // ensureClusterNetworkDefaults will apply default cluster network configuration
 func (r *Reconciler) ensureClusterNetworkDefaults(ctx context.Context, cluster *kubermaticv1.Cluster) error {
 	var modifiers []func(*kubermaticv1.Cluster)
 
+	dualStack := cluster.Spec.ClusterNetwork.IsDualStack()
+
 	if len(cluster.Spec.ClusterNetwork.Services.CIDRBlocks) == 0 {
 		setServiceNetwork := func(c *kubermaticv1.Cluster) {
 			c.Spec.ClusterNetwork.Services.CIDRBlocks = []string{"10.240.16.0/20"}
+			if dualStack {
+				c.Spec.ClusterNetwork.Services.CIDRBlocks = append(c.Spec.ClusterNetwork.Services.CIDRBlocks, "fd02::/120")
+			}
 		}
 		modifiers = append(modifiers, setServiceNetwork)
 	}
@@ -111,6 +241,9 @@ func (r *Reconciler) ensureClusterNetworkDefaults(ctx context.Context, cluster *
 	if len(cluster.Spec.ClusterNetwork.Pods.CIDRBlocks) == 0 {
 		setPodNetwork := func(c *kubermaticv1.Cluster) {
 			c.Spec.ClusterNetwork.Pods.CIDRBlocks = []string{"172.25.0.0/16"}
+			if dualStack {
+				c.Spec.ClusterNetwork.Pods.CIDRBlocks = append(c.Spec.ClusterNetwork.Pods.CIDRBlocks, "fd01::/48")
+			}
 		}
 		modifiers = append(modifiers, setPodNetwork)
 	}
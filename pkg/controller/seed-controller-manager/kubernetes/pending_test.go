@@ -18,10 +18,14 @@ package kubernetes
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	kubermaticv1helper "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1/helper"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrlruntimefakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
@@ -83,3 +87,92 @@ func TestEnsureEtcdLauncherFeatureFlag(t *testing.T) {
 	}
 
 }
+
+func TestLaunchCheck(t *testing.T) {
+	tests := []struct {
+		name            string
+		checkErr        error
+		expectedStatus  corev1.ConditionStatus
+		expectedMessage string
+	}{
+		{
+			name:           "Successful check",
+			expectedStatus: corev1.ConditionTrue,
+		},
+		{
+			name:            "Failed check",
+			checkErr:        errors.New("failed to create service"),
+			expectedStatus:  corev1.ConditionFalse,
+			expectedMessage: "failed to create service",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cluster := &kubermaticv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-cluster",
+				},
+			}
+			r := &Reconciler{
+				Client: ctrlruntimefakeclient.NewClientBuilder().WithObjects(cluster).Build(),
+			}
+
+			err := r.launchCheck(context.Background(), cluster, kubermaticv1.ClusterConditionServicesReconciled, func() error {
+				return test.checkErr
+			})
+			if err != test.checkErr {
+				t.Fatalf("expected error %v, got %v", test.checkErr, err)
+			}
+
+			_, condition := kubermaticv1helper.GetClusterCondition(cluster, kubermaticv1.ClusterConditionServicesReconciled)
+			if condition == nil {
+				t.Fatal("expected condition to be set")
+			}
+			if condition.Status != test.expectedStatus {
+				t.Errorf("expected condition status %q, got %q", test.expectedStatus, condition.Status)
+			}
+			if condition.Message != test.expectedMessage {
+				t.Errorf("expected condition message %q, got %q", test.expectedMessage, condition.Message)
+			}
+		})
+	}
+}
+
+func TestReachableCheckDelay(t *testing.T) {
+	cluster := &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-cluster",
+		},
+	}
+	r := &Reconciler{
+		reachableCheckBackoff: newReachableCheckBackoff(),
+	}
+
+	expected := reachableCheckPeriod
+	for i := 0; i < 10; i++ {
+		if delay := r.reachableCheckDelay(cluster); delay != expected {
+			t.Fatalf("expected delay %v after %d failed checks, got %v", expected, i, delay)
+		}
+
+		// a reconciliation triggered before the scheduled check does not advance the backoff
+		if delay := r.reachableCheckDelay(cluster); delay > expected || delay < expected-time.Second {
+			t.Fatalf("expected the remaining delay of %v to be kept, got %v", expected, delay)
+		}
+		r.reachableCheckBackoff.due[cluster.Name] = time.Now()
+
+		expected *= 2
+		if expected > maxReachableCheckPeriod {
+			expected = maxReachableCheckPeriod
+		}
+	}
+
+	r.resetReachableCheckDelay(cluster)
+	if delay := r.reachableCheckDelay(cluster); delay != reachableCheckPeriod {
+		t.Errorf("expected delay %v after a reset, got %v", reachableCheckPeriod, delay)
+	}
+
+	if delay := (&Reconciler{}).reachableCheckDelay(cluster); delay != reachableCheckPeriod {
+		t.Errorf("expected delay %v without backoff, got %v", reachableCheckPeriod, delay)
+	}
+}
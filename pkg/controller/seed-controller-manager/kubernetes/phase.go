@@ -0,0 +1,181 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	kubermaticv1helper "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1/helper"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	// phaseNotificationQueueSize is the number of phase transitions buffered for delivery.
+	// Transitions are dropped when the webhook can not keep up, reconciling is never blocked.
+	phaseNotificationQueueSize = 100
+)
+
+// phaseTransition is the payload posted to the phase webhook.
+type phaseTransition struct {
+	ClusterID string                    `json:"clusterID"`
+	OldPhase  kubermaticv1.ClusterPhase `json:"oldPhase"`
+	NewPhase  kubermaticv1.ClusterPhase `json:"newPhase"`
+	Timestamp time.Time                 `json:"timestamp"`
+	Reason    string                    `json:"reason,omitempty"`
+}
+
+// PhaseNotifier posts the phase transitions of clusters to a webhook. Transitions are
+// delivered in order by a single worker, which retries failed deliveries with backoff.
+type PhaseNotifier struct {
+	log     *zap.SugaredLogger
+	url     string
+	client  *http.Client
+	backoff wait.Backoff
+	queue   chan phaseTransition
+}
+
+// NewPhaseNotifier returns a notifier posting to the given URL, every delivery attempt is
+// aborted after the timeout. It must be added to the manager to deliver notifications.
+func NewPhaseNotifier(log *zap.SugaredLogger, url string, timeout time.Duration) *PhaseNotifier {
+	return &PhaseNotifier{
+		log:    log.Named("phase-notifier"),
+		url:    url,
+		client: &http.Client{Timeout: timeout},
+		backoff: wait.Backoff{
+			Duration: time.Second,
+			Factor:   2,
+			Jitter:   0.1,
+			Steps:    5,
+		},
+		queue: make(chan phaseTransition, phaseNotificationQueueSize),
+	}
+}
+
+// Start delivers the queued transitions until the context is closed.
+func (n *PhaseNotifier) Start(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case transition := <-n.queue:
+			if err := n.deliver(ctx, transition); err != nil {
+				n.log.Errorw("Failed to deliver phase transition", "cluster", transition.ClusterID, zap.Error(err))
+			}
+		}
+	}
+}
+
+// notify queues a phase transition for delivery. A nil notifier discards it.
+func (n *PhaseNotifier) notify(transition phaseTransition) {
+	if n == nil {
+		return
+	}
+
+	select {
+	case n.queue <- transition:
+	default:
+		n.log.Warnw("Dropping phase transition, the webhook does not keep up", "cluster", transition.ClusterID, "phase", transition.NewPhase)
+	}
+}
+
+func (n *PhaseNotifier) deliver(ctx context.Context, transition phaseTransition) error {
+	body, err := json.Marshal(transition)
+	if err != nil {
+		return fmt.Errorf("failed to encode phase transition: %v", err)
+	}
+
+	var lastErr error
+	err = wait.ExponentialBackoff(n.backoff, func() (bool, error) {
+		if ctx.Err() != nil {
+			return false, ctx.Err()
+		}
+		if lastErr = n.post(ctx, body); lastErr != nil {
+			n.log.Debugw("Phase webhook failed, retrying", "cluster", transition.ClusterID, zap.Error(lastErr))
+			return false, nil
+		}
+		return true, nil
+	})
+	if err == wait.ErrWaitTimeout {
+		return lastErr
+	}
+
+	return err
+}
+
+func (n *PhaseNotifier) post(ctx context.Context, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected response %d: %s", resp.StatusCode, bytes.TrimSpace(respBody))
+	}
+
+	return nil
+}
+
+// syncPhase stores the phase derived from the cluster status and notifies about its transitions.
+func (r *Reconciler) syncPhase(ctx context.Context, cluster *kubermaticv1.Cluster) error {
+	oldPhase := cluster.Status.Phase
+	newPhase := kubermaticv1helper.ClusterPhase(cluster)
+	if oldPhase == newPhase {
+		return nil
+	}
+
+	if err := r.updateCluster(ctx, cluster, func(c *kubermaticv1.Cluster) {
+		c.Status.Phase = newPhase
+	}); err != nil {
+		return fmt.Errorf("failed to set the cluster phase: %v", err)
+	}
+
+	// notifications would announce transitions which have not been stored
+	if r.dryRun {
+		return nil
+	}
+
+	transition := phaseTransition{
+		ClusterID: cluster.Name,
+		OldPhase:  oldPhase,
+		NewPhase:  newPhase,
+		Timestamp: time.Now().UTC(),
+	}
+	if cluster.Status.ErrorMessage != nil {
+		transition.Reason = *cluster.Status.ErrorMessage
+	}
+	r.phaseNotifier.notify(transition)
+
+	return nil
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+func TestPhaseNotifierDeliver(t *testing.T) {
+	testCases := []struct {
+		name          string
+		failures      int32
+		expectErr     bool
+		expectedCalls int32
+	}{
+		{
+			name:          "delivered at once",
+			failures:      0,
+			expectedCalls: 1,
+		},
+		{
+			name:          "delivered after retries",
+			failures:      2,
+			expectedCalls: 3,
+		},
+		{
+			name:          "retries used up",
+			failures:      10,
+			expectErr:     true,
+			expectedCalls: 3,
+		},
+	}
+
+	transition := phaseTransition{
+		ClusterID: "test-cluster",
+		OldPhase:  kubermaticv1.ClusterPhaseLaunching,
+		NewPhase:  kubermaticv1.ClusterPhaseFailed,
+		Timestamp: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		Reason:    "launch timed out",
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var calls int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if atomic.AddInt32(&calls, 1) <= tc.failures {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+
+				received := phaseTransition{}
+				if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+					t.Errorf("failed to decode payload: %v", err)
+				}
+				if received != transition {
+					t.Errorf("expected payload %+v, got %+v", transition, received)
+				}
+			}))
+			defer server.Close()
+
+			notifier := NewPhaseNotifier(zap.NewNop().Sugar(), server.URL, time.Second)
+			notifier.backoff = wait.Backoff{Duration: time.Millisecond, Factor: 1, Steps: 3}
+
+			if err := notifier.deliver(context.Background(), transition); (err != nil) != tc.expectErr {
+				t.Fatalf("expected error: %t, got: %v", tc.expectErr, err)
+			}
+			if calls := atomic.LoadInt32(&calls); calls != tc.expectedCalls {
+				t.Errorf("expected %d requests, got %d", tc.expectedCalls, calls)
+			}
+		})
+	}
+}
+
+func TestPhaseNotifierDropsWhenFull(t *testing.T) {
+	notifier := NewPhaseNotifier(zap.NewNop().Sugar(), "http://localhost", time.Second)
+
+	for i := 0; i < phaseNotificationQueueSize+1; i++ {
+		notifier.notify(phaseTransition{ClusterID: "test-cluster"})
+	}
+	if len(notifier.queue) != phaseNotificationQueueSize {
+		t.Errorf("expected %d queued transitions, got %d", phaseNotificationQueueSize, len(notifier.queue))
+	}
+
+	// a nil notifier discards transitions
+	var nilNotifier *PhaseNotifier
+	nilNotifier.notify(phaseTransition{ClusterID: "test-cluster"})
+}
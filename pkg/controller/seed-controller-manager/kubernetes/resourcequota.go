@@ -0,0 +1,90 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources/reconciling"
+	"k8c.io/kubermatic/v2/pkg/resources/resourcequota"
+
+	corev1 "k8s.io/api/core/v1"
+	kubeapierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ensureResourceQuota creates the ResourceQuota and LimitRange of the plan of the cluster in
+// its namespace, so a single control plane can not exhaust the resources of the seed. Clusters
+// whose plan is not configured are not limited.
+func (r *Reconciler) ensureResourceQuota(ctx context.Context, cluster *kubermaticv1.Cluster) error {
+	plan := r.resourceQuotaPlans.ForCluster(cluster)
+	if plan == nil {
+		return nil
+	}
+
+	key := types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: resourcequota.Name}
+	modifiers := clusterObjectModifiers(cluster)
+	var created []string
+
+	if plan.ResourceQuota != nil {
+		exists, err := r.objectExists(ctx, key, &corev1.ResourceQuota{})
+		if err != nil {
+			return err
+		}
+		creators := []reconciling.NamedResourceQuotaCreatorGetter{resourcequota.ResourceQuotaCreator(*plan.ResourceQuota)}
+		if err := reconciling.ReconcileResourceQuotas(ctx, creators, key.Namespace, r, modifiers...); err != nil {
+			return fmt.Errorf("failed to ensure ResourceQuota: %v", err)
+		}
+		if !exists {
+			created = append(created, "ResourceQuota")
+		}
+	}
+
+	if plan.LimitRange != nil {
+		exists, err := r.objectExists(ctx, key, &corev1.LimitRange{})
+		if err != nil {
+			return err
+		}
+		creators := []reconciling.NamedLimitRangeCreatorGetter{resourcequota.LimitRangeCreator(*plan.LimitRange)}
+		if err := reconciling.ReconcileLimitRanges(ctx, creators, key.Namespace, r, modifiers...); err != nil {
+			return fmt.Errorf("failed to ensure LimitRange: %v", err)
+		}
+		if !exists {
+			created = append(created, "LimitRange")
+		}
+	}
+
+	if len(created) > 0 {
+		r.recorder.Eventf(cluster, corev1.EventTypeNormal, EventReasonResourceQuotaCreated, "Created %s for plan %q", strings.Join(created, " and "), resourcequota.PlanName(cluster))
+	}
+
+	return nil
+}
+
+func (r *Reconciler) objectExists(ctx context.Context, key types.NamespacedName, obj ctrlruntimeclient.Object) (bool, error) {
+	if err := r.Get(ctx, key, obj); err != nil {
+		if kubeapierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get %s: %v", key, err)
+	}
+	return true, nil
+}
@@ -17,10 +17,13 @@ limitations under the License.
 package kubernetes
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"sync"
 
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/provider/kubernetes"
 	"k8c.io/kubermatic/v2/pkg/resources"
 	"k8c.io/kubermatic/v2/pkg/resources/apiserver"
 	"k8c.io/kubermatic/v2/pkg/resources/certificates"
@@ -41,12 +44,19 @@ import (
 	"k8c.io/kubermatic/v2/pkg/resources/scheduler"
 	"k8c.io/kubermatic/v2/pkg/resources/usercluster"
 
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// maxConcurrentSecretReconciles limits the number of secrets of a single cluster which
+// are reconciled at the same time, to not overwhelm the seed apiserver.
+const maxConcurrentSecretReconciles = 5
+
 func (r *Reconciler) ensureResourcesAreDeployed(ctx context.Context, cluster *kubermaticv1.Cluster) error {
 	seed, err := r.seedGetter()
 	if err != nil {
@@ -57,11 +67,22 @@ func (r *Reconciler) ensureResourcesAreDeployed(ctx context.Context, cluster *ku
 		return err
 	}
 
-	// check that all services are available
-	if err := r.ensureServices(ctx, cluster, data); err != nil {
+	// Check running clusters for drifted deployments and services before they are overwritten,
+	// depending on the drift detection mode they are left untouched
+	applyControlPlane, err := r.checkControlPlaneDrift(ctx, cluster, data)
+	if err != nil {
 		return err
 	}
 
+	// check that all services are available
+	if applyControlPlane {
+		if err := r.launchCheck(ctx, cluster, kubermaticv1.ClusterConditionServicesReconciled, func() error {
+			return r.ensureServices(ctx, cluster, data)
+		}); err != nil {
+			return err
+		}
+	}
+
 	// Set the hostname & url
 	if err := r.syncAddress(ctx, r.log.With("cluster", cluster.Name), cluster, seed); err != nil {
 		return fmt.Errorf("failed to sync address: %v", err)
@@ -71,11 +92,26 @@ func (r *Reconciler) ensureResourcesAreDeployed(ctx context.Context, cluster *ku
 	// strategy is used. Its required for all Kubeconfigs & triggers errors
 	// otherwise.
 	if cluster.Address.IP == "" && cluster.Spec.ExposeStrategy != kubermaticv1.ExposeStrategyTunneling {
-		return nil
+		return r.setLaunchCheckConditions(ctx, cluster, corev1.ConditionFalse, kubermaticv1.ReasonWaitingForAddress, "Waiting for the cluster address to be assigned",
+			kubermaticv1.ClusterConditionRootCAReconciled,
+			kubermaticv1.ClusterConditionSecretsReconciled,
+			kubermaticv1.ClusterConditionStatefulSetsReconciled,
+			kubermaticv1.ClusterConditionConfigMapsReconciled,
+			kubermaticv1.ClusterConditionDeploymentsReconciled,
+		)
+	}
+
+	// all other certificates are signed by the CAs, so they have to exist first
+	if err := r.launchCheck(ctx, cluster, kubermaticv1.ClusterConditionRootCAReconciled, func() error {
+		return r.ensureCASecrets(ctx, cluster, data, clusterObjectModifiers(cluster)...)
+	}); err != nil {
+		return err
 	}
 
 	// check that all secrets are available // New way of handling secrets
-	if err := r.ensureSecrets(ctx, cluster, data); err != nil {
+	if err := r.launchCheck(ctx, cluster, kubermaticv1.ClusterConditionSecretsReconciled, func() error {
+		return r.ensureSecrets(ctx, cluster, data)
+	}); err != nil {
 		return err
 	}
 
@@ -91,8 +127,14 @@ func (r *Reconciler) ensureResourcesAreDeployed(ctx context.Context, cluster *ku
 		return err
 	}
 
+	if err := r.ensureResourceQuota(ctx, cluster); err != nil {
+		return err
+	}
+
 	// check that all StatefulSets are created
-	if err := r.ensureStatefulSets(ctx, cluster, data); err != nil {
+	if err := r.launchCheck(ctx, cluster, kubermaticv1.ClusterConditionStatefulSetsReconciled, func() error {
+		return r.ensureStatefulSets(ctx, cluster, data)
+	}); err != nil {
 		return err
 	}
 
@@ -107,17 +149,29 @@ func (r *Reconciler) ensureResourcesAreDeployed(ctx context.Context, cluster *ku
 	// isn't working correctly"
 	// https://github.com/kubermatic/kubermatic/issues/2948
 	if kubermaticv1.HealthStatusUp != cluster.Status.ExtendedHealth.CloudProviderInfrastructure {
-		return nil
+		return r.setLaunchCheckConditions(ctx, cluster, corev1.ConditionFalse, kubermaticv1.ReasonWaitingForCloudProvider, "Waiting for the cloud provider infrastructure to be ready",
+			kubermaticv1.ClusterConditionConfigMapsReconciled,
+			kubermaticv1.ClusterConditionDeploymentsReconciled,
+		)
 	}
 
 	// check that all ConfigMaps are available
-	if err := r.ensureConfigMaps(ctx, cluster, data); err != nil {
+	if err := r.launchCheck(ctx, cluster, kubermaticv1.ClusterConditionConfigMapsReconciled, func() error {
+		return r.ensureConfigMaps(ctx, cluster, data)
+	}); err != nil {
 		return err
 	}
 
 	// check that all Deployments are available
-	if err := r.ensureDeployments(ctx, cluster, data); err != nil {
-		return err
+	if applyControlPlane {
+		if err := r.launchCheck(ctx, cluster, kubermaticv1.ClusterConditionDeploymentsReconciled, func() error {
+			return r.ensureDeployments(ctx, cluster, data)
+		}); err != nil {
+			return err
+		}
+		if err := r.removeReapplyControlPlaneAnnotation(ctx, cluster); err != nil {
+			return err
+		}
 	}
 
 	// check that all CronJobs are created
@@ -178,6 +232,7 @@ func (r *Reconciler) getClusterTemplateData(ctx context.Context, cluster *kuberm
 		WithInClusterPrometheusDefaultScrapingConfigsDisabled(r.inClusterPrometheusDisableDefaultScrapingConfigs).
 		WithInClusterPrometheusScrapingConfigsFile(r.inClusterPrometheusScrapingConfigsFile).
 		WithCABundle(r.caBundle).
+		WithRootCASigningCA(r.rootCASigningCA).
 		WithOIDCIssuerURL(r.oidcIssuerURL).
 		WithOIDCIssuerClientID(r.oidcIssuerClientID).
 		WithNodeLocalDNSCacheEnabled(r.nodeLocalDNSCacheEnabled).
@@ -194,7 +249,7 @@ func (r *Reconciler) getClusterTemplateData(ctx context.Context, cluster *kuberm
 func (r *Reconciler) ensureNamespaceExists(ctx context.Context, cluster *kubermaticv1.Cluster) error {
 	if cluster.Status.NamespaceName == "" {
 		err := r.updateCluster(ctx, cluster, func(c *kubermaticv1.Cluster) {
-			c.Status.NamespaceName = fmt.Sprintf("cluster-%s", c.Name)
+			c.Status.NamespaceName = kubernetes.NamespaceNameWithPrefix(r.namespacePrefix, c.Name)
 		})
 		if err != nil {
 			return err
@@ -202,7 +257,9 @@ func (r *Reconciler) ensureNamespaceExists(ctx context.Context, cluster *kuberma
 	}
 
 	ns := &corev1.Namespace{}
-	if err := r.Get(ctx, types.NamespacedName{Name: cluster.Status.NamespaceName}, ns); !errors.IsNotFound(err) {
+	if err := r.Get(ctx, types.NamespacedName{Name: cluster.Status.NamespaceName}, ns); err == nil {
+		return r.ensureNamespaceOwnerRef(ctx, cluster, ns)
+	} else if !errors.IsNotFound(err) {
 		return err
 	}
 
@@ -210,6 +267,8 @@ func (r *Reconciler) ensureNamespaceExists(ctx context.Context, cluster *kuberma
 		ObjectMeta: metav1.ObjectMeta{
 			Name:            cluster.Status.NamespaceName,
 			OwnerReferences: []metav1.OwnerReference{r.getOwnerRefForCluster(cluster)},
+			Labels:          cluster.Spec.ResourceLabels,
+			Annotations:     cluster.Spec.ResourceAnnotations,
 		},
 	}
 	if err := r.Client.Create(ctx, ns); err != nil {
@@ -219,10 +278,46 @@ func (r *Reconciler) ensureNamespaceExists(ctx context.Context, cluster *kuberma
 	return nil
 }
 
+// ensureNamespaceOwnerRef adds the owner reference to the cluster to an existing namespace,
+// which e.g. was left over by a cluster which was only partially launched. Without it, the
+// namespace and all resources in it would not be garbage collected once the cluster is deleted.
+func (r *Reconciler) ensureNamespaceOwnerRef(ctx context.Context, cluster *kubermaticv1.Cluster, ns *corev1.Namespace) error {
+	for _, ref := range ns.OwnerReferences {
+		if ref.UID == cluster.UID {
+			return nil
+		}
+	}
+
+	oldNs := ns.DeepCopy()
+	ns.OwnerReferences = append(ns.OwnerReferences, r.getOwnerRefForCluster(cluster))
+	if err := r.Patch(ctx, ns, ctrlruntimeclient.MergeFrom(oldNs)); err != nil {
+		return fmt.Errorf("failed to add the owner reference to Namespace %s: %v", ns.Name, err)
+	}
+
+	return nil
+}
+
+// clusterObjectModifiers returns the modifiers applied to all resources created for the cluster.
+// The owner reference makes sure they are garbage collected once the cluster is deleted, the
+// resource labels and annotations configured in the cluster spec are added to them.
+func clusterObjectModifiers(c *kubermaticv1.Cluster) []reconciling.ObjectModifier {
+	return []reconciling.ObjectModifier{
+		reconciling.OwnerRefWrapper(resources.GetClusterRef(c)),
+		reconciling.LabelsAnnotationsWrapper(c.Spec.ResourceLabels, c.Spec.ResourceAnnotations),
+	}
+}
+
+// podObjectModifiers returns the modifiers applied to all resources of the cluster which run pods.
+// In addition to the cluster modifiers, the pods reference the image pull secret of the cluster
+// namespace, so images can be pulled from private registries.
+func podObjectModifiers(c *kubermaticv1.Cluster) []reconciling.ObjectModifier {
+	return append(clusterObjectModifiers(c), reconciling.ImagePullSecretsWrapper(resources.ImagePullSecretName))
+}
+
 // GetServiceCreators returns all service creators that are currently in use
 func GetServiceCreators(data *resources.TemplateData) []reconciling.NamedServiceCreatorGetter {
 	creators := []reconciling.NamedServiceCreatorGetter{
-		apiserver.ServiceCreator(data.Cluster().Spec.ExposeStrategy, data.Cluster().Address.ExternalName),
+		apiserver.ServiceCreator(data.Cluster().Spec.ExposeStrategy, data.Cluster().Address.ExternalName, apiserver.ServiceSettings(data.Cluster(), data.DC())),
 		openvpn.ServiceCreator(data.Cluster().Spec.ExposeStrategy),
 		etcd.ServiceCreator(data),
 		dns.ServiceCreator(),
@@ -244,8 +339,40 @@ func GetServiceCreators(data *resources.TemplateData) []reconciling.NamedService
 }
 
 func (r *Reconciler) ensureServices(ctx context.Context, c *kubermaticv1.Cluster, data *resources.TemplateData) error {
-	creators := GetServiceCreators(data)
-	return reconciling.ReconcileServices(ctx, creators, c.Status.NamespaceName, r, reconciling.OwnerRefWrapper(resources.GetClusterRef(c)))
+	err := r.reconcileServices(ctx, c, data)
+	if err != nil {
+		if portRange, exhausted := r.exhaustedNodePortRange(ctx, err); exhausted {
+			r.reportNodePortRangeExhausted(c, portRange)
+		}
+	}
+	return err
+}
+
+// reconcileServices reconciles the services of the cluster. If the NodePort picked for the
+// apiserver service got allocated to another service in the meantime, another port is picked.
+func (r *Reconciler) reconcileServices(ctx context.Context, c *kubermaticv1.Cluster, data *resources.TemplateData) error {
+	excluded := sets.NewInt()
+	for attempt := 1; ; attempt++ {
+		nodePort, err := r.allocateAPIServerNodePort(ctx, c, data.DC(), excluded)
+		if err != nil {
+			return err
+		}
+
+		modifiers := clusterObjectModifiers(c)
+		if nodePort != 0 {
+			modifiers = append(modifiers, apiserverNodePortWrapper(nodePort))
+		}
+		err = reconciling.ReconcileServices(ctx, GetServiceCreators(data), c.Status.NamespaceName, r, modifiers...)
+		if err == nil || nodePort == 0 || attempt == maxNodePortAllocationAttempts {
+			return err
+		}
+
+		owner, ownerErr := r.nodePortOwner(ctx, c, nodePort)
+		if ownerErr != nil || owner == "" {
+			return err
+		}
+		excluded.Insert(int(nodePort))
+	}
 }
 
 // GetDeploymentCreators returns all DeploymentCreators that are currently in use
@@ -282,23 +409,72 @@ func GetDeploymentCreators(data *resources.TemplateData, enableAPIserverOIDCAuth
 }
 
 func (r *Reconciler) ensureDeployments(ctx context.Context, cluster *kubermaticv1.Cluster, data *resources.TemplateData) error {
+	missing, err := r.missingControlPlaneDeployments(ctx, cluster)
+	if err != nil {
+		return err
+	}
+
 	creators := GetDeploymentCreators(data, r.features.KubernetesOIDCAuthentication)
-	return reconciling.ReconcileDeployments(ctx, creators, cluster.Status.NamespaceName, r, reconciling.OwnerRefWrapper(resources.GetClusterRef(cluster)))
+	modifiers := podObjectModifiers(cluster)
+	if err := reconciling.ReconcileDeployments(ctx, creators, cluster.Status.NamespaceName, newTransientErrorRetryingClient(r), modifiers...); err != nil {
+		return err
+	}
+
+	for _, name := range missing {
+		r.recorder.Eventf(cluster, corev1.EventTypeWarning, EventReasonDeploymentRecreated, "Recreated the deleted deployment %s", name)
+	}
+	return nil
 }
 
-// GetSecretCreators returns all SecretCreators that are currently in use
-func (r *Reconciler) GetSecretCreators(data *resources.TemplateData) []reconciling.NamedSecretCreatorGetter {
-	creators := []reconciling.NamedSecretCreatorGetter{
+// controlPlaneDeployments are the deployments whose recreation is reported, as the
+// cluster is not usable while they are missing
+var controlPlaneDeployments = []string{
+	resources.ApiserverDeploymentName,
+	resources.ControllerManagerDeploymentName,
+	resources.SchedulerDeploymentName,
+}
+
+// missingControlPlaneDeployments returns the control plane deployments which got deleted after
+// the cluster was initialized. Missing deployments of new clusters are not reported.
+func (r *Reconciler) missingControlPlaneDeployments(ctx context.Context, cluster *kubermaticv1.Cluster) ([]string, error) {
+	if !cluster.Status.HasConditionValue(kubermaticv1.ClusterConditionClusterInitialized, corev1.ConditionTrue) {
+		return nil, nil
+	}
+
+	var missing []string
+	for _, name := range controlPlaneDeployments {
+		key := types.NamespacedName{Namespace: cluster.Status.NamespaceName, Name: name}
+		if err := r.Get(ctx, key, &appsv1.Deployment{}); err != nil {
+			if !errors.IsNotFound(err) {
+				return nil, fmt.Errorf("failed to get deployment %s: %v", name, err)
+			}
+			missing = append(missing, name)
+		}
+	}
+	return missing, nil
+}
+
+// GetCASecretCreators returns the SecretCreators for the certificate authorities, which must
+// be reconciled before any of the secrets returned by GetSecretCreators
+func GetCASecretCreators(data *resources.TemplateData) []reconciling.NamedSecretCreatorGetter {
+	return []reconciling.NamedSecretCreatorGetter{
 		certificates.RootCACreator(data),
 		openvpn.CACreator(),
 		certificates.FrontProxyCACreator(),
+	}
+}
+
+// GetSecretCreators returns all SecretCreators that are currently in use, except for the CAs.
+// The secrets do not depend on each other and can be reconciled concurrently.
+func (r *Reconciler) GetSecretCreators(data *resources.TemplateData) []reconciling.NamedSecretCreatorGetter {
+	creators := []reconciling.NamedSecretCreatorGetter{
 		resources.ImagePullSecretCreator(r.dockerPullConfigJSON),
 		apiserver.FrontProxyClientCertificateCreator(data),
 		etcd.TLSCertificateCreator(data),
 		apiserver.EtcdClientCertificateCreator(data),
 		apiserver.TLSServingCertificateCreator(data),
 		apiserver.KubeletClientCertificateCreator(data),
-		apiserver.ServiceAccountKeyCreator(),
+		apiserver.ServiceAccountKeyCreator(data),
 		openvpn.TLSServingCertificateCreator(data),
 		openvpn.InternalClientCertificateCreator(data),
 		machinecontroller.TLSServingCertificateCreator(data),
@@ -320,6 +496,10 @@ func (r *Reconciler) GetSecretCreators(data *resources.TemplateData) []reconcili
 		resources.ViewerKubeconfigCreator(data),
 	}
 
+	if settings := data.Cluster().Spec.EncryptionConfiguration; settings != nil && settings.Enabled {
+		creators = append(creators, apiserver.EncryptionConfigurationSecretCreator(data))
+	}
+
 	if flag := data.Cluster().Spec.Features[kubermaticv1.ClusterFeatureExternalCloudProvider]; flag {
 		creators = append(creators, resources.GetInternalKubeconfigCreator(
 			resources.CloudControllerManagerKubeconfigSecretName, resources.CloudControllerManagerCertUsername, nil, data,
@@ -337,16 +517,112 @@ func (r *Reconciler) GetSecretCreators(data *resources.TemplateData) []reconcili
 	return creators
 }
 
+// ensureSecrets reconciles all secrets except the CA secrets, which are reconciled by ensureCASecrets.
 func (r *Reconciler) ensureSecrets(ctx context.Context, c *kubermaticv1.Cluster, data *resources.TemplateData) error {
-	namedSecretCreatorGetters := r.GetSecretCreators(data)
+	modifiers := clusterObjectModifiers(c)
 
-	if err := reconciling.ReconcileSecrets(ctx, namedSecretCreatorGetters, c.Status.NamespaceName, r.Client, reconciling.OwnerRefWrapper(resources.GetClusterRef(c))); err != nil {
+	if err := r.reconcileSecretsConcurrently(ctx, r.GetSecretCreators(data), c.Status.NamespaceName, modifiers...); err != nil {
 		return fmt.Errorf("failed to ensure that the Secret exists: %v", err)
 	}
 
 	return nil
 }
 
+// ensureCASecrets reconciles the CA secrets and emits an event whenever the root CA was created or
+// a step of its rotation happened. The steps are detected by comparing the root CA secret before and
+// after the reconciliation, so every event is emitted exactly once.
+func (r *Reconciler) ensureCASecrets(ctx context.Context, c *kubermaticv1.Cluster, data *resources.TemplateData, modifiers ...reconciling.ObjectModifier) error {
+	before, err := r.secretData(ctx, c.Status.NamespaceName, resources.CASecretName)
+	if err != nil {
+		return err
+	}
+
+	if err := reconciling.ReconcileSecrets(ctx, GetCASecretCreators(data), c.Status.NamespaceName, r.Client, modifiers...); err != nil {
+		return fmt.Errorf("failed to ensure that the CA Secret exists: %v", err)
+	}
+
+	after, err := r.secretData(ctx, c.Status.NamespaceName, resources.CASecretName)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case before == nil:
+		validity, err := certificates.RootCAValidity(c.Spec.RootCA)
+		if err != nil {
+			return err
+		}
+		r.recorder.Eventf(c, corev1.EventTypeNormal, EventReasonRootCACreated, "Created root CA (expiry %s)", validity)
+	case !bytes.Equal(before[resources.CACertSecretKey], after[resources.CACertSecretKey]):
+		r.recorder.Event(c, corev1.EventTypeNormal, EventReasonRootCARotated, "Replaced the root CA by its successor, certificates signed by the old CA are being reissued and control plane components restarted. The old CA stays trusted until it expires")
+	case len(before[resources.CANextCertSecretKey]) == 0 && len(after[resources.CANextCertSecretKey]) > 0:
+		r.recorder.Event(c, corev1.EventTypeNormal, EventReasonRootCARotationStarted, "Root CA is about to expire, added its successor to the trusted CAs of the cluster")
+	}
+
+	return nil
+}
+
+// reconcileSecretsConcurrently reconciles the given secrets with at most maxConcurrentSecretReconciles
+// API requests in flight. Generating keys and certificates is slow, so doing it sequentially adds up
+// on seeds with many clusters. The first error cancels all reconciliations which have not started yet.
+func (r *Reconciler) reconcileSecretsConcurrently(ctx context.Context, namedGetters []reconciling.NamedSecretCreatorGetter, namespace string, objectModifiers ...reconciling.ObjectModifier) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+		sem      = make(chan struct{}, maxConcurrentSecretReconciles)
+	)
+
+	for _, getter := range namedGetters {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(getter reconciling.NamedSecretCreatorGetter) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			if err := reconciling.ReconcileSecrets(ctx, []reconciling.NamedSecretCreatorGetter{getter}, namespace, r.Client, objectModifiers...); err != nil {
+				errOnce.Do(func() {
+					firstErr = err
+					cancel()
+				})
+			}
+		}(getter)
+	}
+
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+
+	return ctx.Err()
+}
+
+// secretData returns the data of the given secret, or nil if it does not exist.
+func (r *Reconciler) secretData(ctx context.Context, namespace, name string) (map[string][]byte, error) {
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, secret); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get Secret %s: %v", name, err)
+	}
+	if secret.Data == nil {
+		return map[string][]byte{}, nil
+	}
+	return secret.Data, nil
+}
+
 func (r *Reconciler) ensureServiceAccounts(ctx context.Context, c *kubermaticv1.Cluster) error {
 	namedServiceAccountCreatorGetters := []reconciling.NamedServiceAccountCreatorGetter{
 		etcd.ServiceAccountCreator,
@@ -355,7 +631,7 @@ func (r *Reconciler) ensureServiceAccounts(ctx context.Context, c *kubermaticv1.
 	if c.Spec.OPAIntegration != nil && c.Spec.OPAIntegration.Enabled {
 		namedServiceAccountCreatorGetters = append(namedServiceAccountCreatorGetters, gatekeeper.ServiceAccountCreator)
 	}
-	if err := reconciling.ReconcileServiceAccounts(ctx, namedServiceAccountCreatorGetters, c.Status.NamespaceName, r.Client); err != nil {
+	if err := reconciling.ReconcileServiceAccounts(ctx, namedServiceAccountCreatorGetters, c.Status.NamespaceName, r.Client, clusterObjectModifiers(c)...); err != nil {
 		return fmt.Errorf("failed to ensure ServiceAccounts: %v", err)
 	}
 
@@ -369,7 +645,7 @@ func (r *Reconciler) ensureRoles(ctx context.Context, c *kubermaticv1.Cluster) e
 	if c.Spec.OPAIntegration != nil && c.Spec.OPAIntegration.Enabled {
 		namedRoleCreatorGetters = append(namedRoleCreatorGetters, gatekeeper.RoleCreator)
 	}
-	if err := reconciling.ReconcileRoles(ctx, namedRoleCreatorGetters, c.Status.NamespaceName, r.Client); err != nil {
+	if err := reconciling.ReconcileRoles(ctx, namedRoleCreatorGetters, c.Status.NamespaceName, r.Client, clusterObjectModifiers(c)...); err != nil {
 		return fmt.Errorf("failed to ensure Roles: %v", err)
 	}
 
@@ -383,7 +659,7 @@ func (r *Reconciler) ensureRoleBindings(ctx context.Context, c *kubermaticv1.Clu
 	if c.Spec.OPAIntegration != nil && c.Spec.OPAIntegration.Enabled {
 		namedRoleBindingCreatorGetters = append(namedRoleBindingCreatorGetters, gatekeeper.RoleBindingCreator)
 	}
-	if err := reconciling.ReconcileRoleBindings(ctx, namedRoleBindingCreatorGetters, c.Status.NamespaceName, r.Client); err != nil {
+	if err := reconciling.ReconcileRoleBindings(ctx, namedRoleBindingCreatorGetters, c.Status.NamespaceName, r.Client, clusterObjectModifiers(c)...); err != nil {
 		return fmt.Errorf("failed to ensure RoleBindings: %v", err)
 	}
 	return nil
@@ -391,20 +667,24 @@ func (r *Reconciler) ensureRoleBindings(ctx context.Context, c *kubermaticv1.Clu
 
 // GetConfigMapCreators returns all ConfigMapCreators that are currently in use
 func GetConfigMapCreators(data *resources.TemplateData) []reconciling.NamedConfigMapCreatorGetter {
-	return []reconciling.NamedConfigMapCreatorGetter{
+	creators := []reconciling.NamedConfigMapCreatorGetter{
 		cloudconfig.ConfigMapCreator(data),
 		openvpn.ServerClientConfigsConfigMapCreator(data),
 		dns.ConfigMapCreator(data),
-		apiserver.AuditConfigMapCreator(),
+		apiserver.AuditConfigMapCreator(data),
 		apiserver.AdmissionControlCreator(data),
 		apiserver.CABundleCreator(data),
 	}
+	if data.Cluster().Spec.ComponentsOverride.Scheduler.Configuration != "" {
+		creators = append(creators, scheduler.ConfigMapCreator(data))
+	}
+	return creators
 }
 
 func (r *Reconciler) ensureConfigMaps(ctx context.Context, c *kubermaticv1.Cluster, data *resources.TemplateData) error {
 	creators := GetConfigMapCreators(data)
 
-	if err := reconciling.ReconcileConfigMaps(ctx, creators, c.Status.NamespaceName, r.Client, reconciling.OwnerRefWrapper(resources.GetClusterRef(c))); err != nil {
+	if err := reconciling.ReconcileConfigMaps(ctx, creators, c.Status.NamespaceName, r.Client, clusterObjectModifiers(c)...); err != nil {
 		return fmt.Errorf("failed to ensure that the ConfigMap exists: %v", err)
 	}
 
@@ -443,7 +723,7 @@ func GetPodDisruptionBudgetCreators(data *resources.TemplateData) []reconciling.
 func (r *Reconciler) ensurePodDisruptionBudgets(ctx context.Context, c *kubermaticv1.Cluster, data *resources.TemplateData) error {
 	creators := GetPodDisruptionBudgetCreators(data)
 
-	if err := reconciling.ReconcilePodDisruptionBudgets(ctx, creators, c.Status.NamespaceName, r.Client, reconciling.OwnerRefWrapper(resources.GetClusterRef(c))); err != nil {
+	if err := reconciling.ReconcilePodDisruptionBudgets(ctx, creators, c.Status.NamespaceName, r.Client, clusterObjectModifiers(c)...); err != nil {
 		return fmt.Errorf("failed to ensure that the PodDisruptionBudget exists: %v", err)
 	}
 
@@ -460,7 +740,8 @@ func GetCronJobCreators(data *resources.TemplateData) []reconciling.NamedCronJob
 func (r *Reconciler) ensureCronJobs(ctx context.Context, c *kubermaticv1.Cluster, data *resources.TemplateData) error {
 	creators := GetCronJobCreators(data)
 
-	if err := reconciling.ReconcileCronJobs(ctx, creators, c.Status.NamespaceName, r.Client, reconciling.OwnerRefWrapper(resources.GetClusterRef(c))); err != nil {
+	modifiers := podObjectModifiers(c)
+	if err := reconciling.ReconcileCronJobs(ctx, creators, c.Status.NamespaceName, r.Client, modifiers...); err != nil {
 		return fmt.Errorf("failed to ensure that the CronJobs exists: %v", err)
 	}
 
@@ -484,13 +765,14 @@ func (r *Reconciler) ensureVerticalPodAutoscalers(ctx context.Context, c *kuberm
 		return fmt.Errorf("failed to create the functions to handle VPA resources: %v", err)
 	}
 
-	return reconciling.ReconcileVerticalPodAutoscalers(ctx, creators, c.Status.NamespaceName, r.Client)
+	return reconciling.ReconcileVerticalPodAutoscalers(ctx, creators, c.Status.NamespaceName, r.Client, clusterObjectModifiers(c)...)
 }
 
 func (r *Reconciler) ensureStatefulSets(ctx context.Context, c *kubermaticv1.Cluster, data *resources.TemplateData) error {
 	creators := GetStatefulSetCreators(data, r.features.EtcdDataCorruptionChecks)
 
-	return reconciling.ReconcileStatefulSets(ctx, creators, c.Status.NamespaceName, r.Client, reconciling.OwnerRefWrapper(resources.GetClusterRef(c)))
+	modifiers := podObjectModifiers(c)
+	return reconciling.ReconcileStatefulSets(ctx, creators, c.Status.NamespaceName, r.Client, modifiers...)
 }
 
 func (r *Reconciler) ensureOPAIntegrationIsRemoved(ctx context.Context, data *resources.TemplateData) error {
@@ -506,5 +788,5 @@ func (r *Reconciler) ensureOPAIntegrationIsRemoved(ctx context.Context, data *re
 func (r *Reconciler) ensureEtcdBackupConfigs(ctx context.Context, c *kubermaticv1.Cluster, data *resources.TemplateData) error {
 	creators := GetEtcdBackupConfigCreators(data)
 
-	return reconciling.ReconcileEtcdBackupConfigs(ctx, creators, c.Status.NamespaceName, r.Client, reconciling.OwnerRefWrapper(resources.GetClusterRef(c)))
+	return reconciling.ReconcileEtcdBackupConfigs(ctx, creators, c.Status.NamespaceName, r.Client, clusterObjectModifiers(c)...)
 }
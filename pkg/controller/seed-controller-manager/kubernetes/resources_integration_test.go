@@ -32,6 +32,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	autoscalingv1beta2 "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/apis/autoscaling.k8s.io/v1beta2"
+	"k8s.io/client-go/tools/record"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/envtest"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
@@ -171,6 +172,7 @@ func TestEnsureResourcesAreDeployedIdempotency(t *testing.T) {
 			}, nil
 		},
 		caBundle: caBundle,
+		recorder: &record.FakeRecorder{},
 	}
 
 	if err := r.ensureClusterNetworkDefaults(ctx, testCluster); err != nil {
@@ -18,14 +18,20 @@ package kubernetes
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync"
 	"testing"
+	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/kubernetes/scheme"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
@@ -33,6 +39,7 @@ import (
 	"k8c.io/kubermatic/v2/pkg/resources/apiserver"
 	"k8c.io/kubermatic/v2/pkg/resources/certificates"
 	"k8c.io/kubermatic/v2/pkg/resources/cloudcontroller"
+	"k8c.io/kubermatic/v2/pkg/resources/reconciling"
 )
 
 func init() {
@@ -375,3 +382,283 @@ func (k KCMDeploymentConfig) Create(td *resources.TemplateData) *appsv1.Deployme
 	d.Spec.Template.Spec = *wrappedPodSpec
 	return &d
 }
+
+func TestMissingControlPlaneDeployments(t *testing.T) {
+	deployment := func(name string) *appsv1.Deployment {
+		return &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "cluster-test"}}
+	}
+
+	tests := []struct {
+		name        string
+		initialized bool
+		deployments []*appsv1.Deployment
+		expected    []string
+	}{
+		{
+			name:        "New clusters are not reported",
+			initialized: false,
+			expected:    nil,
+		},
+		{
+			name:        "All deployments present",
+			initialized: true,
+			deployments: []*appsv1.Deployment{
+				deployment(resources.ApiserverDeploymentName),
+				deployment(resources.ControllerManagerDeploymentName),
+				deployment(resources.SchedulerDeploymentName),
+			},
+			expected: nil,
+		},
+		{
+			name:        "Deleted apiserver deployment",
+			initialized: true,
+			deployments: []*appsv1.Deployment{
+				deployment(resources.ControllerManagerDeploymentName),
+				deployment(resources.SchedulerDeploymentName),
+			},
+			expected: []string{resources.ApiserverDeploymentName},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cluster := &kubermaticv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Status:     kubermaticv1.ClusterStatus{NamespaceName: "cluster-test"},
+			}
+			if test.initialized {
+				cluster.Status.Conditions = []kubermaticv1.ClusterCondition{{
+					Type:   kubermaticv1.ClusterConditionClusterInitialized,
+					Status: corev1.ConditionTrue,
+				}}
+			}
+
+			builder := fake.NewClientBuilder()
+			for _, d := range test.deployments {
+				builder = builder.WithObjects(d)
+			}
+			r := &Reconciler{Client: builder.Build()}
+
+			missing, err := r.missingControlPlaneDeployments(context.Background(), cluster)
+			if err != nil {
+				t.Fatalf("failed to get missing deployments: %v", err)
+			}
+			if !sets.NewString(missing...).Equal(sets.NewString(test.expected...)) {
+				t.Errorf("expected %v, got %v", test.expected, missing)
+			}
+		})
+	}
+}
+
+func TestEnsureNamespaceExists(t *testing.T) {
+	cluster := &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-cluster",
+			UID:  types.UID("cluster-uid"),
+		},
+		Status: kubermaticv1.ClusterStatus{
+			NamespaceName: "cluster-test-cluster",
+		},
+	}
+
+	testCases := []struct {
+		name                 string
+		existingNamespace    *corev1.Namespace
+		expectedOwnerRefUIDs []types.UID
+	}{
+		{
+			name:                 "Namespace is created with owner reference",
+			expectedOwnerRefUIDs: []types.UID{cluster.UID},
+		},
+		{
+			name: "Owner reference is added to a leftover namespace",
+			existingNamespace: &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{Name: cluster.Status.NamespaceName},
+			},
+			expectedOwnerRefUIDs: []types.UID{cluster.UID},
+		},
+		{
+			name: "Existing owner references are kept",
+			existingNamespace: &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            cluster.Status.NamespaceName,
+					OwnerReferences: []metav1.OwnerReference{{Name: "other", UID: types.UID("other-uid")}},
+				},
+			},
+			expectedOwnerRefUIDs: []types.UID{"other-uid", cluster.UID},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			builder := fake.NewClientBuilder().WithObjects(cluster.DeepCopy())
+			if tc.existingNamespace != nil {
+				builder = builder.WithObjects(tc.existingNamespace)
+			}
+			r := &Reconciler{Client: builder.Build()}
+
+			if err := r.ensureNamespaceExists(context.Background(), cluster.DeepCopy()); err != nil {
+				t.Fatalf("failed to ensure namespace: %v", err)
+			}
+
+			ns := &corev1.Namespace{}
+			if err := r.Get(context.Background(), types.NamespacedName{Name: cluster.Status.NamespaceName}, ns); err != nil {
+				t.Fatalf("failed to get namespace: %v", err)
+			}
+
+			var uids []types.UID
+			for _, ref := range ns.OwnerReferences {
+				uids = append(uids, ref.UID)
+			}
+			if fmt.Sprint(uids) != fmt.Sprint(tc.expectedOwnerRefUIDs) {
+				t.Errorf("expected owner references %v, got %v", tc.expectedOwnerRefUIDs, uids)
+			}
+		})
+	}
+}
+
+func TestRBACResourcesHaveOwnerReference(t *testing.T) {
+	cluster := &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-cluster",
+			UID:  types.UID("cluster-uid"),
+		},
+		Status: kubermaticv1.ClusterStatus{
+			NamespaceName: "cluster-test-cluster",
+		},
+	}
+	r := &Reconciler{Client: fake.NewClientBuilder().Build()}
+	ctx := context.Background()
+
+	if err := r.ensureServiceAccounts(ctx, cluster); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.ensureRoles(ctx, cluster); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.ensureRoleBindings(ctx, cluster); err != nil {
+		t.Fatal(err)
+	}
+
+	serviceAccounts := &corev1.ServiceAccountList{}
+	roles := &rbacv1.RoleList{}
+	roleBindings := &rbacv1.RoleBindingList{}
+	for _, list := range []ctrlruntimeclient.ObjectList{serviceAccounts, roles, roleBindings} {
+		if err := r.List(ctx, list, ctrlruntimeclient.InNamespace(cluster.Status.NamespaceName)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var objects []metav1.Object
+	for i := range serviceAccounts.Items {
+		objects = append(objects, &serviceAccounts.Items[i])
+	}
+	for i := range roles.Items {
+		objects = append(objects, &roles.Items[i])
+	}
+	for i := range roleBindings.Items {
+		objects = append(objects, &roleBindings.Items[i])
+	}
+	if len(objects) == 0 {
+		t.Fatal("expected RBAC resources to be created")
+	}
+
+	for _, obj := range objects {
+		refs := obj.GetOwnerReferences()
+		if len(refs) != 1 || refs[0].UID != cluster.UID {
+			t.Errorf("expected %s to be owned by the cluster, got owner references %v", obj.GetName(), refs)
+		}
+	}
+}
+
+func TestReconcileSecretsConcurrently(t *testing.T) {
+	const secretCount = 20
+
+	testCases := []struct {
+		name          string
+		failingSecret int
+		expectError   bool
+	}{
+		{
+			name:          "All secrets are reconciled",
+			failingSecret: -1,
+		},
+		{
+			name:          "First error stops the reconciliation",
+			failingSecret: 0,
+			expectError:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				lock        sync.Mutex
+				running     int
+				maxRunning  int
+				invocations int
+			)
+
+			var getters []reconciling.NamedSecretCreatorGetter
+			for i := 0; i < secretCount; i++ {
+				i := i
+				getters = append(getters, func() (string, reconciling.SecretCreator) {
+					return fmt.Sprintf("secret-%d", i), func(se *corev1.Secret) (*corev1.Secret, error) {
+						lock.Lock()
+						running++
+						invocations++
+						if running > maxRunning {
+							maxRunning = running
+						}
+						lock.Unlock()
+
+						defer func() {
+							lock.Lock()
+							running--
+							lock.Unlock()
+						}()
+
+						if i == tc.failingSecret {
+							return nil, errors.New("failed to create key")
+						}
+						// keep the creator busy, so the reconciliations overlap
+						time.Sleep(10 * time.Millisecond)
+						se.Data = map[string][]byte{"key": []byte(fmt.Sprint(i))}
+						return se, nil
+					}
+				})
+			}
+
+			r := &Reconciler{Client: fake.NewClientBuilder().Build()}
+			err := r.reconcileSecretsConcurrently(context.Background(), getters, "cluster-test")
+			if (err != nil) != tc.expectError {
+				t.Fatalf("expected error: %v, got %v", tc.expectError, err)
+			}
+
+			if maxRunning > maxConcurrentSecretReconciles {
+				t.Errorf("expected at most %d concurrent reconciliations, got %d", maxConcurrentSecretReconciles, maxRunning)
+			}
+
+			if tc.expectError {
+				if invocations == secretCount {
+					t.Error("expected the reconciliation to stop after the first error, but all secrets were reconciled")
+				}
+				return
+			}
+
+			secrets := &corev1.SecretList{}
+			if err := r.List(context.Background(), secrets, ctrlruntimeclient.InNamespace("cluster-test")); err != nil {
+				t.Fatalf("failed to list secrets: %v", err)
+			}
+
+			if len(secrets.Items) != secretCount {
+				t.Errorf("expected %d secrets, got %d", secretCount, len(secrets.Items))
+			}
+			for _, secret := range secrets.Items {
+				if len(secret.Data["key"]) == 0 {
+					t.Errorf("expected secret %s to contain the generated data", secret.Name)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var resyncablePhases = sets.NewString(
+	string(kubermaticv1.ClusterPhasePending),
+	string(kubermaticv1.ClusterPhaseLaunching),
+	string(kubermaticv1.ClusterPhaseRunning),
+	string(kubermaticv1.ClusterPhaseFailed),
+)
+
+// ResyncPeriods configures after which time clusters are reconciled again, depending on their phase.
+// Clusters in phases without a period are only reconciled on changes and the resync of the manager.
+type ResyncPeriods map[kubermaticv1.ClusterPhase]time.Duration
+
+// ParseResyncPeriods parses a comma-separated list of phase=duration pairs, e.g. "Pending=10s,Running=10m".
+func ParseResyncPeriods(s string) (ResyncPeriods, error) {
+	periods := ResyncPeriods{}
+	if s == "" {
+		return periods, nil
+	}
+
+	for _, pair := range strings.Split(s, ",") {
+		parts := strings.SplitN(strings.TrimSpace(pair), "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid resync period %q, expected phase=duration", pair)
+		}
+
+		phase := parts[0]
+		if !resyncablePhases.Has(phase) {
+			return nil, fmt.Errorf("unknown cluster phase %q, must be one of %v", phase, resyncablePhases.List())
+		}
+
+		period, err := time.ParseDuration(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid resync period for phase %s: %v", phase, err)
+		}
+		if period <= 0 {
+			return nil, fmt.Errorf("resync period for phase %s must be positive, got %v", phase, period)
+		}
+
+		periods[kubermaticv1.ClusterPhase(phase)] = period
+	}
+
+	return periods, nil
+}
+
+// apply requeues the cluster after the period configured for its phase, unless the
+// reconciliation already requested a requeue.
+func (p ResyncPeriods) apply(result reconcile.Result, phase kubermaticv1.ClusterPhase) reconcile.Result {
+	if result.Requeue || result.RequeueAfter > 0 {
+		return result
+	}
+	if period, ok := p[phase]; ok {
+		result.RequeueAfter = period
+	}
+	return result
+}
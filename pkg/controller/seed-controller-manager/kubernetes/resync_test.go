@@ -0,0 +1,123 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestParseResyncPeriods(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         string
+		expected      ResyncPeriods
+		expectedError bool
+	}{
+		{
+			name:     "empty",
+			expected: ResyncPeriods{},
+		},
+		{
+			name:  "periods per phase",
+			input: "Pending=10s, Launching=15s,Running=10m",
+			expected: ResyncPeriods{
+				kubermaticv1.ClusterPhasePending:   10 * time.Second,
+				kubermaticv1.ClusterPhaseLaunching: 15 * time.Second,
+				kubermaticv1.ClusterPhaseRunning:   10 * time.Minute,
+			},
+		},
+		{
+			name:          "unknown phase",
+			input:         "Deleting=10s",
+			expectedError: true,
+		},
+		{
+			name:          "invalid duration",
+			input:         "Running=often",
+			expectedError: true,
+		},
+		{
+			name:          "non-positive duration",
+			input:         "Running=0s",
+			expectedError: true,
+		},
+		{
+			name:          "missing duration",
+			input:         "Running",
+			expectedError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			periods, err := ParseResyncPeriods(test.input)
+			if (err != nil) != test.expectedError {
+				t.Fatalf("expected error: %v, got %v", test.expectedError, err)
+			}
+			if err != nil {
+				return
+			}
+			if !reflect.DeepEqual(periods, test.expected) {
+				t.Errorf("expected periods %v, got %v", test.expected, periods)
+			}
+		})
+	}
+}
+
+func TestResyncPeriodsApply(t *testing.T) {
+	periods := ResyncPeriods{
+		kubermaticv1.ClusterPhasePending: 10 * time.Second,
+		kubermaticv1.ClusterPhaseRunning: 10 * time.Minute,
+	}
+
+	tests := []struct {
+		name     string
+		result   reconcile.Result
+		phase    kubermaticv1.ClusterPhase
+		expected reconcile.Result
+	}{
+		{
+			name:     "period of the phase is used",
+			phase:    kubermaticv1.ClusterPhaseRunning,
+			expected: reconcile.Result{RequeueAfter: 10 * time.Minute},
+		},
+		{
+			name:     "requested requeue is kept",
+			result:   reconcile.Result{RequeueAfter: 30 * time.Second},
+			phase:    kubermaticv1.ClusterPhasePending,
+			expected: reconcile.Result{RequeueAfter: 30 * time.Second},
+		},
+		{
+			name:  "phase without period is not requeued",
+			phase: kubermaticv1.ClusterPhaseLaunching,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if result := periods.apply(test.result, test.phase); result != test.expected {
+				t.Errorf("expected %+v, got %+v", test.expected, result)
+			}
+		})
+	}
+}
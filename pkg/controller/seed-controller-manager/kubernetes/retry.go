@@ -0,0 +1,59 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+
+	controllerutil "k8c.io/kubermatic/v2/pkg/controller/util"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// transientErrorRetryingClient retries reads and creates which failed with a transient error,
+// so a short period of throttling or a conflict does not fail the whole reconciliation.
+type transientErrorRetryingClient struct {
+	ctrlruntimeclient.Client
+	backoff wait.Backoff
+}
+
+func newTransientErrorRetryingClient(client ctrlruntimeclient.Client) ctrlruntimeclient.Client {
+	return &transientErrorRetryingClient{Client: client, backoff: controllerutil.TransientErrorBackoff}
+}
+
+func (c *transientErrorRetryingClient) Get(ctx context.Context, key ctrlruntimeclient.ObjectKey, obj ctrlruntimeclient.Object) error {
+	return retry.OnError(c.backoff, controllerutil.IsTransientError, func() error {
+		return c.Client.Get(ctx, key, obj)
+	})
+}
+
+func (c *transientErrorRetryingClient) Create(ctx context.Context, obj ctrlruntimeclient.Object, opts ...ctrlruntimeclient.CreateOption) error {
+	attempted := false
+	return retry.OnError(c.backoff, controllerutil.IsTransientError, func() error {
+		err := c.Client.Create(ctx, obj, opts...)
+		// A create which failed with a transient error might still have been
+		// persisted, the object must not be reported as duplicate then.
+		if attempted && kerrors.IsAlreadyExists(err) {
+			return nil
+		}
+		attempted = true
+		return err
+	})
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlruntimefakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// failingClient fails the first creates with the given error before passing them on
+type failingClient struct {
+	ctrlruntimeclient.Client
+	failures int
+	err      error
+	calls    int
+}
+
+func (c *failingClient) Create(ctx context.Context, obj ctrlruntimeclient.Object, opts ...ctrlruntimeclient.CreateOption) error {
+	c.calls++
+	if c.calls <= c.failures {
+		return c.err
+	}
+	return c.Client.Create(ctx, obj, opts...)
+}
+
+func TestTransientErrorRetryingClientCreate(t *testing.T) {
+	resource := schema.GroupResource{Resource: "configmaps"}
+
+	tests := []struct {
+		name          string
+		failures      int
+		err           error
+		expectErr     bool
+		expectedCalls int
+	}{
+		{
+			name:          "Transient errors are retried",
+			failures:      2,
+			err:           kerrors.NewTooManyRequests("slow down", 1),
+			expectedCalls: 3,
+		},
+		{
+			name:          "Permanent errors are not retried",
+			failures:      1,
+			err:           kerrors.NewForbidden(resource, "test", nil),
+			expectErr:     true,
+			expectedCalls: 1,
+		},
+		{
+			name:          "Retries are bounded",
+			failures:      10,
+			err:           kerrors.NewServerTimeout(resource, "create", 1),
+			expectErr:     true,
+			expectedCalls: 3,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			failing := &failingClient{
+				Client:   ctrlruntimefakeclient.NewClientBuilder().Build(),
+				failures: test.failures,
+				err:      test.err,
+			}
+			client := &transientErrorRetryingClient{
+				Client:  failing,
+				backoff: wait.Backoff{Steps: 3, Duration: time.Millisecond},
+			}
+
+			cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"}}
+			err := client.Create(context.Background(), cm)
+			if (err != nil) != test.expectErr {
+				t.Fatalf("expected error: %v, got %v", test.expectErr, err)
+			}
+			if failing.calls != test.expectedCalls {
+				t.Errorf("expected %d calls, got %d", test.expectedCalls, failing.calls)
+			}
+		})
+	}
+}
+
+func TestTransientErrorRetryingClientCreatePersisted(t *testing.T) {
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"}}
+	resource := schema.GroupResource{Resource: "configmaps"}
+
+	// The first create times out after the object was persisted, so the retry sees it already
+	failing := &failingClient{
+		Client:   ctrlruntimefakeclient.NewClientBuilder().WithObjects(cm.DeepCopy()).Build(),
+		failures: 1,
+		err:      kerrors.NewServerTimeout(resource, "create", 1),
+	}
+	client := &transientErrorRetryingClient{
+		Client:  failing,
+		backoff: wait.Backoff{Steps: 3, Duration: time.Millisecond},
+	}
+
+	if err := client.Create(context.Background(), cm); err != nil {
+		t.Fatalf("expected the persisted object not to be reported as duplicate, got %v", err)
+	}
+}
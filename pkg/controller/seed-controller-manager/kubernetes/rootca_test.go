@@ -0,0 +1,154 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"crypto/x509"
+	"strings"
+	"testing"
+	"time"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/resources/certificates"
+	"k8c.io/kubermatic/v2/pkg/resources/certificates/triple"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestRootCARotationKeepsBothCAsTrusted(t *testing.T) {
+	ctx := context.Background()
+	cluster := &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		Spec: kubermaticv1.ClusterSpec{
+			RootCA: &kubermaticv1.RootCASettings{KeyAlgorithm: kubermaticv1.KeyAlgorithmECDSA, RotateBefore: "24h"},
+		},
+		Address: kubermaticv1.ClusterAddress{ExternalName: "test.example.com"},
+		Status:  kubermaticv1.ClusterStatus{NamespaceName: "cluster-test"},
+	}
+
+	client := fake.NewClientBuilder().WithObjects(cluster).Build()
+	recorder := record.NewFakeRecorder(10)
+	r := &Reconciler{Client: client, recorder: recorder}
+	data := resources.NewTemplateDataBuilder().
+		WithContext(ctx).
+		WithClient(client).
+		WithCluster(cluster).
+		Build()
+
+	reconcile := func(expectedEvent string) {
+		t.Helper()
+		if err := r.ensureCASecrets(ctx, cluster, data); err != nil {
+			t.Fatalf("failed to reconcile CA secrets: %v", err)
+		}
+		switch {
+		case expectedEvent == "" && len(recorder.Events) > 0:
+			t.Fatalf("expected no event, got %q", <-recorder.Events)
+		case expectedEvent != "" && len(recorder.Events) != 1:
+			t.Fatalf("expected exactly one %s event, got %d events", expectedEvent, len(recorder.Events))
+		case expectedEvent != "":
+			if event := <-recorder.Events; !strings.Contains(event, expectedEvent) {
+				t.Fatalf("expected a %s event, got %q", expectedEvent, event)
+			}
+		}
+	}
+
+	// a certificate issued by the CA in the secret at the time of the call, as served by the control plane
+	issueCert := func() *x509.Certificate {
+		t.Helper()
+		ca, err := resources.GetClusterRootCA(ctx, cluster.Status.NamespaceName, client)
+		if err != nil {
+			t.Fatalf("failed to get root CA: %v", err)
+		}
+		kp, err := triple.NewClientKeyPair(ca, "test", nil)
+		if err != nil {
+			t.Fatalf("failed to issue certificate: %v", err)
+		}
+		return kp.Cert
+	}
+
+	assertTrusted := func(certs ...*x509.Certificate) {
+		t.Helper()
+		bundle, err := resources.GetClusterRootCABundle(ctx, cluster.Status.NamespaceName, client)
+		if err != nil {
+			t.Fatalf("failed to get CA bundle: %v", err)
+		}
+		roots := x509.NewCertPool()
+		if !roots.AppendCertsFromPEM(bundle) {
+			t.Fatal("CA bundle contains no certificates")
+		}
+		for _, cert := range certs {
+			if _, err := cert.Verify(x509.VerifyOptions{Roots: roots, KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageAny}}); err != nil {
+				t.Errorf("certificate is not trusted by the CA bundle: %v", err)
+			}
+		}
+	}
+
+	// the secret is created on the first reconciliation and left alone afterwards
+	reconcile(EventReasonRootCACreated)
+	reconcile("")
+
+	// let the root CA approach its expiry by swapping it for a CA which is only valid for 20 hours
+	replaceRootCA(ctx, t, client, cluster.Status.NamespaceName, 20*time.Hour)
+	oldCert := issueCert()
+
+	reconcile(EventReasonRootCARotationStarted)
+	reconcile("")
+	assertTrusted(oldCert)
+
+	// the next CA does not replace the current CA before half of the rotation threshold passed
+	replaceRootCA(ctx, t, client, cluster.Status.NamespaceName, 10*time.Hour)
+	oldCert = issueCert()
+
+	reconcile(EventReasonRootCARotated)
+	reconcile("")
+	assertTrusted(oldCert, issueCert())
+}
+
+// replaceRootCA replaces the current root CA in the CA secret by a new CA with the given validity.
+func replaceRootCA(ctx context.Context, t *testing.T, client ctrlruntimeclient.Client, namespace string, validity time.Duration) {
+	t.Helper()
+
+	key, err := certificates.NewPrivateKey(kubermaticv1.KeyAlgorithmECDSA)
+	if err != nil {
+		t.Fatalf("failed to create key: %v", err)
+	}
+	ca, err := triple.NewCAWithKey("root-ca.test.example.com", key, validity)
+	if err != nil {
+		t.Fatalf("failed to create CA: %v", err)
+	}
+	keyPEM, err := triple.EncodeSignerPEM(key)
+	if err != nil {
+		t.Fatalf("failed to encode key: %v", err)
+	}
+
+	secret := &corev1.Secret{}
+	if err := client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: resources.CASecretName}, secret); err != nil {
+		t.Fatalf("failed to get CA secret: %v", err)
+	}
+	secret.Data[resources.CACertSecretKey] = triple.EncodeCertPEM(ca.Cert)
+	secret.Data[resources.CAKeySecretKey] = keyPEM
+	if err := client.Update(ctx, secret); err != nil {
+		t.Fatalf("failed to update CA secret: %v", err)
+	}
+}
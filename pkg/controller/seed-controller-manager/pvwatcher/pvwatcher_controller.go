@@ -24,6 +24,7 @@ import (
 
 	"go.uber.org/zap"
 
+	controllerutil "k8c.io/kubermatic/v2/pkg/controller/util"
 	predicateutils "k8c.io/kubermatic/v2/pkg/controller/util/predicate"
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
 	"k8c.io/kubermatic/v2/pkg/resources"
@@ -97,9 +98,8 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 	log := r.log.With("request", request)
 	log.Debug("Processing")
 
-	cluster := &kubermaticv1.Cluster{}
-	clusterName := strings.ReplaceAll(request.Namespace, "cluster-", "")
-	if err := r.Get(ctx, types.NamespacedName{Name: clusterName}, cluster); err != nil {
+	cluster, err := controllerutil.ClusterForNamespace(ctx, r, request.Namespace)
+	if err != nil {
 		if kerrors.IsNotFound(err) {
 			log.Debug("Skipping because the cluster is already gone")
 			return reconcile.Result{}, nil
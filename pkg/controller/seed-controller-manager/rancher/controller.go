@@ -27,12 +27,12 @@ import (
 	"os"
 	"os/exec"
 	"path"
-	"strings"
 	"time"
 
 	"go.uber.org/zap"
 
 	rancherclient "k8c.io/kubermatic/v2/pkg/controller/seed-controller-manager/rancher/client"
+	controllerutil "k8c.io/kubermatic/v2/pkg/controller/util"
 	predicateutil "k8c.io/kubermatic/v2/pkg/controller/util/predicate"
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
 	kubermaticv1helper "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1/helper"
@@ -128,9 +128,8 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 		result = &reconcile.Result{}
 	}
 	if err != nil {
-		clusterName := strings.ReplaceAll(statefulSet.Namespace, "cluster-", "")
-		log.Errorf("failed to reconcile %s: %v", clusterName, zap.Error(err))
-		return *result, fmt.Errorf("failed to reconcile %s: %v", clusterName, err)
+		log.Errorf("failed to reconcile cluster in namespace %s: %v", statefulSet.Namespace, zap.Error(err))
+		return *result, fmt.Errorf("failed to reconcile cluster in namespace %s: %v", statefulSet.Namespace, err)
 	}
 	return *result, nil
 }
@@ -139,8 +138,8 @@ func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, stat
 	if statefulSet.DeletionTimestamp != nil {
 		return nil, nil
 	}
-	cluster := &kubermaticv1.Cluster{}
-	if err := r.Get(ctx, types.NamespacedName{Name: strings.ReplaceAll(statefulSet.Namespace, "cluster-", "")}, cluster); err != nil {
+	cluster, err := controllerutil.ClusterForNamespace(ctx, r, statefulSet.Namespace)
+	if err != nil {
 		log.Debugw("can't find cluster", zap.Error(err))
 		return nil, nil
 	}
@@ -294,8 +293,8 @@ func (r *Reconciler) getRancherServerURL(ctx context.Context, namespace string)
 		return "", fmt.Errorf("Can't find rancher server service nodeport")
 	}
 
-	cluster := &kubermaticv1.Cluster{}
-	if err := r.Get(ctx, types.NamespacedName{Name: strings.ReplaceAll(service.Namespace, "cluster-", "")}, cluster); err != nil {
+	cluster, err := controllerutil.ClusterForNamespace(ctx, r, service.Namespace)
+	if err != nil {
 		return "", fmt.Errorf("failed to get cluster: %v", err)
 	}
 
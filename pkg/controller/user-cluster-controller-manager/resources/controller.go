@@ -226,6 +226,10 @@ func (r *reconciler) caCert(ctx context.Context) (*triple.KeyPair, error) {
 	return resources.GetClusterRootCA(ctx, r.namespace, r.seedClient)
 }
 
+func (r *reconciler) caBundle(ctx context.Context) ([]byte, error) {
+	return resources.GetClusterRootCABundle(ctx, r.namespace, r.seedClient)
+}
+
 func (r *reconciler) openVPNCA(ctx context.Context) (*resources.ECDSAKeyPair, error) {
 	return resources.GetOpenVPNCA(ctx, r.namespace, r.seedClient)
 }
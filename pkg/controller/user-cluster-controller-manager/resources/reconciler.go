@@ -54,6 +54,10 @@ func (r *reconciler) reconcile(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("failed to get caCert: %v", err)
 	}
+	caBundle, err := r.caBundle(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get caBundle: %v", err)
+	}
 	openVPNCACert, err := r.openVPNCA(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to get openVPN CA cert: %v", err)
@@ -68,6 +72,7 @@ func (r *reconciler) reconcile(ctx context.Context) error {
 	}
 	data := reconcileData{
 		caCert:        caCert,
+		caBundle:      caBundle,
 		openVPNCACert: openVPNCACert,
 		userSSHKeys:   userSSHKeys,
 		cloudConfig:   cloudConfig,
@@ -391,7 +396,7 @@ func (r *reconciler) reconcileServices(ctx context.Context) error {
 
 func (r *reconciler) reconcileConfigMaps(ctx context.Context, data reconcileData) error {
 	creators := []reconciling.NamedConfigMapCreatorGetter{
-		machinecontroller.ClusterInfoConfigMapCreator(r.clusterURL.String(), data.caCert.Cert),
+		machinecontroller.ClusterInfoConfigMapCreator(r.clusterURL.String(), data.caBundle),
 	}
 
 	if err := reconciling.ReconcileConfigMaps(ctx, creators, metav1.NamespacePublic, r.Client); err != nil {
@@ -531,6 +536,7 @@ func (r *reconciler) reconcilePodDisruptionBudgets(ctx context.Context) error {
 
 type reconcileData struct {
 	caCert        *triple.KeyPair
+	caBundle      []byte
 	openVPNCACert *resources.ECDSAKeyPair
 	userSSHKeys   map[string][]byte
 	cloudConfig   []byte
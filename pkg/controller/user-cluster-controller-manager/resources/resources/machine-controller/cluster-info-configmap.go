@@ -17,11 +17,9 @@ limitations under the License.
 package machinecontroller
 
 import (
-	"crypto/x509"
 	"fmt"
 
 	"k8c.io/kubermatic/v2/pkg/resources"
-	"k8c.io/kubermatic/v2/pkg/resources/certificates/triple"
 	"k8c.io/kubermatic/v2/pkg/resources/reconciling"
 
 	corev1 "k8s.io/api/core/v1"
@@ -29,8 +27,9 @@ import (
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 )
 
-// ClusterInfoConfigMapCreator returns the func to create/update the ConfigMap
-func ClusterInfoConfigMapCreator(url string, caCert *x509.Certificate) reconciling.NamedConfigMapCreatorGetter {
+// ClusterInfoConfigMapCreator returns the func to create/update the ConfigMap.
+// The caBundle contains all root CAs of the cluster which are currently trusted.
+func ClusterInfoConfigMapCreator(url string, caBundle []byte) reconciling.NamedConfigMapCreatorGetter {
 	return func() (string, reconciling.ConfigMapCreator) {
 		return resources.ClusterInfoConfigMapName, func(cm *corev1.ConfigMap) (*corev1.ConfigMap, error) {
 			if cm.Data == nil {
@@ -43,7 +42,7 @@ func ClusterInfoConfigMapCreator(url string, caCert *x509.Certificate) reconcili
 			kubeconfig.Clusters = map[string]*clientcmdapi.Cluster{
 				"": {
 					Server:                   url,
-					CertificateAuthorityData: triple.EncodeCertPEM(caCert),
+					CertificateAuthorityData: caBundle,
 				},
 			}
 
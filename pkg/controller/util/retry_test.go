@@ -0,0 +1,74 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"errors"
+	"testing"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestIsTransientError(t *testing.T) {
+	resource := schema.GroupResource{Resource: "addons"}
+
+	tests := []struct {
+		name      string
+		err       error
+		transient bool
+	}{
+		{
+			name:      "conflict",
+			err:       kerrors.NewConflict(resource, "test", errors.New("object was modified")),
+			transient: true,
+		},
+		{
+			name:      "server timeout",
+			err:       kerrors.NewServerTimeout(resource, "create", 1),
+			transient: true,
+		},
+		{
+			name:      "too many requests",
+			err:       kerrors.NewTooManyRequests("slow down", 1),
+			transient: true,
+		},
+		{
+			name:      "internal error",
+			err:       kerrors.NewInternalError(errors.New("etcd leader changed")),
+			transient: true,
+		},
+		{
+			name:      "forbidden",
+			err:       kerrors.NewForbidden(resource, "test", nil),
+			transient: false,
+		},
+		{
+			name:      "not found",
+			err:       kerrors.NewNotFound(resource, "test"),
+			transient: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if transient := IsTransientError(test.err); transient != test.transient {
+				t.Errorf("expected transient: %t, got %t", test.transient, transient)
+			}
+		})
+	}
+}
@@ -23,6 +23,7 @@ import (
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
 
 	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
@@ -123,6 +124,23 @@ func ClusterAvailableForReconciling(ctx context.Context, client ctrlruntimeclien
 	return !limitReached, err
 }
 
+// ClusterForNamespace returns the cluster whose control plane runs in the given namespace. The
+// cluster is looked up by its status, as the namespace name is not derived from the cluster
+// name for all clusters. A NotFound error is returned if no cluster uses the namespace.
+func ClusterForNamespace(ctx context.Context, client ctrlruntimeclient.Client, namespace string) (*kubermaticv1.Cluster, error) {
+	clusters := &kubermaticv1.ClusterList{}
+	if err := client.List(ctx, clusters); err != nil {
+		return nil, fmt.Errorf("failed to list clusters: %v", err)
+	}
+
+	for i, cluster := range clusters.Items {
+		if cluster.Status.NamespaceName == namespace {
+			return &clusters.Items[i], nil
+		}
+	}
+	return nil, kerrors.NewNotFound(kubermaticv1.Resource("cluster"), namespace)
+}
+
 // ConcurrencyLimitReached checks all the clusters inside the seed cluster and checks for the
 // SeedResourcesUpToDate condition. Returns true if the number of clusters without this condition
 // is equal or larger than the given limit.
@@ -22,9 +22,65 @@ import (
 
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
 
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 	ctrlruntimefakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
+func TestClusterForNamespace(t *testing.T) {
+	cluster := func(name, namespace string) *kubermaticv1.Cluster {
+		c := &kubermaticv1.Cluster{}
+		c.Name = name
+		c.Status.NamespaceName = namespace
+		return c
+	}
+
+	testCases := []struct {
+		name            string
+		namespace       string
+		clusters        []ctrlruntimeclient.Object
+		expectedCluster string
+	}{
+		{
+			name:            "cluster with the default namespace prefix",
+			namespace:       "cluster-abc",
+			clusters:        []ctrlruntimeclient.Object{cluster("abc", "cluster-abc"), cluster("def", "kkp-def")},
+			expectedCluster: "abc",
+		},
+		{
+			name:            "cluster with a custom namespace prefix",
+			namespace:       "kkp-def",
+			clusters:        []ctrlruntimeclient.Object{cluster("abc", "cluster-abc"), cluster("def", "kkp-def")},
+			expectedCluster: "def",
+		},
+		{
+			name:      "no cluster uses the namespace",
+			namespace: "cluster-def",
+			clusters:  []ctrlruntimeclient.Object{cluster("abc", "cluster-abc"), cluster("def", "kkp-def")},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := ctrlruntimefakeclient.NewClientBuilder().WithObjects(tc.clusters...).Build()
+
+			c, err := ClusterForNamespace(context.Background(), client, tc.namespace)
+			if tc.expectedCluster == "" {
+				if !kerrors.IsNotFound(err) {
+					t.Fatalf("expected a NotFound error, got cluster %v and error %v", c, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed to get cluster: %v", err)
+			}
+			if c.Name != tc.expectedCluster {
+				t.Errorf("expected cluster %q, got %q", tc.expectedCluster, c.Name)
+			}
+		})
+	}
+}
+
 func TestConcurrencyLimitReached(t *testing.T) {
 	concurrencyLimitReachedTestCases := []struct {
 		name                 string
@@ -60,6 +60,25 @@ type AddonSpec struct {
 	RequiredResourceTypes []schema.GroupVersionKind `json:"requiredResourceTypes,omitempty"`
 	// IsDefault indicates whether the addon is default
 	IsDefault bool `json:"isDefault,omitempty"`
+	// Manifests contains the Kubernetes manifests of a custom addon as multi document YAML. If set, these
+	// manifests are installed instead of the built-in addon with the given name. They are not templated.
+	Manifests string `json:"manifests,omitempty"`
+	// ManifestsURL is the HTTPS URL the manifests of a custom addon are downloaded from, they are
+	// handled like the Manifests. Its host must be allowed by the seed-controller-manager. It is
+	// mutually exclusive with Manifests.
+	ManifestsURL string `json:"manifestsURL,omitempty"`
+	// Version pins the version of the built-in addon. If set, the manifests are loaded from the
+	// directory "<name>@<version>" instead of the directory of the addon itself.
+	Version string `json:"version,omitempty"`
+	// NodeSelector is merged into the node selector of all workloads of the addon, taking
+	// precedence over the node selectors of the manifests.
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// Tolerations are added to the tolerations of all workloads of the addon.
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+	// DependsOn lists the default addons which must have created their resources before this
+	// default addon is installed, e.g. the CNI plugin for addons which need pod networking.
+	// It is only considered when the addon is created.
+	DependsOn []string `json:"dependsOn,omitempty"`
 }
 
 // AddonList is a list of addons
@@ -42,6 +42,15 @@ const (
 	// enabled when this Annotation is set with any value
 	AnnotationNameClusterAutoscalerEnabled = "kubermatic.io/cluster-autoscaler-enabled"
 
+	// ReconcileRequestedAnnotation is the name of the annotation holding the time a reconciliation
+	// of the cluster was last requested at. Changing it makes all controllers reconcile the cluster.
+	ReconcileRequestedAnnotation = "kubermatic.io/reconcile-requested-at"
+
+	// ReapplyControlPlaneAnnotation makes the cluster controller re-apply control plane resources
+	// which drifted from the cluster spec, if drift detection only reports them. It is removed once
+	// the resources were applied.
+	ReapplyControlPlaneAnnotation = "kubermatic.io/reapply-control-plane"
+
 	// CredentialPrefix is the prefix used for the secrets containing cloud provider crednentials.
 	CredentialPrefix = "credential"
 )
@@ -57,8 +66,8 @@ const (
 	UpdatedByVPALabelKey = "updated-by-vpa"
 
 	DefaultEtcdClusterSize = 3
-	MinEtcdClusterSize     = 3
-	MaxEtcdClusterSize     = 9
+	MinEtcdClusterSize     = 1
+	MaxEtcdClusterSize     = 7
 )
 
 // ProtectedClusterLabels is a set of labels that must not be set by users on clusters,
@@ -155,6 +164,73 @@ type ClusterSpec struct {
 
 	// ServiceAccount contains service account related settings for the kube-apiserver of user cluster.
 	ServiceAccount *ServiceAccountSettings `json:"serviceAccount,omitempty"`
+
+	// RootCA contains settings for the root certificate authority of the user cluster.
+	// The key and expiry settings are only applied when the CA is created or rotated.
+	RootCA *RootCASettings `json:"rootCA,omitempty"`
+
+	// DefaultAddons restricts the default addons installed into the cluster to the given names. All names
+	// must refer to default addons configured for the seed and include the CNI addon. If empty, all default
+	// addons are installed. Cannot be combined with DisabledAddons.
+	DefaultAddons []string `json:"defaultAddons,omitempty"`
+
+	// DisabledAddons lists default addons which must not be installed into the cluster. Disabling an
+	// installed addon removes it and its resources from the cluster. The CNI addon cannot be disabled.
+	// Cannot be combined with DefaultAddons.
+	DisabledAddons []string `json:"disabledAddons,omitempty"`
+
+	// RestoreFromSnapshot launches the etcd of a new cluster from the given snapshot instead of an empty
+	// data directory. It can only be set on creation and is removed once the restore has completed.
+	RestoreFromSnapshot *EtcdSnapshotReference `json:"restoreFromSnapshot,omitempty"`
+
+	// ResourceLabels and ResourceAnnotations are added to all resources created for the control plane
+	// of the cluster in the seed, e.g. to tag them for chargeback. Labels and annotations defined by
+	// the control plane components themselves take precedence.
+	ResourceLabels      map[string]string `json:"resourceLabels,omitempty"`
+	ResourceAnnotations map[string]string `json:"resourceAnnotations,omitempty"`
+
+	// EncryptionConfiguration configures the encryption of secrets at rest in the etcd of the cluster.
+	EncryptionConfiguration *EncryptionConfiguration `json:"encryptionConfiguration,omitempty"`
+}
+
+// EncryptionConfiguration configures the encryption of secrets at rest with an AES-CBC key.
+// A new key is rolled out in phases, see ClusterEncryptionPhase, the previous keys are kept
+// to decrypt the secrets written before until all secrets were encrypted with the new key.
+type EncryptionConfiguration struct {
+	// Enabled enables the encryption of secrets. It can not be disabled again, as the apiserver
+	// could not read the encrypted secrets anymore.
+	Enabled bool `json:"enabled,omitempty"`
+	// KeySecretRef references a secret key containing a base64 encoded 32 byte key. If unset,
+	// a key is generated. Referencing another key rotates the key.
+	KeySecretRef *providerconfig.GlobalSecretKeySelector `json:"keySecretRef,omitempty"`
+	// KeyRotation rotates the generated key when it is increased.
+	KeyRotation int `json:"keyRotation,omitempty"`
+}
+
+// ClusterEncryptionPhase is the last completed phase of rolling out an encryption key. Every
+// phase completes once all apiserver replicas run with the configuration written for it, so
+// replicas which did not restart yet can always read the secrets written by the others.
+type ClusterEncryptionPhase string
+
+const (
+	// ClusterEncryptionPhaseKeyAdded means all apiservers can decrypt secrets with the new key,
+	// but still encrypt them with the previous one.
+	ClusterEncryptionPhaseKeyAdded ClusterEncryptionPhase = "KeyAdded"
+	// ClusterEncryptionPhaseKeyPrimary means all apiservers encrypt secrets with the new key.
+	ClusterEncryptionPhaseKeyPrimary ClusterEncryptionPhase = "KeyPrimary"
+	// ClusterEncryptionPhaseSecretsRewritten means all existing secrets were encrypted with the
+	// new key, so the previous keys can be pruned.
+	ClusterEncryptionPhaseSecretsRewritten ClusterEncryptionPhase = "SecretsRewritten"
+)
+
+// ClusterEncryptionStatus describes the rollout of the encryption key of a cluster.
+type ClusterEncryptionStatus struct {
+	// ActiveKey is the name of the key all secrets are encrypted with.
+	ActiveKey string `json:"activeKey,omitempty"`
+	// RolloutKey is the name of the key which is being rolled out.
+	RolloutKey string `json:"rolloutKey,omitempty"`
+	// Phase is the last completed phase of rolling out the RolloutKey.
+	Phase ClusterEncryptionPhase `json:"phase,omitempty"`
 }
 
 const (
@@ -204,6 +280,25 @@ const (
 
 	ClusterConditionEtcdClusterInitialized ClusterConditionType = "EtcdClusterInitialized"
 
+	// The following conditions track the individual steps of setting up the control plane, so it
+	// is visible which step is blocking the launch of a cluster.
+	// The etcd step is tracked by ClusterConditionEtcdClusterInitialized.
+	ClusterConditionServicesReconciled     ClusterConditionType = "ServicesReconciled"
+	ClusterConditionRootCAReconciled       ClusterConditionType = "RootCAReconciled"
+	ClusterConditionSecretsReconciled      ClusterConditionType = "SecretsReconciled"
+	ClusterConditionStatefulSetsReconciled ClusterConditionType = "StatefulSetsReconciled"
+	ClusterConditionConfigMapsReconciled   ClusterConditionType = "ConfigMapsReconciled"
+	ClusterConditionDeploymentsReconciled  ClusterConditionType = "DeploymentsReconciled"
+	ClusterConditionDefaultAddonsReady     ClusterConditionType = "DefaultAddonsReady"
+
+	// ClusterConditionControlPlaneInSync indicates whether the deployments and services of a running
+	// cluster match what its spec produces. It is only set if drift detection is enabled.
+	ClusterConditionControlPlaneInSync ClusterConditionType = "ControlPlaneInSync"
+
+	// ClusterConditionReconcilingEnabled is false while the cluster is paused. The transition back
+	// to true marks the time reconciling was resumed.
+	ClusterConditionReconcilingEnabled ClusterConditionType = "ReconcilingEnabled"
+
 	// ClusterConditionNone is a special value indicating that no cluster condition should be set
 	ClusterConditionNone ClusterConditionType = ""
 	// This condition is met when a CSI migration is ongoing and the CSI
@@ -216,6 +311,15 @@ const (
 	ReasonClusterUpdateInProgress             = "ClusterUpdateInProgress"
 	ReasonClusterCSIKubeletMigrationCompleted = "CSIKubeletMigrationSuccess"
 	ReasonClusterCCMMigrationInProgress       = "CSIKubeletMigrationInProgress"
+	ReasonReconcilingFailed                   = "ReconcilingFailed"
+	ReasonWaitingForAddress                   = "WaitingForAddress"
+	ReasonWaitingForCloudProvider             = "WaitingForCloudProviderInfrastructure"
+	ReasonWaitingForEtcd                      = "WaitingForEtcd"
+	ReasonWaitingForAddons                    = "WaitingForAddons"
+	ReasonAddonsNotReady                      = "AddonsNotReady"
+	ReasonControlPlaneDrifted                 = "ControlPlaneDrifted"
+	ReasonClusterPaused                       = "ClusterPaused"
+	ReasonClusterLaunching                    = "ClusterLaunching"
 )
 
 var AllClusterConditionTypes = []ClusterConditionType{
@@ -258,6 +362,9 @@ type ClusterStatus struct {
 	ExtendedHealth ExtendedClusterHealth `json:"extendedHealth,omitempty"`
 	// KubermaticVersion is the current kubermatic version in a cluster.
 	KubermaticVersion string `json:"kubermatic_version"`
+	// ControlPlaneVersion is the Kubernetes version the control plane was last deployed with.
+	// Version updates are validated against it.
+	ControlPlaneVersion string `json:"controlPlaneVersion,omitempty"`
 	// Deprecated
 	RootCA *KeyCert `json:"rootCA,omitempty"`
 	// Deprecated
@@ -291,8 +398,28 @@ type ClusterStatus struct {
 
 	// InheritedLabels are labels the cluster inherited from the project. They are read-only for users.
 	InheritedLabels map[string]string `json:"inheritedLabels,omitempty"`
+
+	// Phase is the phase of the cluster, as last observed by the cluster controller.
+	Phase ClusterPhase `json:"phase,omitempty"`
+
+	// APIServerNodePort is the NodePort picked by the cluster controller for the apiserver service.
+	// It is persisted before the service is created, so a retried creation requests the same port.
+	APIServerNodePort int32 `json:"apiserverNodePort,omitempty"`
+
+	// Encryption describes the rollout of the key secrets are encrypted with at rest.
+	Encryption *ClusterEncryptionStatus `json:"encryption,omitempty"`
 }
 
+// ClusterPhase is the phase of a cluster in its lifecycle.
+type ClusterPhase string
+
+const (
+	ClusterPhasePending   ClusterPhase = "Pending"
+	ClusterPhaseLaunching ClusterPhase = "Launching"
+	ClusterPhaseRunning   ClusterPhase = "Running"
+	ClusterPhaseFailed    ClusterPhase = "Failed"
+)
+
 // HasConditionValue returns true if the cluster status has the given condition with the given status.
 // It does not verify that the condition has been set by a certain Kubermatic version, it just checks
 // the existence.
@@ -312,6 +439,7 @@ const (
 	InvalidConfigurationClusterError ClusterStatusError = "InvalidConfiguration"
 	UnsupportedChangeClusterError    ClusterStatusError = "UnsupportedChange"
 	ReconcileClusterError            ClusterStatusError = "ReconcileError"
+	LaunchTimeoutClusterError        ClusterStatusError = "LaunchTimeout"
 )
 
 type OIDCSettings struct {
@@ -326,6 +454,13 @@ type OIDCSettings struct {
 
 type AuditLoggingSettings struct {
 	Enabled bool `json:"enabled,omitempty"`
+
+	// Policy is an inline audit policy for the apiserver. If neither a policy nor a
+	// policy ConfigMap is set, the metadata of all requests is logged.
+	Policy string `json:"policy,omitempty"`
+	// PolicyConfigMapName is the name of a ConfigMap in the cluster namespace which contains
+	// the audit policy in its "policy.yaml" key. It can not be set together with the inline policy.
+	PolicyConfigMapName string `json:"policyConfigMapName,omitempty"`
 }
 
 type OPAIntegrationSettings struct {
@@ -346,10 +481,37 @@ type ServiceAccountSettings struct {
 	APIAudiences []string `json:"apiAudiences,omitempty"`
 }
 
+// KeyAlgorithm is the algorithm used to generate the private key of a certificate.
+type KeyAlgorithm string
+
+const (
+	KeyAlgorithmRSA   KeyAlgorithm = "rsa"
+	KeyAlgorithmECDSA KeyAlgorithm = "ecdsa"
+
+	DefaultRSACAKeySize   = 2048
+	DefaultECDSACAKeySize = 256
+)
+
+type RootCASettings struct {
+	// KeyAlgorithm is the algorithm of the CA private key, either "rsa" or "ecdsa".
+	// Defaults to "rsa".
+	KeyAlgorithm KeyAlgorithm `json:"keyAlgorithm,omitempty"`
+	// KeySize is the size of the CA private key in bits. RSA keys support 2048, 3072 and 4096
+	// bits (default 2048), ECDSA keys support the curve sizes 256 and 384 (default 256).
+	KeySize int `json:"keySize,omitempty"`
+	// Expiry is the validity period of the CA certificate as a duration string, e.g. "17520h".
+	// Defaults to 10 years.
+	Expiry string `json:"expiry,omitempty"`
+	// RotateBefore enables the automatic rotation of the CA. Once the remaining validity of the CA
+	// drops below this duration (e.g. "720h"), a new CA is created and all certificates signed by
+	// the old CA are reissued. Must be shorter than the expiry. Rotation is disabled by default.
+	RotateBefore string `json:"rotateBefore,omitempty"`
+}
+
 type ComponentSettings struct {
 	Apiserver         APIServerSettings       `json:"apiserver"`
 	ControllerManager ControllerSettings      `json:"controllerManager"`
-	Scheduler         ControllerSettings      `json:"scheduler"`
+	Scheduler         SchedulerSettings       `json:"scheduler"`
 	Etcd              EtcdStatefulSetSettings `json:"etcd"`
 	Prometheus        StatefulSetSettings     `json:"prometheus"`
 }
@@ -359,6 +521,67 @@ type APIServerSettings struct {
 
 	EndpointReconcilingDisabled *bool  `json:"endpointReconcilingDisabled,omitempty"`
 	NodePortRange               string `json:"nodePortRange,omitempty"`
+
+	// CertificateKeyAlgorithm is the key algorithm of the certificates issued for the apiserver,
+	// i.e. its serving certificate and the client certificates for etcd, the kubelets and the
+	// front proxy. Either "rsa" (default) or "ecdsa", which uses P-256 keys. Existing certificates
+	// are only reissued if an algorithm is set explicitly and does not match.
+	CertificateKeyAlgorithm KeyAlgorithm `json:"certificateKeyAlgorithm,omitempty"`
+
+	// ServiceAccountKeyAlgorithm is the algorithm of the key used to sign service account
+	// tokens, either "rsa" (default) or "ecdsa". Changing it replaces the key, which
+	// invalidates all existing service account tokens.
+	ServiceAccountKeyAlgorithm KeyAlgorithm `json:"serviceAccountKeyAlgorithm,omitempty"`
+	// ServiceAccountKeySize is the size of the service account key in bits. RSA keys support
+	// 2048, 3072 and 4096 bits (default 2048), ECDSA keys support 256 and 384 (default 256).
+	ServiceAccountKeySize int `json:"serviceAccountKeySize,omitempty"`
+
+	// CertSANs are additional subject alternative names for the serving certificate of the
+	// apiserver, e.g. for custom ingress hostnames or virtual IPs in front of it. Entries which
+	// are valid IP addresses are added as IP SANs, all others as DNS names. Changing the list
+	// reissues the serving certificate.
+	CertSANs []string `json:"certSANs,omitempty"`
+
+	// Service customizes the service which exposes the apiserver. It takes precedence over the
+	// settings of the datacenter.
+	Service *APIServerServiceSettings `json:"service,omitempty"`
+
+	// ExtraVolumes are secrets and config maps of the cluster namespace which are mounted
+	// read-only into the apiserver, e.g. the kubeconfig of an authentication webhook. The
+	// apiserver is not rolled out as long as a referenced object does not exist and is
+	// restarted whenever the volumes or the content of the referenced objects change.
+	ExtraVolumes []APIServerExtraVolume `json:"extraVolumes,omitempty"`
+}
+
+// APIServerExtraVolume is a secret or config map which is mounted into the apiserver.
+type APIServerExtraVolume struct {
+	// Name identifies the volume, it must be a DNS label and unique among the extra volumes.
+	Name string `json:"name"`
+	// MountPath is the absolute path the volume is mounted at. It must not overlap with the
+	// paths Kubermatic mounts into the apiserver.
+	MountPath string `json:"mountPath"`
+	// Secret is the name of a secret in the cluster namespace. Exactly one of Secret and
+	// ConfigMap must be set.
+	Secret string `json:"secret,omitempty"`
+	// ConfigMap is the name of a config map in the cluster namespace.
+	ConfigMap string `json:"configMap,omitempty"`
+}
+
+// APIServerServiceSettings customizes the service which exposes the apiserver, e.g. to request an
+// internal load balancer from the cloud provider of the seed.
+type APIServerServiceSettings struct {
+	// Type overrides the type of the service. Only "NodePort" and "LoadBalancer" are supported,
+	// and only for clusters using the NodePort expose strategy.
+	Type corev1.ServiceType `json:"type,omitempty"`
+	// Annotations are added to the service. Annotations which are managed by Kubermatic
+	// cannot be overridden.
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// NodePort requests a fixed NodePort for the apiserver, e.g. for firewall rules which are
+	// pinned to the port. It must be within the NodePort range of the datacenter and must not be
+	// used by another service. It can only be set for clusters using the NodePort or LoadBalancer
+	// expose strategy, and not in the settings of a datacenter. Defaults to a port allocated by
+	// Kubernetes.
+	NodePort int32 `json:"nodePort,omitempty"`
 }
 
 type ControllerSettings struct {
@@ -366,10 +589,23 @@ type ControllerSettings struct {
 	LeaderElectionSettings `json:"leaderElection,omitempty"`
 }
 
+type SchedulerSettings struct {
+	ControllerSettings `json:",inline"`
+
+	// Configuration is a KubeSchedulerConfiguration as YAML, e.g. to configure scheduling
+	// profiles and plugins. The client connection to the cluster and the configured leader
+	// election settings are always set by Kubermatic. Changing it restarts the scheduler.
+	Configuration string `json:"configuration,omitempty"`
+}
+
 type DeploymentSettings struct {
 	Replicas    *int32                       `json:"replicas,omitempty"`
 	Resources   *corev1.ResourceRequirements `json:"resources,omitempty"`
 	Tolerations []corev1.Toleration          `json:"tolerations,omitempty"`
+	// ExtraArgs are additional command line flags of the component, keyed by the flag name
+	// without leading dashes. They override the flags set by Kubermatic, feature gates are
+	// merged. Flags which Kubermatic relies on cannot be set.
+	ExtraArgs map[string]string `json:"extraArgs,omitempty"`
 }
 
 type StatefulSetSettings struct {
@@ -382,6 +618,39 @@ type EtcdStatefulSetSettings struct {
 	DiskSize     *resource.Quantity           `json:"diskSize,omitempty"`
 	Resources    *corev1.ResourceRequirements `json:"resources,omitempty"`
 	Tolerations  []corev1.Toleration          `json:"tolerations,omitempty"`
+	// ImageTag overrides the tag of the etcd image, which is otherwise derived from the
+	// cluster version. It allows to roll out a patched etcd without a version upgrade.
+	ImageTag string `json:"imageTag,omitempty"`
+	// ServiceAccountToken configures a projected, time-bound token for the etcd-launcher instead
+	// of the legacy service account token. Only used with the etcd-launcher, requires a seed
+	// cluster running Kubernetes 1.20 or later, which publishes the kube-root-ca.crt ConfigMap.
+	ServiceAccountToken *ProjectedServiceAccountTokenSettings `json:"serviceAccountToken,omitempty"`
+}
+
+const (
+	DefaultProjectedServiceAccountTokenExpirationSeconds = 3600
+	// MinProjectedServiceAccountTokenExpirationSeconds is the minimum validity of projected
+	// tokens accepted by the kubelet.
+	MinProjectedServiceAccountTokenExpirationSeconds = 600
+)
+
+// ProjectedServiceAccountTokenSettings configures a projected service account token.
+type ProjectedServiceAccountTokenSettings struct {
+	// Audience is the intended audience of the token, defaults to the audience of the
+	// seed cluster apiserver.
+	Audience string `json:"audience,omitempty"`
+	// ExpirationSeconds is the requested validity of the token, the kubelet rotates it
+	// before it expires. Must be at least 600, defaults to 3600.
+	ExpirationSeconds *int64 `json:"expirationSeconds,omitempty"`
+}
+
+// EtcdSnapshotReference references an etcd backup a cluster can be restored from.
+type EtcdSnapshotReference struct {
+	// BackupName is the name of the backup to restore from
+	BackupName string `json:"backupName"`
+	// BackupDownloadCredentialsSecret is the name of a secret in the cluster-xxx namespace containing
+	// credentials needed to download the backup
+	BackupDownloadCredentialsSecret string `json:"backupDownloadCredentialsSecret,omitempty"`
 }
 
 type LeaderElectionSettings struct {
@@ -415,8 +684,38 @@ type ClusterNetworkingConfig struct {
 	// ProxyMode defines the kube-proxy mode (ipvs/iptables).
 	// Defaults to ipvs.
 	ProxyMode string `json:"proxyMode"`
+
+	// CNIPlugin selects the CNI plugin installed into the cluster (canal/cilium).
+	// Defaults to canal. It cannot be changed after the cluster has been created.
+	CNIPlugin CNIPluginType `json:"cniPlugin,omitempty"`
+
+	// IPFamilies are the IP families of the cluster network, in the order of the pod and
+	// service CIDR blocks. IPv4 followed by IPv6 provisions a dual-stack cluster, which
+	// requires the cilium CNI plugin. Defaults to IPv4 only.
+	IPFamilies []IPFamily `json:"ipFamilies,omitempty"`
+}
+
+// IsDualStack returns true if the cluster network has both IPv4 and IPv6 addresses.
+func (c ClusterNetworkingConfig) IsDualStack() bool {
+	return len(c.IPFamilies) > 1
 }
 
+// IPFamily is an IP address family of the cluster network.
+type IPFamily string
+
+const (
+	IPFamilyIPv4 IPFamily = "IPv4"
+	IPFamilyIPv6 IPFamily = "IPv6"
+)
+
+// CNIPluginType is the CNI plugin installed into a cluster.
+type CNIPluginType string
+
+const (
+	CNIPluginTypeCanal  CNIPluginType = "canal"
+	CNIPluginTypeCilium CNIPluginType = "cilium"
+)
+
 // MachineNetworkingConfig specifies the networking parameters used for IPAM.
 type MachineNetworkingConfig struct {
 	CIDR       string   `json:"cidr"`
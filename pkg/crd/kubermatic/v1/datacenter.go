@@ -167,6 +167,20 @@ type DatacenterSpec struct {
 	// EnforcePodSecurityPolicy enforces pod security policy plugin on every clusters within the DC,
 	// ignoring cluster-specific settings
 	EnforcePodSecurityPolicy bool `json:"enforcePodSecurityPolicy,omitempty"`
+
+	// Optional: NodePortRange is the range of the seed the NodePorts of the apiserver services of
+	// clusters within the DC are allocated from, e.g. "31000-31999". It must be within the NodePort
+	// range of the seed cluster. Defaults to the NodePort range of the seed.
+	NodePortRange string `json:"nodePortRange,omitempty"`
+
+	// Optional: ReservedCIDRBlocks are network ranges the pod and service networks of
+	// clusters within the DC must not overlap with, e.g. on-premise networks the
+	// clusters route to.
+	ReservedCIDRBlocks []string `json:"reservedCIDRBlocks,omitempty"`
+
+	// Optional: APIServerService customizes the service which exposes the apiserver of
+	// clusters within the DC. Settings of the cluster take precedence.
+	APIServerService *APIServerServiceSettings `json:"apiserverService,omitempty"`
 }
 
 // ImageList defines a map of operating system and the image to use
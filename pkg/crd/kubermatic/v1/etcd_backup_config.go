@@ -17,6 +17,8 @@ limitations under the License.
 package v1
 
 import (
+	"time"
+
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -68,6 +70,9 @@ type EtcdBackupConfigSpec struct {
 	// Keep is the number of backups to keep around before deleting the oldest one
 	// If not set, defaults to DefaultKeptBackupsCount. Only used if Schedule is set.
 	Keep *int `json:"keep,omitempty"`
+	// MaxAge is the age after which completed backups are deleted, the most recent backup is always kept.
+	// If not set, backups are only deleted according to Keep. Only used if Schedule is set.
+	MaxAge *metav1.Duration `json:"maxAge,omitempty"`
 }
 
 // EtcdBackupConfigList is a list of etcd backup configs
@@ -148,3 +153,12 @@ func (bc *EtcdBackupConfig) GetKeptBackupsCount() int {
 	}
 	return *bc.Spec.Keep
 }
+
+// GetBackupMaxAge returns the age after which backups are deleted, or 0 if backups are not
+// deleted based on their age.
+func (bc *EtcdBackupConfig) GetBackupMaxAge() time.Duration {
+	if bc.Spec.MaxAge == nil || bc.Spec.MaxAge.Duration <= 0 {
+		return 0
+	}
+	return bc.Spec.MaxAge.Duration
+}
@@ -70,6 +70,22 @@ func ClusterReconcileWrapper(
 	return result, utilerrors.NewAggregate(errs)
 }
 
+// ClusterPhase derives the phase of a cluster from its status. A cluster is pending until its
+// apiserver is up, launching until all control plane components are healthy and running then.
+// Clusters with an error are failed.
+func ClusterPhase(c *kubermaticv1.Cluster) kubermaticv1.ClusterPhase {
+	switch {
+	case c.Status.ErrorReason != nil:
+		return kubermaticv1.ClusterPhaseFailed
+	case c.Status.ExtendedHealth.AllHealthy():
+		return kubermaticv1.ClusterPhaseRunning
+	case c.Status.ExtendedHealth.Apiserver == kubermaticv1.HealthStatusUp:
+		return kubermaticv1.ClusterPhaseLaunching
+	default:
+		return kubermaticv1.ClusterPhasePending
+	}
+}
+
 // GetClusterCondition returns the index of the given condition or -1 and the condition itself
 // or a nilpointer.
 func GetClusterCondition(c *kubermaticv1.Cluster, conditionType kubermaticv1.ClusterConditionType) (int, *kubermaticv1.ClusterCondition) {
@@ -198,6 +214,16 @@ func IsClusterInitialized(cluster *kubermaticv1.Cluster, versions kubermatic.Ver
 	return success && upToDate && cluster.Status.ExtendedHealth.AllHealthy()
 }
 
+// AddonResourcesCreated returns true if the addon created its resources in the user cluster.
+func AddonResourcesCreated(addon *kubermaticv1.Addon) bool {
+	for _, condition := range addon.Status.Conditions {
+		if condition.Type == kubermaticv1.AddonResourcesCreated {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
 // We assume that the cluster is still provisioning if it was not initialized fully at least once.
 func GetHealthStatus(status kubermaticv1.HealthStatus, cluster *kubermaticv1.Cluster, versions kubermatic.Versions) kubermaticv1.HealthStatus {
 	if status == kubermaticv1.HealthStatusDown && !IsClusterInitialized(cluster, versions) {
@@ -207,3 +207,53 @@ func getCluster(condition *kubermaticv1.ClusterCondition) *kubermaticv1.Cluster
 	}
 	return c
 }
+
+func TestClusterPhase(t *testing.T) {
+	healthy := kubermaticv1.ExtendedClusterHealth{
+		Apiserver:                    kubermaticv1.HealthStatusUp,
+		Scheduler:                    kubermaticv1.HealthStatusUp,
+		Controller:                   kubermaticv1.HealthStatusUp,
+		MachineController:            kubermaticv1.HealthStatusUp,
+		Etcd:                         kubermaticv1.HealthStatusUp,
+		CloudProviderInfrastructure:  kubermaticv1.HealthStatusUp,
+		UserClusterControllerManager: kubermaticv1.HealthStatusUp,
+	}
+	errorReason := kubermaticv1.ReconcileClusterError
+
+	testCases := []struct {
+		name          string
+		status        kubermaticv1.ClusterStatus
+		expectedPhase kubermaticv1.ClusterPhase
+	}{
+		{
+			name:          "new cluster",
+			status:        kubermaticv1.ClusterStatus{},
+			expectedPhase: kubermaticv1.ClusterPhasePending,
+		},
+		{
+			name: "apiserver is up",
+			status: kubermaticv1.ClusterStatus{
+				ExtendedHealth: kubermaticv1.ExtendedClusterHealth{Apiserver: kubermaticv1.HealthStatusUp},
+			},
+			expectedPhase: kubermaticv1.ClusterPhaseLaunching,
+		},
+		{
+			name:          "all components are healthy",
+			status:        kubermaticv1.ClusterStatus{ExtendedHealth: healthy},
+			expectedPhase: kubermaticv1.ClusterPhaseRunning,
+		},
+		{
+			name:          "cluster with an error",
+			status:        kubermaticv1.ClusterStatus{ExtendedHealth: healthy, ErrorReason: &errorReason},
+			expectedPhase: kubermaticv1.ClusterPhaseFailed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if phase := ClusterPhase(&kubermaticv1.Cluster{Status: tc.status}); phase != tc.expectedPhase {
+				t.Errorf("expected phase %q, got %q", tc.expectedPhase, phase)
+			}
+		})
+	}
+}
@@ -8,10 +8,50 @@ import (
 	types "github.com/kubermatic/machine-controller/pkg/providerconfig/types"
 	v1beta1 "github.com/open-policy-agent/frameworks/constraint/pkg/apis/templates/v1beta1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	schema "k8s.io/apimachinery/pkg/runtime/schema"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIServerExtraVolume) DeepCopyInto(out *APIServerExtraVolume) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIServerExtraVolume.
+func (in *APIServerExtraVolume) DeepCopy() *APIServerExtraVolume {
+	if in == nil {
+		return nil
+	}
+	out := new(APIServerExtraVolume)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIServerServiceSettings) DeepCopyInto(out *APIServerServiceSettings) {
+	*out = *in
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIServerServiceSettings.
+func (in *APIServerServiceSettings) DeepCopy() *APIServerServiceSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(APIServerServiceSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *APIServerSettings) DeepCopyInto(out *APIServerSettings) {
 	*out = *in
@@ -21,6 +61,21 @@ func (in *APIServerSettings) DeepCopyInto(out *APIServerSettings) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.CertSANs != nil {
+		in, out := &in.CertSANs, &out.CertSANs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Service != nil {
+		in, out := &in.Service, &out.Service
+		*out = new(APIServerServiceSettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExtraVolumes != nil {
+		in, out := &in.ExtraVolumes, &out.ExtraVolumes
+		*out = make([]APIServerExtraVolume, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -258,6 +313,25 @@ func (in *AddonSpec) DeepCopyInto(out *AddonSpec) {
 		*out = make([]schema.GroupVersionKind, len(*in))
 		copy(*out, *in)
 	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -737,6 +811,22 @@ func (in *ClusterCondition) DeepCopy() *ClusterCondition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterEncryptionStatus) DeepCopyInto(out *ClusterEncryptionStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterEncryptionStatus.
+func (in *ClusterEncryptionStatus) DeepCopy() *ClusterEncryptionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterEncryptionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterList) DeepCopyInto(out *ClusterList) {
 	*out = *in
@@ -775,6 +865,11 @@ func (in *ClusterNetworkingConfig) DeepCopyInto(out *ClusterNetworkingConfig) {
 	*out = *in
 	in.Services.DeepCopyInto(&out.Services)
 	in.Pods.DeepCopyInto(&out.Pods)
+	if in.IPFamilies != nil {
+		in, out := &in.IPFamilies, &out.IPFamilies
+		*out = make([]IPFamily, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -842,6 +937,45 @@ func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 		*out = new(ServiceAccountSettings)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.RootCA != nil {
+		in, out := &in.RootCA, &out.RootCA
+		*out = new(RootCASettings)
+		**out = **in
+	}
+	if in.DefaultAddons != nil {
+		in, out := &in.DefaultAddons, &out.DefaultAddons
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DisabledAddons != nil {
+		in, out := &in.DisabledAddons, &out.DisabledAddons
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RestoreFromSnapshot != nil {
+		in, out := &in.RestoreFromSnapshot, &out.RestoreFromSnapshot
+		*out = new(EtcdSnapshotReference)
+		**out = **in
+	}
+	if in.ResourceLabels != nil {
+		in, out := &in.ResourceLabels, &out.ResourceLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ResourceAnnotations != nil {
+		in, out := &in.ResourceAnnotations, &out.ResourceAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.EncryptionConfiguration != nil {
+		in, out := &in.EncryptionConfiguration, &out.EncryptionConfiguration
+		*out = new(EncryptionConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -909,6 +1043,11 @@ func (in *ClusterStatus) DeepCopyInto(out *ClusterStatus) {
 			(*out)[key] = val
 		}
 	}
+	if in.Encryption != nil {
+		in, out := &in.Encryption, &out.Encryption
+		*out = new(ClusterEncryptionStatus)
+		**out = **in
+	}
 	return
 }
 
@@ -1277,6 +1416,16 @@ func (in *DatacenterSpec) DeepCopyInto(out *DatacenterSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ReservedCIDRBlocks != nil {
+		in, out := &in.ReservedCIDRBlocks, &out.ReservedCIDRBlocks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.APIServerService != nil {
+		in, out := &in.APIServerService, &out.APIServerService
+		*out = new(APIServerServiceSettings)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1585,6 +1734,13 @@ func (in *DeploymentSettings) DeepCopyInto(out *DeploymentSettings) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ExtraArgs != nil {
+		in, out := &in.ExtraArgs, &out.ExtraArgs
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	return
 }
 
@@ -1636,6 +1792,27 @@ func (in *DigitaloceanCloudSpec) DeepCopy() *DigitaloceanCloudSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EncryptionConfiguration) DeepCopyInto(out *EncryptionConfiguration) {
+	*out = *in
+	if in.KeySecretRef != nil {
+		in, out := &in.KeySecretRef, &out.KeySecretRef
+		*out = new(types.GlobalSecretKeySelector)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EncryptionConfiguration.
+func (in *EncryptionConfiguration) DeepCopy() *EncryptionConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(EncryptionConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EtcdBackupConfig) DeepCopyInto(out *EtcdBackupConfig) {
 	*out = *in
@@ -1724,6 +1901,11 @@ func (in *EtcdBackupConfigSpec) DeepCopyInto(out *EtcdBackupConfigSpec) {
 		*out = new(int)
 		**out = **in
 	}
+	if in.MaxAge != nil {
+		in, out := &in.MaxAge, &out.MaxAge
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 	return
 }
 
@@ -1865,6 +2047,22 @@ func (in *EtcdRestoreStatus) DeepCopy() *EtcdRestoreStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdSnapshotReference) DeepCopyInto(out *EtcdSnapshotReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdSnapshotReference.
+func (in *EtcdSnapshotReference) DeepCopy() *EtcdSnapshotReference {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdSnapshotReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EtcdStatefulSetSettings) DeepCopyInto(out *EtcdStatefulSetSettings) {
 	*out = *in
@@ -1885,6 +2083,11 @@ func (in *EtcdStatefulSetSettings) DeepCopyInto(out *EtcdStatefulSetSettings) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ServiceAccountToken != nil {
+		in, out := &in.ServiceAccountToken, &out.ServiceAccountToken
+		*out = new(ProjectedServiceAccountTokenSettings)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -2913,6 +3116,27 @@ func (in *ProjectStatus) DeepCopy() *ProjectStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectedServiceAccountTokenSettings) DeepCopyInto(out *ProjectedServiceAccountTokenSettings) {
+	*out = *in
+	if in.ExpirationSeconds != nil {
+		in, out := &in.ExpirationSeconds, &out.ExpirationSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectedServiceAccountTokenSettings.
+func (in *ProjectedServiceAccountTokenSettings) DeepCopy() *ProjectedServiceAccountTokenSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectedServiceAccountTokenSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProxySettings) DeepCopyInto(out *ProxySettings) {
 	*out = *in
@@ -2965,6 +3189,22 @@ func (in *RSAKeys) DeepCopy() *RSAKeys {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RootCASettings) DeepCopyInto(out *RootCASettings) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RootCASettings.
+func (in *RootCASettings) DeepCopy() *RootCASettings {
+	if in == nil {
+		return nil
+	}
+	out := new(RootCASettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SSHKeySpec) DeepCopyInto(out *SSHKeySpec) {
 	*out = *in
@@ -2986,6 +3226,23 @@ func (in *SSHKeySpec) DeepCopy() *SSHKeySpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchedulerSettings) DeepCopyInto(out *SchedulerSettings) {
+	*out = *in
+	in.ControllerSettings.DeepCopyInto(&out.ControllerSettings)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchedulerSettings.
+func (in *SchedulerSettings) DeepCopy() *SchedulerSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(SchedulerSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Seed) DeepCopyInto(out *Seed) {
 	*out = *in
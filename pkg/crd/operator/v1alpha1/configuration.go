@@ -286,6 +286,9 @@ type KubermaticVersioningConfiguration struct {
 	Versions []*semver.Version `json:"versions,omitempty"`
 	// Default is the default version to offer users.
 	Default *semver.Version `json:"default,omitempty"`
+	// Deprecated lists versions which are still available, but users are warned about when
+	// choosing them. All deprecated versions must be configured in the version list.
+	Deprecated []*semver.Version `json:"deprecated,omitempty"`
 
 	// Updates is a list of available and automatic upgrades.
 	// All 'to' versions must be configured in the version list for this orchestrator.
@@ -297,6 +300,21 @@ type KubermaticVersioningConfiguration struct {
 	// updates as well. 'automaticNodeUpdate: true' implies 'automatic: true' as well,
 	// because Nodes may not have a newer version than the controlplane.
 	Updates []Update `json:"updates,omitempty"`
+	// AddonVersions pins the versions of default addons for a subset of the versions, which
+	// allows to roll out changes to an addon to some versions first.
+	AddonVersions []AddonVersion `json:"addonVersions,omitempty"`
+}
+
+// AddonVersion pins the version of a default addon for all matching versions.
+type AddonVersion struct {
+	// Name is the name of the addon, e.g. "canal".
+	Name string `json:"name"`
+	// Version is the version of the addon. The manifests of the addon are loaded
+	// from the "<name>@<version>" directory in the addons image.
+	Version string `json:"version"`
+	// MasterVersions selects the versions the addon version is pinned for. Wildcards
+	// are allowed, e.g. "1.20.*".
+	MasterVersions string `json:"masterVersions"`
 }
 
 // Update represents an update option for a user cluster.
@@ -10,6 +10,22 @@ import (
 	sets "k8s.io/apimachinery/pkg/util/sets"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonVersion) DeepCopyInto(out *AddonVersion) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonVersion.
+func (in *AddonVersion) DeepCopy() *AddonVersion {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonVersion)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KubermaticAPIConfiguration) DeepCopyInto(out *KubermaticAPIConfiguration) {
 	*out = *in
@@ -427,6 +443,17 @@ func (in *KubermaticVersioningConfiguration) DeepCopyInto(out *KubermaticVersion
 		*out = new(v3.Version)
 		**out = **in
 	}
+	if in.Deprecated != nil {
+		in, out := &in.Deprecated, &out.Deprecated
+		*out = make([]*v3.Version, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(v3.Version)
+				**out = **in
+			}
+		}
+	}
 	if in.Updates != nil {
 		in, out := &in.Updates, &out.Updates
 		*out = make([]Update, len(*in))
@@ -434,6 +461,11 @@ func (in *KubermaticVersioningConfiguration) DeepCopyInto(out *KubermaticVersion
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.AddonVersions != nil {
+		in, out := &in.AddonVersions, &out.AddonVersions
+		*out = make([]AddonVersion, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
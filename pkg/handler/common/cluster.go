@@ -21,9 +21,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"sort"
+	"strings"
 	"time"
 
 	jsonpatch "github.com/evanphx/json-patch"
+	providerconfig "github.com/kubermatic/machine-controller/pkg/providerconfig/types"
 	"go.uber.org/zap"
 
 	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
@@ -35,16 +38,22 @@ import (
 	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
 	"k8c.io/kubermatic/v2/pkg/provider"
 	kubernetesprovider "k8c.io/kubermatic/v2/pkg/provider/kubernetes"
+	"k8c.io/kubermatic/v2/pkg/resources"
 	"k8c.io/kubermatic/v2/pkg/resources/cloudcontroller"
 	"k8c.io/kubermatic/v2/pkg/resources/cluster"
 	"k8c.io/kubermatic/v2/pkg/util/errors"
 	"k8c.io/kubermatic/v2/pkg/validation"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/util/rand"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/metrics/pkg/apis/metrics/v1beta1"
 
@@ -66,10 +75,14 @@ type patchCluster struct {
 	Spec          patchClusterSpec `json:"spec"`
 }
 
+// NodeDeploymentValidator checks the initial node deployment of a new cluster against its cloud provider,
+// so that invalid node deployments are rejected before the cluster is created.
+type NodeDeploymentValidator func(ctx context.Context, cloud kubermaticv1.CloudSpec, secretKeyGetter provider.SecretKeySelectorValueFunc, nd *apiv1.NodeDeployment) error
+
 func CreateEndpoint(ctx context.Context, projectID string, body apiv1.CreateClusterSpec,
 	projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider,
 	seedsGetter provider.SeedsGetter, credentialManager provider.PresetProvider, exposeStrategy kubermaticv1.ExposeStrategy,
-	userInfoGetter provider.UserInfoGetter) (interface{}, error) {
+	userInfoGetter provider.UserInfoGetter, validateNodeDeployment NodeDeploymentValidator) (interface{}, error) {
 
 	clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
 	privilegedClusterProvider := ctx.Value(middleware.PrivilegedClusterProviderContextKey).(provider.PrivilegedClusterProvider)
@@ -118,8 +131,8 @@ func CreateEndpoint(ctx context.Context, projectID string, body apiv1.CreateClus
 		return nil, errors.NewAlreadyExists("cluster", spec.HumanReadableName)
 	}
 
-	if err = validation.ValidateUpdateWindow(spec.UpdateWindow); err != nil {
-		return nil, common.KubernetesErrorToHTTPError(err)
+	if err := ValidateNewCluster(spec, body.Cluster.Labels); err != nil {
+		return nil, err
 	}
 
 	// Start filling cluster object.
@@ -141,6 +154,11 @@ func CreateEndpoint(ctx context.Context, projectID string, body apiv1.CreateClus
 			return nil, errors.NewBadRequest("cannot verify the provider due to an invalid spec: %v", err)
 		}
 		if !isBYO {
+			if validateNodeDeployment != nil {
+				if err := validateNodeDeployment(ctx, spec.Cloud, secretKeyGetter, body.NodeDeployment); err != nil {
+					return nil, err
+				}
+			}
 			if body.NodeDeployment.Name == "" {
 				body.NodeDeployment.Name = fmt.Sprintf("%s-worker-%s", body.Cluster.Name, rand.String(6))
 			}
@@ -333,6 +351,10 @@ func PatchEndpoint(ctx context.Context, userInfoGetter provider.UserInfoGetter,
 		return nil, errors.NewBadRequest("cannot decode patched cluster: %v", err)
 	}
 
+	if !equality.Semantic.DeepEqual(externalCluster.Spec.ClusterNetwork, patchedCluster.Spec.ClusterNetwork) {
+		return nil, errors.NewBadRequest("the cluster network cannot be changed after cluster creation")
+	}
+
 	// Only specific fields from old internal cluster will be updated by a patch.
 	// It prevents user from changing other fields like resource ID or version that should not be modified.
 	newInternalCluster := oldInternalCluster.DeepCopy()
@@ -438,6 +460,88 @@ func GetClusterEventsEndpoint(ctx context.Context, userInfoGetter provider.UserI
 	return events, nil
 }
 
+// clusterTimelineEventLimit bounds the number of events listed per query of the cluster timeline.
+const clusterTimelineEventLimit = 500
+
+// GetClusterTimelineEndpoint returns the provisioning history of a cluster, which are the events of the
+// cluster itself and of all objects in its control plane namespace, oldest first.
+func GetClusterTimelineEndpoint(ctx context.Context, userInfoGetter provider.UserInfoGetter, projectID, clusterID string, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider) (interface{}, error) {
+	clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
+	privilegedClusterProvider := ctx.Value(middleware.PrivilegedClusterProviderContextKey).(provider.PrivilegedClusterProvider)
+	client := privilegedClusterProvider.GetSeedClusterAdminRuntimeClient()
+
+	project, err := common.GetProject(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, projectID, nil)
+	if err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+
+	cluster, err := GetInternalCluster(ctx, userInfoGetter, clusterProvider, privilegedClusterProvider, project, projectID, clusterID, &provider.ClusterGetOptions{})
+	if err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+
+	// events of cluster-scoped objects are recorded in the default namespace
+	clusterEvents := &corev1.EventList{}
+	if err := client.List(ctx, clusterEvents, &ctrlruntimeclient.ListOptions{
+		Namespace: metav1.NamespaceDefault,
+		FieldSelector: fields.AndSelectors(
+			fields.OneTermEqualSelector("involvedObject.kind", kubermaticv1.ClusterKindName),
+			fields.OneTermEqualSelector("involvedObject.name", cluster.Name),
+		),
+		Limit: clusterTimelineEventLimit,
+	}); err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+	events := clusterEvents.Items
+
+	if cluster.Status.NamespaceName != "" {
+		namespaceEvents := &corev1.EventList{}
+		if err := client.List(ctx, namespaceEvents, ctrlruntimeclient.InNamespace(cluster.Status.NamespaceName), ctrlruntimeclient.Limit(clusterTimelineEventLimit)); err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+		events = append(events, namespaceEvents.Items...)
+	}
+
+	return clusterTimeline(events), nil
+}
+
+// clusterTimeline converts the events to API events in chronological order, events which were
+// listed more than once are only returned once.
+func clusterTimeline(events []corev1.Event) []apiv1.Event {
+	seen := sets.NewString()
+	unique := make([]corev1.Event, 0, len(events))
+	for _, event := range events {
+		key := event.Namespace + "/" + event.Name
+		if seen.Has(key) {
+			continue
+		}
+		seen.Insert(key)
+		unique = append(unique, event)
+	}
+
+	sort.SliceStable(unique, func(i, j int) bool {
+		return eventTime(unique[i]).Before(eventTime(unique[j]))
+	})
+
+	timeline := make([]apiv1.Event, 0, len(unique))
+	for _, event := range unique {
+		timeline = append(timeline, common.ConvertInternalEventToExternal(event))
+	}
+	return timeline
+}
+
+// eventTime returns the time the event was last observed. Events emitted with the events.k8s.io
+// API only set the event time, so it is used in case the last timestamp is missing.
+func eventTime(event corev1.Event) time.Time {
+	if !event.LastTimestamp.IsZero() {
+		return event.LastTimestamp.Time
+	}
+	if !event.EventTime.IsZero() {
+		return event.EventTime.Time
+	}
+	return event.CreationTimestamp.Time
+}
+
 func HealthEndpoint(ctx context.Context, userInfoGetter provider.UserInfoGetter, projectID, clusterID string, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider) (interface{}, error) {
 	clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
 	privilegedClusterProvider := ctx.Value(middleware.PrivilegedClusterProviderContextKey).(provider.PrivilegedClusterProvider)
@@ -464,6 +568,39 @@ func HealthEndpoint(ctx context.Context, userInfoGetter provider.UserInfoGetter,
 	}, nil
 }
 
+// encodeRootCAResponse holds the PEM encoded root CA certificate of a cluster.
+type encodeRootCAResponse struct {
+	clusterID   string
+	certificate []byte
+}
+
+func GetRootCAEndpoint(ctx context.Context, userInfoGetter provider.UserInfoGetter, projectID, clusterID string, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider) (interface{}, error) {
+	clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
+	cluster, err := GetCluster(ctx, projectProvider, privilegedProjectProvider, userInfoGetter, projectID, clusterID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	certificate, err := clusterProvider.GetRootCACertificateForCustomerCluster(cluster)
+	if err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+
+	return &encodeRootCAResponse{clusterID: cluster.Name, certificate: certificate}, nil
+}
+
+// EncodeRootCA writes the root CA certificate of a cluster as a PEM file.
+func EncodeRootCA(c context.Context, w http.ResponseWriter, response interface{}) (err error) {
+	rsp := response.(*encodeRootCAResponse)
+
+	w.Header().Set("Content-Type", "application/x-pem-file")
+	w.Header().Set("Content-disposition", fmt.Sprintf("attachment; filename=ca-%s.crt", rsp.clusterID))
+	w.Header().Add("Cache-Control", "no-cache")
+
+	_, err = w.Write(rsp.certificate)
+	return err
+}
+
 func GetMetricsEndpoint(ctx context.Context, userInfoGetter provider.UserInfoGetter, projectID, clusterID string, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider) (interface{}, error) {
 	privilegedClusterProvider := ctx.Value(middleware.PrivilegedClusterProviderContextKey).(provider.PrivilegedClusterProvider)
 	clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
@@ -502,7 +639,7 @@ func GetMetricsEndpoint(ctx context.Context, userInfoGetter provider.UserInfoGet
 
 	seedAdminClient := privilegedClusterProvider.GetSeedClusterAdminRuntimeClient()
 	podMetricsList := &v1beta1.PodMetricsList{}
-	if err := seedAdminClient.List(ctx, podMetricsList, &ctrlruntimeclient.ListOptions{Namespace: fmt.Sprintf("cluster-%s", cluster.Name)}); err != nil {
+	if err := seedAdminClient.List(ctx, podMetricsList, &ctrlruntimeclient.ListOptions{Namespace: cluster.Status.NamespaceName}); err != nil {
 		// Happens during cluster creation when the CRD is not setup yet
 		if _, ok := err.(*meta.NoKindMatchError); !ok {
 			return nil, common.KubernetesErrorToHTTPError(err)
@@ -825,6 +962,7 @@ func convertInternalClusterToExternal(internalCluster *kubermaticv1.Cluster, fil
 			Cloud:                                internalCluster.Spec.Cloud,
 			Version:                              internalCluster.Spec.Version,
 			MachineNetworks:                      internalCluster.Spec.MachineNetworks,
+			ClusterNetwork:                       externalClusterNetwork(internalCluster.Spec.ClusterNetwork),
 			OIDC:                                 internalCluster.Spec.OIDC,
 			UpdateWindow:                         internalCluster.Spec.UpdateWindow,
 			AuditLogging:                         internalCluster.Spec.AuditLogging,
@@ -850,6 +988,15 @@ func convertInternalClusterToExternal(internalCluster *kubermaticv1.Cluster, fil
 	return cluster
 }
 
+// externalClusterNetwork returns the cluster network of the API, which is only set once
+// the pod and service networks have been set or defaulted.
+func externalClusterNetwork(network kubermaticv1.ClusterNetworkingConfig) *kubermaticv1.ClusterNetworkingConfig {
+	if len(network.Pods.CIDRBlocks) == 0 && len(network.Services.CIDRBlocks) == 0 {
+		return nil
+	}
+	return &network
+}
+
 func ValidateClusterSpec(clusterType kubermaticv1.ClusterType, updateManager common.UpdateManager, body apiv1.CreateClusterSpec) error {
 	if body.Cluster.Spec.Cloud.DatacenterName == "" {
 		return fmt.Errorf("cluster datacenter name is empty")
@@ -873,6 +1020,9 @@ func ValidateClusterSpec(clusterType kubermaticv1.ClusterType, updateManager com
 	}
 	for _, availableVersion := range versions {
 		if body.Cluster.Spec.Version.Version.Equal(availableVersion.Version) {
+			if availableVersion.Deprecated {
+				return fmt.Errorf("invalid cluster: version %v is deprecated and cannot be used for new clusters", body.Cluster.Spec.Version.Version)
+			}
 			return nil
 		}
 	}
@@ -880,6 +1030,65 @@ func ValidateClusterSpec(clusterType kubermaticv1.ClusterType, updateManager com
 	return fmt.Errorf("invalid cluster: invalid cloud spec: unsupported version %v", body.Cluster.Spec.Version.Version)
 }
 
+// ValidateNewCluster checks the parts of a new cluster which would otherwise only be rejected
+// when the cluster object is created or reconciled, so that users get immediate feedback.
+func ValidateNewCluster(spec *kubermaticv1.ClusterSpec, labels map[string]string) error {
+	if strings.TrimSpace(spec.HumanReadableName) == "" {
+		return errors.NewBadRequest("invalid cluster: the name must not be blank")
+	}
+	if err := metav1validation.ValidateLabels(labels, field.NewPath("labels")).ToAggregate(); err != nil {
+		return errors.NewBadRequest("invalid cluster: %v", err)
+	}
+	if err := validation.ValidateUpdateWindow(spec.UpdateWindow); err != nil {
+		return errors.NewBadRequest("invalid cluster: %v", err)
+	}
+	if err := validation.ValidateEtcdClusterSize(spec.ComponentsOverride.Etcd.ClusterSize); err != nil {
+		return errors.NewBadRequest("invalid cluster: invalid etcd settings: %v", err)
+	}
+	// Credentials are stored by Kubermatic in its own namespace, a reference to a secret
+	// anywhere else would hand the credentials of other tenants to the cluster.
+	if ref := cloudCredentialsReference(spec.Cloud); ref != nil {
+		if ref.Name == "" {
+			return errors.NewBadRequest("invalid cluster: the cloud credentials reference must name a secret")
+		}
+		if ref.Namespace != resources.KubermaticNamespace {
+			return errors.NewBadRequest("invalid cluster: the cloud credentials reference must be in the %q namespace, got %q", resources.KubermaticNamespace, ref.Namespace)
+		}
+	}
+
+	return nil
+}
+
+// cloudCredentialsReference returns the reference to the secret holding the credentials of the
+// cloud provider, or nil if the provider has no credentials or they are given inline.
+func cloudCredentialsReference(cloud kubermaticv1.CloudSpec) *providerconfig.GlobalSecretKeySelector {
+	switch {
+	case cloud.AWS != nil:
+		return cloud.AWS.CredentialsReference
+	case cloud.Azure != nil:
+		return cloud.Azure.CredentialsReference
+	case cloud.Digitalocean != nil:
+		return cloud.Digitalocean.CredentialsReference
+	case cloud.GCP != nil:
+		return cloud.GCP.CredentialsReference
+	case cloud.Hetzner != nil:
+		return cloud.Hetzner.CredentialsReference
+	case cloud.Openstack != nil:
+		return cloud.Openstack.CredentialsReference
+	case cloud.Packet != nil:
+		return cloud.Packet.CredentialsReference
+	case cloud.Kubevirt != nil:
+		return cloud.Kubevirt.CredentialsReference
+	case cloud.VSphere != nil:
+		return cloud.VSphere.CredentialsReference
+	case cloud.Alibaba != nil:
+		return cloud.Alibaba.CredentialsReference
+	case cloud.Anexia != nil:
+		return cloud.Anexia.CredentialsReference
+	}
+	return nil
+}
+
 func ConvertClusterMetrics(podMetrics *v1beta1.PodMetricsList, nodeMetrics []v1beta1.NodeMetrics, availableNodesResources map[string]corev1.ResourceList, clusterName string) (*apiv1.ClusterMetrics, error) {
 	if podMetrics == nil {
 		return nil, fmt.Errorf("metric list can not be nil")
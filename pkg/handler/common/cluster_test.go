@@ -0,0 +1,103 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"testing"
+
+	providerconfig "github.com/kubermatic/machine-controller/pkg/providerconfig/types"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestValidateNewCluster(t *testing.T) {
+	hetznerSpec := func(ref *providerconfig.GlobalSecretKeySelector) kubermaticv1.CloudSpec {
+		return kubermaticv1.CloudSpec{Hetzner: &kubermaticv1.HetznerCloudSpec{CredentialsReference: ref}}
+	}
+	reference := func(namespace, name string) *providerconfig.GlobalSecretKeySelector {
+		return &providerconfig.GlobalSecretKeySelector{ObjectReference: corev1.ObjectReference{Namespace: namespace, Name: name}}
+	}
+
+	testCases := []struct {
+		name      string
+		spec      kubermaticv1.ClusterSpec
+		labels    map[string]string
+		expectErr bool
+	}{
+		{
+			name: "valid cluster",
+			spec: kubermaticv1.ClusterSpec{HumanReadableName: "keen-snyder", Cloud: hetznerSpec(nil)},
+		},
+		{
+			name:      "blank name",
+			spec:      kubermaticv1.ClusterSpec{HumanReadableName: "  "},
+			expectErr: true,
+		},
+		{
+			name:      "invalid label",
+			spec:      kubermaticv1.ClusterSpec{HumanReadableName: "keen-snyder"},
+			labels:    map[string]string{"env": "not valid"},
+			expectErr: true,
+		},
+		{
+			name: "etcd cluster size within the limits",
+			spec: kubermaticv1.ClusterSpec{
+				HumanReadableName:  "keen-snyder",
+				ComponentsOverride: kubermaticv1.ComponentSettings{Etcd: kubermaticv1.EtcdStatefulSetSettings{ClusterSize: kubermaticv1.MaxEtcdClusterSize}},
+			},
+		},
+		{
+			name: "etcd cluster size out of range",
+			spec: kubermaticv1.ClusterSpec{
+				HumanReadableName:  "keen-snyder",
+				ComponentsOverride: kubermaticv1.ComponentSettings{Etcd: kubermaticv1.EtcdStatefulSetSettings{ClusterSize: kubermaticv1.MaxEtcdClusterSize + 1}},
+			},
+			expectErr: true,
+		},
+		{
+			name: "credentials reference in the kubermatic namespace",
+			spec: kubermaticv1.ClusterSpec{HumanReadableName: "keen-snyder", Cloud: hetznerSpec(reference(resources.KubermaticNamespace, "credential-hetzner-abc"))},
+		},
+		{
+			name:      "credentials reference without a name",
+			spec:      kubermaticv1.ClusterSpec{HumanReadableName: "keen-snyder", Cloud: hetznerSpec(reference(resources.KubermaticNamespace, ""))},
+			expectErr: true,
+		},
+		{
+			name:      "credentials reference in another namespace",
+			spec:      kubermaticv1.ClusterSpec{HumanReadableName: "keen-snyder", Cloud: hetznerSpec(reference("cluster-abc", "credential-hetzner-abc"))},
+			expectErr: true,
+		},
+		{
+			name:      "credentials reference without a namespace",
+			spec:      kubermaticv1.ClusterSpec{HumanReadableName: "keen-snyder", Cloud: hetznerSpec(reference("", "credential-hetzner-abc"))},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateNewCluster(&tc.spec, tc.labels)
+			if (err != nil) != tc.expectErr {
+				t.Fatalf("expected error: %v, got: %v", tc.expectErr, err)
+			}
+		})
+	}
+}
@@ -37,6 +37,7 @@ import (
 	"k8c.io/kubermatic/v2/pkg/util/errors"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -94,7 +95,10 @@ func AWSSubnetNoCredentialsEndpoint(ctx context.Context, userInfoGetter provider
 	return SetDefaultSubnet(machineDeployments, subnetList)
 }
 
-func AWSSizeNoCredentialsEndpoint(ctx context.Context, userInfoGetter provider.UserInfoGetter, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, seedsGetter provider.SeedsGetter, settingsProvider provider.SettingsProvider, projectID, clusterID string) (interface{}, error) {
+// AWSSizeWithClusterCredentialsEndpoint lists the AWS sizes offered in the region of the cluster, using
+// the credentials of the cluster. If an availability zone is given, sizes not offered in it are omitted.
+func AWSSizeWithClusterCredentialsEndpoint(ctx context.Context, userInfoGetter provider.UserInfoGetter, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, seedsGetter provider.SeedsGetter, settingsProvider provider.SettingsProvider, projectID, clusterID, availabilityZone string) (interface{}, error) {
+	clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
 	cluster, err := handlercommon.GetCluster(ctx, projectProvider, privilegedProjectProvider, userInfoGetter, projectID, clusterID, &provider.ClusterGetOptions{CheckInitStatus: true})
 	if err != nil {
 		return nil, err
@@ -116,12 +120,33 @@ func AWSSizeNoCredentialsEndpoint(ctx context.Context, userInfoGetter provider.U
 		return nil, errors.NewNotFound("cloud spec (dc) for ", clusterID)
 	}
 
+	assertedClusterProvider, ok := clusterProvider.(*kubernetesprovider.ClusterProvider)
+	if !ok {
+		return nil, errors.New(http.StatusInternalServerError, "failed to assert clusterProvider")
+	}
+
+	secretKeySelector := provider.SecretKeySelectorValueFuncFactory(ctx, assertedClusterProvider.GetSeedClusterAdminRuntimeClient())
+	accessKeyID, secretAccessKey, err := awsprovider.GetCredentialsForCluster(cluster.Spec.Cloud, secretKeySelector)
+	if err != nil {
+		return nil, err
+	}
+
 	settings, err := settingsProvider.GetGlobalSettings()
 	if err != nil {
 		return nil, common.KubernetesErrorToHTTPError(err)
 	}
 
-	return AWSSizes(dc.Spec.AWS.Region, settings.Spec.MachineDeploymentVMResourceQuota)
+	sizes, err := AWSSizes(dc.Spec.AWS.Region, settings.Spec.MachineDeploymentVMResourceQuota)
+	if err != nil {
+		return nil, err
+	}
+
+	offered, err := awsprovider.GetInstanceTypeOfferings(accessKeyID, secretAccessKey, dc.Spec.AWS.Region, availabilityZone)
+	if err != nil {
+		return nil, err
+	}
+
+	return filterAWSByOfferings(sizes, offered), nil
 }
 
 func ListAWSSubnets(accessKeyID, secretAccessKey, vpcID string, datacenter *kubermaticv1.Datacenter) (apiv1.AWSSubnetList, error) {
@@ -257,6 +282,17 @@ func AWSSizes(region string, quota kubermaticv1.MachineDeploymentVMResourceQuota
 	return filterAWSByQuota(sizes, quota), nil
 }
 
+// filterAWSByOfferings removes all sizes which are not offered in the region or availability zone.
+func filterAWSByOfferings(sizes apiv1.AWSSizeList, offered sets.String) apiv1.AWSSizeList {
+	filtered := apiv1.AWSSizeList{}
+	for _, size := range sizes {
+		if offered.Has(size.Name) {
+			filtered = append(filtered, size)
+		}
+	}
+	return filtered
+}
+
 func filterAWSByQuota(instances apiv1.AWSSizeList, quota kubermaticv1.MachineDeploymentVMResourceQuota) apiv1.AWSSizeList {
 	filteredRecords := apiv1.AWSSizeList{}
 
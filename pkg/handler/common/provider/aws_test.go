@@ -0,0 +1,65 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"reflect"
+	"testing"
+
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+func TestFilterAWSByOfferings(t *testing.T) {
+	sizes := apiv1.AWSSizeList{
+		{Name: "t3.small", VCPUs: 2, Memory: 2},
+		{Name: "t3.medium", VCPUs: 2, Memory: 4},
+		{Name: "p3.2xlarge", VCPUs: 8, Memory: 61, GPUs: 1},
+	}
+
+	testCases := []struct {
+		name     string
+		offered  sets.String
+		expected apiv1.AWSSizeList
+	}{
+		{
+			name:     "all sizes offered",
+			offered:  sets.NewString("t3.small", "t3.medium", "p3.2xlarge", "m5.large"),
+			expected: sizes,
+		},
+		{
+			name:     "sizes not offered in the availability zone are omitted",
+			offered:  sets.NewString("t3.medium"),
+			expected: apiv1.AWSSizeList{{Name: "t3.medium", VCPUs: 2, Memory: 4}},
+		},
+		{
+			name:     "no sizes offered",
+			offered:  sets.NewString(),
+			expected: apiv1.AWSSizeList{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			filtered := filterAWSByOfferings(sizes, tc.offered)
+			if !reflect.DeepEqual(filtered, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, filtered)
+			}
+		})
+	}
+}
@@ -20,6 +20,8 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"sort"
+	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-12-01/compute"
 	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-06-01/network"
@@ -36,6 +38,8 @@ import (
 	"k8c.io/kubermatic/v2/pkg/provider/cloud/azure"
 	kubernetesprovider "k8c.io/kubermatic/v2/pkg/provider/kubernetes"
 	"k8c.io/kubermatic/v2/pkg/util/errors"
+
+	"k8s.io/apimachinery/pkg/util/sets"
 )
 
 // https://docs.microsoft.com/en-us/azure/virtual-machines/sizes-gpu
@@ -46,6 +50,12 @@ var gpuInstanceFamilies = map[string]int32{"Standard_NC6": 1, "Standard_NC12": 2
 	"Standard_ND40rs_v2": 8, "Standard_NV6": 1, "Standard_NV12": 2, "Standard_NV24": 4, "Standard_NV12s_v3": 1, "Standard_NV24s_v3": 2, "Standard_NV48s_v3": 4,
 	"Standard_NV32as_v4": 1}
 
+// Types of the restrictions of Azure SKUs
+const (
+	azureRestrictionTypeLocation = "Location"
+	azureRestrictionTypeZone     = "Zone"
+)
+
 var NewAzureClientSet = func(subscriptionID, clientID, clientSecret, tenantID string) (AzureClientSet, error) {
 	var err error
 	sizesClient := compute.NewVirtualMachineSizesClient(subscriptionID)
@@ -286,10 +296,14 @@ func isValidVM(sku compute.ResourceSku, location string) bool {
 		return false
 	}
 
-	// check restricted locations
+	// check restricted locations, sizes which are only restricted in
+	// some zones can still be used in the others
 	restrictions := sku.Restrictions
 	if restrictions != nil {
 		for _, r := range *restrictions {
+			if string(r.Type) != azureRestrictionTypeLocation {
+				continue
+			}
 			restrictionInfo := r.RestrictionInfo
 			if restrictionInfo != nil {
 				if restrictionInfo.Locations != nil {
@@ -306,6 +320,40 @@ func isValidVM(sku compute.ResourceSku, location string) bool {
 	return true
 }
 
+// azureSKUDetails contains the details of a VM size which are only available from its SKU
+type azureSKUDetails struct {
+	premiumIO       bool
+	restrictedZones []string
+}
+
+// getAzureSKUDetails returns the capabilities of the SKU and the zones of the location it is restricted in.
+func getAzureSKUDetails(sku compute.ResourceSku, location string) azureSKUDetails {
+	details := azureSKUDetails{}
+
+	if sku.Capabilities != nil {
+		for _, c := range *sku.Capabilities {
+			if c.Name != nil && c.Value != nil && *c.Name == "PremiumIO" {
+				details.premiumIO = strings.EqualFold(*c.Value, "true")
+			}
+		}
+	}
+
+	if sku.Restrictions != nil {
+		for _, r := range *sku.Restrictions {
+			if string(r.Type) != azureRestrictionTypeZone || r.RestrictionInfo == nil || r.RestrictionInfo.Zones == nil {
+				continue
+			}
+			if r.RestrictionInfo.Locations != nil && !sets.NewString(*r.RestrictionInfo.Locations...).Has(location) {
+				continue
+			}
+			details.restrictedZones = append(details.restrictedZones, *r.RestrictionInfo.Zones...)
+		}
+	}
+	sort.Strings(details.restrictedZones)
+
+	return details
+}
+
 func AzureSize(ctx context.Context, quota kubermaticv1.MachineDeploymentVMResourceQuota, subscriptionID, clientID, clientSecret, tenantID, location string) (apiv1.AzureSizeList, error) {
 	sizesClient, err := NewAzureClientSet(subscriptionID, clientID, clientSecret, tenantID)
 	if err != nil {
@@ -318,10 +366,10 @@ func AzureSize(ctx context.Context, quota kubermaticv1.MachineDeploymentVMResour
 	}
 
 	// prepare set of valid VM size types from SKU resources
-	validSKUSet := make(map[string]struct{}, len(skuList))
+	validSKUSet := make(map[string]azureSKUDetails, len(skuList))
 	for _, v := range skuList {
 		if isValidVM(v, location) {
-			validSKUSet[*v.Name] = struct{}{}
+			validSKUSet[*v.Name] = getAzureSKUDetails(v, location)
 		}
 	}
 
@@ -335,7 +383,7 @@ func AzureSize(ctx context.Context, quota kubermaticv1.MachineDeploymentVMResour
 	for _, v := range listVMSize {
 		if v.Name != nil {
 			vmName := *v.Name
-			_, okSKU := validSKUSet[vmName]
+			details, okSKU := validSKUSet[vmName]
 			gpus, okGPU := gpuInstanceFamilies[vmName]
 			if okSKU {
 				s := apiv1.AzureSize{
@@ -346,6 +394,8 @@ func AzureSize(ctx context.Context, quota kubermaticv1.MachineDeploymentVMResour
 					ResourceDiskSizeInMB: *v.ResourceDiskSizeInMB,
 					MemoryInMB:           *v.MemoryInMB,
 					MaxDataDiskCount:     *v.MaxDataDiskCount,
+					PremiumIO:            details.premiumIO,
+					RestrictedZones:      details.restrictedZones,
 				}
 				if okGPU {
 					s.NumberOfGPUs = gpus
@@ -21,6 +21,10 @@ import (
 	"fmt"
 	"net/http"
 	"regexp"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/hetznercloud/hcloud-go/hcloud"
 
@@ -33,12 +37,39 @@ import (
 	"k8c.io/kubermatic/v2/pkg/provider/cloud/hetzner"
 	kubernetesprovider "k8c.io/kubermatic/v2/pkg/provider/kubernetes"
 	"k8c.io/kubermatic/v2/pkg/util/errors"
+
+	"k8s.io/apimachinery/pkg/util/sets"
 )
 
-var reStandardSize = regexp.MustCompile("(^cx)")
+var reStandardSize = regexp.MustCompile("(^cx|^cpx)")
 var reDedicatedSize = regexp.MustCompile("(^ccx)")
+var reARM64Size = regexp.MustCompile("(^cax)")
+
+const (
+	hetznerArchitectureAMD64 = "amd64"
+	hetznerArchitectureARM64 = "arm64"
+
+	// Features of the Hetzner sizes, the size listing can be filtered by them.
+	HetznerFeatureDedicatedCPU = "dedicated-cpu"
+	HetznerFeatureLocalStorage = "local-storage"
+
+	// DefaultHetznerRequestTimeout is the default timeout of requests to the Hetzner API.
+	DefaultHetznerRequestTimeout = 30 * time.Second
+)
+
+// HetznerFeatures are all known features of Hetzner sizes.
+var HetznerFeatures = sets.NewString(HetznerFeatureDedicatedCPU, HetznerFeatureLocalStorage)
+
+// hetznerRequestTimeout is accessed atomically, it holds a time.Duration.
+var hetznerRequestTimeout = int64(DefaultHetznerRequestTimeout)
 
-func HetznerSizeWithClusterCredentialsEndpoint(ctx context.Context, userInfoGetter provider.UserInfoGetter, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, settingsProvider provider.SettingsProvider, projectID, clusterID string) (interface{}, error) {
+// SetHetznerRequestTimeout configures the timeout of requests to the Hetzner API. The timeout
+// is derived from the context of the incoming request, a timeout of zero disables it.
+func SetHetznerRequestTimeout(timeout time.Duration) {
+	atomic.StoreInt64(&hetznerRequestTimeout, int64(timeout))
+}
+
+func HetznerSizeWithClusterCredentialsEndpoint(ctx context.Context, userInfoGetter provider.UserInfoGetter, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, settingsProvider provider.SettingsProvider, sizeCache *HetznerSizeCache, projectID, clusterID string, refresh bool, features []string) (interface{}, error) {
 	clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
 
 	cluster, err := handlercommon.GetCluster(ctx, projectProvider, privilegedProjectProvider, userInfoGetter, projectID, clusterID, &provider.ClusterGetOptions{CheckInitStatus: true})
@@ -66,67 +97,321 @@ func HetznerSizeWithClusterCredentialsEndpoint(ctx context.Context, userInfoGett
 		return nil, common.KubernetesErrorToHTTPError(err)
 	}
 
-	return HetznerSize(ctx, settings.Spec.MachineDeploymentVMResourceQuota, hetznerToken)
+	return HetznerSize(ctx, sizeCache, settings.Spec.MachineDeploymentVMResourceQuota, hetznerToken, refresh, features)
+}
+
+// HetznerSize lists the Hetzner sizes which match the quota and support all of the given
+// features. The server types are cached per token, refresh bypasses the cache.
+func HetznerSize(ctx context.Context, sizeCache *HetznerSizeCache, quota kubermaticv1.MachineDeploymentVMResourceQuota, token string, refresh bool, features []string) (apiv1.HetznerSizeList, error) {
+	sizes, err := sizeCache.get(ctx, token, refresh)
+	if err != nil {
+		return apiv1.HetznerSizeList{}, err
+	}
+
+	return sortHetznerSizes(filterHetznerByFeatures(filterHetznerByQuota(hetznerSizeList(sizes), quota), features)), nil
+}
+
+// DecodeHetznerFeatures decodes the comma separated list of features the sizes must support.
+func DecodeHetznerFeatures(r *http.Request) ([]string, error) {
+	queryParam := r.URL.Query().Get("features")
+	if queryParam == "" {
+		return nil, nil
+	}
+
+	var features []string
+	for _, feature := range strings.Split(queryParam, ",") {
+		feature = strings.TrimSpace(feature)
+		if !HetznerFeatures.Has(feature) {
+			return nil, errors.NewBadRequest("unknown Hetzner size feature %q, must be one of %s", feature, strings.Join(HetznerFeatures.List(), ", "))
+		}
+		features = append(features, feature)
+	}
+	return features, nil
+}
+
+// HetznerSizeNotFoundError is returned if the Hetzner API does not offer a size with the requested name.
+type HetznerSizeNotFoundError struct {
+	Name string
+}
+
+func (e *HetznerSizeNotFoundError) Error() string {
+	return fmt.Sprintf("Hetzner size %q does not exist", e.Name)
+}
+
+// HetznerSizeByName looks up a single Hetzner size by the name of its server type. The
+// server types are shared with the cached size listing.
+func HetznerSizeByName(ctx context.Context, sizeCache *HetznerSizeCache, token, name string) (apiv1.HetznerSize, error) {
+	sizes, err := sizeCache.get(ctx, token, false)
+	if err != nil {
+		return apiv1.HetznerSize{}, err
+	}
+
+	return hetznerSizeByName(hetznerSizeList(sizes), name)
+}
 
+func hetznerSizeByName(sizeList apiv1.HetznerSizeList, name string) (apiv1.HetznerSize, error) {
+	for _, sizes := range [][]apiv1.HetznerSize{sizeList.Standard, sizeList.Dedicated, sizeList.ARM64} {
+		for _, size := range sizes {
+			if size.Name == name {
+				return size, nil
+			}
+		}
+	}
+
+	return apiv1.HetznerSize{}, &HetznerSizeNotFoundError{Name: name}
 }
 
-func HetznerSize(ctx context.Context, quota kubermaticv1.MachineDeploymentVMResourceQuota, token string) (apiv1.HetznerSizeList, error) {
-	client := hcloud.NewClient(hcloud.WithToken(token))
+// HetznerNodeDeploymentValidator returns a validator which rejects initial node deployments of
+// Hetzner clusters requesting a size the Hetzner API does not offer.
+func HetznerNodeDeploymentValidator(sizeCache *HetznerSizeCache) handlercommon.NodeDeploymentValidator {
+	return func(ctx context.Context, cloud kubermaticv1.CloudSpec, secretKeyGetter provider.SecretKeySelectorValueFunc, nd *apiv1.NodeDeployment) error {
+		if cloud.Hetzner == nil || nd == nil || nd.Spec.Template.Cloud.Hetzner == nil {
+			return nil
+		}
+
+		hetznerToken, err := hetzner.GetCredentialsForCluster(cloud, secretKeyGetter)
+		if err != nil {
+			return err
+		}
+
+		if _, err := HetznerSizeByName(ctx, sizeCache, hetznerToken, nd.Spec.Template.Cloud.Hetzner.Type); err != nil {
+			if _, ok := err.(*HetznerSizeNotFoundError); ok {
+				return errors.NewBadRequest("invalid node deployment: %v", err)
+			}
+			return err
+		}
 
-	listOptions := hcloud.ServerTypeListOpts{
-		ListOpts: hcloud.ListOpts{
-			Page:    1,
-			PerPage: 1000,
-		},
+		return nil
 	}
+}
+
+// hetznerSizeProvider lists the Hetzner sizes for the generic size endpoint.
+type hetznerSizeProvider struct {
+	sizeCache *HetznerSizeCache
+}
 
-	sizes, _, err := client.ServerType.List(ctx, listOptions)
+func (p hetznerSizeProvider) ListSizes(ctx context.Context, credentials SizeCredentials, opts SizeListOptions) (apiv1.SizeList, error) {
+	hetznerToken, err := hetzner.GetCredentialsForCluster(credentials.Cloud, credentials.SecretKeyGetter)
 	if err != nil {
-		return apiv1.HetznerSizeList{}, fmt.Errorf("failed to list sizes: %v", err)
+		return nil, err
 	}
 
+	sizeList, err := HetznerSize(ctx, p.sizeCache, opts.Quota, hetznerToken, opts.Refresh, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return hetznerToSizeList(sizeList), nil
+}
+
+// hetznerToSizeList flattens the Hetzner size buckets, the bucket becomes the category of the size.
+func hetznerToSizeList(sizeList apiv1.HetznerSizeList) apiv1.SizeList {
+	sizes := apiv1.SizeList{}
+
+	for _, bucket := range []struct {
+		category string
+		sizes    []apiv1.HetznerSize
+	}{
+		{category: "standard", sizes: sizeList.Standard},
+		{category: "dedicated", sizes: sizeList.Dedicated},
+		{category: "arm64", sizes: sizeList.ARM64},
+	} {
+		for _, size := range bucket.sizes {
+			sizes = append(sizes, apiv1.Size{
+				Name:         size.Name,
+				Description:  size.Description,
+				Category:     bucket.category,
+				Cores:        size.Cores,
+				Memory:       size.Memory,
+				Disk:         size.Disk,
+				Architecture: size.Architecture,
+			})
+		}
+	}
+
+	return sizes
+}
+
+var listHetznerServerTypes = newHetznerServerTypeLister()
+
+func newHetznerServerTypeLister(opts ...hcloud.ClientOption) hetznerServerTypeLister {
+	return func(ctx context.Context, token string) ([]*hcloud.ServerType, error) {
+		timeout := time.Duration(atomic.LoadInt64(&hetznerRequestTimeout))
+		if timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+
+		client := hcloud.NewClient(append([]hcloud.ClientOption{hcloud.WithToken(token)}, opts...)...)
+
+		listOptions := hcloud.ServerTypeListOpts{
+			ListOpts: hcloud.ListOpts{
+				Page:    1,
+				PerPage: 1000,
+			},
+		}
+
+		var sizes []*hcloud.ServerType
+		err := hetzner.APIObserver.Observe("ServerType.List", func() error {
+			var err error
+			sizes, _, err = client.ServerType.List(ctx, listOptions)
+			return err
+		})
+		if err != nil {
+			if ctx.Err() == context.DeadlineExceeded {
+				return nil, errors.New(http.StatusGatewayTimeout, fmt.Sprintf("the Hetzner API did not respond within %v", timeout))
+			}
+			return nil, hetznerListError(err)
+		}
+
+		return sizes, nil
+	}
+}
+
+// hetznerListError turns errors caused by the token into client errors, so they
+// are not reported as internal server errors.
+func hetznerListError(err error) error {
+	switch {
+	case hcloud.IsError(err, hcloud.ErrorCodeUnauthorized):
+		return errors.New(http.StatusUnauthorized, fmt.Sprintf("the Hetzner token is invalid or expired: %v", err))
+	case hcloud.IsError(err, hcloud.ErrorCodeForbidden):
+		return errors.NewBadRequest("the Hetzner token is not allowed to list server types: %v", err)
+	default:
+		return fmt.Errorf("failed to list sizes: %v", err)
+	}
+}
+
+// hetznerSizeList sorts the server types into the size buckets by their name.
+// Server types of unknown families are left out.
+func hetznerSizeList(sizes []*hcloud.ServerType) apiv1.HetznerSizeList {
 	sizeList := apiv1.HetznerSizeList{}
 
 	for _, size := range sizes {
 		s := apiv1.HetznerSize{
-			ID:          size.ID,
-			Name:        size.Name,
-			Description: size.Description,
-			Cores:       size.Cores,
-			Memory:      size.Memory,
-			Disk:        size.Disk,
+			ID:           size.ID,
+			Name:         size.Name,
+			Description:  size.Description,
+			Cores:        size.Cores,
+			Memory:       size.Memory,
+			Disk:         size.Disk,
+			Architecture: hetznerArchitectureAMD64,
+			Prices:       hetznerSizePrices(size.Pricings),
+			Features:     hetznerSizeFeatures(size),
 		}
 		switch {
 		case reStandardSize.MatchString(size.Name):
 			sizeList.Standard = append(sizeList.Standard, s)
 		case reDedicatedSize.MatchString(size.Name):
 			sizeList.Dedicated = append(sizeList.Dedicated, s)
+		case reARM64Size.MatchString(size.Name):
+			s.Architecture = hetznerArchitectureARM64
+			sizeList.ARM64 = append(sizeList.ARM64, s)
 		}
 	}
 
-	return filterHetznerByQuota(sizeList, quota), nil
+	return sizeList
 }
 
-func filterHetznerByQuota(instances apiv1.HetznerSizeList, quota kubermaticv1.MachineDeploymentVMResourceQuota) apiv1.HetznerSizeList {
-	filteredRecords := apiv1.HetznerSizeList{}
+// hetznerSizeFeatures returns the features supported by a server type, derived from its CPU and
+// storage type. The Hetzner API does not report networking support per server type.
+func hetznerSizeFeatures(size *hcloud.ServerType) []string {
+	var features []string
+	if size.CPUType == hcloud.CPUTypeDedicated {
+		features = append(features, HetznerFeatureDedicatedCPU)
+	}
+	if size.StorageType == hcloud.StorageTypeLocal {
+		features = append(features, HetznerFeatureLocalStorage)
+	}
 
-	// Range over the records and apply all the filters to each record.
-	// If the record passes all the filters, add it to the final slice.
-	for _, r := range instances.Standard {
-		keep := true
+	return features
+}
 
-		if !handlercommon.FilterCPU(r.Cores, quota.MinCPU, quota.MaxCPU) {
-			keep = false
+// sortHetznerSizes orders every size bucket by cores, memory and name, as the Hetzner API
+// does not guarantee the order of the server types.
+func sortHetznerSizes(sizeList apiv1.HetznerSizeList) apiv1.HetznerSizeList {
+	for _, sizes := range [][]apiv1.HetznerSize{sizeList.Standard, sizeList.Dedicated, sizeList.ARM64} {
+		sort.Slice(sizes, func(i, j int) bool {
+			if sizes[i].Cores != sizes[j].Cores {
+				return sizes[i].Cores < sizes[j].Cores
+			}
+			if sizes[i].Memory != sizes[j].Memory {
+				return sizes[i].Memory < sizes[j].Memory
+			}
+			return sizes[i].Name < sizes[j].Name
+		})
+	}
+
+	return sizeList
+}
+
+// hetznerSizePrices converts the per-location pricing of a server type. Missing pricing
+// information is not an error, the affected locations or amounts are left out.
+func hetznerSizePrices(pricings []hcloud.ServerTypeLocationPricing) []apiv1.HetznerSizePrice {
+	var prices []apiv1.HetznerSizePrice
+
+	for _, pricing := range pricings {
+		if pricing.Location == nil {
+			continue
 		}
-		if !handlercommon.FilterMemory(int(r.Memory), quota.MinRAM, quota.MaxRAM) {
-			keep = false
+
+		currency := pricing.Hourly.Currency
+		if currency == "" {
+			currency = pricing.Monthly.Currency
 		}
 
-		if keep {
-			filteredRecords.Standard = append(filteredRecords.Standard, r)
+		prices = append(prices, apiv1.HetznerSizePrice{
+			Location:     pricing.Location.Name,
+			Currency:     currency,
+			HourlyNet:    pricing.Hourly.Net,
+			HourlyGross:  pricing.Hourly.Gross,
+			MonthlyNet:   pricing.Monthly.Net,
+			MonthlyGross: pricing.Monthly.Gross,
+		})
+	}
+
+	return prices
+}
+
+func filterHetznerByQuota(instances apiv1.HetznerSizeList, quota kubermaticv1.MachineDeploymentVMResourceQuota) apiv1.HetznerSizeList {
+	return apiv1.HetznerSizeList{
+		Standard:  filterHetznerSizesByQuota(instances.Standard, quota),
+		Dedicated: filterHetznerSizesByQuota(instances.Dedicated, quota),
+		ARM64:     filterHetznerSizesByQuota(instances.ARM64, quota),
+	}
+}
+
+func filterHetznerByFeatures(instances apiv1.HetznerSizeList, features []string) apiv1.HetznerSizeList {
+	if len(features) == 0 {
+		return instances
+	}
+
+	return apiv1.HetznerSizeList{
+		Standard:  filterHetznerSizesByFeatures(instances.Standard, features),
+		Dedicated: filterHetznerSizesByFeatures(instances.Dedicated, features),
+		ARM64:     filterHetznerSizesByFeatures(instances.ARM64, features),
+	}
+}
+
+// filterHetznerSizesByFeatures keeps the sizes which support all of the given features.
+func filterHetznerSizesByFeatures(sizes []apiv1.HetznerSize, features []string) []apiv1.HetznerSize {
+	var filteredRecords []apiv1.HetznerSize
+
+	for _, r := range sizes {
+		if sets.NewString(r.Features...).HasAll(features...) {
+			filteredRecords = append(filteredRecords, r)
 		}
 	}
-	for _, r := range instances.Dedicated {
+
+	return filteredRecords
+}
+
+func filterHetznerSizesByQuota(sizes []apiv1.HetznerSize, quota kubermaticv1.MachineDeploymentVMResourceQuota) []apiv1.HetznerSize {
+	var filteredRecords []apiv1.HetznerSize
+
+	// Range over the records and apply all the filters to each record.
+	// If the record passes all the filters, add it to the final slice.
+	for _, r := range sizes {
 		keep := true
 
 		if !handlercommon.FilterCPU(r.Cores, quota.MinCPU, quota.MaxCPU) {
@@ -137,7 +422,7 @@ func filterHetznerByQuota(instances apiv1.HetznerSizeList, quota kubermaticv1.Ma
 		}
 
 		if keep {
-			filteredRecords.Dedicated = append(filteredRecords.Dedicated, r)
+			filteredRecords = append(filteredRecords, r)
 		}
 	}
 
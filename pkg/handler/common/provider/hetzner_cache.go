@@ -0,0 +1,101 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/hetznercloud/hcloud-go/hcloud"
+	"golang.org/x/sync/singleflight"
+	"k8s.io/apimachinery/pkg/util/cache"
+)
+
+const (
+	// DefaultHetznerSizeCacheTTL is the default duration for which the Hetzner server types are cached.
+	DefaultHetznerSizeCacheTTL = 5 * time.Minute
+
+	// hetznerSizeCacheMaxEntries bounds the number of cached server type listings. The least
+	// recently used entries are evicted first, expired entries are evicted when accessed.
+	hetznerSizeCacheMaxEntries = 1000
+)
+
+type hetznerServerTypeLister func(ctx context.Context, token string) ([]*hcloud.ServerType, error)
+
+// HetznerSizeCache caches the Hetzner server types per token, so that dashboards polling the
+// sizes of many clusters do not run into the Hetzner API rate limits. Concurrent requests for
+// the same token share a single listing. The tokens are only used as hashed keys.
+type HetznerSizeCache struct {
+	ttl     time.Duration
+	entries *cache.LRUExpireCache
+	calls   singleflight.Group
+	list    hetznerServerTypeLister
+}
+
+// NewHetznerSizeCache returns a cache which keeps the Hetzner server types for the given TTL.
+// A TTL of zero disables the cache, concurrent identical requests are still collapsed.
+func NewHetznerSizeCache(ttl time.Duration) *HetznerSizeCache {
+	return newHetznerSizeCache(ttl, listHetznerServerTypes)
+}
+
+func newHetznerSizeCache(ttl time.Duration, list hetznerServerTypeLister) *HetznerSizeCache {
+	return &HetznerSizeCache{
+		ttl:     ttl,
+		entries: cache.NewLRUExpireCache(hetznerSizeCacheMaxEntries),
+		list:    list,
+	}
+}
+
+// get returns the server types for the token, either from the cache or by listing them.
+// If refresh is true, the cache is bypassed and updated with the fresh listing.
+func (c *HetznerSizeCache) get(ctx context.Context, token string, refresh bool) ([]*hcloud.ServerType, error) {
+	key := hetznerSizeCacheKey(token)
+
+	if !refresh {
+		if sizes, ok := c.entries.Get(key); ok {
+			return sizes.([]*hcloud.ServerType), nil
+		}
+	}
+
+	// The listing is shared by all requests for the token, so it must not be canceled
+	// when the request which happened to start it goes away. It is still bounded by the
+	// Hetzner request timeout.
+	result := c.calls.DoChan(key, func() (interface{}, error) {
+		sizes, err := c.list(context.Background(), token)
+		if err == nil && c.ttl > 0 {
+			c.entries.Add(key, sizes, c.ttl)
+		}
+		return sizes, err
+	})
+
+	select {
+	case res := <-result:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		return res.Val.([]*hcloud.ServerType), nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func hetznerSizeCacheKey(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
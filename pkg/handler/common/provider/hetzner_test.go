@@ -0,0 +1,500 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hetznercloud/hcloud-go/hcloud"
+
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	"k8c.io/kubermatic/v2/pkg/util/errors"
+)
+
+func TestHetznerSizePrices(t *testing.T) {
+	testCases := []struct {
+		name           string
+		pricings       []hcloud.ServerTypeLocationPricing
+		expectedPrices []apiv1.HetznerSizePrice
+	}{
+		{
+			name:           "no pricing",
+			pricings:       nil,
+			expectedPrices: nil,
+		},
+		{
+			name: "full pricing",
+			pricings: []hcloud.ServerTypeLocationPricing{
+				{
+					Location: &hcloud.Location{Name: "nbg1"},
+					Hourly:   hcloud.Price{Currency: "EUR", Net: "0.0050", Gross: "0.0060"},
+					Monthly:  hcloud.Price{Currency: "EUR", Net: "2.9600", Gross: "3.5224"},
+				},
+			},
+			expectedPrices: []apiv1.HetznerSizePrice{
+				{
+					Location:     "nbg1",
+					Currency:     "EUR",
+					HourlyNet:    "0.0050",
+					HourlyGross:  "0.0060",
+					MonthlyNet:   "2.9600",
+					MonthlyGross: "3.5224",
+				},
+			},
+		},
+		{
+			name: "partial pricing",
+			pricings: []hcloud.ServerTypeLocationPricing{
+				{
+					Location: &hcloud.Location{Name: "fsn1"},
+					Monthly:  hcloud.Price{Currency: "EUR", Net: "2.9600"},
+				},
+				{
+					Hourly: hcloud.Price{Currency: "EUR", Net: "0.0050"},
+				},
+			},
+			expectedPrices: []apiv1.HetznerSizePrice{
+				{
+					Location:   "fsn1",
+					Currency:   "EUR",
+					MonthlyNet: "2.9600",
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			prices := hetznerSizePrices(tc.pricings)
+			if !reflect.DeepEqual(prices, tc.expectedPrices) {
+				t.Errorf("expected prices %+v, got %+v", tc.expectedPrices, prices)
+			}
+		})
+	}
+}
+
+func TestHetznerSizeList(t *testing.T) {
+	sizes := []*hcloud.ServerType{
+		{Name: "cx11"},
+		{Name: "cx21"},
+		{Name: "cpx11"},
+		{Name: "cpx51"},
+		{Name: "ccx11"},
+		{Name: "ccx62"},
+		{Name: "cax11"},
+		{Name: "cax41"},
+		{Name: "unknown"},
+	}
+
+	sizeList := hetznerSizeList(sizes)
+
+	names := func(sizes []apiv1.HetznerSize) []string {
+		var result []string
+		for _, s := range sizes {
+			result = append(result, s.Name)
+		}
+		return result
+	}
+
+	if expected, got := []string{"cx11", "cx21", "cpx11", "cpx51"}, names(sizeList.Standard); !reflect.DeepEqual(expected, got) {
+		t.Errorf("expected standard sizes %v, got %v", expected, got)
+	}
+	if expected, got := []string{"ccx11", "ccx62"}, names(sizeList.Dedicated); !reflect.DeepEqual(expected, got) {
+		t.Errorf("expected dedicated sizes %v, got %v", expected, got)
+	}
+	if expected, got := []string{"cax11", "cax41"}, names(sizeList.ARM64); !reflect.DeepEqual(expected, got) {
+		t.Errorf("expected ARM64 sizes %v, got %v", expected, got)
+	}
+
+	for _, s := range append(sizeList.Standard, sizeList.Dedicated...) {
+		if s.Architecture != hetznerArchitectureAMD64 {
+			t.Errorf("expected size %q to have architecture %q, got %q", s.Name, hetznerArchitectureAMD64, s.Architecture)
+		}
+	}
+	for _, s := range sizeList.ARM64 {
+		if s.Architecture != hetznerArchitectureARM64 {
+			t.Errorf("expected size %q to have architecture %q, got %q", s.Name, hetznerArchitectureARM64, s.Architecture)
+		}
+	}
+}
+
+func TestSortHetznerSizes(t *testing.T) {
+	sizes := []*hcloud.ServerType{
+		{Name: "cpx31", Cores: 4, Memory: 8},
+		{Name: "cx11", Cores: 1, Memory: 2},
+		{Name: "ccx22", Cores: 4, Memory: 16},
+		{Name: "cx31", Cores: 2, Memory: 8},
+		{Name: "cpx21", Cores: 3, Memory: 4},
+		{Name: "ccx12", Cores: 2, Memory: 8},
+		{Name: "cx21", Cores: 2, Memory: 4},
+		{Name: "cpx11", Cores: 2, Memory: 2},
+		{Name: "cx41", Cores: 4, Memory: 16},
+		{Name: "cax21", Cores: 4, Memory: 8},
+		{Name: "cax11", Cores: 2, Memory: 4},
+	}
+
+	names := func(sizes []apiv1.HetznerSize) []string {
+		var result []string
+		for _, s := range sizes {
+			result = append(result, s.Name)
+		}
+		return result
+	}
+
+	// the order must not depend on the order of the API response
+	for i := 0; i < 10; i++ {
+		shuffled := make([]*hcloud.ServerType, len(sizes))
+		copy(shuffled, sizes)
+		rand.Shuffle(len(shuffled), func(i, j int) { shuffled[i], shuffled[j] = shuffled[j], shuffled[i] })
+
+		sizeList := sortHetznerSizes(hetznerSizeList(shuffled))
+
+		if expected, got := []string{"cx11", "cpx11", "cx21", "cx31", "cpx21", "cpx31", "cx41"}, names(sizeList.Standard); !reflect.DeepEqual(expected, got) {
+			t.Fatalf("expected standard sizes %v, got %v", expected, got)
+		}
+		if expected, got := []string{"ccx12", "ccx22"}, names(sizeList.Dedicated); !reflect.DeepEqual(expected, got) {
+			t.Fatalf("expected dedicated sizes %v, got %v", expected, got)
+		}
+		if expected, got := []string{"cax11", "cax21"}, names(sizeList.ARM64); !reflect.DeepEqual(expected, got) {
+			t.Fatalf("expected ARM64 sizes %v, got %v", expected, got)
+		}
+	}
+}
+
+func TestHetznerSizeCache(t *testing.T) {
+	var calls int32
+	list := func(ctx context.Context, token string) ([]*hcloud.ServerType, error) {
+		atomic.AddInt32(&calls, 1)
+		// give concurrent requests the chance to wait for this call
+		time.Sleep(10 * time.Millisecond)
+		return []*hcloud.ServerType{{Name: "cx11"}}, nil
+	}
+
+	testCases := []struct {
+		name          string
+		ttl           time.Duration
+		requests      []bool
+		expectedCalls int32
+	}{
+		{
+			name:          "cached listing is reused",
+			ttl:           time.Minute,
+			requests:      []bool{false, false, false},
+			expectedCalls: 1,
+		},
+		{
+			name:          "refresh bypasses the cache",
+			ttl:           time.Minute,
+			requests:      []bool{false, true, false},
+			expectedCalls: 2,
+		},
+		{
+			name:          "zero TTL disables the cache",
+			ttl:           0,
+			requests:      []bool{false, false},
+			expectedCalls: 2,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			atomic.StoreInt32(&calls, 0)
+			c := newHetznerSizeCache(tc.ttl, list)
+
+			for _, refresh := range tc.requests {
+				sizes, err := c.get(context.Background(), "token", refresh)
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if len(sizes) != 1 {
+					t.Fatalf("expected 1 size, got %d", len(sizes))
+				}
+			}
+
+			if calls != tc.expectedCalls {
+				t.Errorf("expected %d calls to the Hetzner API, got %d", tc.expectedCalls, calls)
+			}
+		})
+	}
+
+	t.Run("concurrent requests are collapsed", func(t *testing.T) {
+		atomic.StoreInt32(&calls, 0)
+		c := newHetznerSizeCache(0, list)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if _, err := c.get(context.Background(), "token", false); err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+			}()
+		}
+		wg.Wait()
+
+		if n := atomic.LoadInt32(&calls); n >= 10 {
+			t.Errorf("expected concurrent requests to share calls to the Hetzner API, got %d calls", n)
+		}
+	})
+
+	t.Run("canceled request does not cancel the shared listing", func(t *testing.T) {
+		release := make(chan struct{})
+		blockingList := func(ctx context.Context, token string) ([]*hcloud.ServerType, error) {
+			select {
+			case <-release:
+				return []*hcloud.ServerType{{Name: "cx11"}}, nil
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+		c := newHetznerSizeCache(time.Minute, blockingList)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		canceled := make(chan error)
+		go func() {
+			_, err := c.get(ctx, "token", false)
+			canceled <- err
+		}()
+
+		waiting := make(chan error)
+		go func() {
+			_, err := c.get(context.Background(), "token", false)
+			waiting <- err
+		}()
+
+		cancel()
+		if err := <-canceled; err != context.Canceled {
+			t.Fatalf("expected the canceled request to return %v, got %v", context.Canceled, err)
+		}
+
+		close(release)
+		if err := <-waiting; err != nil {
+			t.Fatalf("expected the waiting request to succeed, got %v", err)
+		}
+	})
+}
+
+func TestListHetznerServerTypesTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer server.Close()
+
+	SetHetznerRequestTimeout(50 * time.Millisecond)
+	defer SetHetznerRequestTimeout(DefaultHetznerRequestTimeout)
+
+	list := newHetznerServerTypeLister(hcloud.WithEndpoint(server.URL))
+	_, err := list(context.Background(), "token")
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	httpErr, ok := err.(errors.HTTPError)
+	if !ok {
+		t.Fatalf("expected an HTTP error, got %T: %v", err, err)
+	}
+	if httpErr.StatusCode() != http.StatusGatewayTimeout {
+		t.Errorf("expected status code %d, got %d", http.StatusGatewayTimeout, httpErr.StatusCode())
+	}
+}
+
+func TestListHetznerServerTypesErrors(t *testing.T) {
+	testCases := []struct {
+		name         string
+		status       int
+		code         string
+		expectedCode int
+	}{
+		{
+			name:         "invalid token",
+			status:       http.StatusUnauthorized,
+			code:         "unauthorized",
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "token without permissions",
+			status:       http.StatusForbidden,
+			code:         "forbidden",
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tc.status)
+				fmt.Fprintf(w, `{"error": {"code": %q, "message": "request failed"}}`, tc.code)
+			}))
+			defer server.Close()
+
+			list := newHetznerServerTypeLister(hcloud.WithEndpoint(server.URL))
+			_, err := list(context.Background(), "token")
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+
+			httpErr, ok := err.(errors.HTTPError)
+			if !ok {
+				t.Fatalf("expected an HTTP error, got %T: %v", err, err)
+			}
+			if httpErr.StatusCode() != tc.expectedCode {
+				t.Errorf("expected status code %d, got %d", tc.expectedCode, httpErr.StatusCode())
+			}
+		})
+	}
+}
+
+func TestHetznerSizeByName(t *testing.T) {
+	sizeList := hetznerSizeList([]*hcloud.ServerType{
+		{Name: "cx11"},
+		{Name: "ccx11"},
+		{Name: "cax11"},
+		{Name: "unknown"},
+	})
+
+	testCases := []struct {
+		name          string
+		size          string
+		expectedError bool
+	}{
+		{
+			name: "standard size",
+			size: "cx11",
+		},
+		{
+			name: "dedicated size",
+			size: "ccx11",
+		},
+		{
+			name: "ARM64 size",
+			size: "cax11",
+		},
+		{
+			name:          "size of an unknown family",
+			size:          "unknown",
+			expectedError: true,
+		},
+		{
+			name:          "missing size",
+			size:          "cx99",
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			size, err := hetznerSizeByName(sizeList, tc.size)
+			if tc.expectedError {
+				if _, ok := err.(*HetznerSizeNotFoundError); !ok {
+					t.Fatalf("expected a not found error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if size.Name != tc.size {
+				t.Errorf("expected size %q, got %q", tc.size, size.Name)
+			}
+		})
+	}
+}
+
+func TestFilterHetznerByFeatures(t *testing.T) {
+	sizeList := hetznerSizeList([]*hcloud.ServerType{
+		{Name: "cx11", CPUType: hcloud.CPUTypeShared, StorageType: hcloud.StorageTypeLocal},
+		{Name: "cx11-ceph", CPUType: hcloud.CPUTypeShared, StorageType: hcloud.StorageTypeNetwork},
+		{Name: "ccx12", CPUType: hcloud.CPUTypeDedicated, StorageType: hcloud.StorageTypeLocal},
+		{Name: "cax11", CPUType: hcloud.CPUTypeShared, StorageType: hcloud.StorageTypeLocal},
+	})
+
+	names := func(sizeList apiv1.HetznerSizeList) []string {
+		var result []string
+		for _, sizes := range [][]apiv1.HetznerSize{sizeList.Standard, sizeList.Dedicated, sizeList.ARM64} {
+			for _, s := range sizes {
+				result = append(result, s.Name)
+			}
+		}
+		return result
+	}
+
+	testCases := []struct {
+		name          string
+		features      []string
+		expectedSizes []string
+	}{
+		{
+			name:          "no features requested",
+			expectedSizes: []string{"cx11", "cx11-ceph", "ccx12", "cax11"},
+		},
+		{
+			name:          "dedicated CPU",
+			features:      []string{HetznerFeatureDedicatedCPU},
+			expectedSizes: []string{"ccx12"},
+		},
+		{
+			name:          "local storage",
+			features:      []string{HetznerFeatureLocalStorage},
+			expectedSizes: []string{"cx11", "ccx12", "cax11"},
+		},
+		{
+			name:          "dedicated CPU and local storage",
+			features:      []string{HetznerFeatureDedicatedCPU, HetznerFeatureLocalStorage},
+			expectedSizes: []string{"ccx12"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := names(filterHetznerByFeatures(sizeList, tc.features)); !reflect.DeepEqual(tc.expectedSizes, got) {
+				t.Errorf("expected sizes %v, got %v", tc.expectedSizes, got)
+			}
+		})
+	}
+}
+
+func TestHetznerToSizeList(t *testing.T) {
+	sizeList := apiv1.HetznerSizeList{
+		Standard:  []apiv1.HetznerSize{{ID: 1, Name: "cx11", Cores: 1, Memory: 2, Disk: 20, Architecture: hetznerArchitectureAMD64}},
+		Dedicated: []apiv1.HetznerSize{{ID: 2, Name: "ccx12", Cores: 2, Memory: 8, Disk: 80, Architecture: hetznerArchitectureAMD64}},
+		ARM64:     []apiv1.HetznerSize{{ID: 3, Name: "cax11", Cores: 2, Memory: 4, Disk: 40, Architecture: hetznerArchitectureARM64}},
+	}
+
+	expected := apiv1.SizeList{
+		{Name: "cx11", Category: "standard", Cores: 1, Memory: 2, Disk: 20, Architecture: hetznerArchitectureAMD64},
+		{Name: "ccx12", Category: "dedicated", Cores: 2, Memory: 8, Disk: 80, Architecture: hetznerArchitectureAMD64},
+		{Name: "cax11", Category: "arm64", Cores: 2, Memory: 4, Disk: 40, Architecture: hetznerArchitectureARM64},
+	}
+
+	if got := hetznerToSizeList(sizeList); !reflect.DeepEqual(expected, got) {
+		t.Errorf("expected sizes %v, got %v", expected, got)
+	}
+}
@@ -30,6 +30,7 @@ import (
 	handlercommon "k8c.io/kubermatic/v2/pkg/handler/common"
 	"k8c.io/kubermatic/v2/pkg/handler/middleware"
 	"k8c.io/kubermatic/v2/pkg/handler/v1/common"
+	"k8c.io/kubermatic/v2/pkg/handler/v1/dc"
 	"k8c.io/kubermatic/v2/pkg/provider"
 	"k8c.io/kubermatic/v2/pkg/provider/cloud/packet"
 	kubernetesprovider "k8c.io/kubermatic/v2/pkg/provider/kubernetes"
@@ -41,7 +42,17 @@ type plansRoot struct {
 	Plans []packngo.Plan `json:"plans"`
 }
 
-func PacketSizesWithClusterCredentialsEndpoint(ctx context.Context, userInfoGetter provider.UserInfoGetter, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, settingsProvider provider.SettingsProvider, projectID, clusterID string) (interface{}, error) {
+// packetCapacityUnavailable is the capacity level of plans which are sold out in a facility.
+const packetCapacityUnavailable = "unavailable"
+
+// Used to decode the capacity response, which contains the capacity level per facility and plan
+type capacityRoot struct {
+	Capacity map[string]map[string]struct {
+		Level string `json:"level"`
+	} `json:"capacity"`
+}
+
+func PacketSizesWithClusterCredentialsEndpoint(ctx context.Context, userInfoGetter provider.UserInfoGetter, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, seedsGetter provider.SeedsGetter, settingsProvider provider.SettingsProvider, projectID, clusterID string) (interface{}, error) {
 
 	clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
 	cluster, err := handlercommon.GetCluster(ctx, projectProvider, privilegedProjectProvider, userInfoGetter, projectID, clusterID, &provider.ClusterGetOptions{CheckInitStatus: true})
@@ -52,6 +63,18 @@ func PacketSizesWithClusterCredentialsEndpoint(ctx context.Context, userInfoGett
 		return nil, errors.NewNotFound("cloud spec for ", clusterID)
 	}
 
+	userInfo, err := userInfoGetter(ctx, "")
+	if err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+	datacenter, err := dc.GetDatacenter(userInfo, seedsGetter, cluster.Spec.Cloud.DatacenterName)
+	if err != nil {
+		return nil, errors.New(http.StatusInternalServerError, err.Error())
+	}
+	if datacenter.Spec.Packet == nil {
+		return nil, errors.NewNotFound("cloud spec (dc) for ", clusterID)
+	}
+
 	assertedClusterProvider, ok := clusterProvider.(*kubernetesprovider.ClusterProvider)
 	if !ok {
 		return nil, errors.New(http.StatusInternalServerError, "clusterprovider is not a kubernetesprovider.Clusterprovider")
@@ -67,11 +90,13 @@ func PacketSizesWithClusterCredentialsEndpoint(ctx context.Context, userInfoGett
 		return nil, common.KubernetesErrorToHTTPError(err)
 	}
 
-	return PacketSizes(apiKey, projectID, settings.Spec.MachineDeploymentVMResourceQuota)
+	return PacketSizes(apiKey, projectID, datacenter.Spec.Packet.Facilities, settings.Spec.MachineDeploymentVMResourceQuota)
 
 }
 
-func PacketSizes(apiKey, projectID string, quota kubermaticv1.MachineDeploymentVMResourceQuota) (apiv1.PacketSizeList, error) {
+// PacketSizes lists the plans of the project. If facilities are given, plans which are sold
+// out in all of them are marked as not available.
+func PacketSizes(apiKey, projectID string, facilities []string, quota kubermaticv1.MachineDeploymentVMResourceQuota) (apiv1.PacketSizeList, error) {
 	sizes := apiv1.PacketSizeList{}
 	root := new(plansRoot)
 
@@ -94,9 +119,22 @@ func PacketSizes(apiKey, projectID string, quota kubermaticv1.MachineDeploymentV
 		return sizes, err
 	}
 
+	capacity := &capacityRoot{}
+	if len(facilities) > 0 {
+		req, err := client.NewRequest("GET", "/capacity", nil)
+		if err != nil {
+			return sizes, err
+		}
+		if _, err := client.Do(req, capacity); err != nil {
+			return sizes, fmt.Errorf("failed to get capacity: %v", err)
+		}
+	}
+
 	plans := root.Plans
 	for _, plan := range plans {
-		sizes = append(sizes, toPacketSize(plan))
+		size := toPacketSize(plan)
+		size.Available = len(facilities) == 0 || packetPlanAvailable(capacity, facilities, plan.Slug)
+		sizes = append(sizes, size)
 	}
 
 	return filterPacketByQuota(sizes, quota), nil
@@ -153,10 +191,29 @@ func toPacketSize(plan packngo.Plan) apiv1.PacketSize {
 		})
 	}
 
+	var price *apiv1.PacketSizePrice
+	if plan.Pricing != nil {
+		price = &apiv1.PacketSizePrice{
+			Hourly:  float64(plan.Pricing.Hour),
+			Monthly: float64(plan.Pricing.Month),
+		}
+	}
+
 	return apiv1.PacketSize{
 		Name:   plan.Name,
 		CPUs:   cpus,
 		Memory: memory,
 		Drives: drives,
+		Price:  price,
+	}
+}
+
+// packetPlanAvailable returns true if the plan is not sold out in at least one of the facilities.
+func packetPlanAvailable(capacity *capacityRoot, facilities []string, slug string) bool {
+	for _, facility := range facilities {
+		if level, ok := capacity.Capacity[facility][slug]; ok && level.Level != packetCapacityUnavailable {
+			return true
+		}
 	}
+	return false
 }
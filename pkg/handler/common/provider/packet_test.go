@@ -0,0 +1,119 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/packethost/packngo"
+
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+)
+
+func TestPacketPlanAvailable(t *testing.T) {
+	capacity := &capacityRoot{}
+	if err := json.Unmarshal([]byte(`{
+		"capacity": {
+			"ams1": {
+				"c3.small.x86": {"level": "unavailable"},
+				"m3.large.x86": {"level": "limited"}
+			},
+			"ewr1": {
+				"c3.small.x86": {"level": "normal"},
+				"m3.large.x86": {"level": "unavailable"}
+			}
+		}
+	}`), capacity); err != nil {
+		t.Fatalf("failed to decode capacity: %v", err)
+	}
+
+	testCases := []struct {
+		name       string
+		facilities []string
+		slug       string
+		available  bool
+	}{
+		{
+			name:       "available in the facility",
+			facilities: []string{"ams1"},
+			slug:       "m3.large.x86",
+			available:  true,
+		},
+		{
+			name:       "sold out in the facility",
+			facilities: []string{"ams1"},
+			slug:       "c3.small.x86",
+			available:  false,
+		},
+		{
+			name:       "sold out in one of the facilities",
+			facilities: []string{"ams1", "ewr1"},
+			slug:       "c3.small.x86",
+			available:  true,
+		},
+		{
+			name:       "plan not offered in the facility",
+			facilities: []string{"ewr1"},
+			slug:       "s3.xlarge.x86",
+			available:  false,
+		},
+		{
+			name:       "unknown facility",
+			facilities: []string{"fra2"},
+			slug:       "c3.small.x86",
+			available:  false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if available := packetPlanAvailable(capacity, tc.facilities, tc.slug); available != tc.available {
+				t.Errorf("expected available to be %t, got %t", tc.available, available)
+			}
+		})
+	}
+}
+
+func TestToPacketSizePrice(t *testing.T) {
+	testCases := []struct {
+		name          string
+		pricing       *packngo.Pricing
+		expectedPrice *apiv1.PacketSizePrice
+	}{
+		{
+			name:          "no pricing",
+			pricing:       nil,
+			expectedPrice: nil,
+		},
+		{
+			name:          "hourly and monthly pricing",
+			pricing:       &packngo.Pricing{Hour: 0.5, Month: 365},
+			expectedPrice: &apiv1.PacketSizePrice{Hourly: 0.5, Monthly: 365},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			size := toPacketSize(packngo.Plan{Name: "c3.small.x86", Pricing: tc.pricing})
+			if !reflect.DeepEqual(size.Price, tc.expectedPrice) {
+				t.Errorf("expected price %+v, got %+v", tc.expectedPrice, size.Price)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	handlercommon "k8c.io/kubermatic/v2/pkg/handler/common"
+	"k8c.io/kubermatic/v2/pkg/handler/middleware"
+	"k8c.io/kubermatic/v2/pkg/handler/v1/common"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	kubernetesprovider "k8c.io/kubermatic/v2/pkg/provider/kubernetes"
+	"k8c.io/kubermatic/v2/pkg/util/errors"
+)
+
+// SizeCredentials gives a SizeProvider access to the credentials of a cluster.
+type SizeCredentials struct {
+	Cloud           kubermaticv1.CloudSpec
+	SecretKeyGetter provider.SecretKeySelectorValueFunc
+}
+
+// SizeListOptions are the options which apply to the sizes of every provider.
+type SizeListOptions struct {
+	Quota kubermaticv1.MachineDeploymentVMResourceQuota
+	// Refresh bypasses the caches of the provider
+	Refresh bool
+}
+
+// SizeProvider lists the node sizes offered by a cloud provider.
+type SizeProvider interface {
+	ListSizes(ctx context.Context, credentials SizeCredentials, opts SizeListOptions) (apiv1.SizeList, error)
+}
+
+// sizeProviders returns the size providers by the name of their cloud provider.
+func sizeProviders(hetznerSizeCache *HetznerSizeCache) map[string]SizeProvider {
+	return map[string]SizeProvider{
+		provider.HetznerCloudProvider: hetznerSizeProvider{sizeCache: hetznerSizeCache},
+	}
+}
+
+// SizeWithClusterCredentialsEndpoint lists the sizes of the cloud provider of the cluster.
+func SizeWithClusterCredentialsEndpoint(ctx context.Context, userInfoGetter provider.UserInfoGetter, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, settingsProvider provider.SettingsProvider, hetznerSizeCache *HetznerSizeCache, projectID, clusterID string, refresh bool) (interface{}, error) {
+	clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
+
+	cluster, err := handlercommon.GetCluster(ctx, projectProvider, privilegedProjectProvider, userInfoGetter, projectID, clusterID, &provider.ClusterGetOptions{CheckInitStatus: true})
+	if err != nil {
+		return nil, err
+	}
+
+	assertedClusterProvider, ok := clusterProvider.(*kubernetesprovider.ClusterProvider)
+	if !ok {
+		return nil, errors.New(http.StatusInternalServerError, "failed to assert clusterProvider")
+	}
+
+	settings, err := settingsProvider.GetGlobalSettings()
+	if err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+
+	credentials := SizeCredentials{
+		Cloud:           cluster.Spec.Cloud,
+		SecretKeyGetter: provider.SecretKeySelectorValueFuncFactory(ctx, assertedClusterProvider.GetSeedClusterAdminRuntimeClient()),
+	}
+	opts := SizeListOptions{
+		Quota:   settings.Spec.MachineDeploymentVMResourceQuota,
+		Refresh: refresh,
+	}
+
+	return listSizes(ctx, sizeProviders(hetznerSizeCache), credentials, opts)
+}
+
+// listSizes dispatches to the size provider of the cloud spec.
+func listSizes(ctx context.Context, providers map[string]SizeProvider, credentials SizeCredentials, opts SizeListOptions) (apiv1.SizeList, error) {
+	name, err := provider.ClusterCloudProviderName(credentials.Cloud)
+	if err != nil {
+		return nil, errors.NewBadRequest("invalid cloud spec: %v", err)
+	}
+
+	sizeProvider, ok := providers[name]
+	if !ok {
+		return nil, errors.NewBadRequest("listing sizes is not supported for the cloud provider %q", name)
+	}
+
+	return sizeProvider.ListSizes(ctx, credentials, opts)
+}
+
+// DecodeRefresh decodes the query parameter which bypasses the caches of a listing.
+func DecodeRefresh(r *http.Request) (bool, error) {
+	queryParam := r.URL.Query().Get("refresh")
+	if queryParam == "" {
+		return false, nil
+	}
+
+	refresh, err := strconv.ParseBool(queryParam)
+	if err != nil {
+		return false, errors.NewBadRequest("invalid value for refresh: %v", err)
+	}
+	return refresh, nil
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/provider"
+	"k8c.io/kubermatic/v2/pkg/util/errors"
+)
+
+type fakeSizeProvider struct {
+	sizes apiv1.SizeList
+}
+
+func (p fakeSizeProvider) ListSizes(ctx context.Context, credentials SizeCredentials, opts SizeListOptions) (apiv1.SizeList, error) {
+	return p.sizes, nil
+}
+
+func TestListSizes(t *testing.T) {
+	sizes := apiv1.SizeList{{Name: "cx11", Category: "standard", Cores: 1, Memory: 2, Disk: 20}}
+	providers := map[string]SizeProvider{
+		provider.HetznerCloudProvider: fakeSizeProvider{sizes: sizes},
+	}
+
+	testCases := []struct {
+		name          string
+		cloud         kubermaticv1.CloudSpec
+		expectedSizes apiv1.SizeList
+		expectedCode  int
+	}{
+		{
+			name:          "registered provider",
+			cloud:         kubermaticv1.CloudSpec{Hetzner: &kubermaticv1.HetznerCloudSpec{}},
+			expectedSizes: sizes,
+		},
+		{
+			name:         "provider without sizes",
+			cloud:        kubermaticv1.CloudSpec{AWS: &kubermaticv1.AWSCloudSpec{}},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "multiple providers",
+			cloud:        kubermaticv1.CloudSpec{AWS: &kubermaticv1.AWSCloudSpec{}, Hetzner: &kubermaticv1.HetznerCloudSpec{}},
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := listSizes(context.Background(), providers, SizeCredentials{Cloud: tc.cloud}, SizeListOptions{})
+			if tc.expectedCode != 0 {
+				httpErr, ok := err.(errors.HTTPError)
+				if !ok {
+					t.Fatalf("expected an HTTP error, got %T: %v", err, err)
+				}
+				if httpErr.StatusCode() != tc.expectedCode {
+					t.Errorf("expected status code %d, got %d", tc.expectedCode, httpErr.StatusCode())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(result, tc.expectedSizes) {
+				t.Errorf("expected sizes %v, got %v", tc.expectedSizes, result)
+			}
+		})
+	}
+}
@@ -67,6 +67,11 @@ func GetUpgradesEndpoint(ctx context.Context, userInfoGetter provider.UserInfoGe
 
 	upgrades := make([]*apiv1.MasterVersion, 0)
 	for _, v := range versions {
+		// updates to deprecated versions are rejected by the controller
+		if v.Deprecated {
+			continue
+		}
+
 		isRestricted := false
 		isRestricted, err = isRestrictedByKubeletVersions(v, machineDeployments.Items)
 		if err != nil {
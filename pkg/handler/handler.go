@@ -21,13 +21,17 @@ import (
 	"encoding/json"
 	"net/http"
 	"reflect"
+	"strconv"
 
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
 	"k8c.io/kubermatic/v2/pkg/log"
 	"k8c.io/kubermatic/v2/pkg/util/errors"
 )
 
 const (
 	headerContentType = "Content-Type"
+	headerTotalCount  = "X-Total-Count"
+	headerETag        = "ETag"
 
 	contentTypeJSON = "application/json"
 )
@@ -111,6 +115,34 @@ func EncodeJSON(c context.Context, w http.ResponseWriter, response interface{})
 	return json.NewEncoder(w).Encode(response)
 }
 
+// EncodeDatacenterListPaginated writes the total number of datacenters to the X-Total-Count
+// header and the page of datacenters as body
+func EncodeDatacenterListPaginated(c context.Context, w http.ResponseWriter, response interface{}) error {
+	page, ok := response.(apiv1.DatacenterListPaginated)
+	if !ok {
+		return EncodeJSON(c, w, response)
+	}
+
+	w.Header().Set(headerTotalCount, strconv.Itoa(page.TotalCount))
+	return EncodeJSON(c, w, page.Body)
+}
+
+// EncodeClusterStatusSummaryList writes the ETag of the cluster statuses to the ETag header
+// and only writes the statuses if they were modified
+func EncodeClusterStatusSummaryList(c context.Context, w http.ResponseWriter, response interface{}) error {
+	list, ok := response.(apiv1.ClusterStatusSummaryList)
+	if !ok {
+		return EncodeJSON(c, w, response)
+	}
+
+	w.Header().Set(headerETag, list.ETag)
+	if list.NotModified {
+		w.WriteHeader(http.StatusNotModified)
+		return nil
+	}
+	return EncodeJSON(c, w, list.Body)
+}
+
 // statusOK returns the status code 200
 func statusOK(res http.ResponseWriter, _ *http.Request) {
 	res.WriteHeader(http.StatusOK)
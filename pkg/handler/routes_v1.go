@@ -45,8 +45,10 @@ import (
 
 	"github.com/go-kit/kit/endpoint"
 	httptransport "github.com/go-kit/kit/transport/http"
+	"github.com/gorilla/handlers"
 	"github.com/gorilla/mux"
 
+	providercommon "k8c.io/kubermatic/v2/pkg/handler/common/provider"
 	"k8c.io/kubermatic/v2/pkg/handler/middleware"
 	v1 "k8c.io/kubermatic/v2/pkg/handler/v1"
 	"k8c.io/kubermatic/v2/pkg/handler/v1/addon"
@@ -74,14 +76,19 @@ func (r Routing) RegisterV1(mux *mux.Router, metrics common.ServerMetrics) {
 		HandlerFunc(statusOK)
 	//
 	// Defines endpoints for managing data centers
+	// The datacenter lists can get large, they are compressed if the client accepts it
 	mux.Methods(http.MethodGet).
 		Path("/dc").
-		Handler(r.datacentersHandler())
+		Handler(handlers.CompressHandler(r.datacentersHandler()))
 
 	mux.Methods(http.MethodGet).
 		Path("/dc/{dc}").
 		Handler(r.datacenterHandler())
 
+	mux.Methods(http.MethodGet).
+		Path("/datacenters").
+		Handler(handlers.CompressHandler(r.listDatacentersPaginated()))
+
 	mux.Methods(http.MethodGet).
 		Path("/seed/{seed_name}/dc").
 		Handler(r.listDCForSeed())
@@ -289,6 +296,10 @@ func (r Routing) RegisterV1(mux *mux.Router, metrics common.ServerMetrics) {
 		Path("/projects/{project_id}/dc/{dc}/clusters/{cluster_id}/events").
 		Handler(r.getClusterEvents())
 
+	mux.Methods(http.MethodGet).
+		Path("/projects/{project_id}/dc/{dc}/clusters/{cluster_id}/timeline").
+		Handler(r.getClusterTimeline())
+
 	mux.Methods(http.MethodGet).
 		Path("/projects/{project_id}/dc/{dc}/clusters/{cluster_id}/kubeconfig").
 		Handler(r.getClusterKubeconfig())
@@ -305,6 +316,10 @@ func (r Routing) RegisterV1(mux *mux.Router, metrics common.ServerMetrics) {
 		Path("/projects/{project_id}/dc/{dc}/clusters/{cluster_id}/health").
 		Handler(r.getClusterHealth())
 
+	mux.Methods(http.MethodGet).
+		Path("/projects/{project_id}/dc/{dc}/clusters/{cluster_id}/rootca").
+		Handler(r.getClusterRootCA())
+
 	mux.Methods(http.MethodGet).
 		Path("/projects/{project_id}/dc/{dc}/clusters/{cluster_id}/upgrades").
 		Handler(r.getClusterUpgrades())
@@ -415,6 +430,10 @@ func (r Routing) RegisterV1(mux *mux.Router, metrics common.ServerMetrics) {
 		Path("/projects/{project_id}/dc/{dc}/clusters/{cluster_id}/viewertoken").
 		Handler(r.revokeClusterViewerToken())
 
+	mux.Methods(http.MethodPost).
+		Path("/projects/{project_id}/dc/{dc}/clusters/{cluster_id}/reconcile").
+		Handler(r.reconcileCluster())
+
 	//
 	// Defines a set of HTTP endpoint for node deployments that belong to a cluster
 	mux.Methods(http.MethodPost).
@@ -522,6 +541,10 @@ func (r Routing) RegisterV1(mux *mux.Router, metrics common.ServerMetrics) {
 		Path("/projects/{project_id}/dc/{dc}/clusters/{cluster_id}/providers/hetzner/sizes").
 		Handler(r.listHetznerSizesNoCredentials())
 
+	mux.Methods(http.MethodGet).
+		Path("/projects/{project_id}/dc/{dc}/clusters/{cluster_id}/providers/sizes").
+		Handler(r.listSizesNoCredentials())
+
 	mux.Methods(http.MethodGet).
 		Path("/projects/{project_id}/dc/{dc}/clusters/{cluster_id}/providers/digitalocean/sizes").
 		Handler(r.listDigitaloceanSizesNoCredentials())
@@ -643,6 +666,10 @@ func (r Routing) RegisterV1(mux *mux.Router, metrics common.ServerMetrics) {
 		Path("/upgrades/cluster").
 		Handler(r.getMasterVersions())
 
+	mux.Methods(http.MethodGet).
+		Path("/upgrades/cluster/overview").
+		Handler(r.getMasterVersionOverview())
+
 	mux.Methods(http.MethodGet).
 		Path("/upgrades/node").
 		Handler(r.getNodeUpgrades())
@@ -1143,7 +1170,7 @@ func (r Routing) listPacketSizesNoCredentials() http.Handler {
 			middleware.UserSaver(r.userProvider),
 			middleware.SetClusterProvider(r.clusterProviderGetter, r.seedsGetter),
 			middleware.SetPrivilegedClusterProvider(r.clusterProviderGetter, r.seedsGetter),
-		)(provider.PacketSizesWithClusterCredentialsEndpoint(r.projectProvider, r.privilegedProjectProvider, r.userInfoGetter, r.settingsProvider)),
+		)(provider.PacketSizesWithClusterCredentialsEndpoint(r.projectProvider, r.privilegedProjectProvider, r.seedsGetter, r.userInfoGetter, r.settingsProvider)),
 		provider.DecodePacketSizesNoCredentialsReq,
 		EncodeJSON,
 		r.defaultServerOptions()...,
@@ -1275,7 +1302,7 @@ func (r Routing) listHetznerSizes() http.Handler {
 		endpoint.Chain(
 			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
 			middleware.UserSaver(r.userProvider),
-		)(provider.HetznerSizeEndpoint(r.presetsProvider, r.userInfoGetter, r.settingsProvider)),
+		)(provider.HetznerSizeEndpoint(r.presetsProvider, r.userInfoGetter, r.settingsProvider, r.hetznerSizeCache)),
 		provider.DecodeHetznerSizesReq,
 		EncodeJSON,
 		r.defaultServerOptions()...,
@@ -1340,12 +1367,34 @@ func (r Routing) datacentersHandler() http.Handler {
 			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
 			middleware.UserSaver(r.userProvider),
 		)(dc.ListEndpoint(r.seedsGetter, r.userInfoGetter)),
-		common.DecodeEmptyReq,
+		dc.DecodeListDCReq,
 		EncodeJSON,
 		r.defaultServerOptions()...,
 	)
 }
 
+// swagger:route GET /api/v1/datacenters datacenter listDatacentersPaginated
+//
+//     Lists a page of datacenters. The total number of datacenters is returned in the X-Total-Count header.
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: DatacenterListPaginated
+func (r Routing) listDatacentersPaginated() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
+			middleware.UserSaver(r.userProvider),
+		)(dc.ListPaginatedEndpoint(r.seedsGetter, r.userInfoGetter)),
+		dc.DecodeListDCPaginatedReq,
+		EncodeDatacenterListPaginated,
+		r.defaultServerOptions()...,
+	)
+}
+
 // Get the datacenter
 // swagger:route GET /api/v1/dc/{dc} datacenter getDatacenter
 //
@@ -1611,6 +1660,28 @@ func (r Routing) getMasterVersions() http.Handler {
 	)
 }
 
+// swagger:route GET /api/v1/upgrades/cluster/overview versions getMasterVersionOverview
+//
+// Lists all versions which don't result in automatic updates together with the updates allowed between them
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: MasterVersionOverview
+func (r Routing) getMasterVersionOverview() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
+			middleware.UserSaver(r.userProvider),
+		)(cluster.GetMasterVersionOverviewEndpoint(r.updateManager)),
+		cluster.DecodeClusterTypeReq,
+		EncodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
+
 // swagger:route GET /api/v1/version versions getKubermaticVersion
 //
 // Get versions of running Kubermatic components.
@@ -1783,7 +1854,7 @@ func (r Routing) createCluster() http.Handler {
 			middleware.UserSaver(r.userProvider),
 			middleware.SetClusterProvider(r.clusterProviderGetter, r.seedsGetter),
 			middleware.SetPrivilegedClusterProvider(r.clusterProviderGetter, r.seedsGetter),
-		)(cluster.CreateEndpoint(r.projectProvider, r.privilegedProjectProvider, r.seedsGetter, r.presetsProvider, r.exposeStrategy, r.userInfoGetter, r.settingsProvider, r.updateManager)),
+		)(cluster.CreateEndpoint(r.projectProvider, r.privilegedProjectProvider, r.seedsGetter, r.presetsProvider, r.exposeStrategy, r.userInfoGetter, r.settingsProvider, r.updateManager, providercommon.HetznerNodeDeploymentValidator(r.hetznerSizeCache))),
 		cluster.DecodeCreateReq,
 		SetStatusCreatedHeader(EncodeJSON),
 		r.defaultServerOptions()...,
@@ -1918,6 +1989,33 @@ func (r Routing) getClusterEvents() http.Handler {
 	)
 }
 
+// getClusterTimeline returns the provisioning history of the cluster.
+// swagger:route GET /api/v1/projects/{project_id}/dc/{dc}/clusters/{cluster_id}/timeline project getClusterTimeline
+//
+//     Gets the events of the specified cluster and of its control plane components in chronological order.
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: []Event
+//       401: empty
+//       403: empty
+func (r Routing) getClusterTimeline() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
+			middleware.UserSaver(r.userProvider),
+			middleware.SetClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+			middleware.SetPrivilegedClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+		)(cluster.GetClusterTimelineEndpoint(r.projectProvider, r.privilegedProjectProvider, r.userInfoGetter)),
+		common.DecodeGetClusterReq,
+		EncodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
+
 // getClusterKubeconfig returns the kubeconfig for the cluster.
 // swagger:route GET /api/v1/projects/{project_id}/dc/{dc}/clusters/{cluster_id}/kubeconfig project getClusterKubeconfig
 //
@@ -1999,6 +2097,33 @@ func (r Routing) deleteCluster() http.Handler {
 	)
 }
 
+// getClusterRootCA returns the root CA certificate of the cluster.
+// swagger:route GET /api/v1/projects/{project_id}/dc/{dc}/clusters/{cluster_id}/rootca project getClusterRootCA
+//
+//     Gets the PEM encoded root CA certificate of the specified cluster.
+//
+//     Produces:
+//     - application/x-pem-file
+//
+//     Responses:
+//       default: errorResponse
+//       200: RootCA
+//       401: empty
+//       403: empty
+func (r Routing) getClusterRootCA() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
+			middleware.UserSaver(r.userProvider),
+			middleware.SetClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+			middleware.SetPrivilegedClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+		)(cluster.GetRootCAEndpoint(r.projectProvider, r.privilegedProjectProvider, r.userInfoGetter)),
+		common.DecodeGetClusterReq,
+		cluster.EncodeRootCA,
+		r.defaultServerOptions()...,
+	)
+}
+
 // swagger:route GET /api/v1/projects/{project_id}/dc/{dc}/clusters/{cluster_id}/health project getClusterHealth
 //
 //     Returns the cluster's component health status
@@ -2165,6 +2290,32 @@ func (r Routing) revokeClusterViewerToken() http.Handler {
 	)
 }
 
+// swagger:route POST /api/v1/projects/{project_id}/dc/{dc}/clusters/{cluster_id}/reconcile project reconcileCluster
+//
+//     Makes the controllers reconcile the cluster right away, optionally clearing the error of the cluster to retry it
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: empty
+//       401: empty
+//       403: empty
+func (r Routing) reconcileCluster() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
+			middleware.UserSaver(r.userProvider),
+			middleware.SetClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+			middleware.SetPrivilegedClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+		)(cluster.ReconcileEndpoint(r.projectProvider, r.privilegedProjectProvider, r.userInfoGetter)),
+		cluster.DecodeReconcileReq,
+		EncodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
+
 // swagger:route GET /api/v1/projects/{project_id}/dc/{dc}/clusters/{cluster_id}/upgrades project getClusterUpgrades
 //
 //    Gets possible cluster upgrades
@@ -2695,7 +2846,7 @@ func (r Routing) listAWSSizesNoCredentials() http.Handler {
 			middleware.SetClusterProvider(r.clusterProviderGetter, r.seedsGetter),
 			middleware.SetPrivilegedClusterProvider(r.clusterProviderGetter, r.seedsGetter),
 		)(provider.AWSSizeNoCredentialsEndpoint(r.projectProvider, r.privilegedProjectProvider, r.seedsGetter, r.settingsProvider, r.userInfoGetter)),
-		common.DecodeGetClusterReq,
+		provider.DecodeAWSSizeNoCredentialsReq,
 		EncodeJSON,
 		r.defaultServerOptions()...,
 	)
@@ -2862,13 +3013,37 @@ func (r Routing) listHetznerSizesNoCredentials() http.Handler {
 			middleware.UserSaver(r.userProvider),
 			middleware.SetClusterProvider(r.clusterProviderGetter, r.seedsGetter),
 			middleware.SetPrivilegedClusterProvider(r.clusterProviderGetter, r.seedsGetter),
-		)(provider.HetznerSizeWithClusterCredentialsEndpoint(r.projectProvider, r.privilegedProjectProvider, r.userInfoGetter, r.settingsProvider)),
+		)(provider.HetznerSizeWithClusterCredentialsEndpoint(r.projectProvider, r.privilegedProjectProvider, r.userInfoGetter, r.settingsProvider, r.hetznerSizeCache)),
 		provider.DecodeHetznerSizesNoCredentialsReq,
 		EncodeJSON,
 		r.defaultServerOptions()...,
 	)
 }
 
+// swagger:route GET /api/v1/projects/{project_id}/dc/{dc}/clusters/{cluster_id}/providers/sizes project listSizesNoCredentials
+//
+// Lists the sizes of the cloud provider of the cluster
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       default: errorResponse
+//       200: SizeList
+func (r Routing) listSizesNoCredentials() http.Handler {
+	return httptransport.NewServer(
+		endpoint.Chain(
+			middleware.TokenVerifier(r.tokenVerifiers, r.userProvider),
+			middleware.UserSaver(r.userProvider),
+			middleware.SetClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+			middleware.SetPrivilegedClusterProvider(r.clusterProviderGetter, r.seedsGetter),
+		)(provider.SizeWithClusterCredentialsEndpoint(r.projectProvider, r.privilegedProjectProvider, r.userInfoGetter, r.settingsProvider, r.hetznerSizeCache)),
+		provider.DecodeSizesNoCredentialsReq,
+		EncodeJSON,
+		r.defaultServerOptions()...,
+	)
+}
+
 // swagger:route GET /api/v1/projects/{project_id}/dc/{dc}/clusters/{cluster_id}/providers/digitalocean/sizes digitalocean listDigitaloceanSizesNoCredentials
 //
 // Lists sizes from digitalocean
@@ -26,6 +26,7 @@ import (
 
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
 	"k8c.io/kubermatic/v2/pkg/handler/auth"
+	providercommon "k8c.io/kubermatic/v2/pkg/handler/common/provider"
 	"k8c.io/kubermatic/v2/pkg/handler/middleware"
 	"k8c.io/kubermatic/v2/pkg/handler/v1/common"
 	"k8c.io/kubermatic/v2/pkg/provider"
@@ -75,6 +76,7 @@ type Routing struct {
 	admissionPluginProvider               provider.AdmissionPluginsProvider
 	settingsWatcher                       watcher.SettingsWatcher
 	userWatcher                           watcher.UserWatcher
+	hetznerSizeCache                      *providercommon.HetznerSizeCache
 }
 
 // NewRouting creates a new Routing.
@@ -116,6 +118,7 @@ func NewRouting(routingParams RoutingParams) Routing {
 		admissionPluginProvider:               routingParams.AdmissionPluginProvider,
 		settingsWatcher:                       routingParams.SettingsWatcher,
 		userWatcher:                           routingParams.UserWatcher,
+		hetznerSizeCache:                      routingParams.HetznerSizeCache,
 		versions:                              routingParams.Versions,
 	}
 }
@@ -169,5 +172,6 @@ type RoutingParams struct {
 	ConstraintTemplateProvider            provider.ConstraintTemplateProvider
 	ConstraintProvider                    provider.ConstraintProvider
 	PrivilegedConstraintProvider          provider.PrivilegedConstraintProvider
+	HetznerSizeCache                      *providercommon.HetznerSizeCache
 	Versions                              kubermatic.Versions
 }
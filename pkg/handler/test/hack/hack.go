@@ -29,6 +29,7 @@ import (
 
 	"k8c.io/kubermatic/v2/pkg/handler"
 	"k8c.io/kubermatic/v2/pkg/handler/auth"
+	providercommon "k8c.io/kubermatic/v2/pkg/handler/common/provider"
 	"k8c.io/kubermatic/v2/pkg/handler/test"
 	"k8c.io/kubermatic/v2/pkg/handler/v1/common"
 	kubermaticlog "k8c.io/kubermatic/v2/pkg/log"
@@ -126,6 +127,7 @@ func NewTestRouting(
 		ConstraintTemplateProvider:            constraintTemplateProvider,
 		ConstraintProvider:                    constraintProvider,
 		PrivilegedConstraintProvider:          privilegedConstraintProvider,
+		HetznerSizeCache:                      providercommon.NewHetznerSizeCache(0),
 		Versions:                              kubermaticVersions,
 	}
 
@@ -1013,6 +1013,9 @@ func CompareVersions(t *testing.T, versions, expected []*apiv1.MasterVersion) {
 		if v.Default != expected[i].Default {
 			t.Fatalf("expected flag %v got %v", expected[i].Default, v.Default)
 		}
+		if v.Deprecated != expected[i].Deprecated {
+			t.Fatalf("expected deprecated flag %v got %v", expected[i].Deprecated, v.Deprecated)
+		}
 	}
 }
 
@@ -39,7 +39,8 @@ import (
 )
 
 func CreateEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, seedsGetter provider.SeedsGetter, credentialManager provider.PresetProvider,
-	exposeStrategy kubermaticv1.ExposeStrategy, userInfoGetter provider.UserInfoGetter, settingsProvider provider.SettingsProvider, updateManager common.UpdateManager) endpoint.Endpoint {
+	exposeStrategy kubermaticv1.ExposeStrategy, userInfoGetter provider.UserInfoGetter, settingsProvider provider.SettingsProvider, updateManager common.UpdateManager,
+	validateNodeDeployment handlercommon.NodeDeploymentValidator) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(CreateReq)
 		globalSettings, err := settingsProvider.GetGlobalSettings()
@@ -51,7 +52,7 @@ func CreateEndpoint(projectProvider provider.ProjectProvider, privilegedProjectP
 			return nil, errors.NewBadRequest(err.Error())
 		}
 
-		return handlercommon.CreateEndpoint(ctx, req.ProjectID, req.Body, projectProvider, privilegedProjectProvider, seedsGetter, credentialManager, exposeStrategy, userInfoGetter)
+		return handlercommon.CreateEndpoint(ctx, req.ProjectID, req.Body, projectProvider, privilegedProjectProvider, seedsGetter, credentialManager, exposeStrategy, userInfoGetter, validateNodeDeployment)
 	}
 }
 
@@ -125,6 +126,13 @@ func GetClusterEventsEndpoint(projectProvider provider.ProjectProvider, privileg
 	}
 }
 
+func GetClusterTimelineEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(common.GetClusterReq)
+		return handlercommon.GetClusterTimelineEndpoint(ctx, userInfoGetter, req.ProjectID, req.ClusterID, projectProvider, privilegedProjectProvider)
+	}
+}
+
 func HealthEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(common.GetClusterReq)
@@ -132,6 +140,17 @@ func HealthEndpoint(projectProvider provider.ProjectProvider, privilegedProjectP
 	}
 }
 
+func GetRootCAEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(common.GetClusterReq)
+		return handlercommon.GetRootCAEndpoint(ctx, userInfoGetter, req.ProjectID, req.ClusterID, projectProvider, privilegedProjectProvider)
+	}
+}
+
+func EncodeRootCA(c context.Context, w http.ResponseWriter, response interface{}) (err error) {
+	return handlercommon.EncodeRootCA(c, w, response)
+}
+
 func AssignSSHKeyEndpoint(sshKeyProvider provider.SSHKeyProvider, privilegedSSHKeyProvider provider.PrivilegedSSHKeyProvider, projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(AssignSSHKeysReq)
@@ -223,6 +242,9 @@ func (r CreateReq) Validate(clusterType kubermaticv1.ClusterType, updateManager
 	}
 	for _, availableVersion := range versions {
 		if r.Body.Cluster.Spec.Version.Version.Equal(availableVersion.Version) {
+			if availableVersion.Deprecated {
+				return fmt.Errorf("invalid cluster: version %v is deprecated and cannot be used for new clusters", r.Body.Cluster.Spec.Version.Version)
+			}
 			return nil
 		}
 	}
@@ -415,6 +437,67 @@ func RevokeViewerTokenEndpoint(projectProvider provider.ProjectProvider, privile
 	}
 }
 
+// ReconcileReq defines HTTP request data for reconcileCluster endpoint
+// swagger:parameters reconcileCluster
+type ReconcileReq struct {
+	common.DCReq
+	// in: path
+	ClusterID string `json:"cluster_id"`
+	// in: query
+	ClearError bool `json:"clearError,omitempty"`
+}
+
+func DecodeReconcileReq(c context.Context, r *http.Request) (interface{}, error) {
+	var req ReconcileReq
+	clusterID, err := common.DecodeClusterID(c, r)
+	if err != nil {
+		return nil, err
+	}
+	req.ClusterID = clusterID
+
+	dcr, err := common.DecodeDcReq(c, r)
+	if err != nil {
+		return nil, err
+	}
+	req.DCReq = dcr.(common.DCReq)
+
+	if queryParam := r.URL.Query().Get("clearError"); queryParam != "" {
+		req.ClearError, err = strconv.ParseBool(queryParam)
+		if err != nil {
+			return nil, fmt.Errorf("wrong query parameter: %v", err)
+		}
+	}
+
+	return req, nil
+}
+
+// ReconcileEndpoint makes the controllers reconcile the cluster right away instead of waiting for the next resync
+func ReconcileEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(ReconcileReq)
+		clusterProvider := ctx.Value(middleware.ClusterProviderContextKey).(provider.ClusterProvider)
+		privilegedClusterProvider := ctx.Value(middleware.PrivilegedClusterProviderContextKey).(provider.PrivilegedClusterProvider)
+
+		cluster, err := handlercommon.GetCluster(ctx, projectProvider, privilegedProjectProvider, userInfoGetter, req.ProjectID, req.ClusterID, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		adminUserInfo, err := userInfoGetter(ctx, "")
+		if err != nil {
+			return nil, errors.New(http.StatusInternalServerError, err.Error())
+		}
+		if adminUserInfo.IsAdmin {
+			return nil, common.KubernetesErrorToHTTPError(privilegedClusterProvider.RequestReconcileUnsecured(cluster, req.ClearError))
+		}
+		userInfo, err := userInfoGetter(ctx, req.ProjectID)
+		if err != nil {
+			return nil, errors.New(http.StatusInternalServerError, err.Error())
+		}
+		return nil, common.KubernetesErrorToHTTPError(clusterProvider.RequestReconcile(userInfo, cluster, req.ClearError))
+	}
+}
+
 // DeleteReq defines HTTP request for deleteCluster endpoints
 // swagger:parameters deleteCluster
 type DeleteReq struct {
@@ -29,6 +29,7 @@ import (
 	clusterv1alpha1 "github.com/kubermatic/machine-controller/pkg/apis/cluster/v1alpha1"
 	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	kubermaticv1helper "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1/helper"
 	"k8c.io/kubermatic/v2/pkg/handler/test"
 	"k8c.io/kubermatic/v2/pkg/handler/test/hack"
 	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
@@ -877,6 +878,30 @@ func TestCreateClusterEndpoint(t *testing.T) {
 			ProjectToSync:   test.GenDefaultProject().Name,
 			ExistingAPIUser: test.GenDefaultAPIUser(),
 		},
+		// scenario 15
+		{
+			Name:             "scenario 15: a cluster with a blank name",
+			Body:             `{"cluster":{"name":"  ","spec":{"version":"1.15.0","cloud":{"fake":{"token":"dummy_token"},"dc":"fake-dc"}}}}`,
+			ExpectedResponse: `{"error":{"code":400,"message":"invalid cluster: the name must not be blank"}}`,
+			HTTPStatus:       http.StatusBadRequest,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenTestSeed(),
+			),
+			ProjectToSync:   test.GenDefaultProject().Name,
+			ExistingAPIUser: test.GenDefaultAPIUser(),
+		},
+		// scenario 16
+		{
+			Name:             "scenario 16: a cluster with an invalid label",
+			Body:             `{"cluster":{"name":"keen-snyder","labels":{"env":"not valid"},"spec":{"version":"1.15.0","cloud":{"fake":{"token":"dummy_token"},"dc":"fake-dc"}}}}`,
+			ExpectedResponse: `{"error":{"code":400,"message":"invalid cluster: labels: Invalid value: \"not valid\": a valid label must be an empty string or consist of alphanumeric characters, '-', '_' or '.', and must start and end with an alphanumeric character (e.g. 'MyValue',  or 'my_value',  or '12345', regex used for validation is '(([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9])?')"}}`,
+			HTTPStatus:       http.StatusBadRequest,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenTestSeed(),
+			),
+			ProjectToSync:   test.GenDefaultProject().Name,
+			ExistingAPIUser: test.GenDefaultAPIUser(),
+		},
 	}
 
 	for _, tc := range testcases {
@@ -1803,6 +1828,118 @@ func TestRevokeClusterAdminTokenEndpoint(t *testing.T) {
 
 }
 
+func TestReconcileClusterEndpoint(t *testing.T) {
+	t.Parallel()
+
+	failedCluster := func() *kubermaticv1.Cluster {
+		c := test.GenDefaultCluster()
+		reason := kubermaticv1.LaunchTimeoutClusterError
+		message := "cluster did not become healthy"
+		c.Status.ErrorReason = &reason
+		c.Status.ErrorMessage = &message
+		c.Status.Conditions = []kubermaticv1.ClusterCondition{
+			{
+				Type:               kubermaticv1.ClusterConditionClusterInitialized,
+				Status:             corev1.ConditionFalse,
+				LastTransitionTime: metav1.NewTime(time.Now().Add(-2 * time.Hour)),
+			},
+		}
+		return c
+	}
+
+	testcases := []struct {
+		name                   string
+		query                  string
+		expectedResponse       string
+		httpStatus             int
+		expectErrorCleared     bool
+		existingAPIUser        *apiv1.User
+		existingKubermaticObjs []ctrlruntimeclient.Object
+	}{
+		{
+			name:             "scenario 1: the owner user requests a reconciliation",
+			expectedResponse: `{}`,
+			httpStatus:       http.StatusOK,
+			existingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenTestSeed(),
+				failedCluster(),
+			),
+			existingAPIUser: test.GenDefaultAPIUser(),
+		},
+		{
+			name:               "scenario 2: the owner user requests a reconciliation and clears the error",
+			query:              "?clearError=true",
+			expectedResponse:   `{}`,
+			httpStatus:         http.StatusOK,
+			expectErrorCleared: true,
+			existingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenTestSeed(),
+				failedCluster(),
+			),
+			existingAPIUser: test.GenDefaultAPIUser(),
+		},
+		{
+			name:             "scenario 3: the user John can not request a reconciliation of Bob's cluster",
+			expectedResponse: `{"error":{"code":403,"message":"forbidden: \"john@acme.com\" doesn't belong to the given project = my-first-project-ID"}}`,
+			httpStatus:       http.StatusForbidden,
+			existingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenTestSeed(),
+				genUser("John", "john@acme.com", false),
+				failedCluster(),
+			),
+			existingAPIUser: test.GenAPIUser("John", "john@acme.com"),
+		},
+		{
+			name:               "scenario 4: the admin John can request a reconciliation of Bob's cluster and clear the error",
+			query:              "?clearError=true",
+			expectedResponse:   `{}`,
+			httpStatus:         http.StatusOK,
+			expectErrorCleared: true,
+			existingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenTestSeed(),
+				genUser("John", "john@acme.com", true),
+				failedCluster(),
+			),
+			existingAPIUser: test.GenAPIUser("John", "john@acme.com"),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			ep, clientsSets, err := test.CreateTestEndpointAndGetClients(*tc.existingAPIUser, nil, []ctrlruntimeclient.Object{}, []ctrlruntimeclient.Object{}, tc.existingKubermaticObjs, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			res := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", fmt.Sprintf("/api/v1/projects/%s/dc/us-central1/clusters/%s/reconcile%s", test.ProjectName, test.DefaultClusterID, tc.query), nil)
+			ep.ServeHTTP(res, req)
+
+			test.CheckStatusCode(tc.httpStatus, res, t)
+			test.CompareWithResult(t, res, tc.expectedResponse)
+			if tc.httpStatus != http.StatusOK {
+				return
+			}
+
+			updatedCluster := &kubermaticv1.Cluster{}
+			if err := clientsSets.FakeClient.Get(context.Background(), types.NamespacedName{Name: test.DefaultClusterID}, updatedCluster); err != nil {
+				t.Fatalf("failed to get cluster from fake client: %v", err)
+			}
+			if _, ok := updatedCluster.Annotations[kubermaticv1.ReconcileRequestedAnnotation]; !ok {
+				t.Errorf("expected the %s annotation to be set", kubermaticv1.ReconcileRequestedAnnotation)
+			}
+			if errorCleared := updatedCluster.Status.ErrorReason == nil; errorCleared != tc.expectErrorCleared {
+				t.Errorf("expected error to be cleared: %v, got error reason %v", tc.expectErrorCleared, updatedCluster.Status.ErrorReason)
+			}
+			// clearing a launch timeout must restart the launch, otherwise the cluster fails again right away
+			_, condition := kubermaticv1helper.GetClusterCondition(updatedCluster, kubermaticv1.ClusterConditionClusterInitialized)
+			if launchRestarted := condition != nil && time.Since(condition.LastTransitionTime.Time) < time.Hour; launchRestarted != tc.expectErrorCleared {
+				t.Errorf("expected launch to be restarted: %v, got ClusterInitialized condition %+v", tc.expectErrorCleared, condition)
+			}
+		})
+	}
+}
+
 func TestGetClusterEventsEndpoint(t *testing.T) {
 	t.Parallel()
 	testcases := []struct {
@@ -1834,7 +1971,7 @@ func TestGetClusterEventsEndpoint(t *testing.T) {
 				test.GenTestEvent("event-1", corev1.EventTypeNormal, "Started", "message started", "Cluster", "venus-1-machine"),
 				test.GenTestEvent("event-2", corev1.EventTypeWarning, "Killed", "message killed", "Cluster", "venus-1-machine"),
 			},
-			ExpectedResult: `[{"name":"event-1","creationTimestamp":"0001-01-01T00:00:00Z","message":"message started","type":"Normal","involvedObject":{"type":"Cluster","namespace":"kube-system","name":"testMachine"},"lastTimestamp":"0001-01-01T00:00:00Z","count":1},{"name":"event-2","creationTimestamp":"0001-01-01T00:00:00Z","message":"message killed","type":"Warning","involvedObject":{"type":"Cluster","namespace":"kube-system","name":"testMachine"},"lastTimestamp":"0001-01-01T00:00:00Z","count":1}]`,
+			ExpectedResult: `[{"name":"event-1","creationTimestamp":"0001-01-01T00:00:00Z","message":"message started","reason":"Started","type":"Normal","involvedObject":{"type":"Cluster","namespace":"kube-system","name":"testMachine"},"lastTimestamp":"0001-01-01T00:00:00Z","count":1},{"name":"event-2","creationTimestamp":"0001-01-01T00:00:00Z","message":"message killed","reason":"Killed","type":"Warning","involvedObject":{"type":"Cluster","namespace":"kube-system","name":"testMachine"},"lastTimestamp":"0001-01-01T00:00:00Z","count":1}]`,
 		},
 		// scenario 2
 		{
@@ -1852,7 +1989,7 @@ func TestGetClusterEventsEndpoint(t *testing.T) {
 				test.GenTestEvent("event-1", corev1.EventTypeNormal, "Started", "message started", "Cluster", "venus-1-machine"),
 				test.GenTestEvent("event-2", corev1.EventTypeWarning, "Killed", "message killed", "Cluster", "venus-1-machine"),
 			},
-			ExpectedResult: `[{"name":"event-2","creationTimestamp":"0001-01-01T00:00:00Z","message":"message killed","type":"Warning","involvedObject":{"type":"Cluster","namespace":"kube-system","name":"testMachine"},"lastTimestamp":"0001-01-01T00:00:00Z","count":1}]`,
+			ExpectedResult: `[{"name":"event-2","creationTimestamp":"0001-01-01T00:00:00Z","message":"message killed","reason":"Killed","type":"Warning","involvedObject":{"type":"Cluster","namespace":"kube-system","name":"testMachine"},"lastTimestamp":"0001-01-01T00:00:00Z","count":1}]`,
 		},
 		// scenario 3
 		{
@@ -1870,7 +2007,7 @@ func TestGetClusterEventsEndpoint(t *testing.T) {
 				test.GenTestEvent("event-1", corev1.EventTypeNormal, "Started", "message started", "Cluster", "venus-1-machine"),
 				test.GenTestEvent("event-2", corev1.EventTypeWarning, "Killed", "message killed", "Cluster", "venus-1-machine"),
 			},
-			ExpectedResult: `[{"name":"event-1","creationTimestamp":"0001-01-01T00:00:00Z","message":"message started","type":"Normal","involvedObject":{"type":"Cluster","namespace":"kube-system","name":"testMachine"},"lastTimestamp":"0001-01-01T00:00:00Z","count":1}]`,
+			ExpectedResult: `[{"name":"event-1","creationTimestamp":"0001-01-01T00:00:00Z","message":"message started","reason":"Started","type":"Normal","involvedObject":{"type":"Cluster","namespace":"kube-system","name":"testMachine"},"lastTimestamp":"0001-01-01T00:00:00Z","count":1}]`,
 		},
 		// scenario 4
 		{
@@ -1888,7 +2025,7 @@ func TestGetClusterEventsEndpoint(t *testing.T) {
 				test.GenTestEvent("event-1", corev1.EventTypeNormal, "Started", "message started", "Cluster", "venus-1-machine"),
 				test.GenTestEvent("event-2", corev1.EventTypeWarning, "Killed", "message killed", "Cluster", "venus-1-machine"),
 			},
-			ExpectedResult: `[{"name":"event-1","creationTimestamp":"0001-01-01T00:00:00Z","message":"message started","type":"Normal","involvedObject":{"type":"Cluster","namespace":"kube-system","name":"testMachine"},"lastTimestamp":"0001-01-01T00:00:00Z","count":1},{"name":"event-2","creationTimestamp":"0001-01-01T00:00:00Z","message":"message killed","type":"Warning","involvedObject":{"type":"Cluster","namespace":"kube-system","name":"testMachine"},"lastTimestamp":"0001-01-01T00:00:00Z","count":1}]`,
+			ExpectedResult: `[{"name":"event-1","creationTimestamp":"0001-01-01T00:00:00Z","message":"message started","reason":"Started","type":"Normal","involvedObject":{"type":"Cluster","namespace":"kube-system","name":"testMachine"},"lastTimestamp":"0001-01-01T00:00:00Z","count":1},{"name":"event-2","creationTimestamp":"0001-01-01T00:00:00Z","message":"message killed","reason":"Killed","type":"Warning","involvedObject":{"type":"Cluster","namespace":"kube-system","name":"testMachine"},"lastTimestamp":"0001-01-01T00:00:00Z","count":1}]`,
 		},
 		// scenario 5
 		{
@@ -1938,6 +2075,80 @@ func TestGetClusterEventsEndpoint(t *testing.T) {
 	}
 }
 
+func TestGetClusterTimeline(t *testing.T) {
+	t.Parallel()
+
+	genEvent := func(name, namespace, reason, kind, objectName, objectNamespace string, minute int) *corev1.Event {
+		event := test.GenTestEvent(name, corev1.EventTypeNormal, reason, "message "+name, kind, "")
+		event.Namespace = namespace
+		event.InvolvedObject.Name = objectName
+		event.InvolvedObject.Namespace = objectNamespace
+		event.LastTimestamp = metav1.NewTime(time.Date(2021, 1, 1, 10, minute, 0, 0, time.UTC))
+		return event
+	}
+
+	testcases := []struct {
+		Name                   string
+		HTTPStatus             int
+		ExpectedResult         string
+		ExistingKubermaticObjs []ctrlruntimeclient.Object
+		ExistingAPIUser        *apiv1.User
+		ExistingEvents         []*corev1.Event
+	}{
+		{
+			Name:       "scenario 1: list the events of the cluster and its control plane in chronological order",
+			HTTPStatus: http.StatusOK,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenTestSeed(),
+				test.GenDefaultCluster(),
+			),
+			ExistingAPIUser: test.GenDefaultAPIUser(),
+			ExistingEvents: []*corev1.Event{
+				genEvent("etcd-started", "cluster-defClusterID", "Started", "Pod", "etcd-0", "cluster-defClusterID", 2),
+				genEvent("cluster-reconciled", metav1.NamespaceDefault, "Reconciled", "Cluster", "defClusterID", "", 0),
+				genEvent("apiserver-scheduled", "cluster-defClusterID", "Scheduled", "Pod", "apiserver", "cluster-defClusterID", 1),
+				genEvent("other-pod-started", "other-namespace", "Started", "Pod", "defClusterID", "other-namespace", 3),
+			},
+			ExpectedResult: `[{"name":"cluster-reconciled","creationTimestamp":"0001-01-01T00:00:00Z","message":"message cluster-reconciled","reason":"Reconciled","type":"Normal","involvedObject":{"type":"Cluster","name":"defClusterID"},"lastTimestamp":"2021-01-01T10:00:00Z","count":1},{"name":"apiserver-scheduled","creationTimestamp":"0001-01-01T00:00:00Z","message":"message apiserver-scheduled","reason":"Scheduled","type":"Normal","involvedObject":{"type":"Pod","namespace":"cluster-defClusterID","name":"apiserver"},"lastTimestamp":"2021-01-01T10:01:00Z","count":1},{"name":"etcd-started","creationTimestamp":"0001-01-01T00:00:00Z","message":"message etcd-started","reason":"Started","type":"Normal","involvedObject":{"type":"Pod","namespace":"cluster-defClusterID","name":"etcd-0"},"lastTimestamp":"2021-01-01T10:02:00Z","count":1}]`,
+		},
+		{
+			Name:       "scenario 2: the user John can not list the events of Bob's cluster",
+			HTTPStatus: http.StatusForbidden,
+			ExistingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenTestSeed(),
+				test.GenDefaultCluster(),
+				genUser("John", "john@acme.com", false),
+			),
+			ExistingAPIUser: test.GenAPIUser("John", "john@acme.com"),
+			ExpectedResult:  `{"error":{"code":403,"message":"forbidden: \"john@acme.com\" doesn't belong to the given project = my-first-project-ID"}}`,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", fmt.Sprintf("/api/v1/projects/%s/dc/us-central1/clusters/%s/timeline", test.GenDefaultProject().Name, test.GenDefaultCluster().Name), strings.NewReader(""))
+			res := httptest.NewRecorder()
+			kubernetesObj := make([]ctrlruntimeclient.Object, 0)
+			for _, existingEvent := range tc.ExistingEvents {
+				kubernetesObj = append(kubernetesObj, existingEvent)
+			}
+
+			ep, _, err := test.CreateTestEndpointAndGetClients(*tc.ExistingAPIUser, nil, kubernetesObj, nil, tc.ExistingKubermaticObjs, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.HTTPStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
+			}
+
+			test.CompareWithResult(t, res, tc.ExpectedResult)
+		})
+	}
+}
+
 func TestGetClusterMetrics(t *testing.T) {
 	t.Parallel()
 	cpuQuantity, err := resource.ParseQuantity("290")
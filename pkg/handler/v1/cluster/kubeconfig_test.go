@@ -456,6 +456,96 @@ func TestGetMasterKubeconfig(t *testing.T) {
 
 }
 
+func TestGetClusterRootCA(t *testing.T) {
+	t.Parallel()
+	const testRootCA = "-----BEGIN CERTIFICATE-----\nMIIBfake\n-----END CERTIFICATE-----\n"
+
+	testcases := []struct {
+		Name                   string
+		ExpectedResponseString string
+		ProjectToGet           string
+		ClusterToGet           string
+		HTTPStatus             int
+		ExistingAPIUser        apiv1.User
+		ExistingKubermaticObjs []ctrlruntimeclient.Object
+		ExistingObjects        []ctrlruntimeclient.Object
+	}{
+		{
+			Name:         "scenario 1: project member gets the root CA",
+			HTTPStatus:   http.StatusOK,
+			ProjectToGet: "foo-ID",
+			ClusterToGet: "cluster-foo",
+			ExistingKubermaticObjs: []ctrlruntimeclient.Object{
+				test.GenTestSeed(),
+				test.GenProject("foo", kubermaticapiv1.ProjectActive, test.DefaultCreationTimestamp()),
+				test.GenBinding("foo-ID", "john@acme.com", "viewers"),
+				test.GenUser("", "john", "john@acme.com"),
+				test.GenCluster("cluster-foo", "cluster-foo", "foo-ID", test.DefaultCreationTimestamp()),
+			},
+			ExistingObjects: []ctrlruntimeclient.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "cluster-cluster-foo",
+						Name:      "ca",
+					},
+					Data: map[string][]byte{
+						"ca.crt": []byte(testRootCA),
+						"ca.key": []byte("secret"),
+					},
+				},
+			},
+			ExistingAPIUser:        *test.GenAPIUser("john", "john@acme.com"),
+			ExpectedResponseString: testRootCA,
+		},
+		{
+			Name:         "scenario 2: the user Bob can not get the root CA of John's cluster",
+			HTTPStatus:   http.StatusForbidden,
+			ProjectToGet: "foo-ID",
+			ClusterToGet: "cluster-foo",
+			ExistingKubermaticObjs: []ctrlruntimeclient.Object{
+				test.GenTestSeed(),
+				test.GenProject("foo", kubermaticapiv1.ProjectActive, test.DefaultCreationTimestamp()),
+				test.GenBinding("foo-ID", "john@acme.com", "owners"),
+				test.GenUser("", "john", "john@acme.com"),
+				genUser("bob", "bob@acme.com", false),
+				test.GenCluster("cluster-foo", "cluster-foo", "foo-ID", test.DefaultCreationTimestamp()),
+			},
+			ExistingObjects: []ctrlruntimeclient.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "cluster-cluster-foo",
+						Name:      "ca",
+					},
+					Data: map[string][]byte{
+						"ca.crt": []byte(testRootCA),
+					},
+				},
+			},
+			ExistingAPIUser:        *test.GenAPIUser("bob", "bob@acme.com"),
+			ExpectedResponseString: `{"error":{"code":403,"message":"forbidden: \"bob@acme.com\" doesn't belong to the given project = foo-ID"}}`,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.Name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", fmt.Sprintf("/api/v1/projects/%s/dc/us-central1/clusters/%s/rootca", tc.ProjectToGet, tc.ClusterToGet), nil)
+			res := httptest.NewRecorder()
+			ep, _, err := test.CreateTestEndpointAndGetClients(tc.ExistingAPIUser, nil, tc.ExistingObjects, []ctrlruntimeclient.Object{}, tc.ExistingKubermaticObjs, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.HTTPStatus {
+				t.Fatalf("Expected HTTP status code %d, got %d: %s", tc.HTTPStatus, res.Code, res.Body.String())
+			}
+
+			test.CompareWithResult(t, res, tc.ExpectedResponseString)
+		})
+	}
+}
+
 func genTestKubeconfigKubermaticObjects() []ctrlruntimeclient.Object {
 	return []ctrlruntimeclient.Object{
 		test.GenTestSeed(),
@@ -161,6 +161,38 @@ func GetMasterVersionsEndpoint(updateManager common.UpdateManager) endpoint.Endp
 	}
 }
 
+// GetMasterVersionOverviewEndpoint returns the versions which can be chosen for new clusters together
+// with the updates which are allowed from each of them.
+func GetMasterVersionOverviewEndpoint(updateManager common.UpdateManager) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(TypeReq)
+		err := req.Validate()
+		if err != nil {
+			return nil, errors.NewBadRequest(err.Error())
+		}
+		versions, err := updateManager.GetVersions(req.Type)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get master versions: %v", err)
+		}
+
+		overview := &apiv1.MasterVersionOverview{
+			Versions: convertVersionsToExternal(versions),
+			Updates:  []*apiv1.MasterVersionUpdate{},
+		}
+		for _, v := range versions {
+			updates, err := updateManager.GetPossibleUpdates(v.Version.String(), req.Type)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get updates for version %s: %v", v.Version, err)
+			}
+			for _, u := range updates {
+				overview.Updates = append(overview.Updates, &apiv1.MasterVersionUpdate{From: v.Version, To: u.Version})
+			}
+		}
+
+		return overview, nil
+	}
+}
+
 // TypeReq represents a request that contains the cluster type
 type TypeReq struct {
 	// in: query
@@ -190,8 +222,9 @@ func convertVersionsToExternal(versions []*version.Version) []*apiv1.MasterVersi
 	sv := make([]*apiv1.MasterVersion, len(versions))
 	for v := range versions {
 		sv[v] = &apiv1.MasterVersion{
-			Version: versions[v].Version,
-			Default: versions[v].Default,
+			Version:    versions[v].Version,
+			Default:    versions[v].Default,
+			Deprecated: versions[v].Deprecated,
 		}
 	}
 	return sv
@@ -181,6 +181,54 @@ func TestGetClusterUpgrades(t *testing.T) {
 			},
 			updates: []*version.Update{},
 		},
+		{
+			name: "deprecated versions are not offered as upgrade",
+			cluster: func() *kubermaticv1.Cluster {
+				c := test.GenCluster("foo", "foo", "project", time.Now())
+				c.Labels = map[string]string{"user": test.UserName}
+				c.Spec.Version = *k8csemver.NewSemverOrDie("1.6.0")
+				return c
+			}(),
+			existingKubermaticObjs: test.GenDefaultKubermaticObjects(
+				test.GenTestSeed(),
+			),
+			existingMachineDeployments: []*clusterv1alpha1.MachineDeployment{},
+			apiUser:                    *test.GenDefaultAPIUser(),
+			wantUpdates: []*apiv1.MasterVersion{
+				{
+					Version: semver.MustParse("1.7.0"),
+				},
+			},
+			versions: []*version.Version{
+				{
+					Version: semver.MustParse("1.6.0"),
+					Type:    apiv1.KubernetesClusterType,
+				},
+				{
+					Version:    semver.MustParse("1.6.1"),
+					Type:       apiv1.KubernetesClusterType,
+					Deprecated: true,
+				},
+				{
+					Version: semver.MustParse("1.7.0"),
+					Type:    apiv1.KubernetesClusterType,
+				},
+			},
+			updates: []*version.Update{
+				{
+					From:      "1.6.0",
+					To:        "1.6.1",
+					Automatic: false,
+					Type:      apiv1.KubernetesClusterType,
+				},
+				{
+					From:      "1.6.x",
+					To:        "1.7.0",
+					Automatic: false,
+					Type:      apiv1.KubernetesClusterType,
+				},
+			},
+		},
 		{
 			name: "the admin John can get available upgrades for Bob cluster",
 			cluster: func() *kubermaticv1.Cluster {
@@ -579,3 +627,81 @@ func TestGetMasterVersionsEndpoint(t *testing.T) {
 		})
 	}
 }
+
+func TestGetMasterVersionOverviewEndpoint(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name                   string
+		apiUser                apiv1.User
+		existingUpdates        []*version.Update
+		existingVersions       []*version.Version
+		expectedResponse       string
+		existingKubermaticObjs []ctrlruntimeclient.Object
+	}{
+		{
+			name:                   "versions with updates between them",
+			apiUser:                *test.GenDefaultAPIUser(),
+			existingKubermaticObjs: []ctrlruntimeclient.Object{test.GenDefaultUser()},
+			existingUpdates: []*version.Update{
+				{
+					From: "1.17.*",
+					To:   "1.18.*",
+					Type: apiv1.KubernetesClusterType,
+				},
+				{
+					From: "1.18.*",
+					To:   "1.18.*",
+					Type: apiv1.KubernetesClusterType,
+				},
+			},
+			existingVersions: []*version.Version{
+				{
+					Version:    semver.MustParse("1.17.9"),
+					Deprecated: true,
+					Type:       apiv1.KubernetesClusterType,
+				},
+				{
+					Version: semver.MustParse("1.18.6"),
+					Default: true,
+					Type:    apiv1.KubernetesClusterType,
+				},
+				{
+					Version: semver.MustParse("1.18.8"),
+					Type:    apiv1.KubernetesClusterType,
+				},
+			},
+			expectedResponse: `{"versions":[{"version":"1.17.9","deprecated":true},{"version":"1.18.6","default":true},{"version":"1.18.8"}],"updates":[{"from":"1.17.9","to":"1.18.6"},{"from":"1.17.9","to":"1.18.8"},{"from":"1.18.6","to":"1.18.8"},{"from":"1.18.8","to":"1.18.6"}]}`,
+		},
+		{
+			name:                   "versions without updates",
+			apiUser:                *test.GenDefaultAPIUser(),
+			existingKubermaticObjs: []ctrlruntimeclient.Object{test.GenDefaultUser()},
+			existingUpdates:        []*version.Update{},
+			existingVersions: []*version.Version{
+				{
+					Version: semver.MustParse("1.18.6"),
+					Default: true,
+					Type:    apiv1.KubernetesClusterType,
+				},
+			},
+			expectedResponse: `{"versions":[{"version":"1.18.6","default":true}],"updates":[]}`,
+		},
+	}
+	for _, testStruct := range tests {
+		t.Run(testStruct.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/api/v1/upgrades/cluster/overview", nil)
+			res := httptest.NewRecorder()
+			ep, err := test.CreateTestEndpoint(testStruct.apiUser, nil, testStruct.existingKubermaticObjs,
+				testStruct.existingVersions, testStruct.existingUpdates, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create testStruct endpoint due to %v", err)
+			}
+			ep.ServeHTTP(res, req)
+			if res.Code != http.StatusOK {
+				t.Fatalf("expected status code to be 200, got %d\nResponse body: %q", res.Code, res.Body.String())
+			}
+
+			test.CompareWithResult(t, res, testStruct.expectedResponse)
+		})
+	}
+}
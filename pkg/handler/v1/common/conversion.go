@@ -61,6 +61,7 @@ func ConvertInternalEventToExternal(event corev1.Event) apiv1.Event {
 			CreationTimestamp: apiv1.NewTime(event.ObjectMeta.CreationTimestamp.Time),
 		},
 		Message: event.Message,
+		Reason:  event.Reason,
 		Type:    event.Type,
 		InvolvedObject: apiv1.ObjectReferenceResource{
 			Name:      event.InvolvedObject.Name,
@@ -23,6 +23,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"sort"
+	"strconv"
 	"strings"
 
 	jsonpatch "github.com/evanphx/json-patch"
@@ -40,33 +41,84 @@ import (
 // ListEndpoint an HTTP endpoint that returns a list of apiv1.Datacenter
 func ListEndpoint(seedsGetter provider.SeedsGetter, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
-		seeds, err := seedsGetter()
-		if err != nil {
-			return nil, errors.New(http.StatusInternalServerError, fmt.Sprintf("failed to list seeds: %v", err))
+		req, ok := request.(listDCReq)
+		if !ok {
+			return nil, errors.NewBadRequest("invalid request")
 		}
 
-		userInfo, err := userInfoGetter(ctx, "")
+		return listDCs(ctx, seedsGetter, userInfoGetter, req)
+	}
+}
+
+// ListPaginatedEndpoint an HTTP endpoint that returns a page of apiv1.Datacenter together
+// with the total number of datacenters
+func ListPaginatedEndpoint(seedsGetter provider.SeedsGetter, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(listDCPaginatedReq)
+		if !ok {
+			return nil, errors.NewBadRequest("invalid request")
+		}
+
+		dcs, err := listDCs(ctx, seedsGetter, userInfoGetter, req.listDCReq)
 		if err != nil {
-			return nil, common.KubernetesErrorToHTTPError(err)
+			return nil, err
 		}
 
-		// Get the DCs and immediately filter out the ones restricted by e-mail domain if user is not admin
-		dcs := getAPIDCsFromSeedMap(seeds)
-		if !userInfo.IsAdmin {
-			dcs, err = filterDCsByEmail(userInfo, dcs)
-			if err != nil {
-				return apiv1.Datacenter{}, errors.New(http.StatusInternalServerError,
-					fmt.Sprintf("failed to filter datacenters by email: %v", err))
-			}
+		return apiv1.DatacenterListPaginated{
+			TotalCount: len(dcs),
+			Body:       paginateDCs(dcs, req.Page, req.PageSize),
+		}, nil
+	}
+}
+
+func listDCs(ctx context.Context, seedsGetter provider.SeedsGetter, userInfoGetter provider.UserInfoGetter, req listDCReq) ([]apiv1.Datacenter, error) {
+	seeds, err := seedsGetter()
+	if err != nil {
+		return nil, errors.New(http.StatusInternalServerError, fmt.Sprintf("failed to list seeds: %v", err))
+	}
+
+	userInfo, err := userInfoGetter(ctx, "")
+	if err != nil {
+		return nil, common.KubernetesErrorToHTTPError(err)
+	}
+
+	// Get the DCs and immediately filter out the ones restricted by e-mail domain if user is not admin
+	dcs := getAPIDCsFromSeedMap(seeds)
+	if req.providerSet {
+		dcs = filterDCsByProvider(req.Provider, dcs)
+	}
+	if !userInfo.IsAdmin {
+		dcs, err = filterDCsByEmail(userInfo, dcs)
+		if err != nil {
+			return nil, errors.New(http.StatusInternalServerError,
+				fmt.Sprintf("failed to filter datacenters by email: %v", err))
 		}
+	}
 
-		// Maintain a stable order. We do not check for duplicate names here
-		sort.SliceStable(dcs, func(i, j int) bool {
-			return dcs[i].Metadata.Name < dcs[j].Metadata.Name
-		})
+	// Maintain a stable order. We do not check for duplicate names here
+	sort.SliceStable(dcs, func(i, j int) bool {
+		return dcs[i].Metadata.Name < dcs[j].Metadata.Name
+	})
 
-		return dcs, nil
+	return dcs, nil
+}
+
+// paginateDCs returns the given page of the sorted datacenters. Pages start at 1,
+// pages after the last one are empty.
+func paginateDCs(dcs []apiv1.Datacenter, page, pageSize int) []apiv1.Datacenter {
+	// compare page numbers before multiplying, a large page would overflow the start index
+	pages := (len(dcs) + pageSize - 1) / pageSize
+	if page-1 >= pages {
+		return []apiv1.Datacenter{}
 	}
+	start := (page - 1) * pageSize
+
+	end := start + pageSize
+	if end > len(dcs) {
+		end = len(dcs)
+	}
+
+	return dcs[start:end]
 }
 
 // ListEndpoint an HTTP endpoint that returns a list of apiv1.Datacenter for a specified provider
@@ -741,6 +793,73 @@ func DecodeListDCForSeedReq(c context.Context, r *http.Request) (interface{}, er
 	return req, nil
 }
 
+const (
+	defaultDCPageSize = 50
+	maxDCPageSize     = 1000
+)
+
+// listDCReq represents a request for datacenters
+// swagger:parameters listDatacenters
+type listDCReq struct {
+	// in: query
+	// Provider only returns the datacenters of the given provider. Unknown providers result in an empty list.
+	Provider string `json:"provider,omitempty"`
+
+	// providerSet is true if the provider parameter was given, even if it was empty
+	providerSet bool
+}
+
+func DecodeListDCReq(c context.Context, r *http.Request) (interface{}, error) {
+	var req listDCReq
+
+	if providers, ok := r.URL.Query()["provider"]; ok {
+		req.providerSet = true
+		req.Provider = providers[0]
+	}
+
+	return req, nil
+}
+
+// listDCPaginatedReq represents a request for a page of datacenters
+// swagger:parameters listDatacentersPaginated
+type listDCPaginatedReq struct {
+	listDCReq
+	// in: query
+	// Page is the page to return, starting at 1. Defaults to 1.
+	Page int `json:"page,omitempty"`
+	// in: query
+	// PageSize is the number of datacenters per page, at most 1000. Defaults to 50.
+	PageSize int `json:"pageSize,omitempty"`
+}
+
+func DecodeListDCPaginatedReq(c context.Context, r *http.Request) (interface{}, error) {
+	req := listDCPaginatedReq{
+		Page:     1,
+		PageSize: defaultDCPageSize,
+	}
+
+	listReq, err := DecodeListDCReq(c, r)
+	if err != nil {
+		return nil, err
+	}
+	req.listDCReq = listReq.(listDCReq)
+
+	if page := r.URL.Query().Get("page"); page != "" {
+		req.Page, err = strconv.Atoi(page)
+		if err != nil || req.Page < 1 {
+			return nil, errors.NewBadRequest("'page' must be a positive number, got %q", page)
+		}
+	}
+	if pageSize := r.URL.Query().Get("pageSize"); pageSize != "" {
+		req.PageSize, err = strconv.Atoi(pageSize)
+		if err != nil || req.PageSize < 1 || req.PageSize > maxDCPageSize {
+			return nil, errors.NewBadRequest("'pageSize' must be a number between 1 and %d, got %q", maxDCPageSize, pageSize)
+		}
+	}
+
+	return req, nil
+}
+
 // getDCForSeedReq represents a request for a datacenter in the specified seed
 // swagger:parameters getDCForSeed
 type getDCForSeedReq struct {
@@ -18,8 +18,11 @@ package dc_test
 
 import (
 	"bytes"
+	"compress/gzip"
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
+	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
@@ -73,6 +76,191 @@ func TestDatacentersListEndpoint(t *testing.T) {
 	}
 }
 
+func TestDatacentersListEndpointCompression(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		name             string
+		acceptEncoding   string
+		expectCompressed bool
+	}{
+		{
+			name:             "response is compressed if the client accepts gzip",
+			acceptEncoding:   "gzip",
+			expectCompressed: true,
+		},
+		{
+			name:             "response is not compressed without Accept-Encoding header",
+			expectCompressed: false,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/api/v1/dc?provider=digitalocean", nil)
+			if tc.acceptEncoding != "" {
+				req.Header.Set("Accept-Encoding", tc.acceptEncoding)
+			}
+			res := httptest.NewRecorder()
+			apiUser := test.GenDefaultAdminAPIUser()
+			ep, err := test.CreateTestEndpoint(*apiUser, []ctrlruntimeclient.Object{},
+				[]ctrlruntimeclient.Object{test.APIUserToKubermaticUser(*apiUser), test.GenTestSeed()}, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+			ep.ServeHTTP(res, req)
+
+			if res.Code != http.StatusOK {
+				t.Fatalf("Expected route to return code %d, got %d: %s", http.StatusOK, res.Code, res.Body.String())
+			}
+			if compressed := res.Header().Get("Content-Encoding") == "gzip"; compressed != tc.expectCompressed {
+				t.Fatalf("Expected compressed response: %v, got Content-Encoding %q", tc.expectCompressed, res.Header().Get("Content-Encoding"))
+			}
+
+			body := res.Body.Bytes()
+			if tc.expectCompressed {
+				reader, err := gzip.NewReader(res.Body)
+				if err != nil {
+					t.Fatalf("failed to create gzip reader: %v", err)
+				}
+				if body, err = ioutil.ReadAll(reader); err != nil {
+					t.Fatalf("failed to decompress response: %v", err)
+				}
+			}
+
+			dcs := []apiv1.Datacenter{}
+			if err := json.Unmarshal(body, &dcs); err != nil {
+				t.Fatalf("failed to unmarshal response: %v", err)
+			}
+			if len(dcs) != 2 {
+				t.Errorf("Expected 2 datacenters, got %d", len(dcs))
+			}
+		})
+	}
+}
+
+func TestDatacentersListEndpointFilteredByProvider(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		name             string
+		query            string
+		expectedResponse string
+		httpStatus       int
+		existingAPIUser  *apiv1.User
+	}{
+		{
+			name:             "should only list dcs of the given provider",
+			query:            "?provider=digitalocean",
+			expectedResponse: `[{"metadata":{"name":"private-do1"},"spec":{"seed":"us-central1","country":"NL","location":"US ","provider":"digitalocean","digitalocean":{"region":"ams2"},"node":{"pause_image":"image-pause"},"enforceAuditLogging":false,"enforcePodSecurityPolicy":true}},{"metadata":{"name":"regular-do1"},"spec":{"seed":"us-central1","country":"NL","location":"Amsterdam","provider":"digitalocean","digitalocean":{"region":"ams2"},"node":{},"enforceAuditLogging":false,"enforcePodSecurityPolicy":false}}]`,
+			httpStatus:       200,
+			existingAPIUser:  test.GenDefaultAdminAPIUser(),
+		},
+		{
+			name:             "unknown provider should return an empty list",
+			query:            "?provider=idontexist",
+			expectedResponse: `[]`,
+			httpStatus:       200,
+			existingAPIUser:  test.GenDefaultAdminAPIUser(),
+		},
+		{
+			name:             "empty provider should return an empty list",
+			query:            "?provider=",
+			expectedResponse: `[]`,
+			httpStatus:       200,
+			existingAPIUser:  test.GenDefaultAdminAPIUser(),
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/api/v1/dc"+tc.query, nil)
+			res := httptest.NewRecorder()
+			ep, err := test.CreateTestEndpoint(*tc.existingAPIUser, []ctrlruntimeclient.Object{},
+				[]ctrlruntimeclient.Object{test.APIUserToKubermaticUser(*tc.existingAPIUser), test.GenTestSeed()}, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.httpStatus {
+				t.Fatalf("Expected route to return code %d, got %d: %s", tc.httpStatus, res.Code, res.Body.String())
+			}
+
+			test.CompareWithResult(t, res, tc.expectedResponse)
+		})
+	}
+}
+
+func TestDatacentersListPaginatedEndpoint(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		name               string
+		query              string
+		expectedResponse   string
+		expectedTotalCount string
+		httpStatus         int
+		existingAPIUser    *apiv1.User
+	}{
+		{
+			name:               "admin should be able to list a page of dcs",
+			query:              "?page=2&pageSize=3",
+			expectedResponse:   `[{"metadata":{"name":"private-do1"},"spec":{"seed":"us-central1","country":"NL","location":"US ","provider":"digitalocean","digitalocean":{"region":"ams2"},"node":{"pause_image":"image-pause"},"enforceAuditLogging":false,"enforcePodSecurityPolicy":true}},{"metadata":{"name":"psp-dc"},"spec":{"seed":"us-central1","country":"Egypt","location":"Alexandria","provider":"fake","fake":{},"node":{},"enforceAuditLogging":false,"enforcePodSecurityPolicy":true}},{"metadata":{"name":"regular-do1"},"spec":{"seed":"us-central1","country":"NL","location":"Amsterdam","provider":"digitalocean","digitalocean":{"region":"ams2"},"node":{},"enforceAuditLogging":false,"enforcePodSecurityPolicy":false}}]`,
+			expectedTotalCount: "8",
+			httpStatus:         200,
+			existingAPIUser:    test.GenDefaultAdminAPIUser(),
+		},
+		{
+			name:               "regular user should get the total count after email filtering",
+			query:              "?page=2&pageSize=5",
+			expectedResponse:   `[{"metadata":{"name":"regular-do1"},"spec":{"seed":"us-central1","country":"NL","location":"Amsterdam","provider":"digitalocean","digitalocean":{"region":"ams2"},"node":{},"enforceAuditLogging":false,"enforcePodSecurityPolicy":false}}]`,
+			expectedTotalCount: "6",
+			httpStatus:         200,
+			existingAPIUser:    test.GenDefaultAPIUser(),
+		},
+		{
+			name:               "page after the last one should be empty",
+			query:              "?page=3&pageSize=5",
+			expectedResponse:   `[]`,
+			expectedTotalCount: "8",
+			httpStatus:         200,
+			existingAPIUser:    test.GenDefaultAdminAPIUser(),
+		},
+		{
+			name:               "page which would overflow the start index should be empty",
+			query:              "?page=4611686018427387904&pageSize=4",
+			expectedResponse:   `[]`,
+			expectedTotalCount: "8",
+			httpStatus:         200,
+			existingAPIUser:    test.GenDefaultAdminAPIUser(),
+		},
+		{
+			name:             "invalid page size should be rejected",
+			query:            "?pageSize=0",
+			expectedResponse: `{"error":{"code":400,"message":"'pageSize' must be a number between 1 and 1000, got \"0\""}}`,
+			httpStatus:       400,
+			existingAPIUser:  test.GenDefaultAdminAPIUser(),
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/api/v1/datacenters"+tc.query, nil)
+			res := httptest.NewRecorder()
+			ep, err := test.CreateTestEndpoint(*tc.existingAPIUser, []ctrlruntimeclient.Object{},
+				[]ctrlruntimeclient.Object{test.APIUserToKubermaticUser(*tc.existingAPIUser), test.GenTestSeed()}, nil, nil, hack.NewTestRouting)
+			if err != nil {
+				t.Fatalf("failed to create test endpoint due to %v", err)
+			}
+			ep.ServeHTTP(res, req)
+
+			if res.Code != tc.httpStatus {
+				t.Fatalf("Expected route to return code %d, got %d: %s", tc.httpStatus, res.Code, res.Body.String())
+			}
+			if totalCount := res.Header().Get("X-Total-Count"); totalCount != tc.expectedTotalCount {
+				t.Fatalf("Expected total count %q, got %q", tc.expectedTotalCount, totalCount)
+			}
+
+			test.CompareWithResult(t, res, tc.expectedResponse)
+		})
+	}
+}
+
 func TestDatacenterGetEndpoint(t *testing.T) {
 	t.Parallel()
 	testcases := []struct {
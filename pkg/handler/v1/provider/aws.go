@@ -84,6 +84,28 @@ type AWSSizeReq struct {
 	Region string
 }
 
+// AWSSizeNoCredentialsReq represent a request for AWS VM sizes of a cluster.
+// swagger:parameters listAWSSizesNoCredentials
+type AWSSizeNoCredentialsReq struct {
+	common.GetClusterReq
+	// in: header
+	// name: AvailabilityZone
+	AvailabilityZone string
+}
+
+func DecodeAWSSizeNoCredentialsReq(c context.Context, r *http.Request) (interface{}, error) {
+	var req AWSSizeNoCredentialsReq
+
+	commonReq, err := common.DecodeGetClusterReq(c, r)
+	if err != nil {
+		return nil, err
+	}
+	req.GetClusterReq = commonReq.(common.GetClusterReq)
+	req.AvailabilityZone = r.Header.Get("AvailabilityZone")
+
+	return req, nil
+}
+
 // DecodeAWSSizesReq decodes the base type for a AWS special endpoint request
 func DecodeAWSSizesReq(c context.Context, r *http.Request) (interface{}, error) {
 	var req AWSSizeReq
@@ -176,8 +198,8 @@ func AWSSizeEndpoint(settingsProvider provider.SettingsProvider) endpoint.Endpoi
 // AWSSizeNoCredentialsEndpoint handles the request to list available AWS sizes.
 func AWSSizeNoCredentialsEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, seedsGetter provider.SeedsGetter, settingsProvider provider.SettingsProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
-		req := request.(common.GetClusterReq)
-		return providercommon.AWSSizeNoCredentialsEndpoint(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, seedsGetter, settingsProvider, req.ProjectID, req.ClusterID)
+		req := request.(AWSSizeNoCredentialsReq)
+		return providercommon.AWSSizeWithClusterCredentialsEndpoint(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, seedsGetter, settingsProvider, req.ProjectID, req.ClusterID, req.AvailabilityZone)
 	}
 }
 
@@ -71,8 +71,8 @@ func TestAzureSizeEndpoint(t *testing.T) {
 			location:   locationUS,
 			secret:     "secret",
 			expectedResponse: `[
-				{"name":"Standard_GS3", "maxDataDiskCount": 3, "memoryInMB": 2048, "numberOfCores": 8, "numberOfGPUs": 0, "osDiskSizeInMB": 1024, "resourceDiskSizeInMB":1024},
-				{"name":"Standard_A5", "maxDataDiskCount": 3, "memoryInMB": 2048, "numberOfCores": 8, "numberOfGPUs": 0, "osDiskSizeInMB": 1024, "resourceDiskSizeInMB":1024}
+				{"name":"Standard_GS3", "maxDataDiskCount": 3, "memoryInMB": 2048, "numberOfCores": 8, "numberOfGPUs": 0, "osDiskSizeInMB": 1024, "resourceDiskSizeInMB":1024, "premiumIO": true, "restrictedZones": ["2"]},
+				{"name":"Standard_A5", "maxDataDiskCount": 3, "memoryInMB": 2048, "numberOfCores": 8, "numberOfGPUs": 0, "osDiskSizeInMB": 1024, "resourceDiskSizeInMB":1024, "premiumIO": false}
 			]`,
 		},
 		{
@@ -81,7 +81,7 @@ func TestAzureSizeEndpoint(t *testing.T) {
 			location:   locationEU,
 			secret:     "secret",
 			expectedResponse: `[
-				{"name":"Standard_GS3", "maxDataDiskCount": 3, "memoryInMB": 2048, "numberOfCores": 8, "numberOfGPUs": 0, "osDiskSizeInMB": 1024, "resourceDiskSizeInMB":1024}
+				{"name":"Standard_GS3", "maxDataDiskCount": 3, "memoryInMB": 2048, "numberOfCores": 8, "numberOfGPUs": 0, "osDiskSizeInMB": 1024, "resourceDiskSizeInMB":1024, "premiumIO": false}
 			]`,
 		},
 	}
@@ -160,6 +160,8 @@ func (s *mockSizeClientImpl) ListSKU(ctx context.Context, location string) ([]co
 	standardA5 := standardA5
 	resourceType := "virtualMachines"
 	tier := "Standard"
+	premiumIO := "PremiumIO"
+	premiumIOSupported := "True"
 
 	resultList := []compute.ResourceSku{
 		{
@@ -173,6 +175,12 @@ func (s *mockSizeClientImpl) ListSKU(ctx context.Context, location string) ([]co
 			Name:         &standardGS3,
 			ResourceType: &resourceType,
 			Tier:         &tier,
+			Capabilities: &[]compute.ResourceSkuCapabilities{{Name: &premiumIO, Value: &premiumIOSupported}},
+			// the size can not be used in one zone of the location
+			Restrictions: &[]compute.ResourceSkuRestrictions{{
+				Type:            "Zone",
+				RestrictionInfo: &compute.ResourceSkuRestrictionInfo{Locations: &[]string{locationUS}, Zones: &[]string{"2"}},
+			}},
 		},
 		{
 			Locations:    &[]string{locationUS},
@@ -180,6 +188,17 @@ func (s *mockSizeClientImpl) ListSKU(ctx context.Context, location string) ([]co
 			ResourceType: &resourceType,
 			Tier:         &tier,
 		},
+		{
+			// the size is not available for the subscription in the location
+			Locations:    &[]string{locationEU},
+			Name:         &standardA5,
+			ResourceType: &resourceType,
+			Tier:         &tier,
+			Restrictions: &[]compute.ResourceSkuRestrictions{{
+				Type:            "Location",
+				RestrictionInfo: &compute.ResourceSkuRestrictionInfo{Locations: &[]string{locationEU}},
+			}},
+		},
 	}
 
 	return resultList, nil
@@ -198,13 +217,16 @@ func (s *mockSizeClientImpl) ListVMSize(ctx context.Context, location string) ([
 	s.machineSizeList = compute.VirtualMachineSizeListResult{Value: &[]compute.VirtualMachineSize{}}
 
 	if location == locationEU {
-		// one valid VM size type, two in total
+		// one valid VM size type, three in total
 		s.machineSizeList.Value = &[]compute.VirtualMachineSize{{Name: &standardGS3,
 			MaxDataDiskCount: &maxDataDiskCount, MemoryInMB: &memoryInMB, NumberOfCores: &numberOfCores,
 			OsDiskSizeInMB: &diskSizeInMB, ResourceDiskSizeInMB: &diskSizeInMB},
 			{Name: &standardFake,
 				MaxDataDiskCount: &maxDataDiskCount, MemoryInMB: &memoryInMB, NumberOfCores: &numberOfCores,
 				OsDiskSizeInMB: &diskSizeInMB, ResourceDiskSizeInMB: &diskSizeInMB},
+			{Name: &standardA5,
+				MaxDataDiskCount: &maxDataDiskCount, MemoryInMB: &memoryInMB, NumberOfCores: &numberOfCores,
+				OsDiskSizeInMB: &diskSizeInMB, ResourceDiskSizeInMB: &diskSizeInMB},
 		}
 	}
 	if location == locationUS {
@@ -29,14 +29,14 @@ import (
 	"k8c.io/kubermatic/v2/pkg/util/errors"
 )
 
-func HetznerSizeWithClusterCredentialsEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter, settingsProvider provider.SettingsProvider) endpoint.Endpoint {
+func HetznerSizeWithClusterCredentialsEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter, settingsProvider provider.SettingsProvider, sizeCache *providercommon.HetznerSizeCache) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(HetznerSizesNoCredentialsReq)
-		return providercommon.HetznerSizeWithClusterCredentialsEndpoint(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, settingsProvider, req.ProjectID, req.ClusterID)
+		return providercommon.HetznerSizeWithClusterCredentialsEndpoint(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, settingsProvider, sizeCache, req.ProjectID, req.ClusterID, req.Refresh, req.Features)
 	}
 }
 
-func HetznerSizeEndpoint(presetsProvider provider.PresetProvider, userInfoGetter provider.UserInfoGetter, settingsProvider provider.SettingsProvider) endpoint.Endpoint {
+func HetznerSizeEndpoint(presetsProvider provider.PresetProvider, userInfoGetter provider.UserInfoGetter, settingsProvider provider.SettingsProvider, sizeCache *providercommon.HetznerSizeCache) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(HetznerSizesReq)
 		token := req.HetznerToken
@@ -58,7 +58,7 @@ func HetznerSizeEndpoint(presetsProvider provider.PresetProvider, userInfoGetter
 		if err != nil {
 			return nil, common.KubernetesErrorToHTTPError(err)
 		}
-		return providercommon.HetznerSize(ctx, settings.Spec.MachineDeploymentVMResourceQuota, token)
+		return providercommon.HetznerSize(ctx, sizeCache, settings.Spec.MachineDeploymentVMResourceQuota, token, req.Refresh, req.Features)
 	}
 }
 
@@ -66,6 +66,12 @@ func HetznerSizeEndpoint(presetsProvider provider.PresetProvider, userInfoGetter
 // swagger:parameters listHetznerSizesNoCredentials
 type HetznerSizesNoCredentialsReq struct {
 	common.GetClusterReq
+	// in: query
+	// Refresh bypasses the cache of the Hetzner sizes
+	Refresh bool `json:"refresh,omitempty"`
+	// in: query
+	// Features limits the list to the sizes supporting all of the given features
+	Features []string `json:"features,omitempty"`
 }
 
 func DecodeHetznerSizesNoCredentialsReq(c context.Context, r *http.Request) (interface{}, error) {
@@ -76,6 +82,14 @@ func DecodeHetznerSizesNoCredentialsReq(c context.Context, r *http.Request) (int
 	}
 
 	req.GetClusterReq = cr.(common.GetClusterReq)
+	req.Refresh, err = providercommon.DecodeRefresh(r)
+	if err != nil {
+		return nil, err
+	}
+	req.Features, err = providercommon.DecodeHetznerFeatures(r)
+	if err != nil {
+		return nil, err
+	}
 	return req, nil
 }
 
@@ -88,12 +102,27 @@ type HetznerSizesReq struct {
 	// in: header
 	// Credential predefined Kubermatic credential name from the presets
 	Credential string
+	// in: query
+	// Refresh bypasses the cache of the Hetzner sizes
+	Refresh bool `json:"refresh,omitempty"`
+	// in: query
+	// Features limits the list to the sizes supporting all of the given features
+	Features []string `json:"features,omitempty"`
 }
 
 func DecodeHetznerSizesReq(c context.Context, r *http.Request) (interface{}, error) {
 	var req HetznerSizesReq
+	var err error
 
 	req.HetznerToken = r.Header.Get("HetznerToken")
 	req.Credential = r.Header.Get("Credential")
+	req.Refresh, err = providercommon.DecodeRefresh(r)
+	if err != nil {
+		return nil, err
+	}
+	req.Features, err = providercommon.DecodeHetznerFeatures(r)
+	if err != nil {
+		return nil, err
+	}
 	return req, nil
 }
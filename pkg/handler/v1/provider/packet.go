@@ -41,6 +41,9 @@ type PacketSizesReq struct {
 	// in: header
 	// name: Credential
 	Credential string `json:"credential"`
+	// in: header
+	// name: Facility
+	Facility string `json:"facility"`
 }
 
 // PacketSizesNoCredentialsReq represent a request for Packet sizes EP
@@ -55,6 +58,7 @@ func DecodePacketSizesReq(_ context.Context, r *http.Request) (interface{}, erro
 	req.APIKey = r.Header.Get("apiKey")
 	req.ProjectID = r.Header.Get("projectID")
 	req.Credential = r.Header.Get("credential")
+	req.Facility = r.Header.Get("facility")
 
 	return req, nil
 }
@@ -98,13 +102,18 @@ func PacketSizesEndpoint(presetsProvider provider.PresetProvider, userInfoGetter
 			return nil, common.KubernetesErrorToHTTPError(err)
 		}
 
-		return providercommon.PacketSizes(apiKey, projectID, settings.Spec.MachineDeploymentVMResourceQuota)
+		var facilities []string
+		if req.Facility != "" {
+			facilities = []string{req.Facility}
+		}
+
+		return providercommon.PacketSizes(apiKey, projectID, facilities, settings.Spec.MachineDeploymentVMResourceQuota)
 	}
 }
 
-func PacketSizesWithClusterCredentialsEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter, settingsProvider provider.SettingsProvider) endpoint.Endpoint {
+func PacketSizesWithClusterCredentialsEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, seedsGetter provider.SeedsGetter, userInfoGetter provider.UserInfoGetter, settingsProvider provider.SettingsProvider) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(PacketSizesNoCredentialsReq)
-		return providercommon.PacketSizesWithClusterCredentialsEndpoint(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, settingsProvider, req.ProjectID, req.ClusterID)
+		return providercommon.PacketSizesWithClusterCredentialsEndpoint(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, seedsGetter, settingsProvider, req.ProjectID, req.ClusterID)
 	}
 }
@@ -0,0 +1,59 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-kit/kit/endpoint"
+
+	providercommon "k8c.io/kubermatic/v2/pkg/handler/common/provider"
+	"k8c.io/kubermatic/v2/pkg/handler/v1/common"
+	"k8c.io/kubermatic/v2/pkg/provider"
+)
+
+func SizeWithClusterCredentialsEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter, settingsProvider provider.SettingsProvider, hetznerSizeCache *providercommon.HetznerSizeCache) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(SizesNoCredentialsReq)
+		return providercommon.SizeWithClusterCredentialsEndpoint(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, settingsProvider, hetznerSizeCache, req.ProjectID, req.ClusterID, req.Refresh)
+	}
+}
+
+// SizesNoCredentialsReq represent a request for the sizes of the cloud provider of a cluster
+// swagger:parameters listSizesNoCredentials
+type SizesNoCredentialsReq struct {
+	common.GetClusterReq
+	// in: query
+	// Refresh bypasses the cache of the sizes
+	Refresh bool `json:"refresh,omitempty"`
+}
+
+func DecodeSizesNoCredentialsReq(c context.Context, r *http.Request) (interface{}, error) {
+	var req SizesNoCredentialsReq
+	cr, err := common.DecodeGetClusterReq(c, r)
+	if err != nil {
+		return nil, err
+	}
+
+	req.GetClusterReq = cr.(common.GetClusterReq)
+	req.Refresh, err = providercommon.DecodeRefresh(r)
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
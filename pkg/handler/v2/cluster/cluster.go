@@ -18,10 +18,12 @@ package cluster
 
 import (
 	"context"
+	"crypto/sha256"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"sort"
 	"strconv"
 
 	"github.com/go-kit/kit/endpoint"
@@ -40,7 +42,8 @@ import (
 )
 
 func CreateEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, seedsGetter provider.SeedsGetter, credentialManager provider.PresetProvider,
-	exposeStrategy kubermaticv1.ExposeStrategy, userInfoGetter provider.UserInfoGetter, settingsProvider provider.SettingsProvider, updateManager common.UpdateManager) endpoint.Endpoint {
+	exposeStrategy kubermaticv1.ExposeStrategy, userInfoGetter provider.UserInfoGetter, settingsProvider provider.SettingsProvider, updateManager common.UpdateManager,
+	validateNodeDeployment handlercommon.NodeDeploymentValidator) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(CreateClusterReq)
 		globalSettings, err := settingsProvider.GetGlobalSettings()
@@ -52,7 +55,7 @@ func CreateEndpoint(projectProvider provider.ProjectProvider, privilegedProjectP
 			return nil, errors.NewBadRequest(err.Error())
 		}
 
-		return handlercommon.CreateEndpoint(ctx, req.ProjectID, req.Body, projectProvider, privilegedProjectProvider, seedsGetter, credentialManager, exposeStrategy, userInfoGetter)
+		return handlercommon.CreateEndpoint(ctx, req.ProjectID, req.Body, projectProvider, privilegedProjectProvider, seedsGetter, credentialManager, exposeStrategy, userInfoGetter, validateNodeDeployment)
 
 	}
 }
@@ -86,6 +89,70 @@ func ListEndpoint(projectProvider provider.ProjectProvider, privilegedProjectPro
 	}
 }
 
+// ListStatusEndpoint returns the status summaries of all clusters of the given project
+func ListStatusEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, seedsGetter provider.SeedsGetter, clusterProviderGetter provider.ClusterProviderGetter, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(ListStatusReq)
+
+		project, err := common.GetProject(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, req.ProjectID, nil)
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		seeds, err := seedsGetter()
+		if err != nil {
+			return nil, common.KubernetesErrorToHTTPError(err)
+		}
+
+		var clusters []kubermaticv1.Cluster
+		for _, seed := range seeds {
+			// if a Seed is bad, do not forward that error to the user, but only log
+			clusterProvider, err := clusterProviderGetter(seed)
+			if err != nil {
+				klog.Errorf("failed to create cluster provider for seed %s: %v", seed.Name, err)
+				continue
+			}
+			seedClusters, err := clusterProvider.List(project, nil)
+			if err != nil {
+				return nil, common.KubernetesErrorToHTTPError(err)
+			}
+			clusters = append(clusters, seedClusters.Items...)
+		}
+
+		return clusterStatusSummaries(clusters, req.IfNoneMatch), nil
+	}
+}
+
+// clusterStatusSummaries converts the clusters to status summaries sorted by their ID. The ETag is
+// derived from the resource versions of the clusters, so it changes with every change of a cluster
+// and when clusters are added or removed.
+func clusterStatusSummaries(clusters []kubermaticv1.Cluster, ifNoneMatch string) apiv1.ClusterStatusSummaryList {
+	sort.Slice(clusters, func(i, j int) bool {
+		return clusters[i].Name < clusters[j].Name
+	})
+
+	hash := sha256.New()
+	summaries := make([]apiv1.ClusterStatusSummary, 0, len(clusters))
+	for _, cluster := range clusters {
+		fmt.Fprintf(hash, "%s/%s\n", cluster.Name, cluster.ResourceVersion)
+		summaries = append(summaries, apiv1.ClusterStatusSummary{
+			ID:      cluster.Name,
+			Name:    cluster.Spec.HumanReadableName,
+			Phase:   cluster.Status.Phase,
+			Version: cluster.Spec.Version,
+			URL:     cluster.Address.URL,
+			Ready:   cluster.Status.ExtendedHealth.AllHealthy(),
+		})
+	}
+	etag := fmt.Sprintf("%q", fmt.Sprintf("%x", hash.Sum(nil)))
+
+	return apiv1.ClusterStatusSummaryList{
+		ETag:        etag,
+		NotModified: ifNoneMatch == etag,
+		Body:        summaries,
+	}
+}
+
 func GetEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(GetClusterReq)
@@ -114,6 +181,13 @@ func GetClusterEventsEndpoint(projectProvider provider.ProjectProvider, privileg
 	}
 }
 
+func GetClusterTimelineEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(GetClusterReq)
+		return handlercommon.GetClusterTimelineEndpoint(ctx, userInfoGetter, req.ProjectID, req.ClusterID, projectProvider, privilegedProjectProvider)
+	}
+}
+
 func HealthEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(GetClusterReq)
@@ -121,6 +195,17 @@ func HealthEndpoint(projectProvider provider.ProjectProvider, privilegedProjectP
 	}
 }
 
+func GetRootCAEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(GetClusterReq)
+		return handlercommon.GetRootCAEndpoint(ctx, userInfoGetter, req.ProjectID, req.ClusterID, projectProvider, privilegedProjectProvider)
+	}
+}
+
+func EncodeRootCA(c context.Context, w http.ResponseWriter, response interface{}) (err error) {
+	return handlercommon.EncodeRootCA(c, w, response)
+}
+
 func GetMetricsEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(GetClusterReq)
@@ -428,8 +513,31 @@ func DecodeDeleteReq(c context.Context, r *http.Request) (interface{}, error) {
 	return req, nil
 }
 
+// ListStatusReq defines HTTP request for listClusterStatusesV2 endpoint
+// swagger:parameters listClusterStatusesV2
+type ListStatusReq struct {
+	common.ProjectReq
+	// The ETag of a previous response, the statuses are only returned if a cluster changed since
+	// in: header
+	// name: If-None-Match
+	IfNoneMatch string
+}
+
+func DecodeListStatusReq(c context.Context, r *http.Request) (interface{}, error) {
+	var req ListStatusReq
+
+	pr, err := common.DecodeProjectRequest(c, r)
+	if err != nil {
+		return nil, err
+	}
+	req.ProjectReq = pr.(common.ProjectReq)
+	req.IfNoneMatch = r.Header.Get("If-None-Match")
+
+	return req, nil
+}
+
 // GetClusterReq defines HTTP request for getCluster endpoint.
-// swagger:parameters getClusterV2 getClusterHealthV2 getOidcClusterKubeconfigV2 getClusterKubeconfigV2 getClusterMetricsV2 listNamespaceV2 getClusterUpgradesV2 listAWSSizesNoCredentialsV2 listAWSSubnetsNoCredentialsV2 listGCPNetworksNoCredentialsV2 listGCPZonesNoCredentialsV2 listHetznerSizesNoCredentialsV2 listDigitaloceanSizesNoCredentialsV2
+// swagger:parameters getClusterV2 getClusterHealthV2 getOidcClusterKubeconfigV2 getClusterKubeconfigV2 getClusterMetricsV2 listNamespaceV2 getClusterUpgradesV2 listAWSSizesNoCredentialsV2 listAWSSubnetsNoCredentialsV2 listGCPNetworksNoCredentialsV2 listGCPZonesNoCredentialsV2 listHetznerSizesNoCredentialsV2 listDigitaloceanSizesNoCredentialsV2 getClusterRootCAV2 getClusterTimelineV2
 type GetClusterReq struct {
 	common.ProjectReq
 	// in: path
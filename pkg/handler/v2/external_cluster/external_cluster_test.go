@@ -656,7 +656,7 @@ func TestGetClusterEvents(t *testing.T) {
 		// scenario 1
 		{
 			Name:             "scenario 1: gets all cluster events",
-			ExpectedResponse: `[{"name":"event-1","creationTimestamp":"0001-01-01T00:00:00Z","message":"message started","type":"Normal","involvedObject":{"type":"Node","namespace":"kube-system","name":"testMachine"},"lastTimestamp":"0001-01-01T00:00:00Z","count":1},{"name":"event-2","creationTimestamp":"0001-01-01T00:00:00Z","message":"message killed","type":"Warning","involvedObject":{"type":"Node","namespace":"kube-system","name":"testMachine"},"lastTimestamp":"0001-01-01T00:00:00Z","count":1}]`,
+			ExpectedResponse: `[{"name":"event-1","creationTimestamp":"0001-01-01T00:00:00Z","message":"message started","reason":"Started","type":"Normal","involvedObject":{"type":"Node","namespace":"kube-system","name":"testMachine"},"lastTimestamp":"0001-01-01T00:00:00Z","count":1},{"name":"event-2","creationTimestamp":"0001-01-01T00:00:00Z","message":"message killed","reason":"Killed","type":"Warning","involvedObject":{"type":"Node","namespace":"kube-system","name":"testMachine"},"lastTimestamp":"0001-01-01T00:00:00Z","count":1}]`,
 			ClusterToGet:     "clusterAbcID",
 			HTTPStatus:       http.StatusOK,
 			ExistingNodes: []*corev1.Node{
@@ -674,7 +674,7 @@ func TestGetClusterEvents(t *testing.T) {
 		// scenario 2
 		{
 			Name:             "scenario 2: gets only warning events",
-			ExpectedResponse: `[{"name":"event-2","creationTimestamp":"0001-01-01T00:00:00Z","message":"message killed","type":"Warning","involvedObject":{"type":"Node","namespace":"kube-system","name":"testMachine"},"lastTimestamp":"0001-01-01T00:00:00Z","count":1}]`,
+			ExpectedResponse: `[{"name":"event-2","creationTimestamp":"0001-01-01T00:00:00Z","message":"message killed","reason":"Killed","type":"Warning","involvedObject":{"type":"Node","namespace":"kube-system","name":"testMachine"},"lastTimestamp":"0001-01-01T00:00:00Z","count":1}]`,
 			QueryParams:      "?type=warning",
 			ClusterToGet:     "clusterAbcID",
 			HTTPStatus:       http.StatusOK,
@@ -693,7 +693,7 @@ func TestGetClusterEvents(t *testing.T) {
 		// scenario 3
 		{
 			Name:             "scenario 3: the admin John can get any cluster events",
-			ExpectedResponse: `[{"name":"event-1","creationTimestamp":"0001-01-01T00:00:00Z","message":"message started","type":"Normal","involvedObject":{"type":"Node","namespace":"kube-system","name":"testMachine"},"lastTimestamp":"0001-01-01T00:00:00Z","count":1},{"name":"event-2","creationTimestamp":"0001-01-01T00:00:00Z","message":"message killed","type":"Warning","involvedObject":{"type":"Node","namespace":"kube-system","name":"testMachine"},"lastTimestamp":"0001-01-01T00:00:00Z","count":1}]`,
+			ExpectedResponse: `[{"name":"event-1","creationTimestamp":"0001-01-01T00:00:00Z","message":"message started","reason":"Started","type":"Normal","involvedObject":{"type":"Node","namespace":"kube-system","name":"testMachine"},"lastTimestamp":"0001-01-01T00:00:00Z","count":1},{"name":"event-2","creationTimestamp":"0001-01-01T00:00:00Z","message":"message killed","reason":"Killed","type":"Warning","involvedObject":{"type":"Node","namespace":"kube-system","name":"testMachine"},"lastTimestamp":"0001-01-01T00:00:00Z","count":1}]`,
 			ClusterToGet:     "clusterAbcID",
 			HTTPStatus:       http.StatusOK,
 			ExistingNodes: []*corev1.Node{
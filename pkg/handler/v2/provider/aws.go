@@ -18,19 +18,61 @@ package provider
 
 import (
 	"context"
+	"net/http"
 
 	"github.com/go-kit/kit/endpoint"
 
+	apiv1 "k8c.io/kubermatic/v2/pkg/api/v1"
 	providercommon "k8c.io/kubermatic/v2/pkg/handler/common/provider"
+	"k8c.io/kubermatic/v2/pkg/handler/v1/common"
 	"k8c.io/kubermatic/v2/pkg/handler/v2/cluster"
 	"k8c.io/kubermatic/v2/pkg/provider"
 )
 
+// awsSizeNoCredentialsReq represent a request for AWS VM sizes of a cluster.
+// swagger:parameters listAWSSizesNoCredentialsV2
+type awsSizeNoCredentialsReq struct {
+	common.ProjectReq
+	// in: path
+	// required: true
+	ClusterID string `json:"cluster_id"`
+	// in: header
+	// name: AvailabilityZone
+	AvailabilityZone string
+}
+
+// GetSeedCluster returns the SeedCluster object
+func (req awsSizeNoCredentialsReq) GetSeedCluster() apiv1.SeedCluster {
+	return apiv1.SeedCluster{
+		ClusterID: req.ClusterID,
+	}
+}
+
+func DecodeAWSSizeNoCredentialsReq(c context.Context, r *http.Request) (interface{}, error) {
+	var req awsSizeNoCredentialsReq
+	clusterID, err := common.DecodeClusterID(c, r)
+	if err != nil {
+		return nil, err
+	}
+
+	req.ClusterID = clusterID
+
+	pr, err := common.DecodeProjectRequest(c, r)
+	if err != nil {
+		return nil, err
+	}
+
+	req.ProjectReq = pr.(common.ProjectReq)
+	req.AvailabilityZone = r.Header.Get("AvailabilityZone")
+
+	return req, nil
+}
+
 // AWSSizeNoCredentialsEndpoint handles the request to list available AWS sizes.
 func AWSSizeNoCredentialsEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, seedsGetter provider.SeedsGetter, settingsProvider provider.SettingsProvider, userInfoGetter provider.UserInfoGetter) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
-		req := request.(cluster.GetClusterReq)
-		return providercommon.AWSSizeNoCredentialsEndpoint(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, seedsGetter, settingsProvider, req.ProjectID, req.ClusterID)
+		req := request.(awsSizeNoCredentialsReq)
+		return providercommon.AWSSizeWithClusterCredentialsEndpoint(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, seedsGetter, settingsProvider, req.ProjectID, req.ClusterID, req.AvailabilityZone)
 	}
 }
 
@@ -18,6 +18,7 @@ package provider
 
 import (
 	"context"
+	"net/http"
 
 	"github.com/go-kit/kit/endpoint"
 
@@ -26,9 +27,40 @@ import (
 	"k8c.io/kubermatic/v2/pkg/provider"
 )
 
-func HetznerSizeWithClusterCredentialsEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter, settingsProvider provider.SettingsProvider) endpoint.Endpoint {
+func HetznerSizeWithClusterCredentialsEndpoint(projectProvider provider.ProjectProvider, privilegedProjectProvider provider.PrivilegedProjectProvider, userInfoGetter provider.UserInfoGetter, settingsProvider provider.SettingsProvider, sizeCache *providercommon.HetznerSizeCache) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
-		req := request.(cluster.GetClusterReq)
-		return providercommon.HetznerSizeWithClusterCredentialsEndpoint(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, settingsProvider, req.ProjectID, req.ClusterID)
+		req := request.(hetznerSizesNoCredentialsReq)
+		return providercommon.HetznerSizeWithClusterCredentialsEndpoint(ctx, userInfoGetter, projectProvider, privilegedProjectProvider, settingsProvider, sizeCache, req.ProjectID, req.ClusterID, req.Refresh, req.Features)
 	}
 }
+
+// hetznerSizesNoCredentialsReq represent a request for the Hetzner sizes of a cluster
+// swagger:parameters listHetznerSizesNoCredentialsV2
+type hetznerSizesNoCredentialsReq struct {
+	cluster.GetClusterReq
+	// in: query
+	// Refresh bypasses the cache of the Hetzner sizes
+	Refresh bool `json:"refresh,omitempty"`
+	// in: query
+	// Features limits the list to the sizes supporting all of the given features
+	Features []string `json:"features,omitempty"`
+}
+
+func DecodeHetznerSizesNoCredentialsReq(c context.Context, r *http.Request) (interface{}, error) {
+	var req hetznerSizesNoCredentialsReq
+	cr, err := cluster.DecodeGetClusterReq(c, r)
+	if err != nil {
+		return nil, err
+	}
+
+	req.GetClusterReq = cr.(cluster.GetClusterReq)
+	req.Refresh, err = providercommon.DecodeRefresh(r)
+	if err != nil {
+		return nil, err
+	}
+	req.Features, err = providercommon.DecodeHetznerFeatures(r)
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
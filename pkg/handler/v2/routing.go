@@ -23,6 +23,7 @@ import (
 	prometheusapi "github.com/prometheus/client_golang/api"
 	"k8c.io/kubermatic/v2/pkg/handler"
 	"k8c.io/kubermatic/v2/pkg/handler/auth"
+	providercommon "k8c.io/kubermatic/v2/pkg/handler/common/provider"
 	"k8c.io/kubermatic/v2/pkg/handler/middleware"
 	"k8c.io/kubermatic/v2/pkg/serviceaccount"
 	"k8c.io/kubermatic/v2/pkg/version/kubermatic"
@@ -80,6 +81,7 @@ type Routing struct {
 	constraintTemplateProvider            provider.ConstraintTemplateProvider
 	constraintProvider                    provider.ConstraintProvider
 	privilegedConstraintProvider          provider.PrivilegedConstraintProvider
+	hetznerSizeCache                      *providercommon.HetznerSizeCache
 	versions                              kubermatic.Versions
 }
 
@@ -127,6 +129,7 @@ func NewV2Routing(routingParams handler.RoutingParams) Routing {
 		constraintTemplateProvider:            routingParams.ConstraintTemplateProvider,
 		constraintProvider:                    routingParams.ConstraintProvider,
 		privilegedConstraintProvider:          routingParams.PrivilegedConstraintProvider,
+		hetznerSizeCache:                      routingParams.HetznerSizeCache,
 		versions:                              routingParams.Versions,
 	}
 }
@@ -0,0 +1,75 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	// providerAPIRequestDuration is the latency of the calls to the API of a cloud
+	// provider, partitioned by the provider and the operation.
+	providerAPIRequestDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "kubermatic_cloud_provider_api_request_duration_seconds",
+			Help:    "Latency of the requests to the API of a cloud provider in seconds. Broken down by provider and operation.",
+			Buckets: []float64{.01, .025, .05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+		},
+		[]string{"provider", "operation"},
+	)
+
+	providerAPIRequestErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "kubermatic_cloud_provider_api_request_errors_total",
+			Help: "Number of failed requests to the API of a cloud provider. Broken down by provider and operation.",
+		},
+		[]string{"provider", "operation"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(providerAPIRequestDuration)
+	prometheus.MustRegister(providerAPIRequestErrors)
+}
+
+// ProviderAPIObserver instruments the calls of a cloud provider client to the API of the provider.
+type ProviderAPIObserver interface {
+	// Observe runs the call of the given operation and records its latency and whether it failed.
+	Observe(operation string, call func() error) error
+}
+
+type providerAPIObserver struct {
+	provider string
+}
+
+// NewProviderAPIObserver returns a ProviderAPIObserver which records the calls to the API of the
+// given cloud provider.
+func NewProviderAPIObserver(provider string) ProviderAPIObserver {
+	return &providerAPIObserver{provider: provider}
+}
+
+func (o *providerAPIObserver) Observe(operation string, call func() error) error {
+	start := time.Now()
+	err := call()
+	providerAPIRequestDuration.WithLabelValues(o.provider, operation).Observe(time.Since(start).Seconds())
+	if err != nil {
+		providerAPIRequestErrors.WithLabelValues(o.provider, operation).Inc()
+	}
+	return err
+}
@@ -0,0 +1,46 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestProviderAPIObserver(t *testing.T) {
+	observer := NewProviderAPIObserver("test")
+
+	if err := observer.Observe("Succeed", func() error { return nil }); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	callErr := errors.New("request failed")
+	if err := observer.Observe("Fail", func() error { return callErr }); err != callErr {
+		t.Fatalf("expected the error of the call to be returned, got %v", err)
+	}
+
+	if n := testutil.CollectAndCount(providerAPIRequestDuration); n != 2 {
+		t.Errorf("expected latencies of 2 operations, got %d", n)
+	}
+	if v := testutil.ToFloat64(providerAPIRequestErrors.WithLabelValues("test", "Succeed")); v != 0 {
+		t.Errorf("expected no errors for the successful operation, got %v", v)
+	}
+	if v := testutil.ToFloat64(providerAPIRequestErrors.WithLabelValues("test", "Fail")); v != 1 {
+		t.Errorf("expected 1 error for the failed operation, got %v", v)
+	}
+}
@@ -32,6 +32,7 @@ import (
 	"k8c.io/kubermatic/v2/pkg/resources"
 	httperror "k8c.io/kubermatic/v2/pkg/util/errors"
 
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog"
 )
 
@@ -734,3 +735,46 @@ func GetSecurityGroups(accessKeyID, secretAccessKey, region string) ([]*ec2.Secu
 
 	return sgOut.SecurityGroups, nil
 }
+
+// GetInstanceTypeOfferings returns the names of the instance types offered in the given region. If an
+// availability zone is given, only the instance types offered in this zone are returned, as not all
+// instance types are available in all zones of a region.
+func GetInstanceTypeOfferings(accessKeyID, secretAccessKey, region, availabilityZone string) (sets.String, error) {
+	client, err := GetClientSet(accessKeyID, secretAccessKey, region)
+	if err != nil {
+		return nil, err
+	}
+
+	return getInstanceTypeOfferings(client.EC2, region, availabilityZone)
+}
+
+func getInstanceTypeOfferings(client ec2iface.EC2API, region, availabilityZone string) (sets.String, error) {
+	locationType, location := ec2.LocationTypeRegion, region
+	if availabilityZone != "" {
+		locationType, location = ec2.LocationTypeAvailabilityZone, availabilityZone
+	}
+
+	input := &ec2.DescribeInstanceTypeOfferingsInput{
+		LocationType: aws.String(locationType),
+		Filters: []*ec2.Filter{
+			{Name: aws.String("location"), Values: []*string{aws.String(location)}},
+		},
+	}
+
+	offered := sets.NewString()
+	err := client.DescribeInstanceTypeOfferingsPages(input, func(page *ec2.DescribeInstanceTypeOfferingsOutput, _ bool) bool {
+		for _, offering := range page.InstanceTypeOfferings {
+			offered.Insert(aws.StringValue(offering.InstanceType))
+		}
+		return true
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == authFailure {
+			return nil, httperror.New(401, fmt.Sprintf("failed to list instance type offerings: %s", awsErr.Message()))
+		}
+
+		return nil, fmt.Errorf("failed to list instance type offerings: %v", err)
+	}
+
+	return offered, nil
+}
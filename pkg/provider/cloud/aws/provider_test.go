@@ -0,0 +1,103 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// fakeInstanceTypeOfferingsClient is a fake client which returns the offerings of the
+// requested location in pages of a single offering.
+type fakeInstanceTypeOfferingsClient struct {
+	ec2iface.EC2API
+	offerings map[string][]string
+	err       error
+}
+
+func (c *fakeInstanceTypeOfferingsClient) DescribeInstanceTypeOfferingsPages(input *ec2.DescribeInstanceTypeOfferingsInput, fn func(*ec2.DescribeInstanceTypeOfferingsOutput, bool) bool) error {
+	if c.err != nil {
+		return c.err
+	}
+
+	instanceTypes := c.offerings[aws.StringValue(input.LocationType)+"/"+aws.StringValue(input.Filters[0].Values[0])]
+	for i, instanceType := range instanceTypes {
+		page := &ec2.DescribeInstanceTypeOfferingsOutput{
+			InstanceTypeOfferings: []*ec2.InstanceTypeOffering{{InstanceType: aws.String(instanceType)}},
+		}
+		if !fn(page, i == len(instanceTypes)-1) {
+			break
+		}
+	}
+	return nil
+}
+
+func TestGetInstanceTypeOfferings(t *testing.T) {
+	client := &fakeInstanceTypeOfferingsClient{
+		offerings: map[string][]string{
+			"region/eu-central-1":             {"t3.small", "t3.medium", "p3.2xlarge"},
+			"availability-zone/eu-central-1a": {"t3.small", "t3.medium"},
+			"availability-zone/eu-central-1b": {"t3.small"},
+		},
+	}
+
+	testCases := []struct {
+		name             string
+		client           ec2iface.EC2API
+		availabilityZone string
+		expected         sets.String
+		expectErr        bool
+	}{
+		{
+			name:     "all offerings of the region",
+			client:   client,
+			expected: sets.NewString("t3.small", "t3.medium", "p3.2xlarge"),
+		},
+		{
+			name:             "offerings of an availability zone",
+			client:           client,
+			availabilityZone: "eu-central-1b",
+			expected:         sets.NewString("t3.small"),
+		},
+		{
+			name:      "API error",
+			client:    &fakeInstanceTypeOfferingsClient{err: errors.New("throttled")},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			offered, err := getInstanceTypeOfferings(tc.client, "eu-central-1", tc.availabilityZone)
+			if (err != nil) != tc.expectErr {
+				t.Fatalf("expected error: %v, got: %v", tc.expectErr, err)
+			}
+			if tc.expectErr {
+				return
+			}
+			if !offered.Equal(tc.expected) {
+				t.Errorf("expected offerings %v, got %v", tc.expected.List(), offered.List())
+			}
+		})
+	}
+}
@@ -23,10 +23,14 @@ import (
 
 	"github.com/hetznercloud/hcloud-go/hcloud"
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/metrics"
 	"k8c.io/kubermatic/v2/pkg/provider"
 	"k8c.io/kubermatic/v2/pkg/resources"
 )
 
+// APIObserver records the latency and errors of the calls to the Hetzner API.
+var APIObserver = metrics.NewProviderAPIObserver(provider.HetznerCloudProvider)
+
 type hetzner struct {
 	secretKeySelector provider.SecretKeySelectorValueFunc
 }
@@ -57,13 +61,17 @@ func (h *hetzner) ValidateCloudSpec(spec kubermaticv1.CloudSpec) error {
 
 	if spec.Hetzner.Network == "" {
 		// this validates the token
-		_, _, err = client.ServerType.List(timeout, hcloud.ServerTypeListOpts{})
-	} else {
-		// this validates network and implicitly the token
-		_, _, err = client.Network.GetByName(timeout, spec.Hetzner.Network)
+		return APIObserver.Observe("ServerType.List", func() error {
+			_, _, err := client.ServerType.List(timeout, hcloud.ServerTypeListOpts{})
+			return err
+		})
 	}
 
-	return err
+	// this validates network and implicitly the token
+	return APIObserver.Observe("Network.GetByName", func() error {
+		_, _, err := client.Network.GetByName(timeout, spec.Hetzner.Network)
+		return err
+	})
 }
 
 // InitializeCloudProvider
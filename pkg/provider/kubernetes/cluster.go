@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"time"
 
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
@@ -164,7 +165,6 @@ func genAPICluster(project *kubermaticv1.Project, cluster *kubermaticv1.Cluster,
 		Spec: cluster.Spec,
 		Status: kubermaticv1.ClusterStatus{
 			UserEmail:              email,
-			NamespaceName:          NamespaceName(name),
 			CloudMigrationRevision: cloud.CurrentMigrationRevision,
 			KubermaticVersion:      versions.Kubermatic,
 			ExtendedHealth: kubermaticv1.ExtendedClusterHealth{
@@ -322,6 +322,13 @@ func (p *ClusterProvider) GetViewerKubeconfigForCustomerCluster(c *kubermaticv1.
 	return clientcmd.Load(d)
 }
 
+// GetRootCACertificateForCustomerCluster returns the PEM encoded root CA certificate of the given cluster.
+// The certificates are read from the CA secret. While the root CA is rotated, the bundle contains
+// both the old and the new CA, so clients trusting it keep working during the whole rotation.
+func (p *ClusterProvider) GetRootCACertificateForCustomerCluster(c *kubermaticv1.Cluster) ([]byte, error) {
+	return resources.GetClusterRootCABundle(context.Background(), c.Status.NamespaceName, p.GetSeedClusterAdminRuntimeClient())
+}
+
 // RevokeViewerKubeconfig revokes the viewer token and kubeconfig
 func (p *ClusterProvider) RevokeViewerKubeconfig(c *kubermaticv1.Cluster) error {
 	s := &corev1.Secret{
@@ -348,6 +355,65 @@ func (p *ClusterProvider) RevokeAdminKubeconfig(c *kubermaticv1.Cluster) error {
 	return nil
 }
 
+// RequestReconcile makes the controllers reconcile the cluster right away. If clearError is set,
+// the error of the cluster is cleared as well, so the controllers retry a failed cluster.
+func (p *ClusterProvider) RequestReconcile(userInfo *provider.UserInfo, c *kubermaticv1.Cluster, clearError bool) error {
+	seedImpersonatedClient, err := createImpersonationClientWrapperFromUserInfo(userInfo, p.createSeedImpersonatedClient)
+	if err != nil {
+		return err
+	}
+	return requestReconcile(seedImpersonatedClient, c, clearError)
+}
+
+// RequestReconcileUnsecured makes the controllers reconcile the cluster right away.
+//
+// Note that the admin privileges are used to patch the cluster
+func (p *ClusterProvider) RequestReconcileUnsecured(c *kubermaticv1.Cluster, clearError bool) error {
+	return requestReconcile(p.client, c, clearError)
+}
+
+func requestReconcile(client ctrlruntimeclient.Client, c *kubermaticv1.Cluster, clearError bool) error {
+	oldCluster := c.DeepCopy()
+	if c.Annotations == nil {
+		c.Annotations = map[string]string{}
+	}
+	c.Annotations[kubermaticv1.ReconcileRequestedAnnotation] = time.Now().UTC().Format(time.RFC3339Nano)
+	if clearError {
+		if c.Status.ErrorReason != nil && *c.Status.ErrorReason == kubermaticv1.LaunchTimeoutClusterError {
+			restartLaunch(c)
+		}
+		c.Status.ErrorReason = nil
+		c.Status.ErrorMessage = nil
+	}
+	if err := client.Patch(context.Background(), c, ctrlruntimeclient.MergeFrom(oldCluster)); err != nil {
+		return fmt.Errorf("failed to patch cluster: %v", err)
+	}
+	return nil
+}
+
+// restartLaunch resets the launch start time of the cluster, which is the transition of its
+// ClusterInitialized condition to false. Otherwise the launch timeout would fail the cluster
+// again on the next reconciliation.
+func restartLaunch(c *kubermaticv1.Cluster) {
+	now := metav1.Now()
+	for i := range c.Status.Conditions {
+		if c.Status.Conditions[i].Type == kubermaticv1.ClusterConditionClusterInitialized {
+			c.Status.Conditions[i].Status = corev1.ConditionFalse
+			c.Status.Conditions[i].LastHeartbeatTime = now
+			c.Status.Conditions[i].LastTransitionTime = now
+			return
+		}
+	}
+	c.Status.Conditions = append(c.Status.Conditions, kubermaticv1.ClusterCondition{
+		Type:               kubermaticv1.ClusterConditionClusterInitialized,
+		Status:             corev1.ConditionFalse,
+		LastHeartbeatTime:  now,
+		LastTransitionTime: now,
+		Reason:             "LaunchRestarted",
+		Message:            "The launch was restarted after the launch timeout error was cleared",
+	})
+}
+
 // GetAdminClientForCustomerCluster returns a client to interact with all resources in the given cluster
 //
 // Note that the client you will get has admin privileges
@@ -33,9 +33,14 @@ const (
 // impersonationClient gives runtime controller client that uses user impersonation
 type impersonationClient func(impCfg restclient.ImpersonationConfig) (ctrlruntimeclient.Client, error)
 
-// NamespaceName returns the namespace name for a cluster
+// NamespaceName returns the namespace name for a cluster using the default prefix
 func NamespaceName(clusterName string) string {
-	return NamespacePrefix + clusterName
+	return NamespaceNameWithPrefix(NamespacePrefix, clusterName)
+}
+
+// NamespaceNameWithPrefix returns the namespace name for a cluster using the given prefix
+func NamespaceNameWithPrefix(prefix, clusterName string) string {
+	return prefix + clusterName
 }
 
 // createImpersonationClientWrapperFromUserInfo is a helper method that spits back controller runtime client that uses user impersonation
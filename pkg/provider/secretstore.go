@@ -0,0 +1,51 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+)
+
+// ExternalSecretStorePathAnnotation is set on credential secrets whose values are kept in an
+// external secret store. Such secrets only hold a reference, the values are read from the
+// SecretStore of the context at the path of the annotation.
+const ExternalSecretStorePathAnnotation = "kubermatic.io/external-secret-store-path"
+
+// SecretStore resolves credentials which are kept outside of Kubernetes, e.g. in Vault
+type SecretStore interface {
+	// GetSecretValue returns the value of the key of the secret at the given path. Paths
+	// outside of the part of the store Kubermatic may read from are rejected.
+	GetSecretValue(ctx context.Context, path, key string) (string, error)
+}
+
+type secretStoreContextKey struct{}
+
+// WithSecretStore returns a context which makes SecretKeySelectorValueFuncFactory read credential
+// secrets annotated with ExternalSecretStorePathAnnotation from the given store. Without a store,
+// the context is returned as is and such secrets cannot be resolved.
+func WithSecretStore(ctx context.Context, store SecretStore) context.Context {
+	if store == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, secretStoreContextKey{}, store)
+}
+
+// secretStoreFromContext returns the store configured by WithSecretStore, or nil.
+func secretStoreFromContext(ctx context.Context) SecretStore {
+	store, _ := ctx.Value(secretStoreContextKey{}).(SecretStore)
+	return store
+}
@@ -94,6 +94,8 @@ type ClusterGetOptions struct {
 
 // SecretKeySelectorValueFunc is used to fetch the value of a config var. Do not build your own
 // implementation, use SecretKeySelectorValueFuncFactory.
+// If the referenced secret is annotated with ExternalSecretStorePathAnnotation, the value is read
+// from the external secret store of the context instead of the secret, see WithSecretStore.
 type SecretKeySelectorValueFunc func(configVar *providerconfig.GlobalSecretKeySelector, key string) (string, error)
 
 func SecretKeySelectorValueFuncFactory(ctx context.Context, client ctrlruntimeclient.Client) SecretKeySelectorValueFunc {
@@ -117,6 +119,18 @@ func SecretKeySelectorValueFuncFactory(ctx context.Context, client ctrlruntimecl
 			return "", fmt.Errorf("failed to get secret %q: %v", namespacedName.String(), err)
 		}
 
+		if path, ok := secret.Annotations[ExternalSecretStorePathAnnotation]; ok {
+			externalSecretStore := secretStoreFromContext(ctx)
+			if externalSecretStore == nil {
+				return "", fmt.Errorf("secret %q references the external secret store path %q, but no external secret store is configured", namespacedName.String(), path)
+			}
+			value, err := externalSecretStore.GetSecretValue(ctx, path, key)
+			if err != nil {
+				return "", fmt.Errorf("failed to get key %q of secret %q from the external secret store: %v", key, namespacedName.String(), err)
+			}
+			return value, nil
+		}
+
 		if _, ok := secret.Data[key]; !ok {
 			return "", fmt.Errorf("secret %q has no key %q", namespacedName.String(), key)
 		}
@@ -173,12 +187,19 @@ type ClusterProvider interface {
 	// GetViewerKubeconfigForCustomerCluster returns the viewer kubeconfig for the given cluster
 	GetViewerKubeconfigForCustomerCluster(cluster *kubermaticv1.Cluster) (*clientcmdapi.Config, error)
 
+	// GetRootCACertificateForCustomerCluster returns the PEM encoded root CA certificate of the given cluster
+	GetRootCACertificateForCustomerCluster(cluster *kubermaticv1.Cluster) ([]byte, error)
+
 	// RevokeViewerKubeconfig revokes viewer token and kubeconfig
 	RevokeViewerKubeconfig(c *kubermaticv1.Cluster) error
 
 	// RevokeAdminKubeconfig revokes the viewer token and kubeconfig
 	RevokeAdminKubeconfig(c *kubermaticv1.Cluster) error
 
+	// RequestReconcile makes the controllers reconcile the cluster right away. If clearError is set,
+	// the error of the cluster is cleared as well, so the controllers retry a failed cluster.
+	RequestReconcile(userInfo *UserInfo, c *kubermaticv1.Cluster, clearError bool) error
+
 	// GetAdminClientForCustomerCluster returns a client to interact with all resources in the given cluster
 	//
 	// Note that the client you will get has admin privileges
@@ -225,6 +246,12 @@ type PrivilegedClusterProvider interface {
 	// Note that the admin privileges are used to delete cluster
 	DeleteUnsecured(cluster *kubermaticv1.Cluster) error
 
+	// RequestReconcileUnsecured makes the controllers reconcile the cluster right away. If clearError
+	// is set, the error of the cluster is cleared as well.
+	//
+	// Note that the admin privileges are used to patch the cluster
+	RequestReconcileUnsecured(c *kubermaticv1.Cluster, clearError bool) error
+
 	// NewUnsecured creates a brand new cluster that is bound to the given project.
 	//
 	// Note that the admin privileges are used to create cluster
@@ -18,6 +18,7 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	providerconfig "github.com/kubermatic/machine-controller/pkg/providerconfig/types"
@@ -33,6 +34,7 @@ func TestSecretKeySelectorValueFuncFactory(t *testing.T) {
 		configVar *providerconfig.GlobalSecretKeySelector
 		secret    *corev1.Secret
 		key       string
+		store     SecretStore
 
 		expectedError  string
 		expectedResult string
@@ -89,6 +91,62 @@ func TestSecretKeySelectorValueFuncFactory(t *testing.T) {
 			},
 			expectedResult: "value",
 		},
+		{
+			name: "value from external secret store",
+			configVar: &providerconfig.GlobalSecretKeySelector{
+				ObjectReference: corev1.ObjectReference{
+					Namespace: "default",
+					Name:      "foo",
+				},
+			},
+			key: "bar",
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "default",
+					Name:        "foo",
+					Annotations: map[string]string{ExternalSecretStorePathAnnotation: "kv/foo"},
+				},
+			},
+			store:          fakeSecretStore{"kv/foo": {"bar": "external-value"}},
+			expectedResult: "external-value",
+		},
+		{
+			name: "error on missing key in external secret store",
+			configVar: &providerconfig.GlobalSecretKeySelector{
+				ObjectReference: corev1.ObjectReference{
+					Namespace: "default",
+					Name:      "foo",
+				},
+			},
+			key: "baz",
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "default",
+					Name:        "foo",
+					Annotations: map[string]string{ExternalSecretStorePathAnnotation: "kv/foo"},
+				},
+			},
+			store:         fakeSecretStore{"kv/foo": {"bar": "external-value"}},
+			expectedError: `failed to get key "baz" of secret "default/foo" from the external secret store: not found`,
+		},
+		{
+			name: "error on unconfigured external secret store",
+			configVar: &providerconfig.GlobalSecretKeySelector{
+				ObjectReference: corev1.ObjectReference{
+					Namespace: "default",
+					Name:      "foo",
+				},
+			},
+			key: "bar",
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "default",
+					Name:        "foo",
+					Annotations: map[string]string{ExternalSecretStorePathAnnotation: "kv/foo"},
+				},
+			},
+			expectedError: `secret "default/foo" references the external secret store path "kv/foo", but no external secret store is configured`,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -99,7 +157,7 @@ func TestSecretKeySelectorValueFuncFactory(t *testing.T) {
 			}
 
 			client := clientBuilder.Build()
-			valueFunc := SecretKeySelectorValueFuncFactory(context.Background(), client)
+			valueFunc := SecretKeySelectorValueFuncFactory(WithSecretStore(context.Background(), tc.store), client)
 
 			result, err := valueFunc(tc.configVar, tc.key)
 
@@ -118,3 +176,13 @@ func TestSecretKeySelectorValueFuncFactory(t *testing.T) {
 		})
 	}
 }
+
+type fakeSecretStore map[string]map[string]string
+
+func (s fakeSecretStore) GetSecretValue(_ context.Context, path, key string) (string, error) {
+	value, ok := s[path][key]
+	if !ok {
+		return "", errors.New("not found")
+	}
+	return value, nil
+}
@@ -0,0 +1,287 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package vault implements a provider.SecretStore which reads credentials from the
+// key/value secrets engine of HashiCorp Vault.
+package vault
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"k8c.io/kubermatic/v2/pkg/provider"
+)
+
+const (
+	// DefaultAuthMount is the default mount path of the Kubernetes auth method
+	DefaultAuthMount = "kubernetes"
+	// DefaultServiceAccountTokenFile is the service account token of the pod, which is used to
+	// log in with the Kubernetes auth method
+	DefaultServiceAccountTokenFile = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+
+	requestTimeout = 30 * time.Second
+)
+
+// validPath matches slash-separated paths without empty, relative or escaped segments
+var validPath = regexp.MustCompile(`^[a-zA-Z0-9_.-]+(/[a-zA-Z0-9_.-]+)*$`)
+
+// Options configures the Vault secret store.
+type Options struct {
+	// Address of the Vault server
+	Address string
+	// PathPrefix is the path secrets are read from, e.g. "secret/data/kubermatic". Secrets
+	// outside of it are rejected, so that credential secrets can not reference arbitrary
+	// secrets the store has access to.
+	PathPrefix string
+	// AuthMount is the mount path of the Kubernetes auth method, defaults to DefaultAuthMount
+	AuthMount string
+	// AuthRole is the role of the Kubernetes auth method the store logs in with
+	AuthRole string
+	// ServiceAccountTokenFile is the service account token the store logs in with, defaults
+	// to DefaultServiceAccountTokenFile
+	ServiceAccountTokenFile string
+}
+
+// AddFlags adds the flags configuring the Vault secret store to the given FlagSet.
+func (o *Options) AddFlags(fs *flag.FlagSet) {
+	fs.StringVar(&o.Address, "vault-address", "", "Address of the Vault server cloud credentials can be read from. Leave empty to only read credentials from Kubernetes secrets.")
+	fs.StringVar(&o.PathPrefix, "vault-path-prefix", "", "Path in Vault credential secrets can reference, e.g. secret/data/kubermatic. Secrets outside of it are rejected.")
+	fs.StringVar(&o.AuthRole, "vault-auth-role", "", "Role of the Vault Kubernetes auth method used to log in with the service account of the pod.")
+	fs.StringVar(&o.AuthMount, "vault-auth-mount", DefaultAuthMount, "Mount path of the Vault Kubernetes auth method.")
+	o.ServiceAccountTokenFile = DefaultServiceAccountTokenFile
+}
+
+type store struct {
+	address    string
+	pathPrefix string
+	loginURL   string
+	role       string
+	tokenFile  string
+	client     *http.Client
+	now        func() time.Time
+
+	lock sync.Mutex
+	// token is the Vault token of the last login, it is replaced before it expires. A zero
+	// expiry is used for tokens without a lease.
+	token       string
+	tokenExpiry time.Time
+}
+
+// New returns a SecretStore which reads secrets below the configured path prefix from the Vault
+// server. It logs in with the Kubernetes auth method and logs in again before its token expires,
+// re-reading the service account token, so neither token has to be renewed manually.
+func New(opts Options) (provider.SecretStore, error) {
+	if opts.Address == "" {
+		return nil, errors.New("no Vault address given")
+	}
+	if opts.AuthRole == "" {
+		return nil, errors.New("no Vault auth role given")
+	}
+	pathPrefix := strings.Trim(opts.PathPrefix, "/")
+	if !validPath.MatchString(pathPrefix) || path.Clean(pathPrefix) != pathPrefix {
+		return nil, fmt.Errorf("invalid Vault path prefix %q", opts.PathPrefix)
+	}
+	if opts.AuthMount == "" {
+		opts.AuthMount = DefaultAuthMount
+	}
+	if opts.ServiceAccountTokenFile == "" {
+		opts.ServiceAccountTokenFile = DefaultServiceAccountTokenFile
+	}
+
+	address := strings.TrimSuffix(opts.Address, "/")
+	return &store{
+		address:    address,
+		pathPrefix: pathPrefix,
+		loginURL:   fmt.Sprintf("%s/v1/auth/%s/login", address, strings.Trim(opts.AuthMount, "/")),
+		role:       opts.AuthRole,
+		tokenFile:  opts.ServiceAccountTokenFile,
+		client:     &http.Client{Timeout: requestTimeout},
+		now:        time.Now,
+	}, nil
+}
+
+// secretResponse is the response of a read from the key/value secrets engine. For version 2
+// of the engine, the values are nested in another data object next to the metadata.
+type secretResponse struct {
+	Data map[string]interface{} `json:"data"`
+}
+
+// loginResponse is the response of a login with an auth method
+type loginResponse struct {
+	Auth struct {
+		ClientToken   string `json:"client_token"`
+		LeaseDuration int64  `json:"lease_duration"`
+	} `json:"auth"`
+}
+
+// GetSecretValue returns the value of key of the secret at path, e.g. "secret/data/kubermatic/hetzner".
+// The path must be below the configured path prefix.
+func (s *store) GetSecretValue(ctx context.Context, secretPath, key string) (string, error) {
+	if err := s.validatePath(secretPath); err != nil {
+		return "", err
+	}
+
+	token, err := s.getToken(ctx)
+	if err != nil {
+		return "", err
+	}
+	status, body, err := s.read(ctx, secretPath, token)
+	if status == http.StatusForbidden {
+		// the token might have been revoked, log in again once
+		s.forgetToken(token)
+		if token, err = s.getToken(ctx); err != nil {
+			return "", err
+		}
+		status, body, err = s.read(ctx, secretPath, token)
+	}
+	if err != nil {
+		return "", err
+	}
+	if status != http.StatusOK {
+		return "", fmt.Errorf("failed to read secret %q: Vault responded with status %d", secretPath, status)
+	}
+
+	secret := &secretResponse{}
+	if err := json.Unmarshal(body, secret); err != nil {
+		return "", fmt.Errorf("failed to decode secret %q: %v", secretPath, err)
+	}
+
+	data := secret.Data
+	if nested, ok := data["data"].(map[string]interface{}); ok {
+		if _, ok := data["metadata"]; ok {
+			data = nested
+		}
+	}
+
+	value, ok := data[key]
+	if !ok {
+		return "", fmt.Errorf("secret %q has no key %q", secretPath, key)
+	}
+	stringValue, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("key %q of secret %q is not a string", key, secretPath)
+	}
+
+	return stringValue, nil
+}
+
+// validatePath rejects paths outside of the path prefix, including paths which only escape it
+// after being resolved by the Vault server.
+func (s *store) validatePath(secretPath string) error {
+	trimmed := strings.TrimPrefix(secretPath, "/")
+	if !validPath.MatchString(trimmed) || path.Clean(trimmed) != trimmed || !strings.HasPrefix(trimmed, s.pathPrefix+"/") {
+		return fmt.Errorf("secret %q is not below the allowed path %q", secretPath, s.pathPrefix)
+	}
+	return nil
+}
+
+func (s *store) read(ctx context.Context, secretPath, token string) (int, []byte, error) {
+	url := fmt.Sprintf("%s/v1/%s", s.address, strings.TrimPrefix(secretPath, "/"))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("X-Vault-Token", token)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to read secret %q: %v", secretPath, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to read response for secret %q: %v", secretPath, err)
+	}
+	return resp.StatusCode, body, nil
+}
+
+// getToken returns the token of the last login. A new login is done if there is none or once two
+// thirds of its lease passed, so the token never expires while it is used.
+func (s *store) getToken(ctx context.Context) (string, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.token != "" && (s.tokenExpiry.IsZero() || s.now().Before(s.tokenExpiry)) {
+		return s.token, nil
+	}
+
+	jwt, err := ioutil.ReadFile(s.tokenFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read service account token: %v", err)
+	}
+	payload, err := json.Marshal(map[string]string{
+		"role": s.role,
+		"jwt":  strings.TrimSpace(string(jwt)),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode login request: %v", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.loginURL, bytes.NewReader(payload))
+	if err != nil {
+		return "", fmt.Errorf("failed to create login request: %v", err)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to log in to Vault: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read login response: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to log in to Vault: Vault responded with status %d", resp.StatusCode)
+	}
+
+	login := &loginResponse{}
+	if err := json.Unmarshal(body, login); err != nil {
+		return "", fmt.Errorf("failed to decode login response: %v", err)
+	}
+	if login.Auth.ClientToken == "" {
+		return "", errors.New("failed to log in to Vault: no token returned")
+	}
+
+	s.token = login.Auth.ClientToken
+	s.tokenExpiry = time.Time{}
+	if login.Auth.LeaseDuration > 0 {
+		s.tokenExpiry = s.now().Add(time.Duration(login.Auth.LeaseDuration) * time.Second * 2 / 3)
+	}
+	return s.token, nil
+}
+
+// forgetToken drops the token, unless another login replaced it already.
+func (s *store) forgetToken(token string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.token == token {
+		s.token = ""
+	}
+}
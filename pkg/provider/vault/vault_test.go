@@ -0,0 +1,267 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vault
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeVault serves secrets to tokens issued by its Kubernetes auth method
+type fakeVault struct {
+	lock    sync.Mutex
+	secrets map[string]string
+	logins  int
+	valid   map[string]bool
+	lease   int64
+}
+
+func (v *fakeVault) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	if r.URL.Path == "/v1/auth/kubernetes/login" {
+		login := map[string]string{}
+		if err := json.NewDecoder(r.Body).Decode(&login); err != nil || login["role"] != "kubermatic" || login["jwt"] != "service-account-token" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		v.logins++
+		token := fmt.Sprintf("vault-token-%d", v.logins)
+		v.valid[token] = true
+		_, _ = fmt.Fprintf(w, `{"auth": {"client_token": %q, "lease_duration": %d}}`, token, v.lease)
+		return
+	}
+
+	if !v.valid[r.Header.Get("X-Vault-Token")] {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	secret, ok := v.secrets[r.URL.Path]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	_, _ = w.Write([]byte(secret))
+}
+
+func (v *fakeVault) loginCount() int {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	return v.logins
+}
+
+func (v *fakeVault) revokeAll() {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	v.valid = map[string]bool{}
+}
+
+func newFakeVault(t *testing.T) (*fakeVault, *httptest.Server, string) {
+	vault := &fakeVault{
+		secrets: map[string]string{
+			"/v1/kv/kubermatic/hetzner":          `{"data": {"token": "kv1-token"}}`,
+			"/v1/secret/data/kubermatic/hetzner": `{"data": {"data": {"token": "kv2-token"}, "metadata": {"version": 1}}}`,
+			"/v1/kv/kubermatic/invalid":          `{"data": {"token": 42}}`,
+			"/v1/kv/other/hetzner":               `{"data": {"token": "other-token"}}`,
+		},
+		valid: map[string]bool{},
+		lease: 3600,
+	}
+	server := httptest.NewServer(vault)
+
+	dir, err := ioutil.TempDir("", "vault")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	tokenFile := filepath.Join(dir, "token")
+	if err := ioutil.WriteFile(tokenFile, []byte("service-account-token\n"), 0600); err != nil {
+		t.Fatalf("failed to write service account token: %v", err)
+	}
+	return vault, server, tokenFile
+}
+
+func TestGetSecretValue(t *testing.T) {
+	_, server, tokenFile := newFakeVault(t)
+	defer server.Close()
+
+	testCases := []struct {
+		name       string
+		pathPrefix string
+		path       string
+		key        string
+
+		expectedError  string
+		expectedResult string
+	}{
+		{
+			name:           "read from kv version 1",
+			pathPrefix:     "kv/kubermatic",
+			path:           "kv/kubermatic/hetzner",
+			key:            "token",
+			expectedResult: "kv1-token",
+		},
+		{
+			name:           "read from kv version 2",
+			pathPrefix:     "/secret/data/kubermatic/",
+			path:           "/secret/data/kubermatic/hetzner",
+			key:            "token",
+			expectedResult: "kv2-token",
+		},
+		{
+			name:          "error on missing key",
+			pathPrefix:    "kv/kubermatic",
+			path:          "kv/kubermatic/hetzner",
+			key:           "user",
+			expectedError: `secret "kv/kubermatic/hetzner" has no key "user"`,
+		},
+		{
+			name:          "error on non-string value",
+			pathPrefix:    "kv/kubermatic",
+			path:          "kv/kubermatic/invalid",
+			key:           "token",
+			expectedError: `key "token" of secret "kv/kubermatic/invalid" is not a string`,
+		},
+		{
+			name:          "error on missing secret",
+			pathPrefix:    "kv/kubermatic",
+			path:          "kv/kubermatic/missing",
+			key:           "token",
+			expectedError: `failed to read secret "kv/kubermatic/missing": Vault responded with status 404`,
+		},
+		{
+			name:          "error on secret outside of the path prefix",
+			pathPrefix:    "kv/kubermatic",
+			path:          "kv/other/hetzner",
+			key:           "token",
+			expectedError: `secret "kv/other/hetzner" is not below the allowed path "kv/kubermatic"`,
+		},
+		{
+			name:          "error on secret escaping the path prefix",
+			pathPrefix:    "kv/kubermatic",
+			path:          "kv/kubermatic/../other/hetzner",
+			key:           "token",
+			expectedError: `secret "kv/kubermatic/../other/hetzner" is not below the allowed path "kv/kubermatic"`,
+		},
+		{
+			name:          "error on escaped path",
+			pathPrefix:    "kv/kubermatic",
+			path:          "kv/kubermatic/%2e%2e/other/hetzner",
+			key:           "token",
+			expectedError: `secret "kv/kubermatic/%2e%2e/other/hetzner" is not below the allowed path "kv/kubermatic"`,
+		},
+		{
+			name:          "error on the path prefix itself",
+			pathPrefix:    "kv/kubermatic",
+			path:          "kv/kubermatic",
+			key:           "token",
+			expectedError: `secret "kv/kubermatic" is not below the allowed path "kv/kubermatic"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			store, err := New(Options{
+				Address:                 server.URL + "/",
+				PathPrefix:              tc.pathPrefix,
+				AuthRole:                "kubermatic",
+				ServiceAccountTokenFile: tokenFile,
+			})
+			if err != nil {
+				t.Fatalf("failed to create store: %v", err)
+			}
+
+			result, err := store.GetSecretValue(context.Background(), tc.path, tc.key)
+
+			var actualErr string
+			if err != nil {
+				actualErr = err.Error()
+			}
+
+			if actualErr != tc.expectedError {
+				t.Fatalf("actual err %q does not match expected err %q", actualErr, tc.expectedError)
+			}
+
+			if result != tc.expectedResult {
+				t.Errorf("actual result %q does not match expected result %q", result, tc.expectedResult)
+			}
+		})
+	}
+}
+
+func TestNewValidatesPathPrefix(t *testing.T) {
+	for _, pathPrefix := range []string{"", "/", "kv/../other", "kv//kubermatic", "kv/%2e%2e"} {
+		if _, err := New(Options{Address: "https://vault", PathPrefix: pathPrefix, AuthRole: "kubermatic"}); err == nil {
+			t.Errorf("expected path prefix %q to be rejected", pathPrefix)
+		}
+	}
+}
+
+func TestTokenRenewal(t *testing.T) {
+	vault, server, tokenFile := newFakeVault(t)
+	defer server.Close()
+
+	now := time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+	secretStore, err := New(Options{
+		Address:                 server.URL,
+		PathPrefix:              "kv/kubermatic",
+		AuthRole:                "kubermatic",
+		ServiceAccountTokenFile: tokenFile,
+	})
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	secretStore.(*store).now = func() time.Time { return now }
+
+	read := func() {
+		t.Helper()
+		if _, err := secretStore.GetSecretValue(context.Background(), "kv/kubermatic/hetzner", "token"); err != nil {
+			t.Fatalf("failed to read secret: %v", err)
+		}
+	}
+
+	read()
+	read()
+	if vault.loginCount() != 1 {
+		t.Fatalf("expected the token to be reused, got %d logins", vault.loginCount())
+	}
+
+	// two thirds of the lease passed, the store logs in again before the token expires
+	now = now.Add(41 * time.Minute)
+	read()
+	if vault.loginCount() != 2 {
+		t.Fatalf("expected a new login before the token expires, got %d logins", vault.loginCount())
+	}
+
+	// a revoked token is replaced by logging in again
+	vault.revokeAll()
+	read()
+	if vault.loginCount() != 3 {
+		t.Fatalf("expected a new login after the token was revoked, got %d logins", vault.loginCount())
+	}
+}
@@ -21,6 +21,9 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"net/url"
+	"strings"
+	"text/template"
 
 	"go.uber.org/zap"
 
@@ -33,6 +36,50 @@ import (
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// DefaultURLTemplate is the template for the apiserver URL of clusters, which
+// points to the external name of the cluster.
+const DefaultURLTemplate = "https://{{ .ExternalName }}:{{ .Port }}"
+
+// URLTemplateData is the data available in apiserver URL templates.
+type URLTemplateData struct {
+	// Name is the name of the cluster.
+	Name string
+	// DC is the DNS name of the seed the cluster is running in.
+	DC string
+	// ExternalURL is the external URL of the Kubermatic installation.
+	ExternalURL string
+	// ExternalName is the external name of the cluster, which is the IP or hostname of
+	// the front load balancer for the LoadBalancer expose strategy and
+	// <Name>.<DC>.<ExternalURL> otherwise.
+	ExternalName string
+	// Port is the port the apiserver is exposed on.
+	Port int32
+}
+
+// RenderURL renders the given apiserver URL template. The result must be a https URL.
+func RenderURL(urlTemplate string, data URLTemplateData) (string, error) {
+	tpl, err := template.New("url").Option("missingkey=error").Parse(urlTemplate)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse URL template: %v", err)
+	}
+
+	buf := &strings.Builder{}
+	if err := tpl.Execute(buf, data); err != nil {
+		return "", fmt.Errorf("failed to render URL template: %v", err)
+	}
+	rendered := buf.String()
+
+	parsed, err := url.Parse(rendered)
+	if err != nil {
+		return "", fmt.Errorf("rendered URL %q is invalid: %v", rendered, err)
+	}
+	if parsed.Scheme != "https" || parsed.Host == "" {
+		return "", fmt.Errorf("rendered URL %q must be a https URL with a host", rendered)
+	}
+
+	return rendered, nil
+}
+
 type lookupFunction func(host string) ([]net.IP, error)
 
 type ModifiersBuilder struct {
@@ -41,6 +88,7 @@ type ModifiersBuilder struct {
 	cluster     *kubermaticv1.Cluster
 	seed        *kubermaticv1.Seed
 	externalURL string
+	urlTemplate string
 	// used to ease unit tests
 	lookupFunction lookupFunction
 	// ip used by tunneling agents (tunneling expose strategy only)
@@ -51,6 +99,7 @@ func NewModifiersBuilder(log *zap.SugaredLogger) *ModifiersBuilder {
 	return &ModifiersBuilder{
 		log:            log,
 		lookupFunction: net.LookupIP,
+		urlTemplate:    DefaultURLTemplate,
 	}
 }
 
@@ -74,6 +123,15 @@ func (m *ModifiersBuilder) ExternalURL(e string) *ModifiersBuilder {
 	return m
 }
 
+// URLTemplate sets the template for the apiserver URL, an empty template
+// keeps the default.
+func (m *ModifiersBuilder) URLTemplate(t string) *ModifiersBuilder {
+	if t != "" {
+		m.urlTemplate = t
+	}
+	return m
+}
+
 func (m *ModifiersBuilder) TunnelingAgentIP(ip string) *ModifiersBuilder {
 	m.tunnelingAgentIP = ip
 	return m
@@ -102,6 +160,7 @@ func (m *ModifiersBuilder) Build(ctx context.Context) ([]func(*kubermaticv1.Clus
 	}
 
 	frontProxyLoadBalancerServiceIP := ""
+	frontProxyLoadBalancerServiceHostname := ""
 	if m.cluster.Spec.ExposeStrategy == kubermaticv1.ExposeStrategyLoadBalancer {
 		frontProxyLoadBalancerService := &corev1.Service{}
 		nn := types.NamespacedName{Namespace: m.cluster.Status.NamespaceName, Name: resources.FrontLoadBalancerServiceName}
@@ -115,6 +174,10 @@ func (m *ModifiersBuilder) Build(ctx context.Context) ([]func(*kubermaticv1.Clus
 			if ingress.IP != "" {
 				frontProxyLoadBalancerServiceIP = ingress.IP
 			}
+			// Some cloud providers (e.g. AWS) only expose the load balancer by a hostname
+			if ingress.Hostname != "" {
+				frontProxyLoadBalancerServiceHostname = ingress.Hostname
+			}
 		}
 	}
 
@@ -122,6 +185,9 @@ func (m *ModifiersBuilder) Build(ctx context.Context) ([]func(*kubermaticv1.Clus
 	externalName := ""
 	if m.cluster.Spec.ExposeStrategy == kubermaticv1.ExposeStrategyLoadBalancer {
 		externalName = frontProxyLoadBalancerServiceIP
+		if externalName == "" {
+			externalName = frontProxyLoadBalancerServiceHostname
+		}
 	} else {
 		externalName = fmt.Sprintf("%s.%s.%s", m.cluster.Name, subdomain, m.externalURL)
 	}
@@ -150,6 +216,13 @@ func (m *ModifiersBuilder) Build(ctx context.Context) ([]func(*kubermaticv1.Clus
 	switch m.cluster.Spec.ExposeStrategy {
 	case kubermaticv1.ExposeStrategyLoadBalancer:
 		ip = frontProxyLoadBalancerServiceIP
+		if ip == "" && frontProxyLoadBalancerServiceHostname != "" {
+			var err error
+			ip, err = m.getExternalIPv4(frontProxyLoadBalancerServiceHostname)
+			if err != nil {
+				return nil, err
+			}
+		}
 	case kubermaticv1.ExposeStrategyNodePort:
 		var err error
 		// Always lookup IP address, in case it changes (IP's on AWS LB's change)
@@ -193,12 +266,21 @@ func (m *ModifiersBuilder) Build(ctx context.Context) ([]func(*kubermaticv1.Clus
 	}
 
 	// URL
-	url := fmt.Sprintf("https://%s:%d", externalName, port)
-	if m.cluster.Address.URL != url {
+	apiserverURL, err := RenderURL(m.urlTemplate, URLTemplateData{
+		Name:         m.cluster.Name,
+		DC:           subdomain,
+		ExternalURL:  m.externalURL,
+		ExternalName: externalName,
+		Port:         port,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if m.cluster.Address.URL != apiserverURL {
 		modifiers = append(modifiers, func(c *kubermaticv1.Cluster) {
-			c.Address.URL = url
+			c.Address.URL = apiserverURL
 		})
-		m.log.Debugw("Set URL for cluster", "url", url)
+		m.log.Debugw("Set URL for cluster", "url", apiserverURL)
 	}
 
 	return modifiers, nil
@@ -74,6 +74,7 @@ func TestSyncClusterAddress(t *testing.T) {
 		frontproxyService    corev1.Service
 		exposeStrategy       kubermaticv1.ExposeStrategy
 		seedDNSOverwrite     string
+		urlTemplate          string
 		expectedExternalName string
 		expectedIP           string
 		expectedPort         int32
@@ -122,6 +123,26 @@ func TestSyncClusterAddress(t *testing.T) {
 			expectedPort:         int32(443),
 			expectedURL:          "https://1.2.3.4:443",
 		},
+		{
+			name: "Verify properties for service type LoadBalancer with hostname ingress",
+			apiserverService: corev1.Service{
+				Spec: corev1.ServiceSpec{
+					Ports: []corev1.ServicePort{{NodePort: int32(443)}},
+				},
+			},
+			frontproxyService: corev1.Service{
+				Status: corev1.ServiceStatus{
+					LoadBalancer: corev1.LoadBalancerStatus{
+						Ingress: []corev1.LoadBalancerIngress{{Hostname: testDomain}},
+					},
+				},
+			},
+			exposeStrategy:       kubermaticv1.ExposeStrategyLoadBalancer,
+			expectedExternalName: testDomain,
+			expectedIP:           "192.168.1.1",
+			expectedPort:         int32(443),
+			expectedURL:          fmt.Sprintf("https://%s:443", testDomain),
+		},
 		{
 			name: "Verify properties for service type NodePort",
 			apiserverService: corev1.Service{
@@ -161,6 +182,43 @@ func TestSyncClusterAddress(t *testing.T) {
 			expectedPort:         int32(32000),
 			expectedURL:          fmt.Sprintf("https://%s.alias-europe-west3-c.%s:32000", fakeClusterName, fakeExternalURL),
 		},
+		{
+			name: "Verify URL for service type NodePort with URL template",
+			apiserverService: corev1.Service{
+				Spec: corev1.ServiceSpec{
+					Type: corev1.ServiceTypeNodePort,
+					Ports: []corev1.ServicePort{
+						{
+							Port:       int32(32000),
+							TargetPort: intstr.FromInt(32000),
+							NodePort:   32000,
+						},
+					},
+				}},
+			exposeStrategy:       kubermaticv1.ExposeStrategyNodePort,
+			urlTemplate:          "https://{{ .Name }}-apiserver.{{ .DC }}.lb.{{ .ExternalURL }}:{{ .Port }}",
+			expectedExternalName: fmt.Sprintf("%s.%s.%s", fakeClusterName, fakeDCName, fakeExternalURL),
+			expectedIP:           externalIP,
+			expectedPort:         int32(32000),
+			expectedURL:          fmt.Sprintf("https://%s-apiserver.%s.lb.%s:32000", fakeClusterName, fakeDCName, fakeExternalURL),
+		},
+		{
+			name: "Verify error when URL template renders a non https URL",
+			apiserverService: corev1.Service{
+				Spec: corev1.ServiceSpec{
+					Type: corev1.ServiceTypeNodePort,
+					Ports: []corev1.ServicePort{
+						{
+							Port:       int32(32000),
+							TargetPort: intstr.FromInt(32000),
+							NodePort:   32000,
+						},
+					},
+				}},
+			exposeStrategy: kubermaticv1.ExposeStrategyNodePort,
+			urlTemplate:    "http://{{ .ExternalName }}:{{ .Port }}",
+			errExpected:    true,
+		},
 		{
 			name: "Verify error when service has less than one ports",
 			apiserverService: corev1.Service{
@@ -210,6 +268,7 @@ func TestSyncClusterAddress(t *testing.T) {
 				Cluster(cluster).
 				Seed(seed).
 				ExternalURL(fakeExternalURL).
+				URLTemplate(tc.urlTemplate).
 				lookupFunc(testLookupFunction).
 				Build(context.Background())
 			if err != nil {
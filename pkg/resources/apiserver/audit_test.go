@@ -0,0 +1,88 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"testing"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestAuditConfigMapCreator(t *testing.T) {
+	testCases := []struct {
+		name           string
+		settings       *kubermaticv1.AuditLoggingSettings
+		existingPolicy string
+		expectedPolicy string
+		expectedSource string
+	}{
+		{
+			name:           "default policy is created",
+			expectedPolicy: "apiVersion: audit.k8s.io/v1\nkind: Policy\nrules:\n- level: Metadata\n",
+			expectedSource: resources.AuditConfigMapName,
+		},
+		{
+			name:           "existing policy is kept without inline policy",
+			settings:       &kubermaticv1.AuditLoggingSettings{Enabled: true},
+			existingPolicy: "custom",
+			expectedPolicy: "custom",
+			expectedSource: resources.AuditConfigMapName,
+		},
+		{
+			name:           "inline policy replaces existing policy",
+			settings:       &kubermaticv1.AuditLoggingSettings{Enabled: true, Policy: "inline"},
+			existingPolicy: "custom",
+			expectedPolicy: "inline",
+			expectedSource: resources.AuditConfigMapName,
+		},
+		{
+			name:           "policy is mounted from the referenced ConfigMap",
+			settings:       &kubermaticv1.AuditLoggingSettings{Enabled: true, PolicyConfigMapName: "custom-audit-policy"},
+			expectedPolicy: "apiVersion: audit.k8s.io/v1\nkind: Policy\nrules:\n- level: Metadata\n",
+			expectedSource: "custom-audit-policy",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cluster := &kubermaticv1.Cluster{
+				Spec: kubermaticv1.ClusterSpec{AuditLogging: tc.settings},
+			}
+			data := resources.NewTemplateDataBuilder().WithCluster(cluster).Build()
+
+			cm := &corev1.ConfigMap{}
+			if tc.existingPolicy != "" {
+				cm.Data = map[string]string{auditPolicyKey: tc.existingPolicy}
+			}
+
+			_, create := AuditConfigMapCreator(data)()
+			cm, err := create(cm)
+			if err != nil {
+				t.Fatalf("failed to create audit ConfigMap: %v", err)
+			}
+			if policy := cm.Data[auditPolicyKey]; policy != tc.expectedPolicy {
+				t.Errorf("expected policy %q, got %q", tc.expectedPolicy, policy)
+			}
+			if source := auditPolicyConfigMapName(cluster); source != tc.expectedSource {
+				t.Errorf("expected policy to be mounted from %q, got %q", tc.expectedSource, source)
+			}
+		})
+	}
+}
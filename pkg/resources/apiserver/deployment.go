@@ -54,14 +54,29 @@ const (
 	name = "apiserver"
 
 	defaultNodePortRange = "30000-32767"
+
+	// extraVolumePrefix is prepended to the names of the extra volumes from the cluster spec,
+	// so they never clash with the volumes managed by Kubermatic
+	extraVolumePrefix = "extra-"
+
+	// auditPolicyKey is the key of the audit policy in its ConfigMap
+	auditPolicyKey = "policy.yaml"
 )
 
-func AuditConfigMapCreator() reconciling.NamedConfigMapCreatorGetter {
+// AuditConfigMapCreator returns a function to create the ConfigMap with the audit policy of the
+// apiserver. An inline policy from the cluster spec always replaces the content, otherwise the
+// default policy is only set once, so it can still be adjusted manually.
+func AuditConfigMapCreator(data *resources.TemplateData) reconciling.NamedConfigMapCreatorGetter {
 	return func() (string, reconciling.ConfigMapCreator) {
 		return resources.AuditConfigMapName, func(cm *corev1.ConfigMap) (*corev1.ConfigMap, error) {
+			if settings := data.Cluster().Spec.AuditLogging; settings != nil && settings.Policy != "" {
+				cm.Data = map[string]string{auditPolicyKey: settings.Policy}
+				return cm, nil
+			}
+
 			if cm.Data == nil {
 				cm.Data = map[string]string{
-					"policy.yaml": `apiVersion: audit.k8s.io/v1
+					auditPolicyKey: `apiVersion: audit.k8s.io/v1
 kind: Policy
 rules:
 - level: Metadata
@@ -72,6 +87,14 @@ rules:
 	}
 }
 
+// auditPolicyConfigMapName returns the name of the ConfigMap the audit policy is mounted from.
+func auditPolicyConfigMapName(cluster *kubermaticv1.Cluster) string {
+	if settings := cluster.Spec.AuditLogging; settings != nil && settings.PolicyConfigMapName != "" {
+		return settings.PolicyConfigMapName
+	}
+	return resources.AuditConfigMapName
+}
+
 // DeploymentCreator returns the function to create and update the API server deployment
 func DeploymentCreator(data *resources.TemplateData, enableOIDCAuthentication bool) reconciling.NamedDeploymentCreatorGetter {
 	return func() (string, reconciling.DeploymentCreator) {
@@ -89,9 +112,34 @@ func DeploymentCreator(data *resources.TemplateData, enableOIDCAuthentication bo
 			}
 			dep.Spec.Template.Spec.ImagePullSecrets = []corev1.LocalObjectReference{{Name: resources.ImagePullSecretName}}
 
-			volumes := getVolumes()
+			volumes := getVolumes(auditPolicyConfigMapName(data.Cluster()))
 			volumeMounts := getVolumeMounts()
 
+			if encryptionEnabled(data.Cluster()) {
+				volumes = append(volumes, corev1.Volume{
+					Name: resources.ApiserverEncryptionConfigurationSecretName,
+					VolumeSource: corev1.VolumeSource{
+						Secret: &corev1.SecretVolumeSource{
+							SecretName: resources.ApiserverEncryptionConfigurationSecretName,
+						},
+					},
+				})
+				volumeMounts = append(volumeMounts, corev1.VolumeMount{
+					Name:      resources.ApiserverEncryptionConfigurationSecretName,
+					MountPath: "/etc/kubernetes/encryption",
+					ReadOnly:  true,
+				})
+			}
+
+			extraVolumes, extraVolumeMounts, err := getExtraVolumes(data.Cluster().Spec.ComponentsOverride.Apiserver.ExtraVolumes, volumeMounts)
+			if err != nil {
+				return nil, err
+			}
+			volumes = append(volumes, extraVolumes...)
+			volumeMounts = append(volumeMounts, extraVolumeMounts...)
+
+			// The revision labels include the extra volumes, so the apiserver is restarted
+			// whenever the set of volumes or the content of the referenced objects changes
 			podLabels, err := data.GetPodTemplateLabels(name, volumes, nil)
 			if err != nil {
 				return nil, err
@@ -199,10 +247,14 @@ func DeploymentCreator(data *resources.TemplateData, enableOIDCAuthentication bo
 			}
 
 			if data.Cluster().Spec.AuditLogging != nil && data.Cluster().Spec.AuditLogging.Enabled {
+				fluentBitImage, err := data.RewriteImage("docker.io/fluent/fluent-bit:1.2.2")
+				if err != nil {
+					return nil, err
+				}
 				dep.Spec.Template.Spec.Containers = append(dep.Spec.Template.Spec.Containers,
 					corev1.Container{
 						Name:    "audit-logs",
-						Image:   "docker.io/fluent/fluent-bit:1.2.2",
+						Image:   fluentBitImage,
 						Command: []string{"/fluent-bit/bin/fluent-bit"},
 						Args:    []string{"-i", "tail", "-p", "path=/var/log/kubernetes/audit/audit.log", "-p", "db=/var/log/kubernetes/audit/fluentbit.db", "-o", "stdout"},
 						VolumeMounts: []corev1.VolumeMount{
@@ -262,6 +314,8 @@ func getApiserverFlags(data *resources.TemplateData, etcdEndpoints []string, ena
 	admissionPlugins.Insert(cluster.Spec.AdmissionPlugins...)
 
 	serviceAccountKeyFile := filepath.Join("/etc/kubernetes/service-account-key", resources.ServiceAccountKeySecretKey)
+	// the verification keys include the public key of a replaced signing key during its rotation
+	serviceAccountVerificationKeysFile := filepath.Join("/etc/kubernetes/service-account-key", resources.ServiceAccountKeyVerificationKeys)
 	flags := []string{
 		"--etcd-servers", strings.Join(etcdEndpoints, ","),
 		"--etcd-cafile", "/etc/etcd/pki/client/ca.crt",
@@ -274,20 +328,16 @@ func getApiserverFlags(data *resources.TemplateData, etcdEndpoints []string, ena
 		"--external-hostname", cluster.Address.ExternalName,
 		"--token-auth-file", "/etc/kubernetes/tokens/tokens.csv",
 		"--enable-bootstrap-token-auth",
-		"--service-account-key-file", serviceAccountKeyFile,
+		"--service-account-key-file", serviceAccountVerificationKeysFile,
 		// There are efforts upstream adding support for multiple cidr's. Until that has landed, we'll take the first entry
-		"--service-cluster-ip-range", cluster.Spec.ClusterNetwork.Services.CIDRBlocks[0],
+		"--service-cluster-ip-range", resources.NetworkRangesFlag(cluster, cluster.Spec.ClusterNetwork.Services),
 		"--service-node-port-range", overrideFlags.NodePortRange,
 		"--allow-privileged",
-		"--audit-log-maxage", "30",
-		"--audit-log-maxbackup", "3",
-		"--audit-log-maxsize", "100",
-		"--audit-log-path", "/var/log/kubernetes/audit/audit.log",
 		"--tls-cert-file", "/etc/kubernetes/tls/apiserver-tls.crt",
 		"--tls-private-key-file", "/etc/kubernetes/tls/apiserver-tls.key",
 		"--proxy-client-cert-file", "/etc/kubernetes/pki/front-proxy/client/" + resources.ApiserverProxyClientCertificateCertSecretKey,
 		"--proxy-client-key-file", "/etc/kubernetes/pki/front-proxy/client/" + resources.ApiserverProxyClientCertificateKeySecretKey,
-		"--client-ca-file", "/etc/kubernetes/pki/ca/ca.crt",
+		"--client-ca-file", "/etc/kubernetes/pki/ca/" + resources.CABundleSecretKey,
 		"--kubelet-client-certificate", "/etc/kubernetes/kubelet/kubelet-client.crt",
 		"--kubelet-client-key", "/etc/kubernetes/kubelet/kubelet-client.key",
 		"--requestheader-client-ca-file", "/etc/kubernetes/pki/front-proxy/ca/ca.crt",
@@ -322,7 +372,17 @@ func getApiserverFlags(data *resources.TemplateData, etcdEndpoints []string, ena
 	}
 
 	if auditLogEnabled {
-		flags = append(flags, "--audit-policy-file", "/etc/kubernetes/audit/policy.yaml")
+		flags = append(flags,
+			"--audit-log-maxage", "30",
+			"--audit-log-maxbackup", "3",
+			"--audit-log-maxsize", "100",
+			"--audit-log-path", "/var/log/kubernetes/audit/audit.log",
+			"--audit-policy-file", "/etc/kubernetes/audit/"+auditPolicyKey,
+		)
+	}
+
+	if encryptionEnabled(cluster) {
+		flags = append(flags, "--encryption-provider-config", "/etc/kubernetes/encryption/"+resources.ApiserverEncryptionConfigurationSecretKey)
 	}
 
 	if *overrideFlags.EndpointReconcilingDisabled {
@@ -353,6 +413,7 @@ func getApiserverFlags(data *resources.TemplateData, etcdEndpoints []string, ena
 		flags = append(flags,
 			"--service-account-issuer", issuer,
 			"--service-account-signing-key-file", serviceAccountKeyFile,
+			"--service-account-max-token-expiration", ServiceAccountMaxTokenExpiration.String(),
 			"--api-audiences", strings.Join(audiences, ","),
 		)
 	}
@@ -363,11 +424,7 @@ func getApiserverFlags(data *resources.TemplateData, etcdEndpoints []string, ena
 		flags = append(flags, "--kubelet-preferred-address-types", "ExternalIP,InternalIP")
 	}
 
-	cloudProviderName := resources.GetKubernetesCloudProviderName(data.Cluster(), resources.ExternalCloudProviderEnabled(data.Cluster()))
-	if cloudProviderName != "" && cloudProviderName != "external" {
-		flags = append(flags, "--cloud-provider", cloudProviderName)
-		flags = append(flags, "--cloud-config", "/etc/kubernetes/cloud/config")
-	}
+	flags = append(flags, resources.CloudProviderFlags(data.Cluster())...)
 
 	oidcSettings := cluster.Spec.OIDC
 	if oidcSettings.IssuerURL != "" && oidcSettings.ClientID != "" {
@@ -397,12 +454,12 @@ func getApiserverFlags(data *resources.TemplateData, etcdEndpoints []string, ena
 		)
 	}
 
-	if fg := data.GetCSIMigrationFeatureGates(); len(fg) > 0 {
+	if fg := append(data.GetCSIMigrationFeatureGates(), resources.DualStackFeatureGates(cluster)...); len(fg) > 0 {
 		flags = append(flags, "--feature-gates")
 		flags = append(flags, strings.Join(fg, ","))
 	}
 
-	return flags, nil
+	return resources.AppendExtraArgs(flags, cluster.Spec.ComponentsOverride.Apiserver.ExtraArgs), nil
 }
 
 // getApiserverOverrideFlags creates all settings that may be overridden by cluster specific componentsOverrideSettings
@@ -465,7 +522,7 @@ func getVolumeMounts() []corev1.VolumeMount {
 		},
 		{
 			Name:      resources.CloudConfigConfigMapName,
-			MountPath: "/etc/kubernetes/cloud",
+			MountPath: resources.CloudConfigMountPath,
 			ReadOnly:  true,
 		},
 		{
@@ -501,7 +558,49 @@ func getVolumeMounts() []corev1.VolumeMount {
 	}
 }
 
-func getVolumes() []corev1.Volume {
+// getExtraVolumes returns the volumes and read-only mounts for the extra volumes of the cluster.
+// Mount paths overlapping with one of the given mounts are rejected, as they would shadow or be
+// shadowed by the files Kubermatic provides to the apiserver.
+func getExtraVolumes(extraVolumes []kubermaticv1.APIServerExtraVolume, mounts []corev1.VolumeMount) ([]corev1.Volume, []corev1.VolumeMount, error) {
+	var (
+		volumes      []corev1.Volume
+		volumeMounts []corev1.VolumeMount
+	)
+
+	for _, extraVolume := range extraVolumes {
+		for _, mount := range mounts {
+			if isSubPath(extraVolume.MountPath, mount.MountPath) || isSubPath(mount.MountPath, extraVolume.MountPath) {
+				return nil, nil, fmt.Errorf("mount path %s of extra volume %q overlaps with %s", extraVolume.MountPath, extraVolume.Name, mount.MountPath)
+			}
+		}
+
+		volume := corev1.Volume{Name: extraVolumePrefix + extraVolume.Name}
+		if extraVolume.Secret != "" {
+			volume.Secret = &corev1.SecretVolumeSource{SecretName: extraVolume.Secret}
+		} else {
+			volume.ConfigMap = &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: extraVolume.ConfigMap},
+			}
+		}
+		volumes = append(volumes, volume)
+
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      volume.Name,
+			MountPath: extraVolume.MountPath,
+			ReadOnly:  true,
+		})
+	}
+
+	return volumes, volumeMounts, nil
+}
+
+// isSubPath returns whether p is the directory dir or located below it.
+func isSubPath(p, dir string) bool {
+	p, dir = filepath.Clean(p), filepath.Clean(dir)
+	return p == dir || strings.HasPrefix(p, strings.TrimSuffix(dir, "/")+"/")
+}
+
+func getVolumes(auditPolicyConfigMap string) []corev1.Volume {
 	return []corev1.Volume{
 		{
 			Name: resources.ApiserverTLSSecretName,
@@ -545,6 +644,10 @@ func getVolumes() []corev1.Volume {
 							Path: resources.CACertSecretKey,
 							Key:  resources.CACertSecretKey,
 						},
+						{
+							Path: resources.CABundleSecretKey,
+							Key:  resources.CABundleSecretKey,
+						},
 					},
 				},
 			},
@@ -614,7 +717,7 @@ func getVolumes() []corev1.Volume {
 			VolumeSource: corev1.VolumeSource{
 				ConfigMap: &corev1.ConfigMapVolumeSource{
 					LocalObjectReference: corev1.LocalObjectReference{
-						Name: resources.AuditConfigMapName,
+						Name: auditPolicyConfigMap,
 					},
 					Optional: new(bool),
 				},
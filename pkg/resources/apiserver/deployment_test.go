@@ -0,0 +1,184 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"testing"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/semver"
+)
+
+func TestGetExtraVolumes(t *testing.T) {
+	testCases := []struct {
+		name          string
+		extraVolumes  []kubermaticv1.APIServerExtraVolume
+		expectedError bool
+	}{
+		{
+			name: "secret and config map are mounted",
+			extraVolumes: []kubermaticv1.APIServerExtraVolume{
+				{Name: "webhook", MountPath: "/etc/webhook", Secret: "webhook-kubeconfig"},
+				{Name: "policies", MountPath: "/etc/kubernetes/policies", ConfigMap: "policies"},
+			},
+		},
+		{
+			name: "mount path below a managed mount is rejected",
+			extraVolumes: []kubermaticv1.APIServerExtraVolume{
+				{Name: "tls", MountPath: "/etc/kubernetes/tls/extra", Secret: "tls"},
+			},
+			expectedError: true,
+		},
+		{
+			name: "mount path above a managed mount is rejected",
+			extraVolumes: []kubermaticv1.APIServerExtraVolume{
+				{Name: "etc", MountPath: "/etc/kubernetes/", Secret: "etc"},
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			volumes, mounts, err := getExtraVolumes(tc.extraVolumes, getVolumeMounts())
+			if (err != nil) != tc.expectedError {
+				t.Fatalf("expected error: %v, got %v", tc.expectedError, err)
+			}
+			if err != nil {
+				return
+			}
+
+			if len(volumes) != len(tc.extraVolumes) || len(mounts) != len(tc.extraVolumes) {
+				t.Fatalf("expected %d volumes and mounts, got %d volumes and %d mounts", len(tc.extraVolumes), len(volumes), len(mounts))
+			}
+			for i, extraVolume := range tc.extraVolumes {
+				volume, mount := volumes[i], mounts[i]
+				if volume.Name != extraVolumePrefix+extraVolume.Name || mount.Name != volume.Name {
+					t.Errorf("unexpected volume name %q and mount name %q for extra volume %q", volume.Name, mount.Name, extraVolume.Name)
+				}
+				if mount.MountPath != extraVolume.MountPath || !mount.ReadOnly {
+					t.Errorf("expected extra volume %q to be mounted read-only at %s, got %+v", extraVolume.Name, extraVolume.MountPath, mount)
+				}
+				if extraVolume.Secret != "" && (volume.Secret == nil || volume.Secret.SecretName != extraVolume.Secret) {
+					t.Errorf("expected volume %q to reference secret %q, got %+v", volume.Name, extraVolume.Secret, volume.VolumeSource)
+				}
+				if extraVolume.ConfigMap != "" && (volume.ConfigMap == nil || volume.ConfigMap.Name != extraVolume.ConfigMap) {
+					t.Errorf("expected volume %q to reference config map %q, got %+v", volume.Name, extraVolume.ConfigMap, volume.VolumeSource)
+				}
+			}
+		})
+	}
+}
+
+func TestServiceNodePortRangeFlag(t *testing.T) {
+	const globalRange = "30000-32767"
+
+	testCases := []struct {
+		name          string
+		datacenter    *kubermaticv1.Datacenter
+		clusterRange  string
+		expectedRange string
+	}{
+		{
+			name:          "Global range without a datacenter range",
+			datacenter:    &kubermaticv1.Datacenter{},
+			expectedRange: globalRange,
+		},
+		{
+			name: "Datacenter range only applies to the seed",
+			datacenter: &kubermaticv1.Datacenter{
+				Spec: kubermaticv1.DatacenterSpec{NodePortRange: "31000-31999"},
+			},
+			expectedRange: globalRange,
+		},
+		{
+			name: "Cluster override takes precedence",
+			datacenter: &kubermaticv1.Datacenter{
+				Spec: kubermaticv1.DatacenterSpec{NodePortRange: "31000-31999"},
+			},
+			clusterRange:  "32000-32100",
+			expectedRange: "32000-32100",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cluster := &kubermaticv1.Cluster{
+				Spec: kubermaticv1.ClusterSpec{
+					Version: *semver.NewSemverOrDie("1.20.0"),
+					ClusterNetwork: kubermaticv1.ClusterNetworkingConfig{
+						Services: kubermaticv1.NetworkRanges{CIDRBlocks: []string{"10.240.16.0/20"}},
+					},
+					ComponentsOverride: kubermaticv1.ComponentSettings{
+						Apiserver: kubermaticv1.APIServerSettings{NodePortRange: tc.clusterRange},
+					},
+				},
+			}
+			data := resources.NewTemplateDataBuilder().
+				WithCluster(cluster).
+				WithDatacenter(tc.datacenter).
+				WithNodePortRange(globalRange).
+				Build()
+
+			flags, err := getApiserverFlags(data, []string{"https://etcd-0.etcd:2379"}, false, false)
+			if err != nil {
+				t.Fatalf("failed to get apiserver flags: %v", err)
+			}
+			if value := flagValue(flags, "--service-node-port-range"); value != tc.expectedRange {
+				t.Errorf("expected --service-node-port-range %q, got %q", tc.expectedRange, value)
+			}
+		})
+	}
+}
+
+func flagValue(flags []string, name string) string {
+	for i := 0; i < len(flags)-1; i++ {
+		if flags[i] == name {
+			return flags[i+1]
+		}
+	}
+	return ""
+}
+
+func TestClientCAFileFlag(t *testing.T) {
+	cluster := &kubermaticv1.Cluster{
+		Spec: kubermaticv1.ClusterSpec{
+			Version: *semver.NewSemverOrDie("1.20.0"),
+			ClusterNetwork: kubermaticv1.ClusterNetworkingConfig{
+				Services: kubermaticv1.NetworkRanges{CIDRBlocks: []string{"10.240.16.0/20"}},
+			},
+		},
+	}
+	data := resources.NewTemplateDataBuilder().
+		WithCluster(cluster).
+		WithDatacenter(&kubermaticv1.Datacenter{}).
+		WithNodePortRange("30000-32767").
+		Build()
+
+	flags, err := getApiserverFlags(data, []string{"https://etcd-0.etcd:2379"}, false, false)
+	if err != nil {
+		t.Fatalf("failed to get apiserver flags: %v", err)
+	}
+
+	// the chain of a signing CA must never be trusted for client certificates, it is only
+	// published under its own secret key
+	expected := "/etc/kubernetes/pki/ca/" + resources.CABundleSecretKey
+	if value := flagValue(flags, "--client-ca-file"); value != expected {
+		t.Errorf("expected --client-ca-file %q, got %q", expected, value)
+	}
+}
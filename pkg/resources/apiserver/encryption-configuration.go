@@ -0,0 +1,231 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+
+	providerconfig "github.com/kubermatic/machine-controller/pkg/providerconfig/types"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/resources/reconciling"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// encryptionKeySize is the size of AES-CBC keys in bytes, the apiserver supports 16, 24 and 32 bytes.
+const encryptionKeySize = 32
+
+// encryptionConfiguration is the subset of the EncryptionConfiguration of the apiserver needed to
+// encrypt secrets with AES-CBC.
+type encryptionConfiguration struct {
+	APIVersion string                            `json:"apiVersion"`
+	Kind       string                            `json:"kind"`
+	Resources  []encryptionResourceConfiguration `json:"resources"`
+}
+
+type encryptionResourceConfiguration struct {
+	Resources []string             `json:"resources"`
+	Providers []encryptionProvider `json:"providers"`
+}
+
+type encryptionProvider struct {
+	AESCBC   *encryptionAESConfiguration `json:"aescbc,omitempty"`
+	Identity *struct{}                   `json:"identity,omitempty"`
+}
+
+type encryptionAESConfiguration struct {
+	Keys []encryptionKey `json:"keys"`
+}
+
+type encryptionKey struct {
+	Name   string `json:"name"`
+	Secret string `json:"secret"`
+}
+
+// encryptionEnabled returns true if secrets of the cluster are encrypted at rest.
+func encryptionEnabled(cluster *kubermaticv1.Cluster) bool {
+	return cluster.Spec.EncryptionConfiguration != nil && cluster.Spec.EncryptionConfiguration.Enabled
+}
+
+type encryptionConfigurationCreatorData interface {
+	Cluster() *kubermaticv1.Cluster
+	GetGlobalSecretKeySelectorValue(configVar *providerconfig.GlobalSecretKeySelector, key string) (string, error)
+}
+
+// EncryptionConfigurationSecretCreator returns a function to create/update the secret with the
+// encryption configuration of the apiserver. The configured key is rolled out in the phases
+// recorded in the cluster status by the cluster controller: it is added for decryption first,
+// made the primary key next and the previous keys are pruned once all secrets were rewritten.
+// The key which is rolled out is recorded in the ApiserverEncryptionKeyAnnotation.
+func EncryptionConfigurationSecretCreator(data encryptionConfigurationCreatorData) reconciling.NamedSecretCreatorGetter {
+	return func() (string, reconciling.SecretCreator) {
+		return resources.ApiserverEncryptionConfigurationSecretName, func(se *corev1.Secret) (*corev1.Secret, error) {
+			if se.Data == nil {
+				se.Data = map[string][]byte{}
+			}
+			if se.Annotations == nil {
+				se.Annotations = map[string]string{}
+			}
+
+			existingKeys, err := encryptionKeys(se.Data[resources.ApiserverEncryptionConfigurationSecretKey])
+			if err != nil {
+				return nil, err
+			}
+
+			target, err := primaryEncryptionKey(data, existingKeys)
+			if err != nil {
+				return nil, err
+			}
+			se.Annotations[resources.ApiserverEncryptionKeyAnnotation] = target.Name
+
+			config := encryptionConfiguration{
+				APIVersion: "apiserver.config.k8s.io/v1",
+				Kind:       "EncryptionConfiguration",
+				Resources: []encryptionResourceConfiguration{{
+					Resources: []string{"secrets"},
+					Providers: encryptionProviders(target, existingKeys, data.Cluster().Status.Encryption),
+				}},
+			}
+
+			configYAML, err := yaml.Marshal(config)
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode the encryption configuration: %v", err)
+			}
+			se.Data[resources.ApiserverEncryptionConfigurationSecretKey] = configYAML
+
+			return se, nil
+		}
+	}
+}
+
+// encryptionProviders returns the providers of the encryption configuration for the phase the
+// rollout of the target key is in. The identity provider is only the primary provider while
+// encryption is enabled initially, afterwards it comes last, so secrets written before
+// encryption was enabled stay readable until they are rewritten.
+func encryptionProviders(target encryptionKey, existingKeys []encryptionKey, status *kubermaticv1.ClusterEncryptionStatus) []encryptionProvider {
+	if status == nil {
+		status = &kubermaticv1.ClusterEncryptionStatus{}
+	}
+
+	var previousKeys []encryptionKey
+	for _, key := range existingKeys {
+		if key.Name != target.Name {
+			previousKeys = append(previousKeys, key)
+		}
+	}
+
+	identity := encryptionProvider{Identity: &struct{}{}}
+	switch {
+	case status.ActiveKey == target.Name:
+		// all secrets are encrypted with the target key, the previous keys are pruned
+		return []encryptionProvider{
+			{AESCBC: &encryptionAESConfiguration{Keys: []encryptionKey{target}}},
+			identity,
+		}
+
+	case status.RolloutKey == target.Name && status.Phase != "":
+		// all apiservers can decrypt with the target key, so it can be used for encryption
+		return []encryptionProvider{
+			{AESCBC: &encryptionAESConfiguration{Keys: append([]encryptionKey{target}, previousKeys...)}},
+			identity,
+		}
+
+	case len(previousKeys) == 0:
+		// encryption is enabled, secrets are still written unencrypted until all apiservers
+		// can decrypt them
+		return []encryptionProvider{
+			identity,
+			{AESCBC: &encryptionAESConfiguration{Keys: []encryptionKey{target}}},
+		}
+
+	default:
+		// the target key is only used for decryption until all apiservers have it
+		return []encryptionProvider{
+			{AESCBC: &encryptionAESConfiguration{Keys: append(previousKeys, target)}},
+			identity,
+		}
+	}
+}
+
+// encryptionKeys returns the AES-CBC keys of an existing encryption configuration in their order.
+func encryptionKeys(configYAML []byte) ([]encryptionKey, error) {
+	if len(configYAML) == 0 {
+		return nil, nil
+	}
+
+	config := &encryptionConfiguration{}
+	// a broken configuration must not be replaced, the keys are required to read existing secrets
+	if err := yaml.Unmarshal(configYAML, config); err != nil {
+		return nil, fmt.Errorf("failed to parse the existing encryption configuration: %v", err)
+	}
+
+	var keys []encryptionKey
+	for _, resource := range config.Resources {
+		for _, provider := range resource.Providers {
+			if provider.AESCBC != nil {
+				keys = append(keys, provider.AESCBC.Keys...)
+			}
+		}
+	}
+
+	return keys, nil
+}
+
+// primaryEncryptionKey returns the key secrets are encrypted with. A referenced key is named after
+// its checksum, a generated key after the rotation it was generated for, so a rotation results in
+// a new key name.
+func primaryEncryptionKey(data encryptionConfigurationCreatorData, existingKeys []encryptionKey) (encryptionKey, error) {
+	settings := data.Cluster().Spec.EncryptionConfiguration
+
+	if ref := settings.KeySecretRef; ref != nil {
+		secret, err := data.GetGlobalSecretKeySelectorValue(ref, ref.Key)
+		if err != nil {
+			return encryptionKey{}, fmt.Errorf("failed to get the encryption key: %v", err)
+		}
+		key, err := base64.StdEncoding.DecodeString(secret)
+		if err != nil {
+			return encryptionKey{}, fmt.Errorf("encryption key is not base64 encoded: %v", err)
+		}
+		if len(key) != encryptionKeySize {
+			return encryptionKey{}, fmt.Errorf("encryption key must be %d bytes, got %d", encryptionKeySize, len(key))
+		}
+
+		checksum := sha256.Sum256(key)
+		return encryptionKey{Name: "key-" + hex.EncodeToString(checksum[:8]), Secret: secret}, nil
+	}
+
+	name := fmt.Sprintf("generated-%d", settings.KeyRotation)
+	for _, key := range existingKeys {
+		if key.Name == name {
+			return key, nil
+		}
+	}
+
+	key := make([]byte, encryptionKeySize)
+	if _, err := rand.Read(key); err != nil {
+		return encryptionKey{}, fmt.Errorf("failed to generate the encryption key: %v", err)
+	}
+
+	return encryptionKey{Name: name, Secret: base64.StdEncoding.EncodeToString(key)}, nil
+}
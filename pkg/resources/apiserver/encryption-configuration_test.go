@@ -0,0 +1,133 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"testing"
+
+	providerconfig "github.com/kubermatic/machine-controller/pkg/providerconfig/types"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+type fakeEncryptionConfigurationCreatorData struct {
+	cluster *kubermaticv1.Cluster
+	secrets map[string]string
+}
+
+func (f *fakeEncryptionConfigurationCreatorData) Cluster() *kubermaticv1.Cluster {
+	return f.cluster
+}
+
+func (f *fakeEncryptionConfigurationCreatorData) GetGlobalSecretKeySelectorValue(configVar *providerconfig.GlobalSecretKeySelector, key string) (string, error) {
+	value, ok := f.secrets[configVar.Name+"/"+key]
+	if !ok {
+		return "", fmt.Errorf("secret %q has no key %q", configVar.Name, key)
+	}
+	return value, nil
+}
+
+func TestEncryptionConfigurationSecretCreator(t *testing.T) {
+	providedKey := base64.StdEncoding.EncodeToString([]byte(strings.Repeat("k", encryptionKeySize)))
+
+	data := &fakeEncryptionConfigurationCreatorData{
+		cluster: &kubermaticv1.Cluster{
+			Spec: kubermaticv1.ClusterSpec{
+				EncryptionConfiguration: &kubermaticv1.EncryptionConfiguration{Enabled: true},
+			},
+		},
+		secrets: map[string]string{
+			"provided/key": providedKey,
+			"short/key":    base64.StdEncoding.EncodeToString([]byte("short")),
+		},
+	}
+	_, create := EncryptionConfigurationSecretCreator(data)()
+
+	// reconcile returns the names of the AES-CBC keys in their order and whether the identity
+	// provider is the primary provider
+	reconcile := func(secret *corev1.Secret, status *kubermaticv1.ClusterEncryptionStatus) ([]string, bool) {
+		t.Helper()
+
+		data.cluster.Status.Encryption = status
+		secret, err := create(secret)
+		if err != nil {
+			t.Fatalf("failed to create encryption configuration: %v", err)
+		}
+		config := &encryptionConfiguration{}
+		if err := yaml.Unmarshal(secret.Data[resources.ApiserverEncryptionConfigurationSecretKey], config); err != nil {
+			t.Fatalf("failed to parse encryption configuration: %v", err)
+		}
+		keys, err := encryptionKeys(secret.Data[resources.ApiserverEncryptionConfigurationSecretKey])
+		if err != nil {
+			t.Fatalf("failed to parse encryption configuration: %v", err)
+		}
+		var names []string
+		for _, key := range keys {
+			names = append(names, key.Name)
+		}
+		return names, config.Resources[0].Providers[0].Identity != nil
+	}
+
+	expect := func(secret *corev1.Secret, status *kubermaticv1.ClusterEncryptionStatus, expectedKeys []string, expectIdentityFirst bool) {
+		t.Helper()
+
+		keys, identityFirst := reconcile(secret, status)
+		if strings.Join(keys, ",") != strings.Join(expectedKeys, ",") || identityFirst != expectIdentityFirst {
+			t.Fatalf("expected keys %v with identity first %v, got %v with identity first %v", expectedKeys, expectIdentityFirst, keys, identityFirst)
+		}
+	}
+
+	// enabling encryption keeps writing unencrypted secrets until all apiservers have the key
+	secret := &corev1.Secret{}
+	expect(secret, nil, []string{"generated-0"}, true)
+	expect(secret, &kubermaticv1.ClusterEncryptionStatus{RolloutKey: "generated-0", Phase: kubermaticv1.ClusterEncryptionPhaseKeyAdded}, []string{"generated-0"}, false)
+	expect(secret, &kubermaticv1.ClusterEncryptionStatus{ActiveKey: "generated-0"}, []string{"generated-0"}, false)
+
+	// a rotated key is added for decryption, made primary and the previous key is pruned last
+	data.cluster.Spec.EncryptionConfiguration.KeyRotation = 1
+	expect(secret, &kubermaticv1.ClusterEncryptionStatus{ActiveKey: "generated-0"}, []string{"generated-0", "generated-1"}, false)
+	if target := secret.Annotations[resources.ApiserverEncryptionKeyAnnotation]; target != "generated-1" {
+		t.Fatalf("expected the rotated key to be rolled out, got %q", target)
+	}
+	expect(secret, &kubermaticv1.ClusterEncryptionStatus{ActiveKey: "generated-0", RolloutKey: "generated-1"}, []string{"generated-0", "generated-1"}, false)
+	expect(secret, &kubermaticv1.ClusterEncryptionStatus{ActiveKey: "generated-0", RolloutKey: "generated-1", Phase: kubermaticv1.ClusterEncryptionPhaseKeyAdded}, []string{"generated-1", "generated-0"}, false)
+	expect(secret, &kubermaticv1.ClusterEncryptionStatus{ActiveKey: "generated-0", RolloutKey: "generated-1", Phase: kubermaticv1.ClusterEncryptionPhaseSecretsRewritten}, []string{"generated-1", "generated-0"}, false)
+	expect(secret, &kubermaticv1.ClusterEncryptionStatus{ActiveKey: "generated-1"}, []string{"generated-1"}, false)
+
+	// a provided key is rolled out the same way
+	data.cluster.Spec.EncryptionConfiguration.KeySecretRef = &providerconfig.GlobalSecretKeySelector{
+		ObjectReference: corev1.ObjectReference{Name: "provided", Namespace: "kubermatic"},
+		Key:             "key",
+	}
+	keys, _ := reconcile(secret, &kubermaticv1.ClusterEncryptionStatus{ActiveKey: "generated-1"})
+	if len(keys) != 2 || keys[0] != "generated-1" || !strings.HasPrefix(keys[1], "key-") {
+		t.Fatalf("expected the provided key to be added after the active key, got %v", keys)
+	}
+
+	// a key of the wrong size is rejected
+	data.cluster.Spec.EncryptionConfiguration.KeySecretRef.Name = "short"
+	if _, err := create(secret); err == nil {
+		t.Fatal("expected an error for a key of the wrong size")
+	}
+}
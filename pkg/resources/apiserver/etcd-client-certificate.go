@@ -17,6 +17,7 @@ limitations under the License.
 package apiserver
 
 import (
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
 	"k8c.io/kubermatic/v2/pkg/resources"
 	"k8c.io/kubermatic/v2/pkg/resources/certificates"
 	"k8c.io/kubermatic/v2/pkg/resources/certificates/triple"
@@ -24,16 +25,18 @@ import (
 )
 
 type etcdClientCertificateCreatorData interface {
+	Cluster() *kubermaticv1.Cluster
 	GetRootCA() (*triple.KeyPair, error)
 }
 
 // EtcdClientCertificateCreator returns a function to create/update the secret with the client certificate for authenticating against etcd
 func EtcdClientCertificateCreator(data etcdClientCertificateCreatorData) reconciling.NamedSecretCreatorGetter {
-	return certificates.GetClientCertificateCreator(
+	return certificates.GetClientCertificateCreatorWithKeyAlgorithm(
 		resources.ApiserverEtcdClientCertificateSecretName,
 		"apiserver",
 		nil,
 		resources.ApiserverEtcdClientCertificateCertSecretKey,
 		resources.ApiserverEtcdClientCertificateKeySecretKey,
-		data.GetRootCA)
+		data.GetRootCA,
+		data.Cluster().Spec.ComponentsOverride.Apiserver.CertificateKeyAlgorithm)
 }
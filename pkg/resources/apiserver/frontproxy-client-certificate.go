@@ -17,6 +17,7 @@ limitations under the License.
 package apiserver
 
 import (
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
 	"k8c.io/kubermatic/v2/pkg/resources"
 	"k8c.io/kubermatic/v2/pkg/resources/certificates"
 	"k8c.io/kubermatic/v2/pkg/resources/certificates/triple"
@@ -24,17 +25,18 @@ import (
 )
 
 type frontProxyClientCertificateCreatorData interface {
+	Cluster() *kubermaticv1.Cluster
 	GetFrontProxyCA() (*triple.KeyPair, error)
 }
 
 // FrontProxyClientCertificateCreator returns a function to create/update the secret with the client certificate for authenticating against extension apiserver
 func FrontProxyClientCertificateCreator(data frontProxyClientCertificateCreatorData) reconciling.NamedSecretCreatorGetter {
-	return certificates.GetClientCertificateCreator(
+	return certificates.GetClientCertificateCreatorWithKeyAlgorithm(
 		resources.ApiserverFrontProxyClientCertificateSecretName,
 		"apiserver-aggregator",
 		nil,
 		resources.ApiserverProxyClientCertificateCertSecretKey,
 		resources.ApiserverProxyClientCertificateKeySecretKey,
-		data.GetFrontProxyCA)
-
+		data.GetFrontProxyCA,
+		data.Cluster().Spec.ComponentsOverride.Apiserver.CertificateKeyAlgorithm)
 }
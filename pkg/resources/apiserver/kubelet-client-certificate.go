@@ -17,6 +17,7 @@ limitations under the License.
 package apiserver
 
 import (
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
 	"k8c.io/kubermatic/v2/pkg/resources"
 	"k8c.io/kubermatic/v2/pkg/resources/certificates"
 	"k8c.io/kubermatic/v2/pkg/resources/certificates/triple"
@@ -24,17 +25,19 @@ import (
 )
 
 type kubeletClientCertificateCreatorData interface {
+	Cluster() *kubermaticv1.Cluster
 	GetRootCA() (*triple.KeyPair, error)
 }
 
 // KubeletClientCertificateCreator returns a function to create/update a secret with the client certificate for the apiserver -> kubelet connection.
 func KubeletClientCertificateCreator(data kubeletClientCertificateCreatorData) reconciling.NamedSecretCreatorGetter {
-	return certificates.GetClientCertificateCreator(
+	return certificates.GetClientCertificateCreatorWithKeyAlgorithm(
 		resources.KubeletClientCertificatesSecretName,
 		"kube-apiserver-kubelet-client",
 		[]string{"system:masters"},
 		resources.KubeletClientCertSecretKey,
 		resources.KubeletClientKeySecretKey,
 		data.GetRootCA,
+		data.Cluster().Spec.ComponentsOverride.Apiserver.CertificateKeyAlgorithm,
 	)
 }
@@ -17,34 +17,72 @@ limitations under the License.
 package apiserver
 
 import (
-	cryptorand "crypto/rand"
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
 	"crypto/rsa"
 	"crypto/x509"
 	"encoding/pem"
+	"fmt"
+	"time"
 
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
 	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/resources/certificates"
 	"k8c.io/kubermatic/v2/pkg/resources/reconciling"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/util/keyutil"
 )
 
-// ServiceAccountKeyCreator returns a function to create/update a secret with the ServiceAccount key
-func ServiceAccountKeyCreator() reconciling.NamedSecretCreatorGetter {
+// ServiceAccountMaxTokenExpiration is the longest lifetime of a service account token issued by the
+// apiserver. The public key of a replaced service account key is kept for this long.
+const ServiceAccountMaxTokenExpiration = 24 * time.Hour
+
+type serviceAccountKeyCreatorData interface {
+	Cluster() *kubermaticv1.Cluster
+}
+
+// ServiceAccountKeyCreator returns a function to create/update a secret with the ServiceAccount key.
+// An existing key is only replaced if it is corrupt or does not match the configured algorithm and
+// size. The public key of a replaced key is still accepted by the apiserver until the tokens signed
+// with it expired or the key is replaced again, so that tokens stay valid while they are reissued. The apiserver
+// and controller manager are restarted with a new key by their secret revision labels.
+func ServiceAccountKeyCreator(data serviceAccountKeyCreatorData) reconciling.NamedSecretCreatorGetter {
 	return func() (string, reconciling.SecretCreator) {
 		return resources.ServiceAccountKeySecretName, func(se *corev1.Secret) (*corev1.Secret, error) {
-			if _, exists := se.Data[resources.ServiceAccountKeySecretKey]; exists {
-				return se, nil
-			}
-			priv, err := rsa.GenerateKey(cryptorand.Reader, 2048)
-			if err != nil {
-				return nil, err
+			if se.Data == nil {
+				se.Data = map[string][]byte{}
 			}
-			saKey := x509.MarshalPKCS1PrivateKey(priv)
-			privKeyBlock := pem.Block{
-				Type:  "RSA PRIVATE KEY",
-				Bytes: saKey,
+
+			settings := data.Cluster().Spec.ComponentsOverride.Apiserver
+			priv := existingServiceAccountKey(se.Data[resources.ServiceAccountKeySecretKey], settings.ServiceAccountKeyAlgorithm, settings.ServiceAccountKeySize)
+			if priv == nil {
+				var err error
+				priv, err = certificates.NewPrivateKeyWithSize(settings.ServiceAccountKeyAlgorithm, settings.ServiceAccountKeySize)
+				if err != nil {
+					return nil, fmt.Errorf("failed to create the service account key: %v", err)
+				}
+				privKeyPEM, err := keyutil.MarshalPrivateKeyToPEM(priv)
+				if err != nil {
+					return nil, fmt.Errorf("failed to encode the service account key: %v", err)
+				}
+				se.Data[resources.ServiceAccountKeySecretKey] = privKeyPEM
+
+				// the public key of a corrupt private key was derived when it was still usable
+				if previous := se.Data[resources.ServiceAccountKeyPublicKey]; len(previous) > 0 {
+					se.Data[resources.ServiceAccountKeyPreviousPublicKey] = previous
+					if se.Annotations == nil {
+						se.Annotations = map[string]string{}
+					}
+					se.Annotations[resources.ServiceAccountKeyRotatedAnnotation] = time.Now().UTC().Format(time.RFC3339)
+				}
 			}
-			publicKeyDer, err := x509.MarshalPKIXPublicKey(&priv.PublicKey)
+			expirePreviousServiceAccountKey(se, time.Now())
+
+			// The public key is always derived from the private key, so a missing or
+			// outdated public key does not require a new key pair
+			publicKeyDer, err := x509.MarshalPKIXPublicKey(priv.Public())
 			if err != nil {
 				return nil, err
 			}
@@ -53,14 +91,82 @@ func ServiceAccountKeyCreator() reconciling.NamedSecretCreatorGetter {
 				Headers: nil,
 				Bytes:   publicKeyDer,
 			}
-			if se.Data == nil {
-				se.Data = map[string][]byte{}
+			publicKeyPEM := pem.EncodeToMemory(&publicKeyBlock)
+			se.Data[resources.ServiceAccountKeyPublicKey] = publicKeyPEM
+
+			verificationKeys := publicKeyPEM
+			if previous := se.Data[resources.ServiceAccountKeyPreviousPublicKey]; len(previous) > 0 && !bytes.Equal(previous, publicKeyPEM) {
+				verificationKeys = append(append([]byte{}, publicKeyPEM...), previous...)
 			}
-			se.Data[resources.ServiceAccountKeySecretKey] = pem.EncodeToMemory(&privKeyBlock)
-			se.Data[resources.ServiceAccountKeyPublicKey] = pem.EncodeToMemory(&publicKeyBlock)
+			se.Data[resources.ServiceAccountKeyVerificationKeys] = verificationKeys
 			return se, nil
 
 		}
 	}
 
 }
+
+// expirePreviousServiceAccountKey removes the public key of the replaced service account key once all
+// tokens signed with it expired. A previous key without a valid rotation time, as kept by older
+// versions, expires from now on.
+func expirePreviousServiceAccountKey(se *corev1.Secret, now time.Time) {
+	if len(se.Data[resources.ServiceAccountKeyPreviousPublicKey]) == 0 {
+		delete(se.Annotations, resources.ServiceAccountKeyRotatedAnnotation)
+		return
+	}
+
+	rotated, err := time.Parse(time.RFC3339, se.Annotations[resources.ServiceAccountKeyRotatedAnnotation])
+	if err != nil {
+		if se.Annotations == nil {
+			se.Annotations = map[string]string{}
+		}
+		se.Annotations[resources.ServiceAccountKeyRotatedAnnotation] = now.UTC().Format(time.RFC3339)
+		return
+	}
+
+	if now.Sub(rotated) > ServiceAccountMaxTokenExpiration {
+		delete(se.Data, resources.ServiceAccountKeyPreviousPublicKey)
+		delete(se.Annotations, resources.ServiceAccountKeyRotatedAnnotation)
+	}
+}
+
+// existingServiceAccountKey returns the PEM-encoded private key if it can be parsed and matches the
+// given algorithm and size. Nil is returned if there is no usable key and a new one must be created.
+func existingServiceAccountKey(keyPEM []byte, algorithm kubermaticv1.KeyAlgorithm, size int) crypto.Signer {
+	if len(keyPEM) == 0 {
+		return nil
+	}
+
+	// a corrupt key can not be used by the apiserver anyway
+	key, err := keyutil.ParsePrivateKeyPEM(keyPEM)
+	if err != nil {
+		return nil
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok || !serviceAccountKeyMatches(signer, algorithm, size) {
+		return nil
+	}
+
+	return signer
+}
+
+// serviceAccountKeyMatches returns true if the private key was created with the given algorithm
+// and size. Without any settings every key matches, so that existing keys are kept.
+func serviceAccountKeyMatches(key crypto.Signer, algorithm kubermaticv1.KeyAlgorithm, size int) bool {
+	if algorithm == "" && size == 0 {
+		return true
+	}
+	if algorithm == "" {
+		algorithm = kubermaticv1.KeyAlgorithmRSA
+	}
+
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		return algorithm == kubermaticv1.KeyAlgorithmRSA && (size == 0 || k.N.BitLen() == size)
+	case *ecdsa.PrivateKey:
+		return algorithm == kubermaticv1.KeyAlgorithmECDSA && (size == 0 || k.Curve.Params().BitSize == size)
+	default:
+		return false
+	}
+}
@@ -0,0 +1,243 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+type fakeServiceAccountKeyCreatorData struct {
+	cluster *kubermaticv1.Cluster
+}
+
+func (f *fakeServiceAccountKeyCreatorData) Cluster() *kubermaticv1.Cluster {
+	return f.cluster
+}
+
+func TestServiceAccountKeyCreator(t *testing.T) {
+	testCases := []struct {
+		name          string
+		existingKey   *kubermaticv1.APIServerSettings
+		modifySecret  func(*corev1.Secret)
+		settings      kubermaticv1.APIServerSettings
+		expectReplace bool
+		expectErr     bool
+	}{
+		{
+			name:     "default RSA key is created",
+			settings: kubermaticv1.APIServerSettings{},
+		},
+		{
+			name: "ECDSA key is created",
+			settings: kubermaticv1.APIServerSettings{
+				ServiceAccountKeyAlgorithm: kubermaticv1.KeyAlgorithmECDSA,
+				ServiceAccountKeySize:      384,
+			},
+		},
+		{
+			name:          "existing key is kept without settings",
+			existingKey:   &kubermaticv1.APIServerSettings{ServiceAccountKeySize: 3072},
+			settings:      kubermaticv1.APIServerSettings{},
+			expectReplace: false,
+		},
+		{
+			name:          "existing key is kept if it matches the settings",
+			existingKey:   &kubermaticv1.APIServerSettings{ServiceAccountKeyAlgorithm: kubermaticv1.KeyAlgorithmECDSA},
+			settings:      kubermaticv1.APIServerSettings{ServiceAccountKeyAlgorithm: kubermaticv1.KeyAlgorithmECDSA, ServiceAccountKeySize: 256},
+			expectReplace: false,
+		},
+		{
+			name:          "existing key is replaced if the algorithm changes",
+			existingKey:   &kubermaticv1.APIServerSettings{},
+			settings:      kubermaticv1.APIServerSettings{ServiceAccountKeyAlgorithm: kubermaticv1.KeyAlgorithmECDSA},
+			expectReplace: true,
+		},
+		{
+			name:          "existing key is replaced if the size changes",
+			existingKey:   &kubermaticv1.APIServerSettings{},
+			settings:      kubermaticv1.APIServerSettings{ServiceAccountKeySize: 3072},
+			expectReplace: true,
+		},
+		{
+			name:        "corrupt key is replaced",
+			existingKey: &kubermaticv1.APIServerSettings{},
+			modifySecret: func(s *corev1.Secret) {
+				s.Data[resources.ServiceAccountKeySecretKey] = []byte("not a key")
+			},
+			settings:      kubermaticv1.APIServerSettings{},
+			expectReplace: true,
+		},
+		{
+			name:        "missing public key is restored without replacing the key",
+			existingKey: &kubermaticv1.APIServerSettings{},
+			modifySecret: func(s *corev1.Secret) {
+				delete(s.Data, resources.ServiceAccountKeyPublicKey)
+			},
+			settings:      kubermaticv1.APIServerSettings{},
+			expectReplace: false,
+		},
+		{
+			name:      "unsupported key size",
+			settings:  kubermaticv1.APIServerSettings{ServiceAccountKeySize: 1024},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			secret := &corev1.Secret{}
+			if tc.existingKey != nil {
+				var err error
+				secret, err = createServiceAccountKeySecret(*tc.existingKey, &corev1.Secret{})
+				if err != nil {
+					t.Fatalf("failed to create existing key: %v", err)
+				}
+			}
+			if tc.modifySecret != nil {
+				tc.modifySecret(secret)
+			}
+			existingKeyPEM := secret.Data[resources.ServiceAccountKeySecretKey]
+			existingPublicKeyPEM := secret.Data[resources.ServiceAccountKeyPublicKey]
+
+			secret, err := createServiceAccountKeySecret(tc.settings, secret.DeepCopy())
+			if (err != nil) != tc.expectErr {
+				t.Fatalf("expected error: %t, got: %v", tc.expectErr, err)
+			}
+			if err != nil {
+				return
+			}
+
+			keyPEM := secret.Data[resources.ServiceAccountKeySecretKey]
+			if existingServiceAccountKey(keyPEM, tc.settings.ServiceAccountKeyAlgorithm, tc.settings.ServiceAccountKeySize) == nil {
+				t.Errorf("key does not match algorithm %q and size %d", tc.settings.ServiceAccountKeyAlgorithm, tc.settings.ServiceAccountKeySize)
+			}
+			if len(secret.Data[resources.ServiceAccountKeyPublicKey]) == 0 {
+				t.Error("public key is missing")
+			}
+			if existingKeyPEM != nil {
+				if replaced := !bytes.Equal(existingKeyPEM, keyPEM); replaced != tc.expectReplace {
+					t.Errorf("expected key to be replaced: %t, but was replaced: %t", tc.expectReplace, replaced)
+				}
+			}
+			verificationKeys := secret.Data[resources.ServiceAccountKeyVerificationKeys]
+			if !bytes.Contains(verificationKeys, secret.Data[resources.ServiceAccountKeyPublicKey]) {
+				t.Error("verification keys do not contain the public key")
+			}
+			if tc.expectReplace && existingPublicKeyPEM != nil && !bytes.Contains(verificationKeys, existingPublicKeyPEM) {
+				t.Error("verification keys do not contain the public key of the replaced key")
+			}
+		})
+	}
+}
+
+func TestExpirePreviousServiceAccountKey(t *testing.T) {
+	now := time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+	previousKey := []byte("previous public key")
+
+	testCases := []struct {
+		name                string
+		rotated             string
+		expectPreviousKey   bool
+		expectRotationReset bool
+	}{
+		{
+			name:              "previous key is kept while tokens signed with it are valid",
+			rotated:           now.Add(-time.Hour).Format(time.RFC3339),
+			expectPreviousKey: true,
+		},
+		{
+			name:              "previous key is removed once tokens signed with it expired",
+			rotated:           now.Add(-ServiceAccountMaxTokenExpiration - time.Minute).Format(time.RFC3339),
+			expectPreviousKey: false,
+		},
+		{
+			name:                "previous key without rotation time expires from now on",
+			expectPreviousKey:   true,
+			expectRotationReset: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			secret := &corev1.Secret{
+				Data: map[string][]byte{
+					resources.ServiceAccountKeyPreviousPublicKey: previousKey,
+				},
+			}
+			if tc.rotated != "" {
+				secret.Annotations = map[string]string{resources.ServiceAccountKeyRotatedAnnotation: tc.rotated}
+			}
+
+			expirePreviousServiceAccountKey(secret, now)
+
+			if hasPreviousKey := len(secret.Data[resources.ServiceAccountKeyPreviousPublicKey]) > 0; hasPreviousKey != tc.expectPreviousKey {
+				t.Errorf("expected previous key to be kept: %t, but was kept: %t", tc.expectPreviousKey, hasPreviousKey)
+			}
+			rotated, hasRotated := secret.Annotations[resources.ServiceAccountKeyRotatedAnnotation]
+			if hasRotated != tc.expectPreviousKey {
+				t.Errorf("expected rotation annotation: %t, got %q", tc.expectPreviousKey, rotated)
+			}
+			if tc.expectRotationReset && rotated != now.Format(time.RFC3339) {
+				t.Errorf("expected rotation time to be reset to %s, got %q", now.Format(time.RFC3339), rotated)
+			}
+		})
+	}
+}
+
+func TestServiceAccountKeyCreatorRemovesExpiredPreviousKey(t *testing.T) {
+	secret, err := createServiceAccountKeySecret(kubermaticv1.APIServerSettings{}, &corev1.Secret{})
+	if err != nil {
+		t.Fatalf("failed to create existing key: %v", err)
+	}
+	oldPublicKey := secret.Data[resources.ServiceAccountKeyPublicKey]
+
+	// replace the key, the public key of the old one is kept for verification
+	secret, err = createServiceAccountKeySecret(kubermaticv1.APIServerSettings{ServiceAccountKeyAlgorithm: kubermaticv1.KeyAlgorithmECDSA}, secret)
+	if err != nil {
+		t.Fatalf("failed to replace key: %v", err)
+	}
+	if !bytes.Contains(secret.Data[resources.ServiceAccountKeyVerificationKeys], oldPublicKey) {
+		t.Fatal("verification keys do not contain the public key of the replaced key")
+	}
+
+	// once the tokens signed with the old key expired, its public key is no longer accepted
+	secret.Annotations[resources.ServiceAccountKeyRotatedAnnotation] = time.Now().Add(-ServiceAccountMaxTokenExpiration - time.Minute).UTC().Format(time.RFC3339)
+	secret, err = createServiceAccountKeySecret(kubermaticv1.APIServerSettings{ServiceAccountKeyAlgorithm: kubermaticv1.KeyAlgorithmECDSA}, secret)
+	if err != nil {
+		t.Fatalf("failed to reconcile key: %v", err)
+	}
+	if _, ok := secret.Data[resources.ServiceAccountKeyPreviousPublicKey]; ok {
+		t.Error("expired previous public key was not removed")
+	}
+	if bytes.Contains(secret.Data[resources.ServiceAccountKeyVerificationKeys], oldPublicKey) {
+		t.Error("verification keys still contain the expired public key")
+	}
+}
+
+func createServiceAccountKeySecret(settings kubermaticv1.APIServerSettings, secret *corev1.Secret) (*corev1.Secret, error) {
+	cluster := &kubermaticv1.Cluster{}
+	cluster.Spec.ComponentsOverride.Apiserver = settings
+	_, creator := ServiceAccountKeyCreator(&fakeServiceAccountKeyCreatorData{cluster: cluster})()
+	return creator(secret)
+}
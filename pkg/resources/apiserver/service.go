@@ -18,6 +18,7 @@ package apiserver
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
@@ -30,13 +31,50 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
+// customAnnotationsAnnotationKey lists the annotations which were added from the service settings,
+// so they can be removed from the service again once they are dropped from the settings.
+const customAnnotationsAnnotationKey = "kubermatic.io/custom-annotations"
+
+// ServiceSettings returns the service settings of the cluster merged onto those of its datacenter.
+// The NodePort is only taken from the cluster, as it is specific to a single service.
+func ServiceSettings(cluster *kubermaticv1.Cluster, dc *kubermaticv1.Datacenter) *kubermaticv1.APIServerServiceSettings {
+	overrides := []*kubermaticv1.APIServerServiceSettings{cluster.Spec.ComponentsOverride.Apiserver.Service}
+	if dc != nil {
+		overrides = append([]*kubermaticv1.APIServerServiceSettings{dc.Spec.APIServerService}, overrides...)
+	}
+
+	settings := &kubermaticv1.APIServerServiceSettings{}
+	for _, s := range overrides {
+		if s == nil {
+			continue
+		}
+		if s.Type != "" {
+			settings.Type = s.Type
+		}
+		for k, v := range s.Annotations {
+			if settings.Annotations == nil {
+				settings.Annotations = map[string]string{}
+			}
+			settings.Annotations[k] = v
+		}
+	}
+	if s := cluster.Spec.ComponentsOverride.Apiserver.Service; s != nil {
+		settings.NodePort = s.NodePort
+	}
+	return settings
+}
+
 // ServiceCreator returns the function to reconcile the external API server service
-func ServiceCreator(exposeStrategy kubermaticv1.ExposeStrategy, externalURL string) reconciling.NamedServiceCreatorGetter {
+func ServiceCreator(exposeStrategy kubermaticv1.ExposeStrategy, externalURL string, settings *kubermaticv1.APIServerServiceSettings) reconciling.NamedServiceCreatorGetter {
 	return func() (string, reconciling.ServiceCreator) {
 		return resources.ApiserverServiceName, func(se *corev1.Service) (*corev1.Service, error) {
 			if se.Annotations == nil {
 				se.Annotations = map[string]string{}
 			}
+			for _, key := range strings.Split(se.Annotations[customAnnotationsAnnotationKey], ",") {
+				delete(se.Annotations, key)
+			}
+			delete(se.Annotations, customAnnotationsAnnotationKey)
 
 			switch exposeStrategy {
 			case kubermaticv1.ExposeStrategyNodePort:
@@ -64,10 +102,25 @@ func ServiceCreator(exposeStrategy kubermaticv1.ExposeStrategy, externalURL stri
 				return nil, fmt.Errorf("unsupported expose strategy: %q", exposeStrategy)
 			}
 
+			if settings != nil {
+				// The load balancer and tunneling strategies rely on the type set above.
+				if settings.Type != "" && exposeStrategy == kubermaticv1.ExposeStrategyNodePort {
+					se.Spec.Type = settings.Type
+				}
+				setCustomAnnotations(se, settings.Annotations)
+			}
+
 			se.Spec.Selector = map[string]string{
 				resources.AppLabelKey: name,
 			}
 
+			// Only request a fixed port for services which get a NodePort, the port is
+			// allocated by Kubernetes otherwise.
+			var nodePort int32
+			if settings != nil && se.Spec.Type != corev1.ServiceTypeClusterIP {
+				nodePort = settings.NodePort
+			}
+
 			if len(se.Spec.Ports) == 0 {
 				se.Spec.Ports = []corev1.ServicePort{
 					{
@@ -75,6 +128,7 @@ func ServiceCreator(exposeStrategy kubermaticv1.ExposeStrategy, externalURL stri
 						Port:       443,
 						Protocol:   corev1.ProtocolTCP,
 						TargetPort: intstr.FromInt(resources.APIServerSecurePort),
+						NodePort:   nodePort,
 					},
 				}
 
@@ -84,6 +138,9 @@ func ServiceCreator(exposeStrategy kubermaticv1.ExposeStrategy, externalURL stri
 			se.Spec.Ports[0].Name = "secure"
 			se.Spec.Ports[0].Protocol = corev1.ProtocolTCP
 			se.Spec.Ports[0].Port = 443
+			if nodePort != 0 {
+				se.Spec.Ports[0].NodePort = nodePort
+			}
 			if exposeStrategy == kubermaticv1.ExposeStrategyTunneling {
 				se.Spec.Ports[0].TargetPort = intstr.FromInt(resources.APIServerSecurePort)
 			} else {
@@ -97,3 +154,20 @@ func ServiceCreator(exposeStrategy kubermaticv1.ExposeStrategy, externalURL stri
 		}
 	}
 }
+
+// setCustomAnnotations adds the given annotations to the service, except for those which are
+// managed by Kubermatic, and records their keys so they can be removed later on.
+func setCustomAnnotations(se *corev1.Service, annotations map[string]string) {
+	var keys []string
+	for k, v := range annotations {
+		if _, managed := se.Annotations[k]; managed {
+			continue
+		}
+		se.Annotations[k] = v
+		keys = append(keys, k)
+	}
+	if len(keys) > 0 {
+		sort.Strings(keys)
+		se.Annotations[customAnnotationsAnnotationKey] = strings.Join(keys, ",")
+	}
+}
@@ -17,11 +17,14 @@ limitations under the License.
 package apiserver
 
 import (
+	"reflect"
 	"testing"
 
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources/nodeportproxy"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
@@ -48,7 +51,7 @@ func TestServiceCreatorRequiresExposeStrategy(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			_, creator := ServiceCreator(tc.exposeStrategy, tc.internalService)()
+			_, creator := ServiceCreator(tc.exposeStrategy, tc.internalService, nil)()
 			_, err := creator(&corev1.Service{})
 			if (err != nil) != tc.errExpected {
 				t.Errorf("Expected err: %t, but got err %v", tc.errExpected, err)
@@ -132,7 +135,7 @@ func TestServiceCreatorSetsPort(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			_, creator := ServiceCreator(tc.exposeStrategy, tc.internalService)()
+			_, creator := ServiceCreator(tc.exposeStrategy, tc.internalService, nil)()
 			svc, err := creator(tc.inService)
 			if err != nil {
 				t.Fatalf("Unexpected error: %v", err)
@@ -155,3 +158,147 @@ func TestServiceCreatorSetsPort(t *testing.T) {
 		})
 	}
 }
+
+func TestServiceCreatorAppliesSettings(t *testing.T) {
+	testCases := []struct {
+		name                string
+		exposeStrategy      kubermaticv1.ExposeStrategy
+		settings            *kubermaticv1.APIServerServiceSettings
+		inService           *corev1.Service
+		expectedType        corev1.ServiceType
+		expectedAnnotations map[string]string
+	}{
+		{
+			name:           "Type and annotations are set",
+			exposeStrategy: kubermaticv1.ExposeStrategyNodePort,
+			settings: &kubermaticv1.APIServerServiceSettings{
+				Type:        corev1.ServiceTypeLoadBalancer,
+				Annotations: map[string]string{"service.beta.kubernetes.io/aws-load-balancer-internal": "true"},
+			},
+			inService:    &corev1.Service{},
+			expectedType: corev1.ServiceTypeLoadBalancer,
+			expectedAnnotations: map[string]string{
+				nodeportproxy.DefaultExposeAnnotationKey:                nodeportproxy.NodePortType.String(),
+				"service.beta.kubernetes.io/aws-load-balancer-internal": "true",
+				customAnnotationsAnnotationKey:                          "service.beta.kubernetes.io/aws-load-balancer-internal",
+			},
+		},
+		{
+			name:           "Type is ignored for the LoadBalancer expose strategy",
+			exposeStrategy: kubermaticv1.ExposeStrategyLoadBalancer,
+			settings: &kubermaticv1.APIServerServiceSettings{
+				Type: corev1.ServiceTypeLoadBalancer,
+			},
+			inService:    &corev1.Service{},
+			expectedType: corev1.ServiceTypeNodePort,
+			expectedAnnotations: map[string]string{
+				nodeportproxy.NodePortProxyExposeNamespacedAnnotationKey: "true",
+			},
+		},
+		{
+			name:           "Managed annotations are not overridden",
+			exposeStrategy: kubermaticv1.ExposeStrategyNodePort,
+			settings: &kubermaticv1.APIServerServiceSettings{
+				Annotations: map[string]string{nodeportproxy.DefaultExposeAnnotationKey: "SNI"},
+			},
+			inService:    &corev1.Service{},
+			expectedType: corev1.ServiceTypeNodePort,
+			expectedAnnotations: map[string]string{
+				nodeportproxy.DefaultExposeAnnotationKey: nodeportproxy.NodePortType.String(),
+			},
+		},
+		{
+			name:           "Annotations which were removed from the settings are removed from the service",
+			exposeStrategy: kubermaticv1.ExposeStrategyNodePort,
+			settings: &kubermaticv1.APIServerServiceSettings{
+				Annotations: map[string]string{"b": "new"},
+			},
+			inService: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"a":                            "old",
+						"b":                            "old",
+						"foreign":                      "kept",
+						customAnnotationsAnnotationKey: "a,b",
+					},
+				},
+			},
+			expectedType: corev1.ServiceTypeNodePort,
+			expectedAnnotations: map[string]string{
+				nodeportproxy.DefaultExposeAnnotationKey: nodeportproxy.NodePortType.String(),
+				"b":                                      "new",
+				"foreign":                                "kept",
+				customAnnotationsAnnotationKey:           "b",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, creator := ServiceCreator(tc.exposeStrategy, "", tc.settings)()
+			svc, err := creator(tc.inService)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if svc.Spec.Type != tc.expectedType {
+				t.Errorf("Expected type to be %q but was %q", tc.expectedType, svc.Spec.Type)
+			}
+			if !reflect.DeepEqual(svc.Annotations, tc.expectedAnnotations) {
+				t.Errorf("Expected annotations to be %v but were %v", tc.expectedAnnotations, svc.Annotations)
+			}
+		})
+	}
+}
+
+func TestServiceCreatorRequestsNodePort(t *testing.T) {
+	testCases := []struct {
+		name               string
+		exposeStrategy     kubermaticv1.ExposeStrategy
+		inService          *corev1.Service
+		expectedNodePort   int32
+		expectedTargetPort intstr.IntOrString
+	}{
+		{
+			name:               "Requested port is set on a new service",
+			exposeStrategy:     kubermaticv1.ExposeStrategyNodePort,
+			inService:          &corev1.Service{},
+			expectedNodePort:   int32(31000),
+			expectedTargetPort: intstr.FromInt(6443),
+		},
+		{
+			name:           "Requested port replaces the allocated port",
+			exposeStrategy: kubermaticv1.ExposeStrategyLoadBalancer,
+			inService: &corev1.Service{
+				Spec: corev1.ServiceSpec{
+					Type:  corev1.ServiceTypeNodePort,
+					Ports: []corev1.ServicePort{{Port: 443, NodePort: int32(32000)}},
+				},
+			},
+			expectedNodePort:   int32(31000),
+			expectedTargetPort: intstr.FromInt(31000),
+		},
+		{
+			name:               "Requested port is ignored for the tunneling strategy",
+			exposeStrategy:     kubermaticv1.ExposeStrategyTunneling,
+			inService:          &corev1.Service{},
+			expectedNodePort:   int32(0),
+			expectedTargetPort: intstr.FromInt(6443),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, creator := ServiceCreator(tc.exposeStrategy, "", &kubermaticv1.APIServerServiceSettings{NodePort: 31000})()
+			svc, err := creator(tc.inService)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if svc.Spec.Ports[0].NodePort != tc.expectedNodePort {
+				t.Errorf("Expected nodePort to be %d but was %d", tc.expectedNodePort, svc.Spec.Ports[0].NodePort)
+			}
+			if svc.Spec.Ports[0].TargetPort.String() != tc.expectedTargetPort.String() {
+				t.Errorf("Expected targetPort to be %q but was %q", tc.expectedTargetPort.String(), svc.Spec.Ports[0].TargetPort.String())
+			}
+		})
+	}
+}
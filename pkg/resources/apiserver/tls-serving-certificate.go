@@ -24,6 +24,7 @@ import (
 
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
 	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/resources/certificates"
 	"k8c.io/kubermatic/v2/pkg/resources/certificates/triple"
 	"k8c.io/kubermatic/v2/pkg/resources/reconciling"
 
@@ -49,6 +50,8 @@ func TLSServingCertificateCreator(data tlsServingCertCreatorData) reconciling.Na
 				return nil, fmt.Errorf("failed to get cluster ca: %v", err)
 			}
 
+			keyAlgorithm := data.Cluster().Spec.ComponentsOverride.Apiserver.CertificateKeyAlgorithm
+
 			inClusterIP, err := resources.InClusterApiserverIP(data.Cluster())
 			if err != nil {
 				return nil, fmt.Errorf("failed to get the in-cluster ClusterIP for the apiserver: %v", err)
@@ -88,18 +91,27 @@ func TLSServingCertificateCreator(data tlsServingCertCreatorData) reconciling.Na
 				altNames.IPs = append(altNames.IPs, externalIPParsed)
 			}
 
+			for _, san := range data.Cluster().Spec.ComponentsOverride.Apiserver.CertSANs {
+				if ip := net.ParseIP(san); ip != nil {
+					altNames.IPs = append(altNames.IPs, ip)
+				} else {
+					altNames.DNSNames = append(altNames.DNSNames, san)
+				}
+			}
+
 			if b, exists := se.Data[resources.ApiserverTLSCertSecretKey]; exists {
 				certs, err := certutil.ParseCertsPEM(b)
 				if err != nil {
 					return nil, fmt.Errorf("failed to parse certificate (key=%s) from existing secret: %v", resources.ApiserverTLSCertSecretKey, err)
 				}
 
-				if resources.IsServerCertificateValidForAllOf(certs[0], "kube-apiserver", altNames, ca.Cert) {
+				if resources.IsServerCertificateValidForAllOf(certs[0], "kube-apiserver", altNames, ca.Cert) &&
+					certificates.KeyAlgorithmMatches(certs[0], keyAlgorithm) {
 					return se, nil
 				}
 			}
 
-			key, err := triple.NewPrivateKey()
+			key, err := certificates.NewPrivateKey(keyAlgorithm)
 			if err != nil {
 				return nil, fmt.Errorf("unable to create a server private key: %v", err)
 			}
@@ -115,7 +127,12 @@ func TLSServingCertificateCreator(data tlsServingCertCreatorData) reconciling.Na
 				return nil, fmt.Errorf("unable to sign the server certificate: %v", err)
 			}
 
-			se.Data[resources.ApiserverTLSKeySecretKey] = triple.EncodePrivateKeyPEM(key)
+			keyPEM, err := triple.EncodeSignerPEM(key)
+			if err != nil {
+				return nil, fmt.Errorf("unable to encode the server private key: %v", err)
+			}
+
+			se.Data[resources.ApiserverTLSKeySecretKey] = keyPEM
 			se.Data[resources.ApiserverTLSCertSecretKey] = triple.EncodeCertPEM(cert)
 
 			return se, nil
@@ -0,0 +1,177 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"testing"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/resources/certificates/triple"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	certutil "k8s.io/client-go/util/cert"
+)
+
+type fakeTLSServingCertCreatorData struct {
+	cluster *kubermaticv1.Cluster
+	ca      *triple.KeyPair
+}
+
+func (f *fakeTLSServingCertCreatorData) Cluster() *kubermaticv1.Cluster {
+	return f.cluster
+}
+
+func (f *fakeTLSServingCertCreatorData) GetRootCA() (*triple.KeyPair, error) {
+	return f.ca, nil
+}
+
+func TestTLSServingCertificateCreatorCertSANs(t *testing.T) {
+	ca, err := triple.NewCA("test-ca")
+	if err != nil {
+		t.Fatalf("failed to create CA: %v", err)
+	}
+
+	cluster := &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		Spec: kubermaticv1.ClusterSpec{
+			ClusterNetwork: kubermaticv1.ClusterNetworkingConfig{
+				Services:  kubermaticv1.NetworkRanges{CIDRBlocks: []string{"10.240.16.0/20"}},
+				DNSDomain: "cluster.local",
+			},
+			ComponentsOverride: kubermaticv1.ComponentSettings{
+				Apiserver: kubermaticv1.APIServerSettings{
+					CertSANs: []string{"api.example.com", "192.168.1.10"},
+				},
+			},
+		},
+		Address: kubermaticv1.ClusterAddress{
+			ExternalName: "test.europe-west3-c.dev.kubermatic.io",
+			IP:           "35.198.93.90",
+		},
+		Status: kubermaticv1.ClusterStatus{NamespaceName: "cluster-test"},
+	}
+	data := &fakeTLSServingCertCreatorData{cluster: cluster, ca: ca}
+
+	_, create := TLSServingCertificateCreator(data)()
+	secret, err := create(&corev1.Secret{})
+	if err != nil {
+		t.Fatalf("failed to create serving certificate: %v", err)
+	}
+
+	cert := parseServingCert(t, secret)
+	if !sets.NewString(cert.DNSNames...).Has("api.example.com") {
+		t.Errorf("expected DNS SAN api.example.com, got %v", cert.DNSNames)
+	}
+	if !hasIP(cert.IPAddresses, "192.168.1.10") {
+		t.Errorf("expected IP SAN 192.168.1.10, got %v", cert.IPAddresses)
+	}
+
+	// Unchanged SANs must not reissue the certificate
+	existing := secret.DeepCopy()
+	secret, err = create(existing)
+	if err != nil {
+		t.Fatalf("failed to reconcile serving certificate: %v", err)
+	}
+	if !parseServingCert(t, secret).Equal(cert) {
+		t.Error("expected the certificate to be kept with unchanged SANs")
+	}
+
+	// Changed SANs must reissue the certificate
+	cluster.Spec.ComponentsOverride.Apiserver.CertSANs = []string{"other.example.com"}
+	secret, err = create(secret)
+	if err != nil {
+		t.Fatalf("failed to reconcile serving certificate: %v", err)
+	}
+	reissued := parseServingCert(t, secret)
+	if reissued.Equal(cert) {
+		t.Fatal("expected the certificate to be reissued after changing the SANs")
+	}
+	if dnsNames := sets.NewString(reissued.DNSNames...); !dnsNames.Has("other.example.com") || dnsNames.Has("api.example.com") {
+		t.Errorf("expected DNS SANs to be updated, got %v", reissued.DNSNames)
+	}
+	if hasIP(reissued.IPAddresses, "192.168.1.10") {
+		t.Errorf("expected IP SAN 192.168.1.10 to be removed, got %v", reissued.IPAddresses)
+	}
+}
+
+func TestTLSServingCertificateCreatorKeyAlgorithm(t *testing.T) {
+	ca, err := triple.NewCA("test-ca")
+	if err != nil {
+		t.Fatalf("failed to create CA: %v", err)
+	}
+
+	cluster := &kubermaticv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		Spec: kubermaticv1.ClusterSpec{
+			ClusterNetwork: kubermaticv1.ClusterNetworkingConfig{
+				Services:  kubermaticv1.NetworkRanges{CIDRBlocks: []string{"10.240.16.0/20"}},
+				DNSDomain: "cluster.local",
+			},
+		},
+		Address: kubermaticv1.ClusterAddress{
+			ExternalName: "test.europe-west3-c.dev.kubermatic.io",
+			IP:           "35.198.93.90",
+		},
+		Status: kubermaticv1.ClusterStatus{NamespaceName: "cluster-test"},
+	}
+	data := &fakeTLSServingCertCreatorData{cluster: cluster, ca: ca}
+
+	_, create := TLSServingCertificateCreator(data)()
+	secret, err := create(&corev1.Secret{})
+	if err != nil {
+		t.Fatalf("failed to create serving certificate: %v", err)
+	}
+	if cert := parseServingCert(t, secret); cert.PublicKeyAlgorithm != x509.RSA {
+		t.Fatalf("expected a RSA key by default, got %v", cert.PublicKeyAlgorithm)
+	}
+
+	// Switching the algorithm must reissue the certificate with a key of the new algorithm
+	cluster.Spec.ComponentsOverride.Apiserver.CertificateKeyAlgorithm = kubermaticv1.KeyAlgorithmECDSA
+	secret, err = create(secret)
+	if err != nil {
+		t.Fatalf("failed to reconcile serving certificate: %v", err)
+	}
+	if cert := parseServingCert(t, secret); cert.PublicKeyAlgorithm != x509.ECDSA {
+		t.Errorf("expected an ECDSA key, got %v", cert.PublicKeyAlgorithm)
+	}
+	if _, err := tls.X509KeyPair(secret.Data[resources.ApiserverTLSCertSecretKey], secret.Data[resources.ApiserverTLSKeySecretKey]); err != nil {
+		t.Errorf("certificate and key do not form a valid key pair: %v", err)
+	}
+}
+
+func parseServingCert(t *testing.T, secret *corev1.Secret) *x509.Certificate {
+	certs, err := certutil.ParseCertsPEM(secret.Data[resources.ApiserverTLSCertSecretKey])
+	if err != nil {
+		t.Fatalf("failed to parse serving certificate: %v", err)
+	}
+	return certs[0]
+}
+
+func hasIP(ips []net.IP, ip string) bool {
+	for _, i := range ips {
+		if i.Equal(net.ParseIP(ip)) {
+			return true
+		}
+	}
+	return false
+}
@@ -19,6 +19,7 @@ package certificates
 import (
 	"fmt"
 
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
 	"k8c.io/kubermatic/v2/pkg/resources"
 	"k8c.io/kubermatic/v2/pkg/resources/certificates/triple"
 	"k8c.io/kubermatic/v2/pkg/resources/reconciling"
@@ -31,6 +32,12 @@ type caGetter func() (*triple.KeyPair, error)
 
 // GetClientCertificateCreator is a generic function to return a secret generator to create a client certificate signed by the cluster CA
 func GetClientCertificateCreator(name, commonName string, organizations []string, dataCertKey, dataKeyKey string, getCA caGetter) reconciling.NamedSecretCreatorGetter {
+	return GetClientCertificateCreatorWithKeyAlgorithm(name, commonName, organizations, dataCertKey, dataKeyKey, getCA, "")
+}
+
+// GetClientCertificateCreatorWithKeyAlgorithm works like GetClientCertificateCreator, but creates the private key using
+// the given algorithm. Existing certificates with a key of a different algorithm get replaced.
+func GetClientCertificateCreatorWithKeyAlgorithm(name, commonName string, organizations []string, dataCertKey, dataKeyKey string, getCA caGetter, algorithm kubermaticv1.KeyAlgorithm) reconciling.NamedSecretCreatorGetter {
 	return func() (string, reconciling.SecretCreator) {
 		return name, func(se *corev1.Secret) (*corev1.Secret, error) {
 			// TODO: Remove this after the backup controller has been adapter to the new reconciling behaviour
@@ -53,17 +60,27 @@ func GetClientCertificateCreator(name, commonName string, organizations []string
 					return nil, fmt.Errorf("failed to parse certificate (key=%s) from existing secret: %v", dataCertKey, err)
 				}
 
-				if resources.IsClientCertificateValidForAllOf(certs[0], commonName, organizations, ca.Cert) {
+				if resources.IsClientCertificateValidForAllOf(certs[0], commonName, organizations, ca.Cert) && KeyAlgorithmMatches(certs[0], algorithm) {
 					return se, nil
 				}
 			}
 
-			newKP, err := triple.NewClientKeyPair(ca, commonName, organizations)
+			privateKey, err := NewPrivateKey(algorithm)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create private key: %v", err)
+			}
+
+			newKP, err := triple.NewClientKeyPairWithKey(ca, privateKey, commonName, organizations)
 			if err != nil {
 				return nil, fmt.Errorf("failed to create key pair: %v", err)
 			}
 
-			se.Data[dataKeyKey] = triple.EncodePrivateKeyPEM(newKP.Key)
+			key, err := triple.EncodeSignerPEM(newKP.Key)
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode private key: %v", err)
+			}
+
+			se.Data[dataKeyKey] = key
 			se.Data[dataCertKey] = triple.EncodeCertPEM(newKP.Cert)
 			// Include the CA for simplicity
 			se.Data[resources.CACertSecretKey] = triple.EncodeCertPEM(ca.Cert)
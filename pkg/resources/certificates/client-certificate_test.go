@@ -0,0 +1,117 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificates
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"testing"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources/certificates/triple"
+
+	corev1 "k8s.io/api/core/v1"
+	certutil "k8s.io/client-go/util/cert"
+)
+
+func TestClientCertificateKeyAlgorithm(t *testing.T) {
+	ca, err := triple.NewCA("test-ca")
+	if err != nil {
+		t.Fatalf("failed to create CA: %v", err)
+	}
+	getCA := func() (*triple.KeyPair, error) { return ca, nil }
+
+	// a secret as created before the key algorithm could be configured
+	existingKP, err := triple.NewClientKeyPair(ca, "apiserver-etcd-client", nil)
+	if err != nil {
+		t.Fatalf("failed to create client certificate: %v", err)
+	}
+	existingKey, err := triple.EncodeSignerPEM(existingKP.Key)
+	if err != nil {
+		t.Fatalf("failed to encode private key: %v", err)
+	}
+	existingCert := triple.EncodeCertPEM(existingKP.Cert)
+
+	tests := []struct {
+		name              string
+		algorithm         kubermaticv1.KeyAlgorithm
+		existing          bool
+		expectReissue     bool
+		expectedPublicKey x509.PublicKeyAlgorithm
+	}{
+		{
+			name:              "RSA by default",
+			expectedPublicKey: x509.RSA,
+		},
+		{
+			name:              "ECDSA if configured",
+			algorithm:         kubermaticv1.KeyAlgorithmECDSA,
+			expectedPublicKey: x509.ECDSA,
+		},
+		{
+			name:              "Existing certificate is kept without a configured algorithm",
+			existing:          true,
+			expectedPublicKey: x509.RSA,
+		},
+		{
+			name:              "Existing certificate is kept if the algorithm matches",
+			algorithm:         kubermaticv1.KeyAlgorithmRSA,
+			existing:          true,
+			expectedPublicKey: x509.RSA,
+		},
+		{
+			name:              "Existing certificate is reissued if the algorithm changed",
+			algorithm:         kubermaticv1.KeyAlgorithmECDSA,
+			existing:          true,
+			expectReissue:     true,
+			expectedPublicKey: x509.ECDSA,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			secret := &corev1.Secret{}
+			if test.existing {
+				secret.Data = map[string][]byte{"client.crt": existingCert, "client.key": existingKey}
+			}
+
+			_, create := GetClientCertificateCreatorWithKeyAlgorithm("client", "apiserver-etcd-client", nil, "client.crt", "client.key", getCA, test.algorithm)()
+			secret, err := create(secret)
+			if err != nil {
+				t.Fatalf("failed to create client certificate: %v", err)
+			}
+
+			if test.existing && bytes.Equal(secret.Data["client.crt"], existingCert) == test.expectReissue {
+				t.Errorf("expected certificate to be reissued: %v", test.expectReissue)
+			}
+
+			certs, err := certutil.ParseCertsPEM(secret.Data["client.crt"])
+			if err != nil {
+				t.Fatalf("failed to parse certificate: %v", err)
+			}
+			if certs[0].PublicKeyAlgorithm != test.expectedPublicKey {
+				t.Errorf("expected a %v key, got %v", test.expectedPublicKey, certs[0].PublicKeyAlgorithm)
+			}
+
+			// consumers load the secret as a regular key pair, regardless of the algorithm
+			if _, err := tls.X509KeyPair(secret.Data["client.crt"], secret.Data["client.key"]); err != nil {
+				t.Errorf("certificate and key do not form a valid key pair: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificates
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"fmt"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources/certificates/triple"
+)
+
+// NewPrivateKey creates a private key for a leaf certificate. ECDSA keys use the P-256 curve,
+// RSA keys are created if no algorithm is given.
+func NewPrivateKey(algorithm kubermaticv1.KeyAlgorithm) (crypto.Signer, error) {
+	switch algorithm {
+	case "", kubermaticv1.KeyAlgorithmRSA:
+		return triple.NewPrivateKey()
+	case kubermaticv1.KeyAlgorithmECDSA:
+		return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	default:
+		return nil, fmt.Errorf("unsupported key algorithm %q", algorithm)
+	}
+}
+
+// NewPrivateKeyWithSize creates a private key with the given algorithm and size in bits. Without
+// an algorithm a RSA key is created, without a size the default size of the algorithm is used.
+// Unsupported combinations of algorithm and key size result in an error instead of falling back
+// to the default.
+func NewPrivateKeyWithSize(algorithm kubermaticv1.KeyAlgorithm, size int) (crypto.Signer, error) {
+	if algorithm == "" {
+		algorithm = kubermaticv1.KeyAlgorithmRSA
+	}
+
+	switch algorithm {
+	case kubermaticv1.KeyAlgorithmRSA:
+		switch size {
+		case 0:
+			return rsa.GenerateKey(rand.Reader, kubermaticv1.DefaultRSACAKeySize)
+		case 2048, 3072, 4096:
+			return rsa.GenerateKey(rand.Reader, size)
+		default:
+			return nil, fmt.Errorf("unsupported RSA key size %d, must be one of 2048, 3072 or 4096", size)
+		}
+	case kubermaticv1.KeyAlgorithmECDSA:
+		switch size {
+		case 0, 256:
+			return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		case 384:
+			return ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+		default:
+			return nil, fmt.Errorf("unsupported ECDSA key size %d, must be one of 256 or 384", size)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported key algorithm %q, must be one of %q or %q", algorithm, kubermaticv1.KeyAlgorithmRSA, kubermaticv1.KeyAlgorithmECDSA)
+	}
+}
+
+// KeyAlgorithmMatches returns true if the certificate's public key was created with the given
+// algorithm. If no algorithm is given, every certificate matches so that existing certificates
+// are kept.
+func KeyAlgorithmMatches(cert *x509.Certificate, algorithm kubermaticv1.KeyAlgorithm) bool {
+	switch algorithm {
+	case "":
+		return true
+	case kubermaticv1.KeyAlgorithmRSA:
+		return cert.PublicKeyAlgorithm == x509.RSA
+	case kubermaticv1.KeyAlgorithmECDSA:
+		return cert.PublicKeyAlgorithm == x509.ECDSA
+	default:
+		return false
+	}
+}
@@ -17,6 +17,8 @@ limitations under the License.
 package certificates
 
 import (
+	"crypto"
+	"crypto/x509"
 	"errors"
 	"fmt"
 	"time"
@@ -32,45 +34,213 @@ import (
 
 // GetCACreator returns a function to create a secret containing a CA with the specified name
 func GetCACreator(commonName string) reconciling.SecretCreator {
+	return getCACreator(commonName, nil, nil)
+}
+
+// getCACreator returns a function to create a secret containing a CA. If a signing CA is given, the CA is
+// an intermediate CA issued by it and the chain of the signing CA is stored next to it.
+//
+// A CA is never replaced right away, as clients only trusting it would break. Once a rotation is due, the
+// new CA is only added to the trust bundle of the secret, while the current CA keeps signing certificates.
+// Halfway between the rotation threshold and the expiry of the current CA, the new CA replaces it and all
+// certificates get reissued. The replaced CA stays in the trust bundle until it expires.
+func getCACreator(commonName string, settings *kubermaticv1.RootCASettings, signer *triple.SigningCA) reconciling.SecretCreator {
 	return func(se *corev1.Secret) (*corev1.Secret, error) {
 		if se.Data == nil {
 			se.Data = map[string][]byte{}
 		}
 
-		// if the CA exists, only check if it's expired but never attempt to replace an existing CA
-		if certPEM, exists := se.Data[resources.CACertSecretKey]; exists {
-			certs, err := certutil.ParseCertsPEM(certPEM)
+		certPEM, exists := se.Data[resources.CACertSecretKey]
+		if !exists {
+			caCertPEM, caKeyPEM, err := newCA(commonName, settings, signer)
 			if err != nil {
-				return se, fmt.Errorf("certificate is not valid PEM-encoded: %v", err)
+				return nil, err
 			}
+			se.Data[resources.CACertSecretKey] = caCertPEM
+			se.Data[resources.CAKeySecretKey] = caKeyPEM
+			return se, setCABundleAndChain(se, signer)
+		}
+
+		certs, err := certutil.ParseCertsPEM(certPEM)
+		if err != nil {
+			return se, fmt.Errorf("certificate is not valid PEM-encoded: %v", err)
+		}
+
+		rotationDue, err := RootCARotationDue(certs[0], settings)
+		if err != nil {
+			return se, err
+		}
 
+		switch {
+		case !rotationDue:
 			if time.Now().After(certs[0].NotAfter) {
 				return se, errors.New("certificate has expired")
 			}
-
-			return se, nil
+		case len(se.Data[resources.CANextCertSecretKey]) == 0:
+			nextCertPEM, nextKeyPEM, err := newCA(commonName, settings, signer)
+			if err != nil {
+				return nil, err
+			}
+			se.Data[resources.CANextCertSecretKey] = nextCertPEM
+			se.Data[resources.CANextKeySecretKey] = nextKeyPEM
+		case rootCAReplacementDue(certs[0], settings):
+			se.Data[resources.CAPreviousCertSecretKey] = triple.EncodeCertPEM(certs[0])
+			se.Data[resources.CACertSecretKey] = se.Data[resources.CANextCertSecretKey]
+			se.Data[resources.CAKeySecretKey] = se.Data[resources.CANextKeySecretKey]
+			delete(se.Data, resources.CANextCertSecretKey)
+			delete(se.Data, resources.CANextKeySecretKey)
 		}
 
-		caKp, err := triple.NewCA(commonName)
+		return se, setCABundleAndChain(se, signer)
+	}
+}
+
+// newCA creates a CA and returns its PEM encoded certificate and private key.
+func newCA(commonName string, settings *kubermaticv1.RootCASettings, signer *triple.SigningCA) ([]byte, []byte, error) {
+	key, err := newCAKey(settings)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to create a private key for a new CA: %v", err)
+	}
+
+	validity, err := RootCAValidity(settings)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var caKp *triple.KeyPair
+	if signer == nil {
+		caKp, err = triple.NewCAWithKey(commonName, key, validity)
+	} else {
+		caKp, err = triple.NewIntermediateCAWithKey(commonName, key, validity, signer)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to create a new CA: %v", err)
+	}
+
+	keyPEM, err := triple.EncodeSignerPEM(caKp.Key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to encode the CA private key: %v", err)
+	}
+
+	return triple.EncodeCertPEM(caKp.Cert), keyPEM, nil
+}
+
+func setCABundleAndChain(se *corev1.Secret, signer *triple.SigningCA) error {
+	if err := setCABundle(se); err != nil {
+		return err
+	}
+	return setCAChain(se, signer)
+}
+
+// setCABundle sets the trust bundle of the CA secret to the current CA, followed by the CA which replaces
+// it and the CA it replaced, if any. The replaced CA is dropped from the secret once it expired.
+func setCABundle(se *corev1.Secret) error {
+	var bundle []byte
+	for _, key := range []string{resources.CACertSecretKey, resources.CANextCertSecretKey, resources.CAPreviousCertSecretKey} {
+		certPEM, exists := se.Data[key]
+		if !exists {
+			continue
+		}
+		certs, err := certutil.ParseCertsPEM(certPEM)
 		if err != nil {
-			return nil, fmt.Errorf("unable to create a new CA: %v", err)
+			return fmt.Errorf("certificate %s is not valid PEM-encoded: %v", key, err)
+		}
+		if key == resources.CAPreviousCertSecretKey && time.Now().After(certs[0].NotAfter) {
+			delete(se.Data, key)
+			continue
+		}
+		bundle = append(bundle, triple.EncodeCertPEM(certs[0])...)
+	}
+
+	se.Data[resources.CABundleSecretKey] = bundle
+	return nil
+}
+
+// setCAChain stores the chain of the signing CA if it issued the current CA. The chain of a CA issued
+// before the signing CA was reconfigured is kept, and a self-signed CA has no chain.
+func setCAChain(se *corev1.Secret, signer *triple.SigningCA) error {
+	certs, err := certutil.ParseCertsPEM(se.Data[resources.CACertSecretKey])
+	if err != nil {
+		return fmt.Errorf("certificate is not valid PEM-encoded: %v", err)
+	}
+
+	switch {
+	case certs[0].CheckSignatureFrom(certs[0]) == nil:
+		delete(se.Data, resources.CAChainSecretKey)
+	case signer != nil && certs[0].CheckSignatureFrom(signer.Chain[0]) == nil:
+		var chain []byte
+		for _, cert := range signer.Chain {
+			chain = append(chain, triple.EncodeCertPEM(cert)...)
 		}
+		se.Data[resources.CAChainSecretKey] = chain
+	}
 
-		se.Data[resources.CAKeySecretKey] = triple.EncodePrivateKeyPEM(caKp.Key)
-		se.Data[resources.CACertSecretKey] = triple.EncodeCertPEM(caKp.Cert)
+	return nil
+}
+
+// RootCAValidity returns the validity period for a new root CA, which defaults to 10 years
+func RootCAValidity(settings *kubermaticv1.RootCASettings) (time.Duration, error) {
+	if settings == nil || settings.Expiry == "" {
+		return triple.DefaultCAValidity, nil
+	}
+
+	validity, err := time.ParseDuration(settings.Expiry)
+	if err != nil {
+		return 0, fmt.Errorf("invalid CA expiry %q: %v", settings.Expiry, err)
+	}
+	if validity <= 0 {
+		return 0, fmt.Errorf("CA expiry must be positive, got %q", settings.Expiry)
+	}
+
+	return validity, nil
+}
+
+// RootCARotationDue returns true if a rotation is configured in the settings and the remaining
+// validity of the given CA certificate dropped below the configured threshold. As the new CA is
+// always valid for longer than the threshold, a rotated CA is never rotated again right away.
+// All certificates signed by the CA are reissued once they do not match the new CA anymore, which
+// in turn restarts the control plane components mounting them.
+func RootCARotationDue(caCert *x509.Certificate, settings *kubermaticv1.RootCASettings) (bool, error) {
+	if settings == nil || settings.RotateBefore == "" {
+		return false, nil
+	}
+
+	threshold, err := time.ParseDuration(settings.RotateBefore)
+	if err != nil {
+		return false, fmt.Errorf("invalid CA rotation threshold %q: %v", settings.RotateBefore, err)
+	}
+
+	return time.Until(caCert.NotAfter) < threshold, nil
+}
+
+// rootCAReplacementDue returns true if a rotated CA has to be replaced by the new CA, which is the
+// case once half of the rotation threshold passed. Until then, clients can pick up the new CA from
+// the trust bundle. The threshold was validated by RootCARotationDue already.
+func rootCAReplacementDue(caCert *x509.Certificate, settings *kubermaticv1.RootCASettings) bool {
+	threshold, _ := time.ParseDuration(settings.RotateBefore)
+	return time.Until(caCert.NotAfter) < threshold/2
+}
 
-		return se, nil
+// newCAKey creates the private key for a new CA. Without settings, a 2048 bit RSA key is created.
+func newCAKey(settings *kubermaticv1.RootCASettings) (crypto.Signer, error) {
+	if settings == nil {
+		return NewPrivateKeyWithSize("", 0)
 	}
+	return NewPrivateKeyWithSize(settings.KeyAlgorithm, settings.KeySize)
 }
 
 type caCreatorData interface {
 	Cluster() *kubermaticv1.Cluster
+	RootCASigningCA() *triple.SigningCA
 }
 
-// RootCACreator returns a function to create a secret with the root ca
+// RootCACreator returns a function to create a secret with the root ca. The root CA is
+// rotated if the cluster configures a rotation and the CA is about to expire.
+// If a signing CA is configured, new root CAs are intermediate CAs issued by it, while
+// existing self-signed root CAs are kept until they are rotated.
 func RootCACreator(data caCreatorData) reconciling.NamedSecretCreatorGetter {
 	return func() (string, reconciling.SecretCreator) {
-		return resources.CASecretName, GetCACreator(fmt.Sprintf("root-ca.%s", data.Cluster().Address.ExternalName))
+		return resources.CASecretName, getCACreator(fmt.Sprintf("root-ca.%s", data.Cluster().Address.ExternalName), data.Cluster().Spec.RootCA, data.RootCASigningCA())
 	}
 }
 
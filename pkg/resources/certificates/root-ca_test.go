@@ -0,0 +1,191 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificates
+
+import (
+	"bytes"
+	"crypto/x509"
+	"testing"
+	"time"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/resources/certificates/triple"
+
+	corev1 "k8s.io/api/core/v1"
+	certutil "k8s.io/client-go/util/cert"
+)
+
+func newTestCA(t *testing.T, validity time.Duration) ([]byte, []byte) {
+	key, err := NewPrivateKey(kubermaticv1.KeyAlgorithmECDSA)
+	if err != nil {
+		t.Fatalf("failed to create key: %v", err)
+	}
+	ca, err := triple.NewCAWithKey("root-ca.test", key, validity)
+	if err != nil {
+		t.Fatalf("failed to create CA: %v", err)
+	}
+	keyPEM, err := triple.EncodeSignerPEM(ca.Key)
+	if err != nil {
+		t.Fatalf("failed to encode key: %v", err)
+	}
+	return triple.EncodeCertPEM(ca.Cert), keyPEM
+}
+
+func TestRootCARotation(t *testing.T) {
+	settings := &kubermaticv1.RootCASettings{KeyAlgorithm: kubermaticv1.KeyAlgorithmECDSA, RotateBefore: "24h"}
+
+	validCert, validKey := newTestCA(t, 48*time.Hour)
+	dueCert, dueKey := newTestCA(t, 20*time.Hour)
+	replaceCert, replaceKey := newTestCA(t, 10*time.Hour)
+	nextCert, nextKey := newTestCA(t, 96*time.Hour)
+	expiredCert, _ := newTestCA(t, -time.Hour)
+
+	tests := []struct {
+		name string
+		data map[string][]byte
+		// expectedBundle lists the secret keys whose certificates must make up the bundle, in order
+		expectedBundle []string
+		expectedCert   []byte
+		expectNext     bool
+		expectPrevious []byte
+	}{
+		{
+			name:           "CA is created",
+			expectedBundle: []string{resources.CACertSecretKey},
+		},
+		{
+			name:           "CA is kept while no rotation is due",
+			data:           map[string][]byte{resources.CACertSecretKey: validCert, resources.CAKeySecretKey: validKey},
+			expectedBundle: []string{resources.CACertSecretKey},
+			expectedCert:   validCert,
+		},
+		{
+			name:           "Next CA is trusted before it replaces the current CA",
+			data:           map[string][]byte{resources.CACertSecretKey: dueCert, resources.CAKeySecretKey: dueKey},
+			expectedBundle: []string{resources.CACertSecretKey, resources.CANextCertSecretKey},
+			expectedCert:   dueCert,
+			expectNext:     true,
+		},
+		{
+			name: "Next CA replaces the current CA, which stays trusted",
+			data: map[string][]byte{
+				resources.CACertSecretKey:     replaceCert,
+				resources.CAKeySecretKey:      replaceKey,
+				resources.CANextCertSecretKey: nextCert,
+				resources.CANextKeySecretKey:  nextKey,
+			},
+			expectedBundle: []string{resources.CACertSecretKey, resources.CAPreviousCertSecretKey},
+			expectedCert:   nextCert,
+			expectPrevious: replaceCert,
+		},
+		{
+			name: "Expired previous CA is dropped",
+			data: map[string][]byte{
+				resources.CACertSecretKey:         validCert,
+				resources.CAKeySecretKey:          validKey,
+				resources.CAPreviousCertSecretKey: expiredCert,
+			},
+			expectedBundle: []string{resources.CACertSecretKey},
+			expectedCert:   validCert,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			se, err := getCACreator("root-ca.test", settings, nil)(&corev1.Secret{Data: test.data})
+			if err != nil {
+				t.Fatalf("failed to reconcile CA secret: %v", err)
+			}
+
+			if test.expectedCert != nil && !bytes.Equal(se.Data[resources.CACertSecretKey], test.expectedCert) {
+				t.Error("CA certificate does not match the expected one")
+			}
+			if _, exists := se.Data[resources.CANextCertSecretKey]; exists != test.expectNext {
+				t.Errorf("expected next CA to exist: %v, got %v", test.expectNext, exists)
+			}
+			if !bytes.Equal(se.Data[resources.CAPreviousCertSecretKey], test.expectPrevious) {
+				t.Error("previous CA certificate does not match the expected one")
+			}
+
+			var expectedBundle []byte
+			for _, key := range test.expectedBundle {
+				expectedBundle = append(expectedBundle, se.Data[key]...)
+			}
+			if !bytes.Equal(se.Data[resources.CABundleSecretKey], expectedBundle) {
+				bundle, _ := certutil.ParseCertsPEM(se.Data[resources.CABundleSecretKey])
+				t.Errorf("expected bundle to consist of %v, got %d certificates", test.expectedBundle, len(bundle))
+			}
+		})
+	}
+}
+
+func TestRootCASigningCAChain(t *testing.T) {
+	signingKey, err := NewPrivateKey(kubermaticv1.KeyAlgorithmECDSA)
+	if err != nil {
+		t.Fatalf("failed to create key: %v", err)
+	}
+	signingCA, err := triple.NewCAWithKey("signing-ca.test", signingKey, 48*time.Hour)
+	if err != nil {
+		t.Fatalf("failed to create signing CA: %v", err)
+	}
+	signer := &triple.SigningCA{Key: signingCA.Key, Chain: []*x509.Certificate{signingCA.Cert}}
+
+	se, err := getCACreator("root-ca.test", nil, signer)(&corev1.Secret{})
+	if err != nil {
+		t.Fatalf("failed to reconcile CA secret: %v", err)
+	}
+
+	caCerts, err := certutil.ParseCertsPEM(se.Data[resources.CACertSecretKey])
+	if err != nil {
+		t.Fatalf("failed to parse CA certificate: %v", err)
+	}
+	if len(caCerts) != 1 {
+		t.Fatalf("expected the CA certificate to hold the cluster CA only, got %d certificates", len(caCerts))
+	}
+	if err := caCerts[0].CheckSignatureFrom(signingCA.Cert); err != nil {
+		t.Errorf("expected the cluster CA to be issued by the signing CA: %v", err)
+	}
+
+	// the bundle is passed to the apiserver as --client-ca-file, trusting the signing CA there would
+	// make any client certificate it issues valid for the cluster
+	bundle, err := certutil.ParseCertsPEM(se.Data[resources.CABundleSecretKey])
+	if err != nil {
+		t.Fatalf("failed to parse CA bundle: %v", err)
+	}
+	for _, cert := range bundle {
+		if cert.Equal(signingCA.Cert) {
+			t.Error("expected the CA bundle to not contain the signing CA")
+		}
+	}
+
+	if !bytes.Equal(se.Data[resources.CAChainSecretKey], triple.EncodeCertPEM(signingCA.Cert)) {
+		t.Error("expected the CA chain to hold the signing CA")
+	}
+
+	// once the CA is self-signed again, there is no chain to publish
+	selfSignedCert, selfSignedKey := newTestCA(t, 48*time.Hour)
+	se.Data[resources.CACertSecretKey] = selfSignedCert
+	se.Data[resources.CAKeySecretKey] = selfSignedKey
+	se, err = getCACreator("root-ca.test", nil, nil)(se)
+	if err != nil {
+		t.Fatalf("failed to reconcile CA secret: %v", err)
+	}
+	if _, exists := se.Data[resources.CAChainSecretKey]; exists {
+		t.Error("expected the CA chain of a self-signed CA to be removed")
+	}
+}
@@ -19,7 +19,9 @@ limitations under the License.
 package triple
 
 import (
+	"bytes"
 	"crypto"
+	"crypto/ecdsa"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
@@ -46,8 +48,11 @@ const (
 	CertificateBlockType  = "CERTIFICATE"
 )
 
+// DefaultCAValidity is the validity period of newly created CAs
+const DefaultCAValidity = duration365d * 10
+
 type KeyPair struct {
-	Key  *rsa.PrivateKey
+	Key  crypto.Signer
 	Cert *x509.Certificate
 }
 
@@ -57,11 +62,21 @@ func NewCA(name string) (*KeyPair, error) {
 		return nil, fmt.Errorf("unable to create a private key for a new CA: %v", err)
 	}
 
+	return NewCAWithKey(name, key, DefaultCAValidity)
+}
+
+// NewCAWithKey creates a new self-signed CA using the given private key,
+// which is valid for the given duration
+func NewCAWithKey(name string, key crypto.Signer, validity time.Duration) (*KeyPair, error) {
+	if validity <= 0 {
+		return nil, fmt.Errorf("CA validity must be positive, got %v", validity)
+	}
+
 	config := certutil.Config{
 		CommonName: name,
 	}
 
-	cert, err := certutil.NewSelfSignedCACert(config, key)
+	cert, err := newSelfSignedCACert(config, key, validity)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create a self-signed certificate for a new CA: %v", err)
 	}
@@ -72,6 +87,76 @@ func NewCA(name string) (*KeyPair, error) {
 	}, nil
 }
 
+// SigningCA is an external CA which issues other CAs, e.g. the intermediate CA of an organization
+type SigningCA struct {
+	Key crypto.Signer
+	// Chain starts with the certificate of the signing CA, followed by the
+	// certificates of the CAs which issued it
+	Chain []*x509.Certificate
+}
+
+// ParseSigningCA parses the PEM-encoded certificate chain of a signing CA, starting with the
+// certificate of the signing CA itself, and its RSA or ECDSA private key
+func ParseSigningCA(chainPEM, keyPEM []byte) (*SigningCA, error) {
+	chain, err := certutil.ParseCertsPEM(chainPEM)
+	if err != nil {
+		return nil, fmt.Errorf("certificate is not valid PEM: %v", err)
+	}
+	if !chain[0].IsCA {
+		return nil, errors.New("certificate is not a CA certificate")
+	}
+	if time.Now().After(chain[0].NotAfter) {
+		return nil, errors.New("certificate has expired")
+	}
+
+	key, err := ParsePrivateKeyPEM(keyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("private key is not valid PEM: %v", err)
+	}
+
+	var signer crypto.Signer
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		signer = k
+	case *ecdsa.PrivateKey:
+		signer = k
+	default:
+		return nil, errors.New("private key is neither a RSA nor an ECDSA key")
+	}
+
+	publicKey, err := x509.MarshalPKIXPublicKey(signer.Public())
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal public key: %v", err)
+	}
+	if !bytes.Equal(publicKey, chain[0].RawSubjectPublicKeyInfo) {
+		return nil, errors.New("private key does not match the certificate")
+	}
+
+	return &SigningCA{Key: signer, Chain: chain}, nil
+}
+
+// NewIntermediateCAWithKey creates a new CA using the given private key, which is issued by the
+// signing CA. The CA is valid for the given duration, but never longer than the signing CA.
+func NewIntermediateCAWithKey(name string, key crypto.Signer, validity time.Duration, signer *SigningCA) (*KeyPair, error) {
+	if validity <= 0 {
+		return nil, fmt.Errorf("CA validity must be positive, got %v", validity)
+	}
+
+	config := certutil.Config{
+		CommonName: name,
+	}
+
+	cert, err := newIntermediateCACert(config, key, validity, signer)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create a certificate for a new intermediate CA: %v", err)
+	}
+
+	return &KeyPair{
+		Key:  key,
+		Cert: cert,
+	}, nil
+}
+
 func NewServerKeyPair(ca *KeyPair, commonName, svcName, svcNamespace, dnsDomain string, ips, hostnames []string) (*KeyPair, error) {
 	key, err := newPrivateKey()
 	if err != nil {
@@ -118,6 +203,11 @@ func NewClientKeyPair(ca *KeyPair, commonName string, organizations []string) (*
 		return nil, fmt.Errorf("unable to create a client private key: %v", err)
 	}
 
+	return NewClientKeyPairWithKey(ca, key, commonName, organizations)
+}
+
+// NewClientKeyPairWithKey creates a client certificate for the given private key
+func NewClientKeyPairWithKey(ca *KeyPair, key crypto.Signer, commonName string, organizations []string) (*KeyPair, error) {
 	config := certutil.Config{
 		CommonName:   commonName,
 		Organization: organizations,
@@ -139,6 +229,69 @@ func newPrivateKey() (*rsa.PrivateKey, error) {
 	return rsa.GenerateKey(rand.Reader, rsaKeySize)
 }
 
+// newSelfSignedCACert creates a CA certificate which is valid for the given duration
+func newSelfSignedCACert(cfg certutil.Config, key crypto.Signer, validity time.Duration) (*x509.Certificate, error) {
+	serial, err := rand.Int(rand.Reader, new(big.Int).SetInt64(math.MaxInt64))
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	tmpl := x509.Certificate{
+		SerialNumber: serial,
+		Subject: pkix.Name{
+			CommonName:   cfg.CommonName,
+			Organization: cfg.Organization,
+		},
+		NotBefore:             now.UTC(),
+		NotAfter:              now.Add(validity).UTC(),
+		KeyUsage:              x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	certDERBytes, err := x509.CreateCertificate(rand.Reader, &tmpl, &tmpl, key.Public(), key)
+	if err != nil {
+		return nil, err
+	}
+	return x509.ParseCertificate(certDERBytes)
+}
+
+func newIntermediateCACert(cfg certutil.Config, key crypto.Signer, validity time.Duration, signer *SigningCA) (*x509.Certificate, error) {
+	serial, err := rand.Int(rand.Reader, new(big.Int).SetInt64(math.MaxInt64))
+	if err != nil {
+		return nil, err
+	}
+
+	signerCert := signer.Chain[0]
+	now := time.Now()
+	notAfter := now.Add(validity)
+	if notAfter.After(signerCert.NotAfter) {
+		notAfter = signerCert.NotAfter
+	}
+
+	tmpl := x509.Certificate{
+		SerialNumber: serial,
+		Subject: pkix.Name{
+			CommonName:   cfg.CommonName,
+			Organization: cfg.Organization,
+		},
+		NotBefore:             now.UTC(),
+		NotAfter:              notAfter.UTC(),
+		KeyUsage:              x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		// the CA only issues the certificates of the cluster, but no further CAs
+		MaxPathLenZero: true,
+	}
+
+	certDERBytes, err := x509.CreateCertificate(rand.Reader, &tmpl, signerCert, key.Public(), signer.Key)
+	if err != nil {
+		return nil, err
+	}
+	return x509.ParseCertificate(certDERBytes)
+}
+
 // newSignedCert creates a signed certificate using the given CA certificate and key
 func newSignedCert(cfg certutil.Config, key crypto.Signer, caCert *x509.Certificate, caKey crypto.Signer) (*x509.Certificate, error) {
 	serial, err := rand.Int(rand.Reader, new(big.Int).SetInt64(math.MaxInt64))
@@ -172,6 +325,32 @@ func newSignedCert(cfg certutil.Config, key crypto.Signer, caCert *x509.Certific
 	return x509.ParseCertificate(certDERBytes)
 }
 
+// ParseKeyPair parses a PEM-encoded certificate and its RSA or ECDSA private key
+func ParseKeyPair(certPEM, keyPEM []byte) (*KeyPair, error) {
+	certs, err := certutil.ParseCertsPEM(certPEM)
+	if err != nil {
+		return nil, fmt.Errorf("certificate is not valid PEM: %v", err)
+	}
+
+	if len(certs) != 1 {
+		return nil, fmt.Errorf("did not find exactly one but %v certificates", len(certs))
+	}
+
+	key, err := ParsePrivateKeyPEM(keyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("private key is not valid PEM: %v", err)
+	}
+
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		return &KeyPair{Cert: certs[0], Key: k}, nil
+	case *ecdsa.PrivateKey:
+		return &KeyPair{Cert: certs[0], Key: k}, nil
+	default:
+		return nil, errors.New("private key is neither a RSA nor an ECDSA key")
+	}
+}
+
 func ParseRSAKeyPair(certPEM, keyPEM []byte) (*KeyPair, error) {
 	certs, err := certutil.ParseCertsPEM(certPEM)
 	if err != nil {
@@ -213,6 +392,23 @@ func EncodePrivateKeyPEM(key *rsa.PrivateKey) []byte {
 	return pem.EncodeToMemory(&block)
 }
 
+// EncodeSignerPEM returns PEM-encoded private key data for RSA and ECDSA keys.
+// RSA keys are encoded exactly like EncodePrivateKeyPEM does.
+func EncodeSignerPEM(key crypto.Signer) ([]byte, error) {
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		return EncodePrivateKeyPEM(k), nil
+	case *ecdsa.PrivateKey:
+		der, err := x509.MarshalECPrivateKey(k)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal ECDSA private key: %v", err)
+		}
+		return pem.EncodeToMemory(&pem.Block{Type: ECPrivateKeyBlockType, Bytes: der}), nil
+	default:
+		return nil, fmt.Errorf("unsupported private key type %T", key)
+	}
+}
+
 // ParsePrivateKeyPEM returns a private key parsed from a PEM block in the supplied data.
 // Recognizes PEM blocks for "EC PRIVATE KEY", "RSA PRIVATE KEY", or "PRIVATE KEY"
 func ParsePrivateKeyPEM(keyData []byte) (interface{}, error) {
@@ -0,0 +1,117 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package triple
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"testing"
+	"time"
+)
+
+func TestNewIntermediateCAWithKey(t *testing.T) {
+	root, err := NewCA("corporate-ca")
+	if err != nil {
+		t.Fatalf("failed to create root CA: %v", err)
+	}
+
+	signer, err := ParseSigningCA(EncodeCertPEM(root.Cert), EncodePrivateKeyPEM(root.Key.(*rsa.PrivateKey)))
+	if err != nil {
+		t.Fatalf("failed to parse signing CA: %v", err)
+	}
+
+	key, err := newPrivateKey()
+	if err != nil {
+		t.Fatalf("failed to create key: %v", err)
+	}
+
+	// the intermediate CA must not outlive the signing CA
+	ca, err := NewIntermediateCAWithKey("root-ca.cluster", key, 2*DefaultCAValidity, signer)
+	if err != nil {
+		t.Fatalf("failed to create intermediate CA: %v", err)
+	}
+	if ca.Cert.NotAfter.After(root.Cert.NotAfter) {
+		t.Errorf("intermediate CA is valid until %v, after the signing CA expires at %v", ca.Cert.NotAfter, root.Cert.NotAfter)
+	}
+
+	serverKp, err := NewServerKeyPair(ca, "apiserver", "apiserver", "cluster-test", "cluster.local", nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create server certificate: %v", err)
+	}
+
+	roots := x509.NewCertPool()
+	roots.AddCert(root.Cert)
+	intermediates := x509.NewCertPool()
+	intermediates.AddCert(ca.Cert)
+	if _, err := serverKp.Cert.Verify(x509.VerifyOptions{Roots: roots, Intermediates: intermediates, CurrentTime: time.Now()}); err != nil {
+		t.Errorf("server certificate does not verify against the signing CA: %v", err)
+	}
+}
+
+func TestParseSigningCA(t *testing.T) {
+	ca, err := NewCA("signing-ca")
+	if err != nil {
+		t.Fatalf("failed to create CA: %v", err)
+	}
+	otherCA, err := NewCA("other-ca")
+	if err != nil {
+		t.Fatalf("failed to create CA: %v", err)
+	}
+	server, err := NewServerKeyPair(ca, "server", "server", "default", "cluster.local", nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create server certificate: %v", err)
+	}
+
+	testCases := []struct {
+		name          string
+		certPEM       []byte
+		keyPEM        []byte
+		expectedError string
+	}{
+		{
+			name:    "valid signing CA",
+			certPEM: EncodeCertPEM(ca.Cert),
+			keyPEM:  EncodePrivateKeyPEM(ca.Key.(*rsa.PrivateKey)),
+		},
+		{
+			name:          "key does not match the certificate",
+			certPEM:       EncodeCertPEM(ca.Cert),
+			keyPEM:        EncodePrivateKeyPEM(otherCA.Key.(*rsa.PrivateKey)),
+			expectedError: "private key does not match the certificate",
+		},
+		{
+			name:          "certificate is no CA",
+			certPEM:       EncodeCertPEM(server.Cert),
+			keyPEM:        EncodePrivateKeyPEM(server.Key.(*rsa.PrivateKey)),
+			expectedError: "certificate is not a CA certificate",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseSigningCA(tc.certPEM, tc.keyPEM)
+
+			var actualErr string
+			if err != nil {
+				actualErr = err.Error()
+			}
+			if actualErr != tc.expectedError {
+				t.Errorf("expected error %q, got %q", tc.expectedError, actualErr)
+			}
+		})
+	}
+}
@@ -30,6 +30,7 @@ import (
 	vsphere "github.com/kubermatic/machine-controller/pkg/cloudprovider/provider/vsphere/types"
 	providerconfig "github.com/kubermatic/machine-controller/pkg/providerconfig/types"
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/provider"
 	gcp "k8c.io/kubermatic/v2/pkg/provider/cloud/gcp"
 	"k8c.io/kubermatic/v2/pkg/resources"
 	"k8c.io/kubermatic/v2/pkg/resources/reconciling"
@@ -74,162 +75,176 @@ func ConfigMapCreator(data configMapCreatorData) reconciling.NamedConfigMapCreat
 	}
 }
 
-// CloudConfig returns the cloud-config for the supplied data
+// cloudConfigGenerator creates the cloud-config for a single cloud provider
+type cloudConfigGenerator func(cluster *kubermaticv1.Cluster, dc *kubermaticv1.Datacenter, credentials resources.Credentials) (string, error)
+
+// cloudConfigGenerators contains the cloud-config generators of all cloud providers which
+// need a cloud-config, keyed by the provider name
+var cloudConfigGenerators = map[string]cloudConfigGenerator{
+	provider.AWSCloudProvider:       awsCloudConfig,
+	provider.AzureCloudProvider:     azureCloudConfig,
+	provider.OpenstackCloudProvider: openstackCloudConfig,
+	provider.VSphereCloudProvider:   vsphereCloudConfig,
+	provider.GCPCloudProvider:       gcpCloudConfig,
+}
+
+// CloudConfig returns the cloud-config for the supplied data. Clusters whose cloud provider
+// does not need a cloud-config get an empty one.
 func CloudConfig(
 	cluster *kubermaticv1.Cluster,
 	dc *kubermaticv1.Datacenter,
 	credentials resources.Credentials,
-) (cloudConfig string, err error) {
-	cloud := cluster.Spec.Cloud
-	switch {
-	case cloud.AWS != nil:
-		awsCloudConfig := &aws.CloudConfig{
-			// Dummy AZ, so that K8S can extract the region from it.
-			// https://github.com/kubernetes/kubernetes/blob/v1.15.0/staging/src/k8s.io/legacy-cloud-providers/aws/aws.go#L1199
-			// https://github.com/kubernetes/kubernetes/blob/v1.15.0/staging/src/k8s.io/legacy-cloud-providers/aws/aws.go#L1174
-			Global: aws.GlobalOpts{
-				Zone:                        dc.Spec.AWS.Region + "x",
-				VPC:                         cloud.AWS.VPCID,
-				KubernetesClusterID:         cluster.Name,
-				DisableSecurityGroupIngress: false,
-				RouteTableID:                cloud.AWS.RouteTableID,
-				DisableStrictZoneCheck:      true,
-				RoleARN:                     cloud.AWS.ControlPlaneRoleARN,
-			},
-		}
-		cloudConfig, err = aws.CloudConfigToString(awsCloudConfig)
-		if err != nil {
-			return cloudConfig, err
-		}
+) (string, error) {
+	providerName, err := provider.ClusterCloudProviderName(cluster.Spec.Cloud)
+	if err != nil {
+		return "", err
+	}
 
-	case cloud.Azure != nil:
-		azureCloudConfig := &azure.CloudConfig{
-			Cloud:                      "AZUREPUBLICCLOUD",
-			TenantID:                   credentials.Azure.TenantID,
-			SubscriptionID:             credentials.Azure.SubscriptionID,
-			AADClientID:                credentials.Azure.ClientID,
-			AADClientSecret:            credentials.Azure.ClientSecret,
-			ResourceGroup:              cloud.Azure.ResourceGroup,
-			Location:                   dc.Spec.Azure.Location,
-			VNetName:                   cloud.Azure.VNetName,
-			SubnetName:                 cloud.Azure.SubnetName,
-			RouteTableName:             cloud.Azure.RouteTableName,
-			SecurityGroupName:          cloud.Azure.SecurityGroup,
-			PrimaryAvailabilitySetName: cloud.Azure.AvailabilitySet,
-			VnetResourceGroup:          cloud.Azure.ResourceGroup,
-			UseInstanceMetadata:        false,
-		}
-		cloudConfig, err = azure.CloudConfigToString(azureCloudConfig)
-		if err != nil {
-			return cloudConfig, err
-		}
+	generator, ok := cloudConfigGenerators[providerName]
+	if !ok {
+		return "", nil
+	}
 
-	case cloud.Openstack != nil:
-		manageSecurityGroups := dc.Spec.Openstack.ManageSecurityGroups
-		trustDevicePath := dc.Spec.Openstack.TrustDevicePath
-		useOctavia := dc.Spec.Openstack.UseOctavia
-		if cluster.Spec.Cloud.Openstack.UseOctavia != nil {
-			useOctavia = cluster.Spec.Cloud.Openstack.UseOctavia
-		}
-		openstackCloudConfig := &openstack.CloudConfig{
-			Global: openstack.GlobalOpts{
-				AuthURL:    dc.Spec.Openstack.AuthURL,
-				Username:   credentials.Openstack.Username,
-				Password:   credentials.Openstack.Password,
-				DomainName: credentials.Openstack.Domain,
-				TenantName: credentials.Openstack.Tenant,
-				TenantID:   credentials.Openstack.TenantID,
-				Region:     dc.Spec.Openstack.Region,
-			},
-			BlockStorage: openstack.BlockStorageOpts{
-				BSVersion:       "auto",
-				TrustDevicePath: trustDevicePath != nil && *trustDevicePath,
-				IgnoreVolumeAZ:  dc.Spec.Openstack.IgnoreVolumeAZ,
-			},
-			LoadBalancer: openstack.LoadBalancerOpts{
-				ManageSecurityGroups: manageSecurityGroups == nil || *manageSecurityGroups,
-				UseOctavia:           useOctavia,
-			},
-			Version: cluster.Spec.Version.String(),
-		}
-		cloudConfig, err = openstack.CloudConfigToString(openstackCloudConfig)
-		if err != nil {
-			return cloudConfig, err
-		}
+	return generator(cluster, dc, credentials)
+}
 
-	case cloud.VSphere != nil:
-		vsphereCloudConfig, err := getVsphereCloudConfig(cluster, dc, credentials)
-		if err != nil {
-			return cloudConfig, err
-		}
-		cloudConfig, err = vsphere.CloudConfigToString(vsphereCloudConfig)
-		if err != nil {
-			return cloudConfig, err
-		}
+func awsCloudConfig(cluster *kubermaticv1.Cluster, dc *kubermaticv1.Datacenter, credentials resources.Credentials) (string, error) {
+	cloud := cluster.Spec.Cloud
+	config := &aws.CloudConfig{
+		// Dummy AZ, so that K8S can extract the region from it.
+		// https://github.com/kubernetes/kubernetes/blob/v1.15.0/staging/src/k8s.io/legacy-cloud-providers/aws/aws.go#L1199
+		// https://github.com/kubernetes/kubernetes/blob/v1.15.0/staging/src/k8s.io/legacy-cloud-providers/aws/aws.go#L1174
+		Global: aws.GlobalOpts{
+			Zone:                        dc.Spec.AWS.Region + "x",
+			VPC:                         cloud.AWS.VPCID,
+			KubernetesClusterID:         cluster.Name,
+			DisableSecurityGroupIngress: false,
+			RouteTableID:                cloud.AWS.RouteTableID,
+			DisableStrictZoneCheck:      true,
+			RoleARN:                     cloud.AWS.ControlPlaneRoleARN,
+		},
+	}
+	return aws.CloudConfigToString(config)
+}
 
-	case cloud.GCP != nil:
-		b, err := base64.StdEncoding.DecodeString(credentials.GCP.ServiceAccount)
-		if err != nil {
-			return "", fmt.Errorf("error decoding service account: %v", err)
-		}
-		sam := map[string]string{}
-		err = json.Unmarshal(b, &sam)
-		if err != nil {
-			return "", fmt.Errorf("failed unmarshaling service account: %v", err)
-		}
-		projectID := sam["project_id"]
-		if projectID == "" {
-			return "", errors.New("empty project_id")
-		}
+func azureCloudConfig(cluster *kubermaticv1.Cluster, dc *kubermaticv1.Datacenter, credentials resources.Credentials) (string, error) {
+	cloud := cluster.Spec.Cloud
+	config := &azure.CloudConfig{
+		Cloud:                      "AZUREPUBLICCLOUD",
+		TenantID:                   credentials.Azure.TenantID,
+		SubscriptionID:             credentials.Azure.SubscriptionID,
+		AADClientID:                credentials.Azure.ClientID,
+		AADClientSecret:            credentials.Azure.ClientSecret,
+		ResourceGroup:              cloud.Azure.ResourceGroup,
+		Location:                   dc.Spec.Azure.Location,
+		VNetName:                   cloud.Azure.VNetName,
+		SubnetName:                 cloud.Azure.SubnetName,
+		RouteTableName:             cloud.Azure.RouteTableName,
+		SecurityGroupName:          cloud.Azure.SecurityGroup,
+		PrimaryAvailabilitySetName: cloud.Azure.AvailabilitySet,
+		VnetResourceGroup:          cloud.Azure.ResourceGroup,
+		UseInstanceMetadata:        false,
+	}
+	return azure.CloudConfigToString(config)
+}
+
+func openstackCloudConfig(cluster *kubermaticv1.Cluster, dc *kubermaticv1.Datacenter, credentials resources.Credentials) (string, error) {
+	manageSecurityGroups := dc.Spec.Openstack.ManageSecurityGroups
+	trustDevicePath := dc.Spec.Openstack.TrustDevicePath
+	useOctavia := dc.Spec.Openstack.UseOctavia
+	if cluster.Spec.Cloud.Openstack.UseOctavia != nil {
+		useOctavia = cluster.Spec.Cloud.Openstack.UseOctavia
+	}
+	config := &openstack.CloudConfig{
+		Global: openstack.GlobalOpts{
+			AuthURL:    dc.Spec.Openstack.AuthURL,
+			Username:   credentials.Openstack.Username,
+			Password:   credentials.Openstack.Password,
+			DomainName: credentials.Openstack.Domain,
+			TenantName: credentials.Openstack.Tenant,
+			TenantID:   credentials.Openstack.TenantID,
+			Region:     dc.Spec.Openstack.Region,
+		},
+		BlockStorage: openstack.BlockStorageOpts{
+			BSVersion:       "auto",
+			TrustDevicePath: trustDevicePath != nil && *trustDevicePath,
+			IgnoreVolumeAZ:  dc.Spec.Openstack.IgnoreVolumeAZ,
+		},
+		LoadBalancer: openstack.LoadBalancerOpts{
+			ManageSecurityGroups: manageSecurityGroups == nil || *manageSecurityGroups,
+			UseOctavia:           useOctavia,
+		},
+		Version: cluster.Spec.Version.String(),
+	}
+	return openstack.CloudConfigToString(config)
+}
+
+func vsphereCloudConfig(cluster *kubermaticv1.Cluster, dc *kubermaticv1.Datacenter, credentials resources.Credentials) (string, error) {
+	config, err := getVsphereCloudConfig(cluster, dc, credentials)
+	if err != nil {
+		return "", err
+	}
+	return vsphere.CloudConfigToString(config)
+}
 
-		tag := fmt.Sprintf("kubernetes-cluster-%s", cluster.Name)
-		localZone := dc.Spec.GCP.Region + "-" + dc.Spec.GCP.ZoneSuffixes[0]
+func gcpCloudConfig(cluster *kubermaticv1.Cluster, dc *kubermaticv1.Datacenter, credentials resources.Credentials) (string, error) {
+	cloud := cluster.Spec.Cloud
+	b, err := base64.StdEncoding.DecodeString(credentials.GCP.ServiceAccount)
+	if err != nil {
+		return "", fmt.Errorf("error decoding service account: %v", err)
+	}
+	sam := map[string]string{}
+	err = json.Unmarshal(b, &sam)
+	if err != nil {
+		return "", fmt.Errorf("failed unmarshaling service account: %v", err)
+	}
+	projectID := sam["project_id"]
+	if projectID == "" {
+		return "", errors.New("empty project_id")
+	}
 
-		// By default, all GCP clusters are assumed to be the in the same zone. If the control plane
-		// and worker nodes are not it the same zone (localZone), the GCP cloud controller fails
-		// to find nodes that are not in the localZone: https://github.com/kubermatic/kubermatic/issues/5025
-		// to avoid this, we should enable multizone or regional configuration.
-		// It's not easily possible to access the MachineDeployment object from here to compare
-		// localZone with the user cluster zone. Additionally, ZoneSuffixes are not used
-		// to limit available zones for the user. So, we will just enable multizone support as a workaround.
+	tag := fmt.Sprintf("kubernetes-cluster-%s", cluster.Name)
+	localZone := dc.Spec.GCP.Region + "-" + dc.Spec.GCP.ZoneSuffixes[0]
 
-		// FIXME: Compare localZone to MachineDeployment.Zone and set multizone to true
-		// when they differ, or if len(dc.Spec.GCP.ZoneSuffixes) > 1
-		multizone := true
+	// By default, all GCP clusters are assumed to be the in the same zone. If the control plane
+	// and worker nodes are not it the same zone (localZone), the GCP cloud controller fails
+	// to find nodes that are not in the localZone: https://github.com/kubermatic/kubermatic/issues/5025
+	// to avoid this, we should enable multizone or regional configuration.
+	// It's not easily possible to access the MachineDeployment object from here to compare
+	// localZone with the user cluster zone. Additionally, ZoneSuffixes are not used
+	// to limit available zones for the user. So, we will just enable multizone support as a workaround.
 
-		if cloud.GCP.Network == "" || cloud.GCP.Network == gcp.DefaultNetwork {
-			// NetworkName is used by the gce cloud provider to populate the provider's NetworkURL.
-			// This value can be provided in the config as a name or a url. Internally,
-			// the gce cloud provider checks it and if it's a name, it will infer the URL from it.
-			// However, if the name has a '/', the provider assumes it's a URL and uses it as is.
-			// This breaks routes cleanup since the routes are matched against the URL,
-			// which would be incorrect in this case.
-			// On the provider side, the "global/networks/default" format is the valid
-			// one since it's used internally for firewall rules and and network interfaces,
-			// so it has to be kept this way.
-			// tl;dr: use "default" or a full network URL, not "global/networks/default"
-			cloud.GCP.Network = "default"
-		}
+	// FIXME: Compare localZone to MachineDeployment.Zone and set multizone to true
+	// when they differ, or if len(dc.Spec.GCP.ZoneSuffixes) > 1
+	multizone := true
 
-		gcpCloudConfig := &gce.CloudConfig{
-			Global: gce.GlobalOpts{
-				ProjectID:      projectID,
-				LocalZone:      localZone,
-				MultiZone:      multizone,
-				Regional:       dc.Spec.GCP.Regional,
-				NetworkName:    cloud.GCP.Network,
-				SubnetworkName: cloud.GCP.Subnetwork,
-				TokenURL:       "nil",
-				NodeTags:       []string{tag},
-			},
-		}
-		cloudConfig, err = gcpCloudConfig.AsString()
-		if err != nil {
-			return cloudConfig, err
-		}
+	if cloud.GCP.Network == "" || cloud.GCP.Network == gcp.DefaultNetwork {
+		// NetworkName is used by the gce cloud provider to populate the provider's NetworkURL.
+		// This value can be provided in the config as a name or a url. Internally,
+		// the gce cloud provider checks it and if it's a name, it will infer the URL from it.
+		// However, if the name has a '/', the provider assumes it's a URL and uses it as is.
+		// This breaks routes cleanup since the routes are matched against the URL,
+		// which would be incorrect in this case.
+		// On the provider side, the "global/networks/default" format is the valid
+		// one since it's used internally for firewall rules and and network interfaces,
+		// so it has to be kept this way.
+		// tl;dr: use "default" or a full network URL, not "global/networks/default"
+		cloud.GCP.Network = "default"
 	}
 
-	return cloudConfig, err
+	config := &gce.CloudConfig{
+		Global: gce.GlobalOpts{
+			ProjectID:      projectID,
+			LocalZone:      localZone,
+			MultiZone:      multizone,
+			Regional:       dc.Spec.GCP.Regional,
+			NetworkName:    cloud.GCP.Network,
+			SubnetworkName: cloud.GCP.Subnetwork,
+			TokenURL:       "nil",
+			NodeTags:       []string{tag},
+		},
+	}
+	return config.AsString()
 }
 
 func getVsphereCloudConfig(
@@ -287,6 +287,35 @@ func TestOpenStackCloudConfig(t *testing.T) {
 	}
 }
 
+func TestCloudConfigWithoutGenerator(t *testing.T) {
+	testCases := []struct {
+		name  string
+		cloud kubermaticv1.CloudSpec
+	}{
+		{
+			name:  "bring your own",
+			cloud: kubermaticv1.CloudSpec{BringYourOwn: &kubermaticv1.BringYourOwnCloudSpec{}},
+		},
+		{
+			name:  "hetzner",
+			cloud: kubermaticv1.CloudSpec{Hetzner: &kubermaticv1.HetznerCloudSpec{}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cluster := &kubermaticv1.Cluster{Spec: kubermaticv1.ClusterSpec{Cloud: tc.cloud}}
+			cloudConfig, err := CloudConfig(cluster, &kubermaticv1.Datacenter{}, resources.Credentials{})
+			if err != nil {
+				t.Fatalf("failed to create cloud config: %v", err)
+			}
+			if cloudConfig != "" {
+				t.Errorf("expected an empty cloud config, got %q", cloudConfig)
+			}
+		})
+	}
+}
+
 func unmarshalINICloudConfig(t *testing.T, config interface{}, rawConfig string) {
 	if err := gcfg.ReadStringInto(config, rawConfig); err != nil {
 		t.Fatalf("error occurred while marshaling config: %v", err)
@@ -212,10 +212,10 @@ func getFlags(data *resources.TemplateData) ([]string, error) {
 	flags := []string{
 		"--kubeconfig", "/etc/kubernetes/kubeconfig/kubeconfig",
 		"--service-account-private-key-file", "/etc/kubernetes/service-account-key/sa.key",
-		"--root-ca-file", "/etc/kubernetes/pki/ca/ca.crt",
+		"--root-ca-file", "/etc/kubernetes/pki/ca/" + resources.CABundleSecretKey,
 		"--cluster-signing-cert-file", "/etc/kubernetes/pki/ca/ca.crt",
 		"--cluster-signing-key-file", "/etc/kubernetes/pki/ca/ca.key",
-		"--cluster-cidr", data.Cluster().Spec.ClusterNetwork.Pods.CIDRBlocks[0],
+		"--cluster-cidr", resources.NetworkRangesFlag(data.Cluster(), data.Cluster().Spec.ClusterNetwork.Pods),
 		"--allocate-node-cidrs",
 		"--controllers", strings.Join(controllers, ","),
 		"--use-service-account-credentials",
@@ -224,16 +224,14 @@ func getFlags(data *resources.TemplateData) ([]string, error) {
 	featureGates := []string{"RotateKubeletClientCertificate=true",
 		"RotateKubeletServerCertificate=true"}
 	featureGates = append(featureGates, data.GetCSIMigrationFeatureGates()...)
+	featureGates = append(featureGates, resources.DualStackFeatureGates(data.Cluster())...)
 
 	flags = append(flags, "--feature-gates")
 	flags = append(flags, strings.Join(featureGates, ","))
 
-	cloudProviderName := resources.GetKubernetesCloudProviderName(data.Cluster(),
-		resources.ExternalCloudProviderEnabled(data.Cluster()))
-	if cloudProviderName != "" && cloudProviderName != "external" {
-		flags = append(flags, "--cloud-provider", cloudProviderName)
-		flags = append(flags, "--cloud-config", "/etc/kubernetes/cloud/config")
-		if cloudProviderName == "azure" && data.Cluster().Spec.Version.Semver().Minor() >= 15 {
+	if cloudProviderFlags := resources.CloudProviderFlags(data.Cluster()); len(cloudProviderFlags) > 0 {
+		flags = append(flags, cloudProviderFlags...)
+		if data.Cluster().Spec.Cloud.Azure != nil && data.Cluster().Spec.Version.Semver().Minor() >= 15 {
 			// Required so multiple clusters using the same resource group can allocate public IPs.
 			// Ref: https://github.com/kubernetes/kubernetes/pull/77630
 			flags = append(flags, "--cluster-name", data.Cluster().Name)
@@ -247,7 +245,7 @@ func getFlags(data *resources.TemplateData) ([]string, error) {
 	// New flag in v1.12 which gets used to perform permission checks for tokens
 	flags = append(flags, "--authentication-kubeconfig", "/etc/kubernetes/kubeconfig/kubeconfig")
 	// New flag in v1.12 which gets used to perform permission checks for certs
-	flags = append(flags, "--client-ca-file", "/etc/kubernetes/pki/ca/ca.crt")
+	flags = append(flags, "--client-ca-file", "/etc/kubernetes/pki/ca/"+resources.CABundleSecretKey)
 
 	// With 1.13 we're using the secure port for scraping metrics as the insecure port got marked deprecated
 	flags = append(flags, "--authentication-kubeconfig", "/etc/kubernetes/kubeconfig/kubeconfig")
@@ -266,7 +264,7 @@ func getFlags(data *resources.TemplateData) ([]string, error) {
 		flags = append(flags, "--leader-elect-retry-period", fmt.Sprintf("%ds", *rps))
 	}
 
-	return flags, nil
+	return resources.AppendExtraArgs(flags, data.Cluster().Spec.ComponentsOverride.ControllerManager.ExtraArgs), nil
 }
 
 func getVolumeMounts() []corev1.VolumeMount {
@@ -288,7 +286,7 @@ func getVolumeMounts() []corev1.VolumeMount {
 		},
 		{
 			Name:      resources.CloudConfigConfigMapName,
-			MountPath: "/etc/kubernetes/cloud",
+			MountPath: resources.CloudConfigMountPath,
 			ReadOnly:  true,
 		},
 		{
@@ -25,6 +25,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/docker/distribution/reference"
 	providerconfig "github.com/kubermatic/machine-controller/pkg/providerconfig/types"
 	httpproberapi "k8c.io/kubermatic/v2/cmd/http-prober/api"
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
@@ -73,6 +74,7 @@ type TemplateData struct {
 	backupSchedule           time.Duration
 	versions                 kubermatic.Versions
 	caBundle                 CABundle
+	rootCASigningCA          *triple.SigningCA
 
 	supportsFailureDomainZoneAntiAffinity bool
 
@@ -166,6 +168,11 @@ func (td *TemplateDataBuilder) WithCABundle(bundle CABundle) *TemplateDataBuilde
 	return td
 }
 
+func (td *TemplateDataBuilder) WithRootCASigningCA(signer *triple.SigningCA) *TemplateDataBuilder {
+	td.data.rootCASigningCA = signer
+	return td
+}
+
 func (td *TemplateDataBuilder) WithOIDCIssuerURL(url string) *TemplateDataBuilder {
 	td.data.oidcIssuerURL = url
 	return td
@@ -288,6 +295,12 @@ func (d *TemplateData) CABundle() CABundle {
 	return d.caBundle
 }
 
+// RootCASigningCA returns the CA which issues the root CAs of clusters,
+// nil if the root CAs are self-signed
+func (d *TemplateData) RootCASigningCA() *triple.SigningCA {
+	return d.rootCASigningCA
+}
+
 // OIDCIssuerURL returns URL of the OpenID token issuer
 func (d *TemplateData) OIDCIssuerURL() string {
 	return d.oidcIssuerURL
@@ -369,7 +382,8 @@ func (d *TemplateData) NodeAccessNetwork() string {
 	return d.nodeAccessNetwork
 }
 
-// NodePortRange returns the node access network
+// NodePortRange returns the NodePort range of the user cluster. The NodePort range of the
+// datacenter only applies to the apiserver services in the seed.
 func (d *TemplateData) NodePortRange() string {
 	return d.nodePortRange
 }
@@ -418,11 +432,47 @@ func (d *TemplateData) ImageRegistry(defaultRegistry string) string {
 	return defaultRegistry
 }
 
+// RewriteImage replaces the registry of the given image reference with the overwrite registry, if
+// one is configured. The repository path, tag and digest are kept, so digest-pinned references still
+// refer to the same image in the mirror.
+func (d *TemplateData) RewriteImage(image string) (string, error) {
+	return rewriteImage(image, d.OverwriteRegistry)
+}
+
+func rewriteImage(image, overwriteRegistry string) (string, error) {
+	named, err := reference.ParseNormalizedNamed(image)
+	if err != nil {
+		return "", fmt.Errorf("invalid image reference %q: %v", image, err)
+	}
+	if overwriteRegistry == "" {
+		return image, nil
+	}
+
+	rewritten := overwriteRegistry + "/" + reference.Path(named)
+	if tagged, ok := named.(reference.Tagged); ok {
+		rewritten += ":" + tagged.Tag()
+	}
+	if digested, ok := named.(reference.Digested); ok {
+		rewritten += "@" + digested.Digest().String()
+	}
+
+	if _, err := reference.ParseNormalizedNamed(rewritten); err != nil {
+		return "", fmt.Errorf("overwrite registry %q results in an invalid reference for image %q: %v", overwriteRegistry, image, err)
+	}
+
+	return rewritten, nil
+}
+
 // GetRootCA returns the root CA of the cluster
 func (d *TemplateData) GetRootCA() (*triple.KeyPair, error) {
 	return GetClusterRootCA(d.ctx, d.cluster.Status.NamespaceName, d.client)
 }
 
+// GetRootCABundle returns the PEM encoded root CAs trusted by the clients of the cluster
+func (d *TemplateData) GetRootCABundle() ([]byte, error) {
+	return GetClusterRootCABundle(d.ctx, d.cluster.Status.NamespaceName, d.client)
+}
+
 // GetFrontProxyCA returns the root CA for the front proxy
 func (d *TemplateData) GetFrontProxyCA() (*triple.KeyPair, error) {
 	return GetClusterFrontProxyCA(d.ctx, d.cluster.Status.NamespaceName, d.client)
@@ -619,6 +669,27 @@ func ExternalCloudProviderEnabled(cluster *kubermaticv1.Cluster) bool {
 			!metav1.HasAnnotation(cluster.ObjectMeta, kubermaticv1.CSIMigrationNeededAnnotation))
 }
 
+// CloudProviderFlags returns the --cloud-provider and --cloud-config flags for the control plane
+// components running the in-tree cloud provider of the cluster. No flags are returned if the
+// cluster has no in-tree cloud provider or uses an external cloud controller manager.
+func CloudProviderFlags(cluster *kubermaticv1.Cluster) []string {
+	cloudProviderName := GetKubernetesCloudProviderName(cluster, ExternalCloudProviderEnabled(cluster))
+	if cloudProviderName == "" || cloudProviderName == cloudProviderExternalFlag {
+		return nil
+	}
+	return []string{
+		"--cloud-provider", cloudProviderName,
+		"--cloud-config", CloudConfigMountPath + "/" + CloudConfigConfigMapKey,
+	}
+}
+
+// NodeExternalCloudProviderEnabled returns true if the kubelets of the cluster must be configured
+// for an external cloud controller manager. Unlike the control plane, nodes switch as soon as the
+// feature is enabled, as the in-tree provider is only kept in the control plane during migration.
+func NodeExternalCloudProviderEnabled(cluster *kubermaticv1.Cluster) bool {
+	return GetKubernetesCloudProviderName(cluster, cluster.Spec.Features[kubermaticv1.ClusterFeatureExternalCloudProvider]) == cloudProviderExternalFlag
+}
+
 func GetCSIMigrationFeatureGates(cluster *kubermaticv1.Cluster) []string {
 	var featureFlags []string
 	if metav1.HasAnnotation(cluster.ObjectMeta, kubermaticv1.CSIMigrationNeededAnnotation) {
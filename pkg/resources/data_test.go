@@ -23,6 +23,7 @@ import (
 
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -109,3 +110,190 @@ func TestGetCSIMigrationFeatureGates(t *testing.T) {
 		})
 	}
 }
+
+func TestNodePortRange(t *testing.T) {
+	const globalRange = "30000-32767"
+
+	testCases := []struct {
+		name       string
+		datacenter *kubermaticv1.Datacenter
+		wantRange  string
+	}{
+		{
+			name:       "No datacenter",
+			datacenter: nil,
+			wantRange:  globalRange,
+		},
+		{
+			name:       "Datacenter without NodePort range",
+			datacenter: &kubermaticv1.Datacenter{},
+			wantRange:  globalRange,
+		},
+		{
+			name: "Datacenter NodePort range only applies to the seed",
+			datacenter: &kubermaticv1.Datacenter{
+				Spec: kubermaticv1.DatacenterSpec{
+					NodePortRange: "31000-31999",
+				},
+			},
+			wantRange: globalRange,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			td := NewTemplateDataBuilder().
+				WithCluster(&kubermaticv1.Cluster{}).
+				WithDatacenter(tc.datacenter).
+				WithNodePortRange(globalRange).
+				Build()
+			if got := td.NodePortRange(); got != tc.wantRange {
+				t.Errorf("Want NodePort range %q, but got %q", tc.wantRange, got)
+			}
+		})
+	}
+}
+
+func TestCloudProviderFlags(t *testing.T) {
+	testCases := []struct {
+		name             string
+		cluster          *kubermaticv1.Cluster
+		wantFlags        []string
+		wantNodeExternal bool
+	}{
+		{
+			name: "Provider without in-tree cloud provider",
+			cluster: &kubermaticv1.Cluster{
+				Spec: kubermaticv1.ClusterSpec{
+					Cloud: kubermaticv1.CloudSpec{Digitalocean: &kubermaticv1.DigitaloceanCloudSpec{}},
+				},
+			},
+		},
+		{
+			name: "AWS",
+			cluster: &kubermaticv1.Cluster{
+				Spec: kubermaticv1.ClusterSpec{
+					Cloud: kubermaticv1.CloudSpec{AWS: &kubermaticv1.AWSCloudSpec{}},
+				},
+			},
+			wantFlags: []string{"--cloud-provider", "aws", "--cloud-config", "/etc/kubernetes/cloud/config"},
+		},
+		{
+			name: "GCP",
+			cluster: &kubermaticv1.Cluster{
+				Spec: kubermaticv1.ClusterSpec{
+					Cloud: kubermaticv1.CloudSpec{GCP: &kubermaticv1.GCPCloudSpec{}},
+				},
+			},
+			wantFlags: []string{"--cloud-provider", "gce", "--cloud-config", "/etc/kubernetes/cloud/config"},
+		},
+		{
+			name: "OpenStack with external cloud provider",
+			cluster: &kubermaticv1.Cluster{
+				Spec: kubermaticv1.ClusterSpec{
+					Cloud:    kubermaticv1.CloudSpec{Openstack: &kubermaticv1.OpenstackCloudSpec{}},
+					Features: map[string]bool{kubermaticv1.ClusterFeatureExternalCloudProvider: true},
+				},
+			},
+			wantNodeExternal: true,
+		},
+		{
+			name: "OpenStack during CSI migration",
+			cluster: &kubermaticv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{kubermaticv1.CSIMigrationNeededAnnotation: ""},
+				},
+				Spec: kubermaticv1.ClusterSpec{
+					Cloud:    kubermaticv1.CloudSpec{Openstack: &kubermaticv1.OpenstackCloudSpec{}},
+					Features: map[string]bool{kubermaticv1.ClusterFeatureExternalCloudProvider: true},
+				},
+			},
+			wantFlags:        []string{"--cloud-provider", "openstack", "--cloud-config", "/etc/kubernetes/cloud/config"},
+			wantNodeExternal: true,
+		},
+		{
+			name: "AWS with external cloud provider feature",
+			cluster: &kubermaticv1.Cluster{
+				Spec: kubermaticv1.ClusterSpec{
+					Cloud:    kubermaticv1.CloudSpec{AWS: &kubermaticv1.AWSCloudSpec{}},
+					Features: map[string]bool{kubermaticv1.ClusterFeatureExternalCloudProvider: true},
+				},
+			},
+			wantFlags: []string{"--cloud-provider", "aws", "--cloud-config", "/etc/kubernetes/cloud/config"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := CloudProviderFlags(tc.cluster); !equality.Semantic.DeepEqual(got, tc.wantFlags) {
+				t.Errorf("Want flags %v, but got %v", tc.wantFlags, got)
+			}
+			if got := NodeExternalCloudProviderEnabled(tc.cluster); got != tc.wantNodeExternal {
+				t.Errorf("Want node external cloud provider %t, but got %t", tc.wantNodeExternal, got)
+			}
+		})
+	}
+}
+
+func TestRewriteImage(t *testing.T) {
+	testCases := []struct {
+		name              string
+		image             string
+		overwriteRegistry string
+		expected          string
+		expectErr         bool
+	}{
+		{
+			name:     "no overwrite registry",
+			image:    "docker.io/fluent/fluent-bit:1.2.2",
+			expected: "docker.io/fluent/fluent-bit:1.2.2",
+		},
+		{
+			name:              "tagged image",
+			image:             "docker.io/rancher/rancher:v2.3.2",
+			overwriteRegistry: "registry.example.com",
+			expected:          "registry.example.com/rancher/rancher:v2.3.2",
+		},
+		{
+			name:              "image without registry",
+			image:             "nginx:1.19",
+			overwriteRegistry: "registry.example.com:5000",
+			expected:          "registry.example.com:5000/library/nginx:1.19",
+		},
+		{
+			name:              "digest-pinned image",
+			image:             "quay.io/kubermatic/etcd-launcher@sha256:8be990ef2aeb16dbcb9271ddfe2610fa6658d13f6dfb8bc72074cc1ca36966a7",
+			overwriteRegistry: "registry.example.com",
+			expected:          "registry.example.com/kubermatic/etcd-launcher@sha256:8be990ef2aeb16dbcb9271ddfe2610fa6658d13f6dfb8bc72074cc1ca36966a7",
+		},
+		{
+			name:              "tagged and digest-pinned image",
+			image:             "quay.io/kubermatic/etcd-launcher:v2.16.0@sha256:8be990ef2aeb16dbcb9271ddfe2610fa6658d13f6dfb8bc72074cc1ca36966a7",
+			overwriteRegistry: "registry.example.com",
+			expected:          "registry.example.com/kubermatic/etcd-launcher:v2.16.0@sha256:8be990ef2aeb16dbcb9271ddfe2610fa6658d13f6dfb8bc72074cc1ca36966a7",
+		},
+		{
+			name:      "invalid image",
+			image:     "Invalid Image",
+			expectErr: true,
+		},
+		{
+			name:              "invalid overwrite registry",
+			image:             "docker.io/rancher/rancher:v2.3.2",
+			overwriteRegistry: "https://registry.example.com",
+			expectErr:         true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			image, err := rewriteImage(tc.image, tc.overwriteRegistry)
+			if (err != nil) != tc.expectErr {
+				t.Fatalf("expected error: %v, got: %v", tc.expectErr, err)
+			}
+			if image != tc.expected {
+				t.Errorf("expected image %q, got %q", tc.expected, image)
+			}
+		})
+	}
+}
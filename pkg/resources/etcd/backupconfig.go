@@ -42,7 +42,7 @@ func BackupConfigCreator(data etcdBackupConfigCreatorData) reconciling.NamedEtcd
 			}
 			config.Spec.Name = resources.EtcdDefaultBackupConfigName
 			config.Spec.Schedule = backupScheduleString
-			keep := 20
+			keep := kubermaticv1.DefaultKeptBackupsCount
 			config.Spec.Keep = &keep
 			config.Spec.Cluster = corev1.ObjectReference{
 				Kind:       kubermaticv1.ClusterKindName,
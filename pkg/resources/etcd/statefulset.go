@@ -44,6 +44,9 @@ const (
 	// ImageTag defines the image tag to use for the etcd image
 	etcdImageTagV33 = "v3.3.18"
 	etcdImageTagV34 = "v3.4.3"
+
+	serviceAccountTokenVolumeName = "service-account-token"
+	serviceAccountTokenMountPath  = "/var/run/secrets/kubernetes.io/serviceaccount"
 )
 
 var (
@@ -271,6 +274,19 @@ func StatefulSetCreator(data etcdStatefulSetCreatorData, enableDataCorruptionChe
 
 			set.Spec.Template.Spec.Volumes = volumes
 
+			// The etcd-launcher authenticates against the seed cluster with a projected,
+			// time-bound token instead of the legacy service account token if configured
+			set.Spec.Template.Spec.AutomountServiceAccountToken = nil
+			if tokenSettings := data.Cluster().Spec.ComponentsOverride.Etcd.ServiceAccountToken; launcherEnabled && tokenSettings != nil {
+				set.Spec.Template.Spec.AutomountServiceAccountToken = resources.Bool(false)
+				set.Spec.Template.Spec.Volumes = append(set.Spec.Template.Spec.Volumes, projectedServiceAccountTokenVolume(tokenSettings))
+				set.Spec.Template.Spec.Containers[0].VolumeMounts = append(set.Spec.Template.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
+					Name:      serviceAccountTokenVolumeName,
+					MountPath: serviceAccountTokenMountPath,
+					ReadOnly:  true,
+				})
+			}
+
 			// Make sure we don't change volume claim template of existing sts
 			if len(set.Spec.VolumeClaimTemplates) == 0 {
 				storageClass := data.Cluster().Spec.ComponentsOverride.Etcd.StorageClass
@@ -304,6 +320,48 @@ func StatefulSetCreator(data etcdStatefulSetCreatorData, enableDataCorruptionChe
 	}
 }
 
+// projectedServiceAccountTokenVolume returns a volume with the same layout as the legacy service
+// account token secret, so in-cluster clients work without changes.
+func projectedServiceAccountTokenVolume(settings *kubermaticv1.ProjectedServiceAccountTokenSettings) corev1.Volume {
+	expirationSeconds := int64(kubermaticv1.DefaultProjectedServiceAccountTokenExpirationSeconds)
+	if settings.ExpirationSeconds != nil {
+		expirationSeconds = *settings.ExpirationSeconds
+	}
+
+	return corev1.Volume{
+		Name: serviceAccountTokenVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Projected: &corev1.ProjectedVolumeSource{
+				Sources: []corev1.VolumeProjection{
+					{
+						ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
+							Audience:          settings.Audience,
+							ExpirationSeconds: &expirationSeconds,
+							Path:              "token",
+						},
+					},
+					{
+						ConfigMap: &corev1.ConfigMapProjection{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "kube-root-ca.crt"},
+							Items:                []corev1.KeyToPath{{Key: "ca.crt", Path: "ca.crt"}},
+						},
+					},
+					{
+						DownwardAPI: &corev1.DownwardAPIProjection{
+							Items: []corev1.DownwardAPIVolumeFile{
+								{
+									Path:     "namespace",
+									FieldRef: &corev1.ObjectFieldSelector{APIVersion: "v1", FieldPath: "metadata.namespace"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func getVolumes() []corev1.Volume {
 	return []corev1.Volume{
 		{
@@ -355,6 +413,9 @@ func getBasePodLabels(cluster *kubermaticv1.Cluster) map[string]string {
 // ImageTag returns the correct etcd image tag for a given Cluster
 // TODO: Other functions use this function, switch them to getLauncherImage
 func ImageTag(c *kubermaticv1.Cluster) string {
+	if tag := c.Spec.ComponentsOverride.Etcd.ImageTag; tag != "" {
+		return tag
+	}
 	if c.Spec.Version.Minor() < 17 {
 		return etcdImageTagV33
 	}
@@ -362,19 +423,7 @@ func ImageTag(c *kubermaticv1.Cluster) string {
 }
 
 func computeReplicas(data etcdStatefulSetCreatorData, set *appsv1.StatefulSet) int {
-	if !data.Cluster().Spec.Features[kubermaticv1.ClusterFeatureEtcdLauncher] {
-		return kubermaticv1.DefaultEtcdClusterSize
-	}
-	etcdClusterSize := data.Cluster().Spec.ComponentsOverride.Etcd.ClusterSize
-	// handle existing clusters that don't have a configured size
-	if etcdClusterSize < kubermaticv1.MinEtcdClusterSize {
-		klog.V(2).Infof("etcdClusterSize [%d] is smaller than MinEtcdClusterSize [%d]. Clamping to MinEtcdClusterSize", etcdClusterSize, kubermaticv1.MinEtcdClusterSize)
-		etcdClusterSize = kubermaticv1.MinEtcdClusterSize
-	}
-	if etcdClusterSize > kubermaticv1.MaxEtcdClusterSize {
-		klog.V(2).Infof("etcdClusterSize [%d] is larger than MaxEtcdClusterSize [%d]. Clamping to MaxEtcdClusterSize", etcdClusterSize, kubermaticv1.MaxEtcdClusterSize)
-		etcdClusterSize = kubermaticv1.MaxEtcdClusterSize
-	}
+	etcdClusterSize := ClusterSize(data.Cluster())
 	if set.Spec.Replicas == nil { // new replicaset
 		return etcdClusterSize
 	}
@@ -394,6 +443,28 @@ func computeReplicas(data etcdStatefulSetCreatorData, set *appsv1.StatefulSet) i
 	return replicas
 }
 
+// ClusterSize returns the number of etcd members the cluster should have. Scaling etcd is
+// only supported by the etcd launcher, without it the default size is used.
+func ClusterSize(cluster *kubermaticv1.Cluster) int {
+	if !cluster.Spec.Features[kubermaticv1.ClusterFeatureEtcdLauncher] {
+		return kubermaticv1.DefaultEtcdClusterSize
+	}
+	etcdClusterSize := cluster.Spec.ComponentsOverride.Etcd.ClusterSize
+	// handle existing clusters that don't have a configured size
+	if etcdClusterSize == 0 {
+		return kubermaticv1.DefaultEtcdClusterSize
+	}
+	if etcdClusterSize < kubermaticv1.MinEtcdClusterSize {
+		klog.V(2).Infof("etcdClusterSize [%d] is smaller than MinEtcdClusterSize [%d]. Clamping to MinEtcdClusterSize", etcdClusterSize, kubermaticv1.MinEtcdClusterSize)
+		return kubermaticv1.MinEtcdClusterSize
+	}
+	if etcdClusterSize > kubermaticv1.MaxEtcdClusterSize {
+		klog.V(2).Infof("etcdClusterSize [%d] is larger than MaxEtcdClusterSize [%d]. Clamping to MaxEtcdClusterSize", etcdClusterSize, kubermaticv1.MaxEtcdClusterSize)
+		return kubermaticv1.MaxEtcdClusterSize
+	}
+	return etcdClusterSize
+}
+
 type commandTplData struct {
 	ServiceName           string
 	Namespace             string
@@ -89,6 +89,18 @@ func TestGetImageTag(t *testing.T) {
 			},
 			expectedResult: etcdImageTagV34,
 		},
+		{
+			name: "Overridden image tag",
+			cluster: &kubermaticv1.Cluster{
+				Spec: kubermaticv1.ClusterSpec{
+					Version: *semver.NewSemverOrDie("1.17.0"),
+					ComponentsOverride: kubermaticv1.ComponentSettings{
+						Etcd: kubermaticv1.EtcdStatefulSetSettings{ImageTag: "v3.4.14"},
+					},
+				},
+			},
+			expectedResult: "v3.4.14",
+		},
 	}
 
 	for idx := range testCases {
@@ -101,3 +113,53 @@ func TestGetImageTag(t *testing.T) {
 		})
 	}
 }
+
+func TestClusterSize(t *testing.T) {
+	testCases := []struct {
+		name           string
+		launcher       bool
+		size           int
+		expectedResult int
+	}{
+		{
+			name:           "Etcd launcher disabled",
+			size:           5,
+			expectedResult: kubermaticv1.DefaultEtcdClusterSize,
+		},
+		{
+			name:           "No size configured",
+			launcher:       true,
+			expectedResult: kubermaticv1.DefaultEtcdClusterSize,
+		},
+		{
+			name:           "Single member",
+			launcher:       true,
+			size:           1,
+			expectedResult: 1,
+		},
+		{
+			name:           "Size above maximum",
+			launcher:       true,
+			size:           9,
+			expectedResult: kubermaticv1.MaxEtcdClusterSize,
+		},
+	}
+
+	for idx := range testCases {
+		tc := testCases[idx]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			cluster := &kubermaticv1.Cluster{
+				Spec: kubermaticv1.ClusterSpec{
+					Features: map[string]bool{kubermaticv1.ClusterFeatureEtcdLauncher: tc.launcher},
+					ComponentsOverride: kubermaticv1.ComponentSettings{
+						Etcd: kubermaticv1.EtcdStatefulSetSettings{ClusterSize: tc.size},
+					},
+				},
+			}
+			if result := ClusterSize(cluster); result != tc.expectedResult {
+				t.Fatalf("expected result %d but got result %d", tc.expectedResult, result)
+			}
+		})
+	}
+}
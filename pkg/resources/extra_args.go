@@ -0,0 +1,61 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// AppendExtraArgs appends the extra arguments of a control plane component to its flags, sorted by
+// name so that the rendered arguments are stable. As the components use the last value of a flag,
+// extra arguments override the flags set by Kubermatic, except for the feature gates, which are
+// merged into the ones set by Kubermatic.
+func AppendExtraArgs(flags []string, extraArgs map[string]string) []string {
+	names := make([]string, 0, len(extraArgs))
+	for name := range extraArgs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		value := extraArgs[name]
+
+		if name == "feature-gates" {
+			if pos := flagValueIndex(flags, "--feature-gates"); pos != -1 {
+				flags[pos] = strings.Join([]string{flags[pos], value}, ",")
+				continue
+			}
+		}
+
+		flags = append(flags, fmt.Sprintf("--%s=%s", name, value))
+	}
+
+	return flags
+}
+
+// flagValueIndex returns the index of the value of a flag which is set as two arguments,
+// or -1 if it is not set this way.
+func flagValueIndex(flags []string, flag string) int {
+	for i := 0; i < len(flags)-1; i++ {
+		if flags[i] == flag {
+			return i + 1
+		}
+	}
+	return -1
+}
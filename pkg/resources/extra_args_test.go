@@ -0,0 +1,63 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAppendExtraArgs(t *testing.T) {
+	testCases := []struct {
+		name      string
+		flags     []string
+		extraArgs map[string]string
+		expected  []string
+	}{
+		{
+			name:     "no extra args",
+			flags:    []string{"--port", "0"},
+			expected: []string{"--port", "0"},
+		},
+		{
+			name:      "extra args are sorted",
+			flags:     []string{"--port", "0"},
+			extraArgs: map[string]string{"v": "4", "profiling": "false"},
+			expected:  []string{"--port", "0", "--profiling=false", "--v=4"},
+		},
+		{
+			name:      "feature gates are merged",
+			flags:     []string{"--feature-gates", "CSIMigration=true", "--port", "0"},
+			extraArgs: map[string]string{"feature-gates": "EphemeralContainers=true"},
+			expected:  []string{"--feature-gates", "CSIMigration=true,EphemeralContainers=true", "--port", "0"},
+		},
+		{
+			name:      "feature gates without feature gates set by Kubermatic",
+			flags:     []string{"--port", "0"},
+			extraArgs: map[string]string{"feature-gates": "EphemeralContainers=true"},
+			expected:  []string{"--port", "0", "--feature-gates=EphemeralContainers=true"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if flags := AppendExtraArgs(tc.flags, tc.extraArgs); !reflect.DeepEqual(flags, tc.expected) {
+				t.Errorf("expected flags %v, got %v", tc.expected, flags)
+			}
+		})
+	}
+}
@@ -34,7 +34,7 @@ import (
 
 type adminKubeconfigCreatorData interface {
 	Cluster() *kubermaticv1.Cluster
-	GetRootCA() (*triple.KeyPair, error)
+	GetRootCABundle() ([]byte, error)
 }
 
 // AdminKubeconfigCreator returns a function to create/update the secret with the admin kubeconfig
@@ -45,12 +45,12 @@ func AdminKubeconfigCreator(data adminKubeconfigCreatorData) reconciling.NamedSe
 				se.Data = map[string][]byte{}
 			}
 
-			ca, err := data.GetRootCA()
+			caBundle, err := data.GetRootCABundle()
 			if err != nil {
 				return nil, fmt.Errorf("failed to get cluster ca: %v", err)
 			}
 
-			config := GetBaseKubeconfig(ca.Cert, data.Cluster().Address.URL, data.Cluster().Name)
+			config := getBaseKubeconfigWithCABundle(caBundle, data.Cluster().Address.URL, data.Cluster().Name)
 			config.AuthInfos = map[string]*clientcmdapi.AuthInfo{
 				KubeconfigDefaultContextKey: {
 					Token: data.Cluster().Address.AdminToken,
@@ -77,12 +77,12 @@ func ViewerKubeconfigCreator(data *TemplateData) reconciling.NamedSecretCreatorG
 				se.Data = map[string][]byte{}
 			}
 
-			ca, err := data.GetRootCA()
+			caBundle, err := data.GetRootCABundle()
 			if err != nil {
 				return nil, fmt.Errorf("failed to get cluster ca: %v", err)
 			}
 
-			config := GetBaseKubeconfig(ca.Cert, data.Cluster().Address.URL, data.Cluster().Name)
+			config := getBaseKubeconfigWithCABundle(caBundle, data.Cluster().Address.URL, data.Cluster().Name)
 			token, err := data.GetViewerToken()
 			if err != nil {
 				return nil, fmt.Errorf("failed to get token: %v", err)
@@ -162,10 +162,15 @@ func buildNewKubeconfig(ca *triple.KeyPair, server, commonName string, organizat
 		return nil, fmt.Errorf("failed to create key pair: %v", err)
 	}
 
+	key, err := triple.EncodeSignerPEM(kp.Key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode private key: %v", err)
+	}
+
 	baseKubconfig.AuthInfos = map[string]*clientcmdapi.AuthInfo{
 		KubeconfigDefaultContextKey: {
 			ClientCertificateData: triple.EncodeCertPEM(kp.Cert),
-			ClientKeyData:         triple.EncodePrivateKeyPEM(kp.Key),
+			ClientKeyData:         key,
 		},
 	}
 
@@ -173,12 +178,18 @@ func buildNewKubeconfig(ca *triple.KeyPair, server, commonName string, organizat
 }
 
 func GetBaseKubeconfig(caCert *x509.Certificate, server, clusterName string) *clientcmdapi.Config {
+	return getBaseKubeconfigWithCABundle(triple.EncodeCertPEM(caCert), server, clusterName)
+}
+
+// getBaseKubeconfigWithCABundle works like GetBaseKubeconfig, but trusts all CAs of the bundle. Kubeconfigs
+// handed out to users use it, so they keep working while the root CA of the cluster is rotated.
+func getBaseKubeconfigWithCABundle(caBundle []byte, server, clusterName string) *clientcmdapi.Config {
 	return &clientcmdapi.Config{
 		Clusters: map[string]*clientcmdapi.Cluster{
 			// We use the actual cluster name here. It is later used in encodeKubeconfig()
 			// to set the filename of the kubeconfig downloaded from API to `kubeconfig-clusterName`.
 			clusterName: {
-				CertificateAuthorityData: triple.EncodeCertPEM(caCert),
+				CertificateAuthorityData: caBundle,
 				Server:                   server,
 			},
 		},
@@ -62,8 +62,7 @@ func WebhookDeploymentCreator(data machinecontrollerData) reconciling.NamedDeplo
 				"-ca-bundle", "/etc/kubernetes/pki/ca-bundle/ca-bundle.pem",
 			}
 
-			externalCloudProvider := data.Cluster().Spec.Features[kubermaticv1.ClusterFeatureExternalCloudProvider]
-			if externalCloudProvider {
+			if resources.NodeExternalCloudProviderEnabled(data.Cluster()) {
 				args = append(args, "-node-external-cloud-provider")
 			}
 
@@ -240,8 +239,12 @@ func TLSServingCertificateCreator(data tlsServingCertCreatorData) reconciling.Na
 			if err != nil {
 				return nil, fmt.Errorf("failed to generate serving cert: %v", err)
 			}
+			key, err := triple.EncodeSignerPEM(newKP.Key)
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode serving cert key: %v", err)
+			}
 			se.Data[resources.MachineControllerWebhookServingCertCertKeyName] = triple.EncodeCertPEM(newKP.Cert)
-			se.Data[resources.MachineControllerWebhookServingCertKeyKeyName] = triple.EncodePrivateKeyPEM(newKP.Key)
+			se.Data[resources.MachineControllerWebhookServingCertKeyKeyName] = key
 			// Include the CA for simplicity
 			se.Data[resources.CACertSecretKey] = triple.EncodeCertPEM(ca.Cert)
 
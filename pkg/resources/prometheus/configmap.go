@@ -19,7 +19,6 @@ package prometheus
 import (
 	"bytes"
 	"fmt"
-	"io/ioutil"
 	"strings"
 	"text/template"
 
@@ -158,20 +157,33 @@ func loadTemplatedFile(file string, data *customizationData) (string, error) {
 		return "", nil
 	}
 
-	content, err := ioutil.ReadFile(file)
+	tpl, err := customizationTemplates.get(file)
 	if err != nil {
-		return "", fmt.Errorf("couldn't read file: %v", err)
+		return "", err
 	}
 
-	return renderTemplate(string(content), data)
+	return executeTemplate(tpl, data)
 }
 
 func renderTemplate(tpl string, data interface{}) (string, error) {
+	t, err := parseTemplate(tpl)
+	if err != nil {
+		return "", err
+	}
+
+	return executeTemplate(t, data)
+}
+
+func parseTemplate(tpl string) (*template.Template, error) {
 	t, err := template.New("base").Funcs(sprig.TxtFuncMap()).Parse(tpl)
 	if err != nil {
-		return "", fmt.Errorf("failed to parse as Go template: %v", err)
+		return nil, fmt.Errorf("failed to parse as Go template: %v", err)
 	}
 
+	return t, nil
+}
+
+func executeTemplate(t *template.Template, data interface{}) (string, error) {
 	output := bytes.Buffer{}
 	if err := t.Execute(&output, data); err != nil {
 		return "", fmt.Errorf("failed to render template: %v", err)
@@ -0,0 +1,83 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package prometheus
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+	"text/template"
+	"time"
+)
+
+// customizationTemplates caches the parsed custom rules and scraping configs, which are
+// rendered for every cluster on every reconciliation.
+var customizationTemplates = newTemplateCache()
+
+// templateCache holds parsed templates keyed by their file, so files are not read and parsed
+// again on every reconciliation. A file is parsed again once its modification time or size
+// changes. Cached templates can be executed concurrently.
+type templateCache struct {
+	lock      sync.Mutex
+	templates map[string]cachedTemplate
+}
+
+type cachedTemplate struct {
+	modTime  time.Time
+	size     int64
+	template *template.Template
+}
+
+func newTemplateCache() *templateCache {
+	return &templateCache{
+		templates: map[string]cachedTemplate{},
+	}
+}
+
+// get returns the parsed template of the given file.
+func (c *templateCache) get(file string) (*template.Template, error) {
+	info, err := os.Stat(file)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read file: %v", err)
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if cached, ok := c.templates[file]; ok && cached.modTime.Equal(info.ModTime()) && cached.size == info.Size() {
+		return cached.template, nil
+	}
+
+	content, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read file: %v", err)
+	}
+
+	tpl, err := parseTemplate(string(content))
+	if err != nil {
+		return nil, err
+	}
+
+	c.templates[file] = cachedTemplate{
+		modTime:  info.ModTime(),
+		size:     info.Size(),
+		template: tpl,
+	}
+
+	return tpl, nil
+}
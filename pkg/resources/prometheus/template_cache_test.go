@@ -0,0 +1,92 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package prometheus
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestTemplateCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "templates")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "rules.yaml")
+	if err := ioutil.WriteFile(file, []byte(`cluster: {{ .ScrapingAnnotationPrefix }}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cache := newTemplateCache()
+	data := &customizationData{ScrapingAnnotationPrefix: "test"}
+
+	first, err := cache.get(file)
+	if err != nil {
+		t.Fatalf("failed to get template: %v", err)
+	}
+
+	// concurrent reconciliations share the cached template
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			tpl, err := cache.get(file)
+			if err != nil {
+				t.Errorf("failed to get template: %v", err)
+				return
+			}
+			if tpl != first {
+				t.Error("expected the cached template to be returned")
+			}
+			if out, err := executeTemplate(tpl, data); err != nil || out != "cluster: test" {
+				t.Errorf("unexpected rendering result %q: %v", out, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// changing the file invalidates the cached template
+	if err := ioutil.WriteFile(file, []byte(`name: {{ .ScrapingAnnotationPrefix }}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(file, later, later); err != nil {
+		t.Fatal(err)
+	}
+
+	tpl, err := cache.get(file)
+	if err != nil {
+		t.Fatalf("failed to get template: %v", err)
+	}
+	if tpl == first {
+		t.Fatal("expected the template to be parsed again after the file changed")
+	}
+	if out, err := executeTemplate(tpl, data); err != nil || out != "name: test" {
+		t.Errorf("unexpected rendering result %q: %v", out, err)
+	}
+
+	if _, err := cache.get(filepath.Join(dir, "missing.yaml")); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
@@ -64,11 +64,15 @@ func StatefulSetCreator(data *resources.TemplateData) reconciling.NamedStatefulS
 			set.Spec.Template.ObjectMeta = metav1.ObjectMeta{
 				Labels: baseLabels,
 			}
+			// TODO this shouldn't be hardcoded
+			image, err := data.RewriteImage("docker.io/rancher/rancher:v2.3.2")
+			if err != nil {
+				return nil, err
+			}
 			set.Spec.Template.Spec.Containers = []corev1.Container{
 				{
-					Name: resources.RancherStatefulSetName,
-					// TODO this shouldn't be hardcoded
-					Image:           "docker.io/rancher/rancher:v2.3.2",
+					Name:            resources.RancherStatefulSetName,
+					Image:           image,
 					ImagePullPolicy: corev1.PullIfNotPresent,
 					Args: []string{
 						"--http-listen-port=80",
@@ -44,11 +44,42 @@ func OwnerRefWrapper(ref metav1.OwnerReference) ObjectModifier {
 	}
 }
 
+// LabelsAnnotationsWrapper is responsible for wrapping a ObjectCreator function, to add the given labels
+// and annotations to the object. Labels and annotations already set by the ObjectCreator are not overwritten.
+func LabelsAnnotationsWrapper(labels, annotations map[string]string) ObjectModifier {
+	return func(create ObjectCreator) ObjectCreator {
+		return func(existing ctrlruntimeclient.Object) (ctrlruntimeclient.Object, error) {
+			obj, err := create(existing)
+			if err != nil {
+				return obj, err
+			}
+
+			o := obj.(metav1.Object)
+			o.SetLabels(mergeMissing(o.GetLabels(), labels))
+			o.SetAnnotations(mergeMissing(o.GetAnnotations(), annotations))
+			return obj, nil
+		}
+	}
+}
+
+// mergeMissing adds all keys from src which are not set in dst.
+func mergeMissing(dst, src map[string]string) map[string]string {
+	for k, v := range src {
+		if _, exists := dst[k]; exists {
+			continue
+		}
+		if dst == nil {
+			dst = map[string]string{}
+		}
+		dst[k] = v
+	}
+	return dst
+}
+
 // ImagePullSecretsWrapper is generating a new ObjectModifier that wraps an ObjectCreator
 // and takes care of adding the secret names provided to the ImagePullSecrets.
 //
-// TODO(irozzo) At the moment only Deployments are supported, but
-// this can be extended to whatever Object carrying a PodSpec.
+// Deployments, StatefulSets and CronJobs are supported.
 func ImagePullSecretsWrapper(secretNames ...string) ObjectModifier {
 	return func(create ObjectCreator) ObjectCreator {
 		return func(existing ctrlruntimeclient.Object) (ctrlruntimeclient.Object, error) {
@@ -63,6 +94,12 @@ func ImagePullSecretsWrapper(secretNames ...string) ObjectModifier {
 			case *appsv1.Deployment:
 				configureImagePullSecrets(&o.Spec.Template.Spec, secretNames)
 				return o, nil
+			case *appsv1.StatefulSet:
+				configureImagePullSecrets(&o.Spec.Template.Spec, secretNames)
+				return o, nil
+			case *batchv1beta1.CronJob:
+				configureImagePullSecrets(&o.Spec.JobTemplate.Spec.Template.Spec, secretNames)
+				return o, nil
 			default:
 				return o, fmt.Errorf(`type %q is not supported by ImagePullSecretModifier`, o.GetObjectKind().GroupVersionKind())
 			}
@@ -802,10 +802,30 @@ func TestImagePullSecretsWrapper(t *testing.T) {
 			},
 			wantSecretNames: []string{"secret_1", "secret_2", "secret_3"},
 		},
+		{
+			name:        "StatefulSet",
+			secretNames: []string{"secret"},
+			inputObj: &appsv1.StatefulSet{
+				Spec: appsv1.StatefulSetSpec{
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							ImagePullSecrets: []corev1.LocalObjectReference{{Name: "secret"}},
+						},
+					},
+				},
+			},
+			wantSecretNames: []string{"secret"},
+		},
+		{
+			name:            "CronJob",
+			secretNames:     []string{"secret"},
+			inputObj:        &batchv1beta1.CronJob{},
+			wantSecretNames: []string{"secret"},
+		},
 		{
 			name:        "Unsupported object type",
 			secretNames: []string{"secret"},
-			inputObj:    &appsv1.StatefulSet{TypeMeta: metav1.TypeMeta{Kind: "StatefulSet", APIVersion: "apps/v1"}},
+			inputObj:    &appsv1.DaemonSet{TypeMeta: metav1.TypeMeta{Kind: "DaemonSet", APIVersion: "apps/v1"}},
 			wantErr:     true,
 		},
 	}
@@ -818,22 +838,101 @@ func TestImagePullSecretsWrapper(t *testing.T) {
 				t.Fatalf("wanted error = %v, but got %v", tt.wantErr, err)
 			}
 			if !tt.wantErr {
-				if d, ok := tt.inputObj.(*appsv1.Deployment); ok {
-					actualSecretNames := sets.NewString()
-					for _, ips := range d.Spec.Template.Spec.ImagePullSecrets {
-						actualSecretNames.Insert(ips.Name)
-					}
-					if len(d.Spec.Template.Spec.ImagePullSecrets) != len(tt.wantSecretNames) || !actualSecretNames.HasAll(tt.wantSecretNames...) {
-						t.Errorf("actual and expected image pull secret names do not match. expected: %v actual: %v", tt.wantSecretNames, actualSecretNames.List())
-					}
-				} else {
-					t.Fatal("this is an unexpected condition for this test that today only supports Deployments, if support for other resource types has been added please update this test accordingly")
+				var podSpec *corev1.PodSpec
+				switch o := tt.inputObj.(type) {
+				case *appsv1.Deployment:
+					podSpec = &o.Spec.Template.Spec
+				case *appsv1.StatefulSet:
+					podSpec = &o.Spec.Template.Spec
+				case *batchv1beta1.CronJob:
+					podSpec = &o.Spec.JobTemplate.Spec.Template.Spec
+				default:
+					t.Fatal("this is an unexpected condition for this test that today only supports Deployments, StatefulSets and CronJobs, if support for other resource types has been added please update this test accordingly")
+				}
+				actualSecretNames := sets.NewString()
+				for _, ips := range podSpec.ImagePullSecrets {
+					actualSecretNames.Insert(ips.Name)
+				}
+				if len(podSpec.ImagePullSecrets) != len(tt.wantSecretNames) || !actualSecretNames.HasAll(tt.wantSecretNames...) {
+					t.Errorf("actual and expected image pull secret names do not match. expected: %v actual: %v", tt.wantSecretNames, actualSecretNames.List())
 				}
 			}
 		})
 	}
 }
 
+func TestLabelsAnnotationsWrapper(t *testing.T) {
+	labels := map[string]string{"cost-center": "1234", "app": "propagated"}
+	annotations := map[string]string{"environment": "production"}
+
+	tests := []struct {
+		name            string
+		inputObj        controllerruntimeclient.Object
+		wantLabels      map[string]string
+		wantAnnotations map[string]string
+	}{
+		{
+			name:            "Secret without labels",
+			inputObj:        &corev1.Secret{},
+			wantLabels:      map[string]string{"cost-center": "1234", "app": "propagated"},
+			wantAnnotations: map[string]string{"environment": "production"},
+		},
+		{
+			name: "Service with template labels",
+			inputObj: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"app": "apiserver"},
+				},
+			},
+			wantLabels:      map[string]string{"cost-center": "1234", "app": "apiserver"},
+			wantAnnotations: map[string]string{"environment": "production"},
+		},
+		{
+			name: "Deployment with template annotations",
+			inputObj: &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      map[string]string{"app": "scheduler"},
+					Annotations: map[string]string{"environment": "template"},
+				},
+			},
+			wantLabels:      map[string]string{"cost-center": "1234", "app": "scheduler"},
+			wantAnnotations: map[string]string{"environment": "template"},
+		},
+		{
+			name:            "CronJob",
+			inputObj:        &batchv1beta1.CronJob{},
+			wantLabels:      map[string]string{"cost-center": "1234", "app": "propagated"},
+			wantAnnotations: map[string]string{"environment": "production"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			create := LabelsAnnotationsWrapper(labels, annotations)(identityCreator)
+			obj, err := create(tt.inputObj)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := deep.Equal(obj.GetLabels(), tt.wantLabels); diff != nil {
+				t.Errorf("labels differ from the expected ones: %v", diff)
+			}
+			if diff := deep.Equal(obj.GetAnnotations(), tt.wantAnnotations); diff != nil {
+				t.Errorf("annotations differ from the expected ones: %v", diff)
+			}
+		})
+	}
+}
+
+func TestLabelsAnnotationsWrapperWithoutValues(t *testing.T) {
+	create := LabelsAnnotationsWrapper(nil, nil)(identityCreator)
+	obj, err := create(&corev1.ConfigMap{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if obj.GetLabels() != nil || obj.GetAnnotations() != nil {
+		t.Errorf("expected labels and annotations to stay unset, got %v and %v", obj.GetLabels(), obj.GetAnnotations())
+	}
+}
+
 // identityCreator is an ObjectModifier that returns the input object
 // untouched.
 // TODO(irozzo) May be useful to move this in a test package?
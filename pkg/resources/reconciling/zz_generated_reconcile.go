@@ -208,6 +208,80 @@ func ReconcileServiceAccounts(ctx context.Context, namedGetters []NamedServiceAc
 	return nil
 }
 
+// ResourceQuotaCreator defines an interface to create/update ResourceQuotas
+type ResourceQuotaCreator = func(existing *corev1.ResourceQuota) (*corev1.ResourceQuota, error)
+
+// NamedResourceQuotaCreatorGetter returns the name of the resource and the corresponding creator function
+type NamedResourceQuotaCreatorGetter = func() (name string, create ResourceQuotaCreator)
+
+// ResourceQuotaObjectWrapper adds a wrapper so the ResourceQuotaCreator matches ObjectCreator.
+// This is needed as Go does not support function interface matching.
+func ResourceQuotaObjectWrapper(create ResourceQuotaCreator) ObjectCreator {
+	return func(existing ctrlruntimeclient.Object) (ctrlruntimeclient.Object, error) {
+		if existing != nil {
+			return create(existing.(*corev1.ResourceQuota))
+		}
+		return create(&corev1.ResourceQuota{})
+	}
+}
+
+// ReconcileResourceQuotas will create and update the ResourceQuotas coming from the passed ResourceQuotaCreator slice
+func ReconcileResourceQuotas(ctx context.Context, namedGetters []NamedResourceQuotaCreatorGetter, namespace string, client ctrlruntimeclient.Client, objectModifiers ...ObjectModifier) error {
+	for _, get := range namedGetters {
+		name, create := get()
+		createObject := ResourceQuotaObjectWrapper(create)
+		createObject = createWithNamespace(createObject, namespace)
+		createObject = createWithName(createObject, name)
+
+		for _, objectModifier := range objectModifiers {
+			createObject = objectModifier(createObject)
+		}
+
+		if err := EnsureNamedObject(ctx, types.NamespacedName{Namespace: namespace, Name: name}, createObject, client, &corev1.ResourceQuota{}, false); err != nil {
+			return fmt.Errorf("failed to ensure ResourceQuota %s/%s: %v", namespace, name, err)
+		}
+	}
+
+	return nil
+}
+
+// LimitRangeCreator defines an interface to create/update LimitRanges
+type LimitRangeCreator = func(existing *corev1.LimitRange) (*corev1.LimitRange, error)
+
+// NamedLimitRangeCreatorGetter returns the name of the resource and the corresponding creator function
+type NamedLimitRangeCreatorGetter = func() (name string, create LimitRangeCreator)
+
+// LimitRangeObjectWrapper adds a wrapper so the LimitRangeCreator matches ObjectCreator.
+// This is needed as Go does not support function interface matching.
+func LimitRangeObjectWrapper(create LimitRangeCreator) ObjectCreator {
+	return func(existing ctrlruntimeclient.Object) (ctrlruntimeclient.Object, error) {
+		if existing != nil {
+			return create(existing.(*corev1.LimitRange))
+		}
+		return create(&corev1.LimitRange{})
+	}
+}
+
+// ReconcileLimitRanges will create and update the LimitRanges coming from the passed LimitRangeCreator slice
+func ReconcileLimitRanges(ctx context.Context, namedGetters []NamedLimitRangeCreatorGetter, namespace string, client ctrlruntimeclient.Client, objectModifiers ...ObjectModifier) error {
+	for _, get := range namedGetters {
+		name, create := get()
+		createObject := LimitRangeObjectWrapper(create)
+		createObject = createWithNamespace(createObject, namespace)
+		createObject = createWithName(createObject, name)
+
+		for _, objectModifier := range objectModifiers {
+			createObject = objectModifier(createObject)
+		}
+
+		if err := EnsureNamedObject(ctx, types.NamespacedName{Namespace: namespace, Name: name}, createObject, client, &corev1.LimitRange{}, false); err != nil {
+			return fmt.Errorf("failed to ensure LimitRange %s/%s: %v", namespace, name, err)
+		}
+	}
+
+	return nil
+}
+
 // StatefulSetCreator defines an interface to create/update StatefulSets
 type StatefulSetCreator = func(existing *appsv1.StatefulSet) (*appsv1.StatefulSet, error)
 
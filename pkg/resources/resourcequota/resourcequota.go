@@ -0,0 +1,103 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcequota
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/resources/reconciling"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// Name is the name of the ResourceQuota and LimitRange in the cluster namespace.
+	Name = "cluster-quota"
+	// PlanLabelKey is the label on clusters which selects their plan.
+	PlanLabelKey = "plan"
+	// DefaultPlanName is the plan used for clusters without a plan label.
+	DefaultPlanName = "default"
+)
+
+// Plan contains the limits for the control plane of clusters in the seed. Resources
+// which are not configured are not created.
+type Plan struct {
+	ResourceQuota *corev1.ResourceQuotaSpec `json:"resourceQuota,omitempty"`
+	LimitRange    *corev1.LimitRangeSpec    `json:"limitRange,omitempty"`
+}
+
+// Plans maps the plan names to their limits.
+type Plans map[string]Plan
+
+// LoadPlans loads the plans from the given YAML file.
+func LoadPlans(path string) (Plans, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	plans := Plans{}
+	if err := yaml.UnmarshalStrict(content, &plans); err != nil {
+		return nil, fmt.Errorf("failed to parse plans: %v", err)
+	}
+
+	return plans, nil
+}
+
+// PlanName returns the name of the plan selected by the label of the cluster, or the
+// default plan if the cluster has no plan label.
+func PlanName(cluster *kubermaticv1.Cluster) string {
+	if name := cluster.Labels[PlanLabelKey]; name != "" {
+		return name
+	}
+	return DefaultPlanName
+}
+
+// ForCluster returns the plan of the cluster, nil is returned if the plan does not exist.
+func (p Plans) ForCluster(cluster *kubermaticv1.Cluster) *Plan {
+	plan, ok := p[PlanName(cluster)]
+	if !ok {
+		return nil
+	}
+	return &plan
+}
+
+// ResourceQuotaCreator returns a function to create/update the ResourceQuota of the cluster namespace.
+func ResourceQuotaCreator(spec corev1.ResourceQuotaSpec) reconciling.NamedResourceQuotaCreatorGetter {
+	return func() (string, reconciling.ResourceQuotaCreator) {
+		return Name, func(rq *corev1.ResourceQuota) (*corev1.ResourceQuota, error) {
+			rq.Labels = resources.BaseAppLabels(Name, nil)
+			rq.Spec = spec
+			return rq, nil
+		}
+	}
+}
+
+// LimitRangeCreator returns a function to create/update the LimitRange of the cluster namespace.
+func LimitRangeCreator(spec corev1.LimitRangeSpec) reconciling.NamedLimitRangeCreatorGetter {
+	return func() (string, reconciling.LimitRangeCreator) {
+		return Name, func(lr *corev1.LimitRange) (*corev1.LimitRange, error) {
+			lr.Labels = resources.BaseAppLabels(Name, nil)
+			lr.Spec = spec
+			return lr, nil
+		}
+	}
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcequota
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestLoadPlans(t *testing.T) {
+	testCases := []struct {
+		name      string
+		content   string
+		expectErr bool
+		expected  Plans
+	}{
+		{
+			name: "valid plans",
+			content: `
+default:
+  resourceQuota:
+    hard:
+      requests.cpu: "4"
+small:
+  limitRange:
+    limits:
+    - type: Container
+      default:
+        memory: 256Mi
+`,
+			expected: Plans{
+				"default": {
+					ResourceQuota: &corev1.ResourceQuotaSpec{
+						Hard: corev1.ResourceList{corev1.ResourceRequestsCPU: resource.MustParse("4")},
+					},
+				},
+				"small": {
+					LimitRange: &corev1.LimitRangeSpec{
+						Limits: []corev1.LimitRangeItem{{
+							Type:    corev1.LimitTypeContainer,
+							Default: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("256Mi")},
+						}},
+					},
+				},
+			},
+		},
+		{
+			name:      "unknown field",
+			content:   "default:\n  quota: {}\n",
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "plans")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+
+			path := filepath.Join(dir, "plans.yaml")
+			if err := ioutil.WriteFile(path, []byte(tc.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			plans, err := LoadPlans(path)
+			if (err != nil) != tc.expectErr {
+				t.Fatalf("expected error: %v, got: %v", tc.expectErr, err)
+			}
+			if tc.expectErr {
+				return
+			}
+
+			if len(plans) != len(tc.expected) {
+				t.Fatalf("expected %d plans, got %d", len(tc.expected), len(plans))
+			}
+			for name, expected := range tc.expected {
+				plan, ok := plans[name]
+				if !ok {
+					t.Fatalf("plan %q is missing", name)
+				}
+				if (plan.ResourceQuota == nil) != (expected.ResourceQuota == nil) || (plan.LimitRange == nil) != (expected.LimitRange == nil) {
+					t.Fatalf("plan %q does not match, expected %+v, got %+v", name, expected, plan)
+				}
+				if expected.ResourceQuota != nil && !plan.ResourceQuota.Hard.Cpu().Equal(*expected.ResourceQuota.Hard.Cpu()) {
+					t.Errorf("plan %q: expected quota %v, got %v", name, expected.ResourceQuota.Hard, plan.ResourceQuota.Hard)
+				}
+				if expected.LimitRange != nil && !plan.LimitRange.Limits[0].Default.Memory().Equal(*expected.LimitRange.Limits[0].Default.Memory()) {
+					t.Errorf("plan %q: expected limits %v, got %v", name, expected.LimitRange.Limits, plan.LimitRange.Limits)
+				}
+			}
+		})
+	}
+}
+
+func TestPlansForCluster(t *testing.T) {
+	plans := Plans{
+		DefaultPlanName: {ResourceQuota: &corev1.ResourceQuotaSpec{}},
+		"large":         {LimitRange: &corev1.LimitRangeSpec{}},
+	}
+
+	testCases := []struct {
+		name          string
+		labels        map[string]string
+		plans         Plans
+		expectedFound bool
+		expectedQuota bool
+	}{
+		{
+			name:          "cluster without label uses the default plan",
+			plans:         plans,
+			expectedFound: true,
+			expectedQuota: true,
+		},
+		{
+			name:          "cluster selects plan by label",
+			labels:        map[string]string{PlanLabelKey: "large"},
+			plans:         plans,
+			expectedFound: true,
+		},
+		{
+			name:   "unknown plan",
+			labels: map[string]string{PlanLabelKey: "huge"},
+			plans:  plans,
+		},
+		{
+			name: "no plans configured",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cluster := &kubermaticv1.Cluster{ObjectMeta: metav1.ObjectMeta{Labels: tc.labels}}
+
+			plan := tc.plans.ForCluster(cluster)
+			if (plan != nil) != tc.expectedFound {
+				t.Fatalf("expected plan to be found: %v, got %+v", tc.expectedFound, plan)
+			}
+			if plan != nil && (plan.ResourceQuota != nil) != tc.expectedQuota {
+				t.Errorf("expected plan with quota: %v, got %+v", tc.expectedQuota, plan)
+			}
+		})
+	}
+}
@@ -28,6 +28,7 @@ import (
 	"io/ioutil"
 	"net"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/minio/minio-go"
@@ -203,6 +204,14 @@ const (
 	GoogleServiceAccountSecretName = "google-service-account"
 	// GoogleServiceAccountVolumeName is the name of the volume containing the Google Service Account secret.
 	GoogleServiceAccountVolumeName = "google-service-account-volume"
+	// ApiserverEncryptionConfigurationSecretName is the name of the secret containing the encryption configuration of the apiserver
+	ApiserverEncryptionConfigurationSecretName = "apiserver-encryption-configuration"
+	// ApiserverEncryptionConfigurationSecretKey is the key of the encryption configuration in its secret
+	ApiserverEncryptionConfigurationSecretKey = "encryption-configuration.yaml"
+	// ApiserverEncryptionKeyAnnotation is the annotation of the encryption configuration secret naming the key which is rolled out
+	ApiserverEncryptionKeyAnnotation = "kubermatic.io/encryption-key"
+	// ServiceAccountKeyRotatedAnnotation is the annotation of the service account key secret holding the time the key was replaced
+	ServiceAccountKeyRotatedAnnotation = "kubermatic.io/service-account-key-rotated"
 	// AuditLogVolumeName is the name of the volume that hold the audit log of the apiserver.
 	AuditLogVolumeName = "audit-log"
 	// KubernetesDashboardKeyHolderSecretName is the name of the secret that contains JWE token encryption key
@@ -221,6 +230,8 @@ const (
 	CloudConfigConfigMapName = "cloud-config"
 	// CloudConfigConfigMapKey is the key under which the cloud-config in the cloud-config configmap can be found
 	CloudConfigConfigMapKey = "config"
+	// CloudConfigMountPath is the path under which the cloud-config configmap is mounted into the control plane components
+	CloudConfigMountPath = "/etc/kubernetes/cloud"
 	//OpenVPNClientConfigsConfigMapName is the name for the ConfigMap containing the OpenVPN client config used within the user cluster
 	OpenVPNClientConfigsConfigMapName = "openvpn-client-configs"
 	//OpenVPNClientConfigConfigMapName is the name for the ConfigMap containing the OpenVPN client config used by the client inside the user cluster
@@ -233,6 +244,8 @@ const (
 	AuditConfigMapName = "audit-config"
 	//AdmissionControlConfigMapName is the name for the configmap that contains the Admission Controller config file
 	AdmissionControlConfigMapName = "adm-control"
+	//SchedulerConfigConfigMapName is the name for the configmap containing the scheduler configuration of the cluster
+	SchedulerConfigConfigMapName = "scheduler-config"
 
 	//PrometheusServiceAccountName is the name for the Prometheus serviceaccount
 	PrometheusServiceAccountName = "prometheus"
@@ -343,9 +356,6 @@ const (
 	// ClusterLabelKey defines the label key for the cluster name
 	ClusterLabelKey = "cluster"
 
-	// EtcdClusterSize defines the size of the etcd to use
-	EtcdClusterSize = 3
-
 	// RegistryK8SGCR defines the kubernetes specific docker registry at google
 	RegistryK8SGCR = "k8s.gcr.io"
 	// RegistryGCR defines the kubernetes docker registry at google
@@ -401,6 +411,19 @@ const (
 	CAKeySecretKey = "ca.key"
 	// CACertSecretKey ca.crt
 	CACertSecretKey = "ca.crt"
+	// CABundleSecretKey ca-bundle.crt contains all CA certificates of the cluster which are
+	// trusted, which are more than the CA in ca.crt while the CA is being rotated
+	CABundleSecretKey = "ca-bundle.crt"
+	// CANextCertSecretKey ca-next.crt is the CA which replaces the CA in ca.crt once it is rotated
+	CANextCertSecretKey = "ca-next.crt"
+	// CANextKeySecretKey ca-next.key
+	CANextKeySecretKey = "ca-next.key"
+	// CAPreviousCertSecretKey ca-previous.crt is the CA which was replaced by the CA in ca.crt,
+	// it is trusted until it expires
+	CAPreviousCertSecretKey = "ca-previous.crt"
+	// CAChainSecretKey ca-chain.crt is the chain of the signing CA which issued the CA in ca.crt. It is
+	// kept apart from ca.crt, as components trusting ca.crt must not trust the signing CA
+	CAChainSecretKey = "ca-chain.crt"
 	// ApiserverTLSKeySecretKey apiserver-tls.key
 	ApiserverTLSKeySecretKey = "apiserver-tls.key"
 	// ApiserverTLSCertSecretKey apiserver-tls.crt
@@ -413,6 +436,11 @@ const (
 	ServiceAccountKeySecretKey = "sa.key"
 	// ServiceAccountKeyPublicKey is the public key for the service account signer key
 	ServiceAccountKeyPublicKey = "sa.pub"
+	// ServiceAccountKeyPreviousPublicKey is the public key of the replaced service account signer key. It is
+	// removed once the tokens signed with the replaced key expired.
+	ServiceAccountKeyPreviousPublicKey = "sa-previous.pub"
+	// ServiceAccountKeyVerificationKeys are the public keys the apiserver verifies service account tokens with
+	ServiceAccountKeyVerificationKeys = "sa-verification.pub"
 	// KubeconfigSecretKey kubeconfig
 	KubeconfigSecretKey = "kubeconfig"
 	// TokensSecretKey tokens.csv
@@ -627,6 +655,24 @@ func String(v string) *string {
 	return &v
 }
 
+// NetworkRangesFlag returns the CIDR blocks of a cluster network range as a flag value. Dual-stack
+// clusters use one block per IP family, single-stack clusters only the first block.
+func NetworkRangesFlag(cluster *kubermaticv1.Cluster, ranges kubermaticv1.NetworkRanges) string {
+	if cluster.Spec.ClusterNetwork.IsDualStack() {
+		return strings.Join(ranges.CIDRBlocks, ",")
+	}
+	return ranges.CIDRBlocks[0]
+}
+
+// DualStackFeatureGates returns the feature gates the control plane components need
+// for dual-stack networking.
+func DualStackFeatureGates(cluster *kubermaticv1.Cluster) []string {
+	if !cluster.Spec.ClusterNetwork.IsDualStack() {
+		return nil
+	}
+	return []string{"IPv6DualStack=true"}
+}
+
 // UserClusterDNSResolverIP returns the 9th usable IP address
 // from the first Service CIDR block from ClusterNetwork spec.
 // This is by convention the IP address of the DNS resolver.
@@ -822,6 +868,22 @@ func getRSAClusterCAFromLister(ctx context.Context, namespace, name string, clie
 	return &triple.KeyPair{Cert: cert, Key: rsaKey}, nil
 }
 
+// getClusterCAKeyPairFromLister returns a CA with either a RSA or an ECDSA key
+func getClusterCAKeyPairFromLister(ctx context.Context, namespace, name string, client ctrlruntimeclient.Client) (*triple.KeyPair, error) {
+	cert, key, err := getClusterCAFromLister(ctx, namespace, name, client)
+	if err != nil {
+		return nil, err
+	}
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		return &triple.KeyPair{Cert: cert, Key: k}, nil
+	case *ecdsa.PrivateKey:
+		return &triple.KeyPair{Cert: cert, Key: k}, nil
+	default:
+		return nil, errors.New("key is neither a RSA nor an ECDSA key")
+	}
+}
+
 // getClusterCAFromLister returns the CA of the cluster from the lister
 func getClusterCAFromLister(ctx context.Context, namespace, name string, client ctrlruntimeclient.Client) (*x509.Certificate, interface{}, error) {
 	caSecret := &corev1.Secret{}
@@ -864,7 +926,28 @@ func GetCABundleFromFile(file string) ([]*x509.Certificate, error) {
 
 // GetClusterRootCA returns the root CA of the cluster from the lister
 func GetClusterRootCA(ctx context.Context, namespace string, client ctrlruntimeclient.Client) (*triple.KeyPair, error) {
-	return getRSAClusterCAFromLister(ctx, namespace, CASecretName, client)
+	return getClusterCAKeyPairFromLister(ctx, namespace, CASecretName, client)
+}
+
+// GetClusterRootCABundle returns the PEM encoded certificates of all root CAs the clients of the cluster
+// have to trust, which includes the previous or next root CA while the root CA is being rotated. If the
+// root CA was issued by a signing CA, the bundle is followed by the chain of the signing CA.
+func GetClusterRootCABundle(ctx context.Context, namespace string, client ctrlruntimeclient.Client) ([]byte, error) {
+	caSecret := &corev1.Secret{}
+	if err := client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: CASecretName}, caSecret); err != nil {
+		return nil, fmt.Errorf("failed to get the CA secret: %v", err)
+	}
+
+	// secrets created before the bundle was introduced only have the CA itself until they are reconciled
+	bundle := caSecret.Data[CABundleSecretKey]
+	if len(bundle) == 0 {
+		bundle = caSecret.Data[CACertSecretKey]
+	}
+	if len(bundle) == 0 {
+		return nil, errors.New("the CA secret contains no certificate")
+	}
+
+	return append(bundle, caSecret.Data[CAChainSecretKey]...), nil
 }
 
 // GetClusterFrontProxyCA returns the frontproxy CA of the cluster from the lister
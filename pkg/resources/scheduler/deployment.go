@@ -19,6 +19,8 @@ package scheduler
 import (
 	"fmt"
 
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+
 	"k8c.io/kubermatic/v2/pkg/resources/apiserver"
 	"k8c.io/kubermatic/v2/pkg/resources/reconciling"
 
@@ -31,6 +33,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/yaml"
 )
 
 var (
@@ -48,8 +51,69 @@ var (
 
 const (
 	name = "scheduler"
+
+	kubeconfigPath = "/etc/kubernetes/kubeconfig/kubeconfig"
+
+	// configKey is the key of the scheduler configuration in its ConfigMap
+	configKey       = "config.yaml"
+	configMountPath = "/etc/kubernetes/scheduler"
 )
 
+// ConfigMapCreator returns the function to create the ConfigMap with the scheduler configuration
+// of the cluster. It is only used if the cluster configures the scheduler.
+func ConfigMapCreator(data *resources.TemplateData) reconciling.NamedConfigMapCreatorGetter {
+	return func() (string, reconciling.ConfigMapCreator) {
+		return resources.SchedulerConfigConfigMapName, func(cm *corev1.ConfigMap) (*corev1.ConfigMap, error) {
+			config, err := configuration(data.Cluster().Spec.ComponentsOverride.Scheduler)
+			if err != nil {
+				return nil, fmt.Errorf("failed to render the scheduler configuration: %v", err)
+			}
+			cm.Data = map[string]string{configKey: config}
+			return cm, nil
+		}
+	}
+}
+
+// configuration returns the scheduler configuration of the cluster with the kubeconfig and the
+// leader election settings managed by Kubermatic, as the scheduler ignores the respective flags
+// once a configuration file is used.
+func configuration(settings kubermaticv1.SchedulerSettings) (string, error) {
+	config := map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(settings.Configuration), &config); err != nil {
+		return "", err
+	}
+
+	clientConnection, _ := config["clientConnection"].(map[string]interface{})
+	if clientConnection == nil {
+		clientConnection = map[string]interface{}{}
+	}
+	clientConnection["kubeconfig"] = kubeconfigPath
+	config["clientConnection"] = clientConnection
+
+	leaderElection, _ := config["leaderElection"].(map[string]interface{})
+	if leaderElection == nil {
+		leaderElection = map[string]interface{}{}
+	}
+	if lds := settings.LeaderElectionSettings.LeaseDurationSeconds; lds != nil {
+		leaderElection["leaseDuration"] = fmt.Sprintf("%ds", *lds)
+	}
+	if rds := settings.LeaderElectionSettings.RenewDeadlineSeconds; rds != nil {
+		leaderElection["renewDeadline"] = fmt.Sprintf("%ds", *rds)
+	}
+	if rps := settings.LeaderElectionSettings.RetryPeriodSeconds; rps != nil {
+		leaderElection["retryPeriod"] = fmt.Sprintf("%ds", *rps)
+	}
+	if len(leaderElection) > 0 {
+		config["leaderElection"] = leaderElection
+	}
+
+	rendered, err := yaml.Marshal(config)
+	if err != nil {
+		return "", err
+	}
+	return string(rendered), nil
+}
+
 // DeploymentCreator returns the function to create and update the scheduler deployment
 func DeploymentCreator(data *resources.TemplateData) reconciling.NamedDeploymentCreatorGetter {
 	return func() (string, reconciling.DeploymentCreator) {
@@ -58,12 +122,12 @@ func DeploymentCreator(data *resources.TemplateData) reconciling.NamedDeployment
 			dep.Labels = resources.BaseAppLabels(name, nil)
 
 			flags := []string{
-				"--kubeconfig", "/etc/kubernetes/kubeconfig/kubeconfig",
+				"--kubeconfig", kubeconfigPath,
 				// These are used to validate tokens
-				"--authentication-kubeconfig", "/etc/kubernetes/kubeconfig/kubeconfig",
-				"--authorization-kubeconfig", "/etc/kubernetes/kubeconfig/kubeconfig",
+				"--authentication-kubeconfig", kubeconfigPath,
+				"--authorization-kubeconfig", kubeconfigPath,
 				// This is used to validate certs
-				"--client-ca-file", "/etc/kubernetes/pki/ca/ca.crt",
+				"--client-ca-file", "/etc/kubernetes/pki/ca/" + resources.CABundleSecretKey,
 				// We're going to use the https endpoints for scraping the metrics starting from 1.13. Thus we can deactivate the http endpoint
 				"--port", "0",
 			}
@@ -78,6 +142,11 @@ func DeploymentCreator(data *resources.TemplateData) reconciling.NamedDeployment
 			if rps := data.Cluster().Spec.ComponentsOverride.Scheduler.LeaderElectionSettings.DeepCopy().RetryPeriodSeconds; rps != nil {
 				flags = append(flags, "--leader-elect-retry-period", fmt.Sprintf("%ds", *rps))
 			}
+			configured := data.Cluster().Spec.ComponentsOverride.Scheduler.Configuration != ""
+			if configured {
+				flags = append(flags, "--config", configMountPath+"/"+configKey)
+			}
+			flags = resources.AppendExtraArgs(flags, data.Cluster().Spec.ComponentsOverride.Scheduler.ExtraArgs)
 
 			dep.Spec.Replicas = resources.Int32(1)
 			if data.Cluster().Spec.ComponentsOverride.Scheduler.Replicas != nil {
@@ -90,6 +159,25 @@ func DeploymentCreator(data *resources.TemplateData) reconciling.NamedDeployment
 
 			volumes := getVolumes()
 			volumeMounts := getVolumeMounts()
+			// The configuration is mounted from a ConfigMap, so the scheduler gets restarted
+			// whenever it changes
+			if configured {
+				volumes = append(volumes, corev1.Volume{
+					Name: resources.SchedulerConfigConfigMapName,
+					VolumeSource: corev1.VolumeSource{
+						ConfigMap: &corev1.ConfigMapVolumeSource{
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: resources.SchedulerConfigConfigMapName,
+							},
+						},
+					},
+				})
+				volumeMounts = append(volumeMounts, corev1.VolumeMount{
+					Name:      resources.SchedulerConfigConfigMapName,
+					MountPath: configMountPath,
+					ReadOnly:  true,
+				})
+			}
 
 			podLabels, err := data.GetPodTemplateLabels(name, volumes, nil)
 			if err != nil {
@@ -226,6 +314,10 @@ func getVolumes() []corev1.Volume {
 							Path: resources.CACertSecretKey,
 							Key:  resources.CACertSecretKey,
 						},
+						{
+							Path: resources.CABundleSecretKey,
+							Key:  resources.CABundleSecretKey,
+						},
 					},
 				},
 			},
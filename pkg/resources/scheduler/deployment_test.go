@@ -0,0 +1,91 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"testing"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+
+	"k8s.io/utils/pointer"
+)
+
+func TestConfiguration(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings kubermaticv1.SchedulerSettings
+		expected string
+	}{
+		{
+			name: "kubeconfig is set",
+			settings: kubermaticv1.SchedulerSettings{
+				Configuration: `apiVersion: kubescheduler.config.k8s.io/v1beta1
+kind: KubeSchedulerConfiguration
+profiles:
+- schedulerName: default-scheduler
+`,
+			},
+			expected: `apiVersion: kubescheduler.config.k8s.io/v1beta1
+clientConnection:
+  kubeconfig: /etc/kubernetes/kubeconfig/kubeconfig
+kind: KubeSchedulerConfiguration
+profiles:
+- schedulerName: default-scheduler
+`,
+		},
+		{
+			name: "kubeconfig and leader election settings are overridden",
+			settings: kubermaticv1.SchedulerSettings{
+				ControllerSettings: kubermaticv1.ControllerSettings{
+					LeaderElectionSettings: kubermaticv1.LeaderElectionSettings{
+						LeaseDurationSeconds: pointer.Int32Ptr(30),
+					},
+				},
+				Configuration: `apiVersion: kubescheduler.config.k8s.io/v1beta1
+kind: KubeSchedulerConfiguration
+clientConnection:
+  kubeconfig: /root/.kube/config
+  qps: 100
+leaderElection:
+  leaseDuration: 5s
+  resourceName: custom-scheduler
+`,
+			},
+			expected: `apiVersion: kubescheduler.config.k8s.io/v1beta1
+clientConnection:
+  kubeconfig: /etc/kubernetes/kubeconfig/kubeconfig
+  qps: 100
+kind: KubeSchedulerConfiguration
+leaderElection:
+  leaseDuration: 30s
+  resourceName: custom-scheduler
+`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config, err := configuration(test.settings)
+			if err != nil {
+				t.Fatalf("failed to render the configuration: %v", err)
+			}
+			if config != test.expected {
+				t.Errorf("expected configuration\n%s\ngot\n%s", test.expected, config)
+			}
+		})
+	}
+}
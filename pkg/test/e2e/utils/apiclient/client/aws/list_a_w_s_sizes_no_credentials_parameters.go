@@ -60,6 +60,8 @@ for the list a w s sizes no credentials operation typically these are written to
 */
 type ListAWSSizesNoCredentialsParams struct {
 
+	/*AvailabilityZone*/
+	AvailabilityZone *string
 	/*ClusterID*/
 	ClusterID string
 	/*Dc*/
@@ -105,6 +107,17 @@ func (o *ListAWSSizesNoCredentialsParams) SetHTTPClient(client *http.Client) {
 	o.HTTPClient = client
 }
 
+// WithAvailabilityZone adds the availabilityZone to the list a w s sizes no credentials params
+func (o *ListAWSSizesNoCredentialsParams) WithAvailabilityZone(availabilityZone *string) *ListAWSSizesNoCredentialsParams {
+	o.SetAvailabilityZone(availabilityZone)
+	return o
+}
+
+// SetAvailabilityZone adds the availabilityZone to the list a w s sizes no credentials params
+func (o *ListAWSSizesNoCredentialsParams) SetAvailabilityZone(availabilityZone *string) {
+	o.AvailabilityZone = availabilityZone
+}
+
 // WithClusterID adds the clusterID to the list a w s sizes no credentials params
 func (o *ListAWSSizesNoCredentialsParams) WithClusterID(clusterID string) *ListAWSSizesNoCredentialsParams {
 	o.SetClusterID(clusterID)
@@ -146,6 +159,15 @@ func (o *ListAWSSizesNoCredentialsParams) WriteToRequest(r runtime.ClientRequest
 	}
 	var res []error
 
+	if o.AvailabilityZone != nil {
+
+		// header param AvailabilityZone
+		if err := r.SetHeaderParam("AvailabilityZone", *o.AvailabilityZone); err != nil {
+			return err
+		}
+
+	}
+
 	// path param cluster_id
 	if err := r.SetPathParam("cluster_id", o.ClusterID); err != nil {
 		return err
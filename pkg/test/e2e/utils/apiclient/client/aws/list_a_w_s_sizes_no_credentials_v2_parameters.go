@@ -60,6 +60,8 @@ for the list a w s sizes no credentials v2 operation typically these are written
 */
 type ListAWSSizesNoCredentialsV2Params struct {
 
+	/*AvailabilityZone*/
+	AvailabilityZone *string
 	/*ClusterID*/
 	ClusterID string
 	/*ProjectID*/
@@ -103,6 +105,17 @@ func (o *ListAWSSizesNoCredentialsV2Params) SetHTTPClient(client *http.Client) {
 	o.HTTPClient = client
 }
 
+// WithAvailabilityZone adds the availabilityZone to the list a w s sizes no credentials v2 params
+func (o *ListAWSSizesNoCredentialsV2Params) WithAvailabilityZone(availabilityZone *string) *ListAWSSizesNoCredentialsV2Params {
+	o.SetAvailabilityZone(availabilityZone)
+	return o
+}
+
+// SetAvailabilityZone adds the availabilityZone to the list a w s sizes no credentials v2 params
+func (o *ListAWSSizesNoCredentialsV2Params) SetAvailabilityZone(availabilityZone *string) {
+	o.AvailabilityZone = availabilityZone
+}
+
 // WithClusterID adds the clusterID to the list a w s sizes no credentials v2 params
 func (o *ListAWSSizesNoCredentialsV2Params) WithClusterID(clusterID string) *ListAWSSizesNoCredentialsV2Params {
 	o.SetClusterID(clusterID)
@@ -133,6 +146,15 @@ func (o *ListAWSSizesNoCredentialsV2Params) WriteToRequest(r runtime.ClientReque
 	}
 	var res []error
 
+	if o.AvailabilityZone != nil {
+
+		// header param AvailabilityZone
+		if err := r.SetHeaderParam("AvailabilityZone", *o.AvailabilityZone); err != nil {
+			return err
+		}
+
+	}
+
 	// path param cluster_id
 	if err := r.SetPathParam("cluster_id", o.ClusterID); err != nil {
 		return err
@@ -41,6 +41,8 @@ type ClientService interface {
 
 	ListDatacenters(params *ListDatacentersParams, authInfo runtime.ClientAuthInfoWriter) (*ListDatacentersOK, error)
 
+	ListDatacentersPaginated(params *ListDatacentersPaginatedParams, authInfo runtime.ClientAuthInfoWriter) (*ListDatacentersPaginatedOK, error)
+
 	PatchDC(params *PatchDCParams, authInfo runtime.ClientAuthInfoWriter) (*PatchDCOK, error)
 
 	UpdateDC(params *UpdateDCParams, authInfo runtime.ClientAuthInfoWriter) (*UpdateDCOK, error)
@@ -320,6 +322,40 @@ func (a *Client) ListDatacenters(params *ListDatacentersParams, authInfo runtime
 	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
 }
 
+/*
+  ListDatacentersPaginated lists a page of datacenters the total number of datacenters is returned in the x total count header
+*/
+func (a *Client) ListDatacentersPaginated(params *ListDatacentersPaginatedParams, authInfo runtime.ClientAuthInfoWriter) (*ListDatacentersPaginatedOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewListDatacentersPaginatedParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "listDatacentersPaginated",
+		Method:             "GET",
+		PathPattern:        "/api/v1/datacenters",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &ListDatacentersPaginatedReader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*ListDatacentersPaginatedOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*ListDatacentersPaginatedDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
 /*
   PatchDC patches the datacenter
 */
@@ -59,6 +59,10 @@ func NewListDatacentersParamsWithHTTPClient(client *http.Client) *ListDatacenter
 for the list datacenters operation typically these are written to a http.Request
 */
 type ListDatacentersParams struct {
+
+	/*Provider*/
+	Provider *string
+
 	timeout    time.Duration
 	Context    context.Context
 	HTTPClient *http.Client
@@ -97,6 +101,17 @@ func (o *ListDatacentersParams) SetHTTPClient(client *http.Client) {
 	o.HTTPClient = client
 }
 
+// WithProvider adds the provider to the list datacenters params
+func (o *ListDatacentersParams) WithProvider(provider *string) *ListDatacentersParams {
+	o.SetProvider(provider)
+	return o
+}
+
+// SetProvider adds the provider to the list datacenters params
+func (o *ListDatacentersParams) SetProvider(provider *string) {
+	o.Provider = provider
+}
+
 // WriteToRequest writes these params to a swagger request
 func (o *ListDatacentersParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
 
@@ -105,6 +120,22 @@ func (o *ListDatacentersParams) WriteToRequest(r runtime.ClientRequest, reg strf
 	}
 	var res []error
 
+	if o.Provider != nil {
+
+		// query param provider
+		var qrProvider string
+		if o.Provider != nil {
+			qrProvider = *o.Provider
+		}
+		qProvider := qrProvider
+		if qProvider != "" {
+			if err := r.SetQueryParam("provider", qProvider); err != nil {
+				return err
+			}
+		}
+
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
@@ -14,6 +14,7 @@ import (
 	"github.com/go-openapi/runtime"
 	cr "github.com/go-openapi/runtime/client"
 	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
 )
 
 // NewListHetznerSizesNoCredentialsParams creates a new ListHetznerSizesNoCredentialsParams object
@@ -64,8 +65,12 @@ type ListHetznerSizesNoCredentialsParams struct {
 	ClusterID string
 	/*Dc*/
 	DC string
+	/*Features*/
+	Features []string
 	/*ProjectID*/
 	ProjectID string
+	/*Refresh*/
+	Refresh *bool
 
 	timeout    time.Duration
 	Context    context.Context
@@ -127,6 +132,17 @@ func (o *ListHetznerSizesNoCredentialsParams) SetDC(dc string) {
 	o.DC = dc
 }
 
+// WithFeatures adds the features to the list hetzner sizes no credentials params
+func (o *ListHetznerSizesNoCredentialsParams) WithFeatures(features []string) *ListHetznerSizesNoCredentialsParams {
+	o.SetFeatures(features)
+	return o
+}
+
+// SetFeatures adds the features to the list hetzner sizes no credentials params
+func (o *ListHetznerSizesNoCredentialsParams) SetFeatures(features []string) {
+	o.Features = features
+}
+
 // WithProjectID adds the projectID to the list hetzner sizes no credentials params
 func (o *ListHetznerSizesNoCredentialsParams) WithProjectID(projectID string) *ListHetznerSizesNoCredentialsParams {
 	o.SetProjectID(projectID)
@@ -138,6 +154,17 @@ func (o *ListHetznerSizesNoCredentialsParams) SetProjectID(projectID string) {
 	o.ProjectID = projectID
 }
 
+// WithRefresh adds the refresh to the list hetzner sizes no credentials params
+func (o *ListHetznerSizesNoCredentialsParams) WithRefresh(refresh *bool) *ListHetznerSizesNoCredentialsParams {
+	o.SetRefresh(refresh)
+	return o
+}
+
+// SetRefresh adds the refresh to the list hetzner sizes no credentials params
+func (o *ListHetznerSizesNoCredentialsParams) SetRefresh(refresh *bool) {
+	o.Refresh = refresh
+}
+
 // WriteToRequest writes these params to a swagger request
 func (o *ListHetznerSizesNoCredentialsParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
 
@@ -156,11 +183,35 @@ func (o *ListHetznerSizesNoCredentialsParams) WriteToRequest(r runtime.ClientReq
 		return err
 	}
 
+	valuesFeatures := o.Features
+
+	joinedFeatures := swag.JoinByFormat(valuesFeatures, "")
+	// query array param features
+	if err := r.SetQueryParam("features", joinedFeatures...); err != nil {
+		return err
+	}
+
 	// path param project_id
 	if err := r.SetPathParam("project_id", o.ProjectID); err != nil {
 		return err
 	}
 
+	if o.Refresh != nil {
+
+		// query param refresh
+		var qrRefresh bool
+		if o.Refresh != nil {
+			qrRefresh = *o.Refresh
+		}
+		qRefresh := swag.FormatBool(qrRefresh)
+		if qRefresh != "" {
+			if err := r.SetQueryParam("refresh", qRefresh); err != nil {
+				return err
+			}
+		}
+
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
@@ -14,6 +14,7 @@ import (
 	"github.com/go-openapi/runtime"
 	cr "github.com/go-openapi/runtime/client"
 	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
 )
 
 // NewListHetznerSizesNoCredentialsV2Params creates a new ListHetznerSizesNoCredentialsV2Params object
@@ -62,8 +63,12 @@ type ListHetznerSizesNoCredentialsV2Params struct {
 
 	/*ClusterID*/
 	ClusterID string
+	/*Features*/
+	Features []string
 	/*ProjectID*/
 	ProjectID string
+	/*Refresh*/
+	Refresh *bool
 
 	timeout    time.Duration
 	Context    context.Context
@@ -114,6 +119,17 @@ func (o *ListHetznerSizesNoCredentialsV2Params) SetClusterID(clusterID string) {
 	o.ClusterID = clusterID
 }
 
+// WithFeatures adds the features to the list hetzner sizes no credentials v2 params
+func (o *ListHetznerSizesNoCredentialsV2Params) WithFeatures(features []string) *ListHetznerSizesNoCredentialsV2Params {
+	o.SetFeatures(features)
+	return o
+}
+
+// SetFeatures adds the features to the list hetzner sizes no credentials v2 params
+func (o *ListHetznerSizesNoCredentialsV2Params) SetFeatures(features []string) {
+	o.Features = features
+}
+
 // WithProjectID adds the projectID to the list hetzner sizes no credentials v2 params
 func (o *ListHetznerSizesNoCredentialsV2Params) WithProjectID(projectID string) *ListHetznerSizesNoCredentialsV2Params {
 	o.SetProjectID(projectID)
@@ -125,6 +141,17 @@ func (o *ListHetznerSizesNoCredentialsV2Params) SetProjectID(projectID string) {
 	o.ProjectID = projectID
 }
 
+// WithRefresh adds the refresh to the list hetzner sizes no credentials v2 params
+func (o *ListHetznerSizesNoCredentialsV2Params) WithRefresh(refresh *bool) *ListHetznerSizesNoCredentialsV2Params {
+	o.SetRefresh(refresh)
+	return o
+}
+
+// SetRefresh adds the refresh to the list hetzner sizes no credentials v2 params
+func (o *ListHetznerSizesNoCredentialsV2Params) SetRefresh(refresh *bool) {
+	o.Refresh = refresh
+}
+
 // WriteToRequest writes these params to a swagger request
 func (o *ListHetznerSizesNoCredentialsV2Params) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
 
@@ -138,11 +165,35 @@ func (o *ListHetznerSizesNoCredentialsV2Params) WriteToRequest(r runtime.ClientR
 		return err
 	}
 
+	valuesFeatures := o.Features
+
+	joinedFeatures := swag.JoinByFormat(valuesFeatures, "")
+	// query array param features
+	if err := r.SetQueryParam("features", joinedFeatures...); err != nil {
+		return err
+	}
+
 	// path param project_id
 	if err := r.SetPathParam("project_id", o.ProjectID); err != nil {
 		return err
 	}
 
+	if o.Refresh != nil {
+
+		// query param refresh
+		var qrRefresh bool
+		if o.Refresh != nil {
+			qrRefresh = *o.Refresh
+		}
+		qRefresh := swag.FormatBool(qrRefresh)
+		if qRefresh != "" {
+			if err := r.SetQueryParam("refresh", qRefresh); err != nil {
+				return err
+			}
+		}
+
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
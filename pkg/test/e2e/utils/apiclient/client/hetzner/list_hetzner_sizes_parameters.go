@@ -14,6 +14,7 @@ import (
 	"github.com/go-openapi/runtime"
 	cr "github.com/go-openapi/runtime/client"
 	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
 )
 
 // NewListHetznerSizesParams creates a new ListHetznerSizesParams object
@@ -62,8 +63,12 @@ type ListHetznerSizesParams struct {
 
 	/*Credential*/
 	Credential *string
+	/*Features*/
+	Features []string
 	/*HetznerToken*/
 	HetznerToken *string
+	/*Refresh*/
+	Refresh *bool
 
 	timeout    time.Duration
 	Context    context.Context
@@ -114,6 +119,17 @@ func (o *ListHetznerSizesParams) SetCredential(credential *string) {
 	o.Credential = credential
 }
 
+// WithFeatures adds the features to the list hetzner sizes params
+func (o *ListHetznerSizesParams) WithFeatures(features []string) *ListHetznerSizesParams {
+	o.SetFeatures(features)
+	return o
+}
+
+// SetFeatures adds the features to the list hetzner sizes params
+func (o *ListHetznerSizesParams) SetFeatures(features []string) {
+	o.Features = features
+}
+
 // WithHetznerToken adds the hetznerToken to the list hetzner sizes params
 func (o *ListHetznerSizesParams) WithHetznerToken(hetznerToken *string) *ListHetznerSizesParams {
 	o.SetHetznerToken(hetznerToken)
@@ -125,6 +141,17 @@ func (o *ListHetznerSizesParams) SetHetznerToken(hetznerToken *string) {
 	o.HetznerToken = hetznerToken
 }
 
+// WithRefresh adds the refresh to the list hetzner sizes params
+func (o *ListHetznerSizesParams) WithRefresh(refresh *bool) *ListHetznerSizesParams {
+	o.SetRefresh(refresh)
+	return o
+}
+
+// SetRefresh adds the refresh to the list hetzner sizes params
+func (o *ListHetznerSizesParams) SetRefresh(refresh *bool) {
+	o.Refresh = refresh
+}
+
 // WriteToRequest writes these params to a swagger request
 func (o *ListHetznerSizesParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
 
@@ -142,6 +169,14 @@ func (o *ListHetznerSizesParams) WriteToRequest(r runtime.ClientRequest, reg str
 
 	}
 
+	valuesFeatures := o.Features
+
+	joinedFeatures := swag.JoinByFormat(valuesFeatures, "")
+	// query array param features
+	if err := r.SetQueryParam("features", joinedFeatures...); err != nil {
+		return err
+	}
+
 	if o.HetznerToken != nil {
 
 		// header param HetznerToken
@@ -151,6 +186,22 @@ func (o *ListHetznerSizesParams) WriteToRequest(r runtime.ClientRequest, reg str
 
 	}
 
+	if o.Refresh != nil {
+
+		// query param refresh
+		var qrRefresh bool
+		if o.Refresh != nil {
+			qrRefresh = *o.Refresh
+		}
+		qRefresh := swag.FormatBool(qrRefresh)
+		if qRefresh != "" {
+			if err := r.SetQueryParam("refresh", qRefresh); err != nil {
+				return err
+			}
+		}
+
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
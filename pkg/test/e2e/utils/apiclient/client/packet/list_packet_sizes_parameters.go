@@ -64,6 +64,8 @@ type ListPacketSizesParams struct {
 	APIKey *string
 	/*Credential*/
 	Credential *string
+	/*Facility*/
+	Facility *string
 	/*ProjectID*/
 	ProjectID *string
 
@@ -127,6 +129,17 @@ func (o *ListPacketSizesParams) SetCredential(credential *string) {
 	o.Credential = credential
 }
 
+// WithFacility adds the facility to the list packet sizes params
+func (o *ListPacketSizesParams) WithFacility(facility *string) *ListPacketSizesParams {
+	o.SetFacility(facility)
+	return o
+}
+
+// SetFacility adds the facility to the list packet sizes params
+func (o *ListPacketSizesParams) SetFacility(facility *string) {
+	o.Facility = facility
+}
+
 // WithProjectID adds the projectID to the list packet sizes params
 func (o *ListPacketSizesParams) WithProjectID(projectID *string) *ListPacketSizesParams {
 	o.SetProjectID(projectID)
@@ -164,6 +177,15 @@ func (o *ListPacketSizesParams) WriteToRequest(r runtime.ClientRequest, reg strf
 
 	}
 
+	if o.Facility != nil {
+
+		// header param facility
+		if err := r.SetHeaderParam("facility", *o.Facility); err != nil {
+			return err
+		}
+
+	}
+
 	if o.ProjectID != nil {
 
 		// header param projectID
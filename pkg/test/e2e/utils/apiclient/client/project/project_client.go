@@ -149,6 +149,8 @@ type ClientService interface {
 
 	ListClusterRoleV2(params *ListClusterRoleV2Params, authInfo runtime.ClientAuthInfoWriter) (*ListClusterRoleV2OK, error)
 
+	ListClusterStatusesV2(params *ListClusterStatusesV2Params, authInfo runtime.ClientAuthInfoWriter) (*ListClusterStatusesV2OK, *ListClusterStatusesV2NotModified, error)
+
 	ListClusters(params *ListClustersParams, authInfo runtime.ClientAuthInfoWriter) (*ListClustersOK, error)
 
 	ListClustersForProject(params *ListClustersForProjectParams, authInfo runtime.ClientAuthInfoWriter) (*ListClustersForProjectOK, error)
@@ -219,6 +221,8 @@ type ClientService interface {
 
 	PatchRole(params *PatchRoleParams, authInfo runtime.ClientAuthInfoWriter) (*PatchRoleOK, error)
 
+	ReconcileCluster(params *ReconcileClusterParams, authInfo runtime.ClientAuthInfoWriter) (*ReconcileClusterOK, error)
+
 	RevokeClusterAdminToken(params *RevokeClusterAdminTokenParams, authInfo runtime.ClientAuthInfoWriter) (*RevokeClusterAdminTokenOK, error)
 
 	RevokeClusterAdminTokenV2(params *RevokeClusterAdminTokenV2Params, authInfo runtime.ClientAuthInfoWriter) (*RevokeClusterAdminTokenV2OK, error)
@@ -2358,6 +2362,43 @@ func (a *Client) ListClusterRoleV2(params *ListClusterRoleV2Params, authInfo run
 	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
 }
 
+/*
+  ListClusterStatusesV2 Lists the status summaries of all clusters of the project. The response carries an ETag,
+sending it in the If-None-Match header returns 304 Not Modified as long as no cluster changed.
+*/
+func (a *Client) ListClusterStatusesV2(params *ListClusterStatusesV2Params, authInfo runtime.ClientAuthInfoWriter) (*ListClusterStatusesV2OK, *ListClusterStatusesV2NotModified, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewListClusterStatusesV2Params()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "listClusterStatusesV2",
+		Method:             "GET",
+		PathPattern:        "/api/v2/projects/{project_id}/clusters/status",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &ListClusterStatusesV2Reader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	switch value := result.(type) {
+	case *ListClusterStatusesV2OK:
+		return value, nil, nil
+	case *ListClusterStatusesV2NotModified:
+		return nil, value, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*ListClusterStatusesV2Default)
+	return nil, nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
 /*
   ListClusters lists clusters for the specified project and data center
 */
@@ -3558,6 +3599,40 @@ func (a *Client) PatchRole(params *PatchRoleParams, authInfo runtime.ClientAuthI
 	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
 }
 
+/*
+  ReconcileCluster Makes the controllers reconcile the cluster right away, optionally clearing the error of the cluster to retry it
+*/
+func (a *Client) ReconcileCluster(params *ReconcileClusterParams, authInfo runtime.ClientAuthInfoWriter) (*ReconcileClusterOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewReconcileClusterParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "reconcileCluster",
+		Method:             "POST",
+		PathPattern:        "/api/v1/projects/{project_id}/dc/{dc}/clusters/{cluster_id}/reconcile",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &ReconcileClusterReader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*ReconcileClusterOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*ReconcileClusterDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
 /*
   RevokeClusterAdminToken Revokes the current admin token
 */
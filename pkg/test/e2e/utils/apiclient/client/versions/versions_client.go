@@ -27,6 +27,8 @@ type Client struct {
 type ClientService interface {
 	GetKubermaticVersion(params *GetKubermaticVersionParams, authInfo runtime.ClientAuthInfoWriter) (*GetKubermaticVersionOK, error)
 
+	GetMasterVersionOverview(params *GetMasterVersionOverviewParams, authInfo runtime.ClientAuthInfoWriter) (*GetMasterVersionOverviewOK, error)
+
 	GetMasterVersions(params *GetMasterVersionsParams, authInfo runtime.ClientAuthInfoWriter) (*GetMasterVersionsOK, error)
 
 	GetNodeUpgrades(params *GetNodeUpgradesParams, authInfo runtime.ClientAuthInfoWriter) (*GetNodeUpgradesOK, error)
@@ -68,6 +70,40 @@ func (a *Client) GetKubermaticVersion(params *GetKubermaticVersionParams, authIn
 	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
 }
 
+/*
+  GetMasterVersionOverview Lists all versions which don't result in automatic updates together with the updates allowed between them
+*/
+func (a *Client) GetMasterVersionOverview(params *GetMasterVersionOverviewParams, authInfo runtime.ClientAuthInfoWriter) (*GetMasterVersionOverviewOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewGetMasterVersionOverviewParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "getMasterVersionOverview",
+		Method:             "GET",
+		PathPattern:        "/api/v1/upgrades/cluster/overview",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &GetMasterVersionOverviewReader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*GetMasterVersionOverviewOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*GetMasterVersionOverviewDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
 /*
   GetMasterVersions Lists all versions which don't result in automatic updates
 */
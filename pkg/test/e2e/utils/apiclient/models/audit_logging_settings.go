@@ -17,6 +17,14 @@ type AuditLoggingSettings struct {
 
 	// enabled
 	Enabled bool `json:"enabled,omitempty"`
+
+	// Policy is an inline audit policy for the apiserver. If neither a policy nor a
+	// policy ConfigMap is set, the metadata of all requests is logged.
+	Policy string `json:"policy,omitempty"`
+
+	// PolicyConfigMapName is the name of a ConfigMap in the cluster namespace which contains
+	// the audit policy in its "policy.yaml" key. It can not be set together with the inline policy.
+	PolicyConfigMapName string `json:"policyConfigMapName,omitempty"`
 }
 
 // Validate validates this audit logging settings
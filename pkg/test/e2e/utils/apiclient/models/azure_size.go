@@ -33,8 +33,15 @@ type AzureSize struct {
 	// os disk size in m b
 	OsDiskSizeInMB int32 `json:"osDiskSizeInMB,omitempty"`
 
+	// PremiumIO is set if the size supports premium storage disks.
+	PremiumIO bool `json:"premiumIO,omitempty"`
+
 	// resource disk size in m b
 	ResourceDiskSizeInMB int32 `json:"resourceDiskSizeInMB,omitempty"`
+
+	// RestrictedZones are the availability zones of the region the size can not be
+	// used in. Sizes which can not be used in the region at all are not listed.
+	RestrictedZones []string `json:"restrictedZones"`
 }
 
 // Validate validates this azure size
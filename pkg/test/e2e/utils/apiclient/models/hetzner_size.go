@@ -6,6 +6,9 @@ package models
 // Editing this file might prove futile when you re-run the swagger generate command
 
 import (
+	"strconv"
+
+	"github.com/go-openapi/errors"
 	"github.com/go-openapi/strfmt"
 	"github.com/go-openapi/swag"
 )
@@ -15,6 +18,9 @@ import (
 // swagger:model HetznerSize
 type HetznerSize struct {
 
+	// Architecture is the CPU architecture of the size, either "amd64" or "arm64".
+	Architecture string `json:"architecture,omitempty"`
+
 	// cores
 	Cores int64 `json:"cores,omitempty"`
 
@@ -24,6 +30,10 @@ type HetznerSize struct {
 	// disk
 	Disk int64 `json:"disk,omitempty"`
 
+	// Features lists the features supported by the size, out of "dedicated-cpu" and
+	// "local-storage".
+	Features []string `json:"features"`
+
 	// ID
 	ID int64 `json:"id,omitempty"`
 
@@ -32,10 +42,48 @@ type HetznerSize struct {
 
 	// name
 	Name string `json:"name,omitempty"`
+
+	// Prices contains the prices of the size per location. Locations without
+	// pricing information are omitted.
+	Prices []*HetznerSizePrice `json:"prices"`
 }
 
 // Validate validates this hetzner size
 func (m *HetznerSize) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validatePrices(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *HetznerSize) validatePrices(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Prices) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.Prices); i++ {
+		if swag.IsZero(m.Prices[i]) { // not required
+			continue
+		}
+
+		if m.Prices[i] != nil {
+			if err := m.Prices[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("prices" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
 	return nil
 }
 
@@ -18,6 +18,9 @@ import (
 // swagger:model HetznerSizeList
 type HetznerSizeList struct {
 
+	// a r m64
+	ARM64 []*HetznerSize `json:"arm64"`
+
 	// dedicated
 	Dedicated []*HetznerSize `json:"dedicated"`
 
@@ -29,6 +32,10 @@ type HetznerSizeList struct {
 func (m *HetznerSizeList) Validate(formats strfmt.Registry) error {
 	var res []error
 
+	if err := m.validateARM64(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateDedicated(formats); err != nil {
 		res = append(res, err)
 	}
@@ -43,6 +50,31 @@ func (m *HetznerSizeList) Validate(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *HetznerSizeList) validateARM64(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.ARM64) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.ARM64); i++ {
+		if swag.IsZero(m.ARM64[i]) { // not required
+			continue
+		}
+
+		if m.ARM64[i] != nil {
+			if err := m.ARM64[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("arm64" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
 func (m *HetznerSizeList) validateDedicated(formats strfmt.Registry) error {
 
 	if swag.IsZero(m.Dedicated) { // not required
@@ -18,6 +18,9 @@ type MasterVersion struct {
 	// default
 	Default bool `json:"default,omitempty"`
 
+	// If true, the version is still available, but should not be chosen for new clusters.
+	Deprecated bool `json:"deprecated,omitempty"`
+
 	// If true, then given version control plane version is not compatible
 	// with one of the kubelets inside cluster and shouldn't be used.
 	RestrictedByKubeletVersion bool `json:"restrictedByKubeletVersion,omitempty"`
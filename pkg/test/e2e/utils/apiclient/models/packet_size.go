@@ -18,6 +18,10 @@ import (
 // swagger:model PacketSize
 type PacketSize struct {
 
+	// Available is false if the size is sold out in all facilities of the datacenter.
+	// Sizes listed without facilities are always available.
+	Available bool `json:"available,omitempty"`
+
 	// c p us
 	CPUs []*PacketCPU `json:"cpus"`
 
@@ -29,6 +33,9 @@ type PacketSize struct {
 
 	// name
 	Name string `json:"name,omitempty"`
+
+	// price
+	Price *PacketSizePrice `json:"price,omitempty"`
 }
 
 // Validate validates this packet size
@@ -43,6 +50,10 @@ func (m *PacketSize) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validatePrice(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
@@ -99,6 +110,24 @@ func (m *PacketSize) validateDrives(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *PacketSize) validatePrice(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Price) { // not required
+		return nil
+	}
+
+	if m.Price != nil {
+		if err := m.Price.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("price")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 // MarshalBinary interface implementation
 func (m *PacketSize) MarshalBinary() ([]byte, error) {
 	if m == nil {
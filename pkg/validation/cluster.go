@@ -21,6 +21,11 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+	"time"
 
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
 	kuberneteshelper "k8c.io/kubermatic/v2/pkg/kubernetes"
@@ -28,10 +33,17 @@ import (
 	"k8c.io/kubermatic/v2/pkg/provider/cloud"
 	kubernetesprovider "k8c.io/kubermatic/v2/pkg/provider/kubernetes"
 	"k8c.io/kubermatic/v2/pkg/resources"
+	"k8c.io/kubermatic/v2/pkg/resources/certificates/triple"
 
 	"github.com/coreos/locksmith/pkg/timeutil"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	utilerror "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	kubevalidation "k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/yaml"
 )
 
 var (
@@ -61,6 +73,38 @@ func ValidateCreateClusterSpec(spec *kubermaticv1.ClusterSpec, dc *kubermaticv1.
 		return fmt.Errorf("machine network validation failed, see: %v", err)
 	}
 
+	if err := ValidateRootCASettings(spec.RootCA); err != nil {
+		return fmt.Errorf("invalid root CA settings: %v", err)
+	}
+
+	if err := ValidateCertificateKeyAlgorithm(spec.ComponentsOverride.Apiserver.CertificateKeyAlgorithm); err != nil {
+		return fmt.Errorf("invalid apiserver certificate settings: %v", err)
+	}
+
+	if err := ValidateCNIPlugin(spec.ClusterNetwork.CNIPlugin); err != nil {
+		return fmt.Errorf("invalid cluster network settings: %v", err)
+	}
+
+	if err := ValidateClusterNetwork(spec.ClusterNetwork); err != nil {
+		return fmt.Errorf("invalid cluster network settings: %v", err)
+	}
+
+	if err := ValidateNetworkOverlap(spec.ClusterNetwork, dc.Spec.ReservedCIDRBlocks); err != nil {
+		return fmt.Errorf("invalid cluster network settings: %v", err)
+	}
+
+	if err := ValidateEtcdClusterSize(spec.ComponentsOverride.Etcd.ClusterSize); err != nil {
+		return fmt.Errorf("invalid etcd settings: %v", err)
+	}
+
+	if err := ValidateImageTag(spec.ComponentsOverride.Etcd.ImageTag); err != nil {
+		return fmt.Errorf("invalid etcd settings: %v", err)
+	}
+
+	if err := ValidateEtcdDiskSize(spec.ComponentsOverride.Etcd.DiskSize); err != nil {
+		return fmt.Errorf("invalid etcd settings: %v", err)
+	}
+
 	return nil
 }
 
@@ -505,3 +549,541 @@ func ValidateLeaderElectionSettings(l kubermaticv1.LeaderElectionSettings) error
 	}
 	return nil
 }
+
+var (
+	supportedRSAKeySizes   = sets.NewInt(2048, 3072, 4096)
+	supportedECDSAKeySizes = sets.NewInt(256, 384)
+)
+
+// ValidateKeySettings validates the algorithm and size of a private key. Empty values
+// are valid, as the defaults are used then.
+func ValidateKeySettings(algorithm kubermaticv1.KeyAlgorithm, size int) error {
+	var supportedSizes sets.Int
+	switch algorithm {
+	case "", kubermaticv1.KeyAlgorithmRSA:
+		supportedSizes = supportedRSAKeySizes
+	case kubermaticv1.KeyAlgorithmECDSA:
+		supportedSizes = supportedECDSAKeySizes
+	default:
+		return fmt.Errorf("unsupported key algorithm %q, must be one of %q or %q", algorithm, kubermaticv1.KeyAlgorithmRSA, kubermaticv1.KeyAlgorithmECDSA)
+	}
+
+	if size != 0 && !supportedSizes.Has(size) {
+		return fmt.Errorf("unsupported key size %d for algorithm %q, must be one of %v", size, algorithm, supportedSizes.List())
+	}
+
+	return nil
+}
+
+// ValidateRootCASettings validates the key and expiry settings of the cluster root CA
+func ValidateRootCASettings(s *kubermaticv1.RootCASettings) error {
+	if s == nil {
+		return nil
+	}
+
+	if err := ValidateKeySettings(s.KeyAlgorithm, s.KeySize); err != nil {
+		return err
+	}
+
+	expiry := triple.DefaultCAValidity
+	if s.Expiry != "" {
+		var err error
+		expiry, err = time.ParseDuration(s.Expiry)
+		if err != nil {
+			return fmt.Errorf("invalid expiry %q: %v", s.Expiry, err)
+		}
+		if expiry <= 0 {
+			return fmt.Errorf("expiry must be positive, got %q", s.Expiry)
+		}
+	}
+
+	if s.RotateBefore != "" {
+		rotateBefore, err := time.ParseDuration(s.RotateBefore)
+		if err != nil {
+			return fmt.Errorf("invalid rotation threshold %q: %v", s.RotateBefore, err)
+		}
+		if rotateBefore <= 0 {
+			return fmt.Errorf("rotation threshold must be positive, got %q", s.RotateBefore)
+		}
+		// a new CA would immediately be due for rotation again
+		if rotateBefore >= expiry {
+			return fmt.Errorf("rotation threshold %v must be shorter than the expiry %v", rotateBefore, expiry)
+		}
+	}
+
+	return nil
+}
+
+// ValidateCertificateKeyAlgorithm validates the key algorithm used for the apiserver certificates
+func ValidateCertificateKeyAlgorithm(algorithm kubermaticv1.KeyAlgorithm) error {
+	switch algorithm {
+	case "", kubermaticv1.KeyAlgorithmRSA, kubermaticv1.KeyAlgorithmECDSA:
+		return nil
+	default:
+		return fmt.Errorf("unsupported key algorithm %q, must be one of %q or %q", algorithm, kubermaticv1.KeyAlgorithmRSA, kubermaticv1.KeyAlgorithmECDSA)
+	}
+}
+
+// ValidateCertSANs validates the additional subject alternative names of the apiserver serving certificate
+func ValidateCertSANs(sans []string) error {
+	for _, san := range sans {
+		if net.ParseIP(san) != nil {
+			continue
+		}
+		if errs := kubevalidation.IsDNS1123Subdomain(strings.TrimPrefix(san, "*.")); len(errs) > 0 {
+			return fmt.Errorf("%q is neither an IP address nor a valid DNS name: %s", san, strings.Join(errs, ", "))
+		}
+	}
+	return nil
+}
+
+// ValidateAPIServerServiceSettings validates the settings of the service which exposes the apiserver
+func ValidateAPIServerServiceSettings(settings *kubermaticv1.APIServerServiceSettings) error {
+	if settings == nil {
+		return nil
+	}
+	if settings.Type != "" && settings.Type != corev1.ServiceTypeNodePort && settings.Type != corev1.ServiceTypeLoadBalancer {
+		return fmt.Errorf("unsupported service type %q, must be one of %s, %s", settings.Type, corev1.ServiceTypeNodePort, corev1.ServiceTypeLoadBalancer)
+	}
+	for key := range settings.Annotations {
+		if errs := kubevalidation.IsQualifiedName(key); len(errs) > 0 {
+			return fmt.Errorf("invalid annotation %q: %s", key, strings.Join(errs, ", "))
+		}
+	}
+	if settings.NodePort != 0 {
+		if errs := kubevalidation.IsValidPortNum(int(settings.NodePort)); len(errs) > 0 {
+			return fmt.Errorf("invalid NodePort %d: %s", settings.NodePort, strings.Join(errs, ", "))
+		}
+	}
+	return nil
+}
+
+// ValidateAPIServerExtraVolumes validates the secrets and config maps which are mounted into the
+// apiserver. Whether the mount paths overlap with the paths managed by Kubermatic is only checked
+// when the apiserver is rendered.
+func ValidateAPIServerExtraVolumes(volumes []kubermaticv1.APIServerExtraVolume) error {
+	names := sets.NewString()
+	mountPaths := sets.NewString()
+
+	for _, volume := range volumes {
+		if errs := kubevalidation.IsDNS1123Label(volume.Name); len(errs) > 0 {
+			return fmt.Errorf("invalid volume name %q: %s", volume.Name, strings.Join(errs, ", "))
+		}
+		if names.Has(volume.Name) {
+			return fmt.Errorf("duplicate volume name %q", volume.Name)
+		}
+		names.Insert(volume.Name)
+
+		if !path.IsAbs(volume.MountPath) || path.Clean(volume.MountPath) == "/" {
+			return fmt.Errorf("mount path %q of volume %q must be an absolute path below /", volume.MountPath, volume.Name)
+		}
+		if mountPaths.Has(path.Clean(volume.MountPath)) {
+			return fmt.Errorf("duplicate mount path %q", volume.MountPath)
+		}
+		mountPaths.Insert(path.Clean(volume.MountPath))
+
+		if (volume.Secret == "") == (volume.ConfigMap == "") {
+			return fmt.Errorf("exactly one of secret and config map must be set for volume %q", volume.Name)
+		}
+		for _, objectName := range []string{volume.Secret, volume.ConfigMap} {
+			if objectName == "" {
+				continue
+			}
+			if errs := kubevalidation.IsDNS1123Subdomain(objectName); len(errs) > 0 {
+				return fmt.Errorf("invalid object name %q for volume %q: %s", objectName, volume.Name, strings.Join(errs, ", "))
+			}
+		}
+	}
+
+	return nil
+}
+
+// ValidateOIDCSettings validates the OIDC settings of the cluster apiserver
+func ValidateOIDCSettings(settings kubermaticv1.OIDCSettings) error {
+	if settings.IssuerURL == "" {
+		return nil
+	}
+
+	issuerURL, err := url.Parse(settings.IssuerURL)
+	if err != nil {
+		return fmt.Errorf("invalid issuer URL %q: %v", settings.IssuerURL, err)
+	}
+	if issuerURL.Scheme != "https" || issuerURL.Host == "" {
+		return fmt.Errorf("issuer URL %q must be a https URL", settings.IssuerURL)
+	}
+	if settings.ClientID == "" {
+		return errors.New("client ID must be set together with the issuer URL")
+	}
+
+	return nil
+}
+
+// ValidateAuditLoggingSettings validates the audit policy configured for the cluster apiserver
+func ValidateAuditLoggingSettings(settings *kubermaticv1.AuditLoggingSettings) error {
+	if settings == nil || settings.Policy == "" {
+		return nil
+	}
+	if settings.PolicyConfigMapName != "" {
+		return errors.New("inline policy and policy ConfigMap can not be set together")
+	}
+
+	policy := &metav1.TypeMeta{}
+	if err := yaml.Unmarshal([]byte(settings.Policy), policy); err != nil {
+		return fmt.Errorf("policy is not valid YAML: %v", err)
+	}
+	if policy.Kind != "Policy" || !strings.HasPrefix(policy.APIVersion, "audit.k8s.io/") {
+		return fmt.Errorf("policy must be an audit.k8s.io Policy, got %s %s", policy.APIVersion, policy.Kind)
+	}
+
+	return nil
+}
+
+// ValidateSchedulerConfiguration validates the configuration of the cluster scheduler
+func ValidateSchedulerConfiguration(config string) error {
+	if config == "" {
+		return nil
+	}
+
+	typeMeta := &metav1.TypeMeta{}
+	if err := yaml.Unmarshal([]byte(config), typeMeta); err != nil {
+		return fmt.Errorf("configuration is not valid YAML: %v", err)
+	}
+	if typeMeta.Kind != "KubeSchedulerConfiguration" || !strings.HasPrefix(typeMeta.APIVersion, "kubescheduler.config.k8s.io/") {
+		return fmt.Errorf("configuration must be a kubescheduler.config.k8s.io KubeSchedulerConfiguration, got %s %s", typeMeta.APIVersion, typeMeta.Kind)
+	}
+
+	return nil
+}
+
+// ValidateEncryptionConfiguration validates the encryption of secrets at rest
+func ValidateEncryptionConfiguration(config *kubermaticv1.EncryptionConfiguration) error {
+	if config == nil {
+		return nil
+	}
+	if config.KeyRotation < 0 {
+		return fmt.Errorf("key rotation must not be negative, got %d", config.KeyRotation)
+	}
+	if ref := config.KeySecretRef; ref != nil && (ref.Name == "" || ref.Namespace == "" || ref.Key == "") {
+		return errors.New("key secret reference must contain name, namespace and key")
+	}
+	return nil
+}
+
+// ValidateCNIPlugin validates the CNI plugin selected for a cluster
+func ValidateCNIPlugin(plugin kubermaticv1.CNIPluginType) error {
+	switch plugin {
+	case "", kubermaticv1.CNIPluginTypeCanal, kubermaticv1.CNIPluginTypeCilium:
+		return nil
+	default:
+		return fmt.Errorf("unsupported CNI plugin %q, must be one of %q or %q", plugin, kubermaticv1.CNIPluginTypeCanal, kubermaticv1.CNIPluginTypeCilium)
+	}
+}
+
+// ValidateClusterNetwork validates the IP families of the cluster network and the pod and service
+// CIDR blocks, which must be given in the order of the families. Only IPv4 single-stack and IPv4/IPv6
+// dual-stack networks are supported, as the control plane expects the first blocks to be IPv4.
+// Empty CIDR blocks are valid, as the defaults are used then.
+func ValidateClusterNetwork(network kubermaticv1.ClusterNetworkingConfig) error {
+	families := network.IPFamilies
+	if len(families) == 0 {
+		families = []kubermaticv1.IPFamily{kubermaticv1.IPFamilyIPv4}
+	}
+
+	switch {
+	case len(families) == 1 && families[0] == kubermaticv1.IPFamilyIPv4:
+	case len(families) == 2 && families[0] == kubermaticv1.IPFamilyIPv4 && families[1] == kubermaticv1.IPFamilyIPv6:
+		if network.CNIPlugin != kubermaticv1.CNIPluginTypeCilium {
+			return fmt.Errorf("dual-stack networking requires the %q CNI plugin", kubermaticv1.CNIPluginTypeCilium)
+		}
+	default:
+		return fmt.Errorf("unsupported IP families %v, must be [%s] or [%s %s]", families, kubermaticv1.IPFamilyIPv4, kubermaticv1.IPFamilyIPv4, kubermaticv1.IPFamilyIPv6)
+	}
+
+	if err := validateNetworkRanges(network.Pods, families); err != nil {
+		return fmt.Errorf("invalid pod network: %v", err)
+	}
+	if err := validateNetworkRanges(network.Services, families); err != nil {
+		return fmt.Errorf("invalid service network: %v", err)
+	}
+	return nil
+}
+
+func validateNetworkRanges(ranges kubermaticv1.NetworkRanges, families []kubermaticv1.IPFamily) error {
+	if len(ranges.CIDRBlocks) == 0 {
+		return nil
+	}
+	if len(families) > 1 && len(ranges.CIDRBlocks) != len(families) {
+		return fmt.Errorf("expected one CIDR block per IP family %v, got %v", families, ranges.CIDRBlocks)
+	}
+
+	for i, block := range ranges.CIDRBlocks {
+		_, ipnet, err := net.ParseCIDR(block)
+		if err != nil {
+			return fmt.Errorf("couldn't parse cidr %q: %v", block, err)
+		}
+		if i >= len(families) {
+			continue
+		}
+
+		family := kubermaticv1.IPFamilyIPv4
+		if ipnet.IP.To4() == nil {
+			family = kubermaticv1.IPFamilyIPv6
+		}
+		if family != families[i] {
+			return fmt.Errorf("cidr %q is not in the %s family, the CIDR blocks must be in the order of the IP families %v", block, families[i], families)
+		}
+	}
+	return nil
+}
+
+// ValidateCIDRBlocks validates that all given CIDR blocks can be parsed.
+func ValidateCIDRBlocks(blocks []string) error {
+	for _, block := range blocks {
+		if _, _, err := net.ParseCIDR(block); err != nil {
+			return fmt.Errorf("couldn't parse cidr %q: %v", block, err)
+		}
+	}
+	return nil
+}
+
+// ValidateNetworkOverlap validates that the pod and service networks of a cluster neither overlap
+// each other nor any of the reserved CIDR blocks.
+func ValidateNetworkOverlap(network kubermaticv1.ClusterNetworkingConfig, reservedCIDRBlocks []string) error {
+	if err := validateCIDRBlocksDisjoint(network.Pods.CIDRBlocks, network.Services.CIDRBlocks); err != nil {
+		return fmt.Errorf("pod and service networks overlap: %v", err)
+	}
+	if err := validateCIDRBlocksDisjoint(network.Pods.CIDRBlocks, reservedCIDRBlocks); err != nil {
+		return fmt.Errorf("pod network overlaps a reserved network: %v", err)
+	}
+	if err := validateCIDRBlocksDisjoint(network.Services.CIDRBlocks, reservedCIDRBlocks); err != nil {
+		return fmt.Errorf("service network overlaps a reserved network: %v", err)
+	}
+	return nil
+}
+
+func validateCIDRBlocksDisjoint(blocks, others []string) error {
+	for _, block := range blocks {
+		_, ipnet, err := net.ParseCIDR(block)
+		if err != nil {
+			return fmt.Errorf("couldn't parse cidr %q: %v", block, err)
+		}
+		for _, other := range others {
+			_, otherNet, err := net.ParseCIDR(other)
+			if err != nil {
+				return fmt.Errorf("couldn't parse cidr %q: %v", other, err)
+			}
+			// two CIDR blocks overlap if and only if one contains the network address of the other
+			if ipnet.Contains(otherNet.IP) || otherNet.Contains(ipnet.IP) {
+				return fmt.Errorf("%q overlaps %q", block, other)
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateDefaultAddons validates the default addons a cluster is restricted to. All names must refer
+// to an available default addon and the addon of the CNI plugin must be included, as the cluster would
+// lose its pod network otherwise. The available addons are named as configured for the seed, with the
+// canal addon standing in for the selected CNI plugin. If availableAddons is nil, the names are not
+// checked against the configured addons.
+func ValidateDefaultAddons(defaultAddons []string, plugin kubermaticv1.CNIPluginType, availableAddons sets.String) error {
+	if len(defaultAddons) == 0 {
+		return nil
+	}
+	if err := validateAddonNames(defaultAddons, plugin, availableAddons); err != nil {
+		return err
+	}
+
+	cniAddon := string(cniPluginOrDefault(plugin))
+	if availableAddons != nil && !availableAddons.Has(string(kubermaticv1.CNIPluginTypeCanal)) {
+		// the CNI is not installed as an addon
+		return nil
+	}
+	if !sets.NewString(defaultAddons...).Has(cniAddon) {
+		return fmt.Errorf("the addon %q of the CNI plugin must be part of the default addons", cniAddon)
+	}
+	return nil
+}
+
+// ValidateDisabledAddons validates the default addons disabled for a cluster. The addon of the
+// CNI plugin cannot be disabled, as the cluster would lose its pod network. Disabled addons and
+// a restricted set of default addons are mutually exclusive.
+func ValidateDisabledAddons(disabledAddons, defaultAddons []string, plugin kubermaticv1.CNIPluginType, availableAddons sets.String) error {
+	if len(disabledAddons) == 0 {
+		return nil
+	}
+	if len(defaultAddons) > 0 {
+		return errors.New("disabled addons cannot be combined with default addons, leave the addon out of the default addons instead")
+	}
+	if err := validateAddonNames(disabledAddons, plugin, availableAddons); err != nil {
+		return err
+	}
+
+	cniAddon := string(cniPluginOrDefault(plugin))
+	for _, name := range disabledAddons {
+		if name == cniAddon {
+			return fmt.Errorf("the addon %q of the CNI plugin cannot be disabled", name)
+		}
+	}
+	return nil
+}
+
+// validateAddonNames checks that all names refer to an available default addon.
+func validateAddonNames(names []string, plugin kubermaticv1.CNIPluginType, availableAddons sets.String) error {
+	if availableAddons == nil {
+		return nil
+	}
+	cniAddon := string(cniPluginOrDefault(plugin))
+	for _, name := range names {
+		if name == cniAddon && availableAddons.Has(string(kubermaticv1.CNIPluginTypeCanal)) {
+			continue
+		}
+		if name == string(kubermaticv1.CNIPluginTypeCanal) || !availableAddons.Has(name) {
+			return fmt.Errorf("unknown default addon %q", name)
+		}
+	}
+	return nil
+}
+
+func cniPluginOrDefault(plugin kubermaticv1.CNIPluginType) kubermaticv1.CNIPluginType {
+	if plugin == "" {
+		return kubermaticv1.CNIPluginTypeCanal
+	}
+	return plugin
+}
+
+// ValidateEtcdClusterSize validates the number of etcd members. An empty size is
+// valid, as the default size is used then.
+func ValidateEtcdClusterSize(size int) error {
+	if size == 0 {
+		return nil
+	}
+	if size < kubermaticv1.MinEtcdClusterSize || size > kubermaticv1.MaxEtcdClusterSize {
+		return fmt.Errorf("etcd cluster size must be between %d and %d, got %d", kubermaticv1.MinEtcdClusterSize, kubermaticv1.MaxEtcdClusterSize, size)
+	}
+	if size%2 == 0 {
+		return fmt.Errorf("etcd cluster size must be odd to tolerate member failures, got %d", size)
+	}
+	return nil
+}
+
+var (
+	// MinEtcdDiskSize is the smallest volume etcd can be run on.
+	MinEtcdDiskSize = resource.MustParse("1Gi")
+	// MaxEtcdDiskSize is the largest volume allowed for etcd. Its database is limited to a few
+	// gigabytes, so larger volumes would only be wasted.
+	MaxEtcdDiskSize = resource.MustParse("100Gi")
+)
+
+// ValidateEtcdDiskSize validates the size of the etcd volumes. An empty size is
+// valid, as the default size of the seed is used then.
+func ValidateEtcdDiskSize(size *resource.Quantity) error {
+	if size == nil {
+		return nil
+	}
+	if size.Cmp(MinEtcdDiskSize) < 0 || size.Cmp(MaxEtcdDiskSize) > 0 {
+		return fmt.Errorf("etcd disk size must be between %s and %s, got %s", MinEtcdDiskSize.String(), MaxEtcdDiskSize.String(), size.String())
+	}
+	return nil
+}
+
+// imageTagRegexp matches the tag grammar of container image references.
+var imageTagRegexp = regexp.MustCompile(`^[\w][\w.-]{0,127}$`)
+
+// ValidateImageTag validates an image tag override, an empty tag is valid as the default
+// tag is used then.
+func ValidateImageTag(tag string) error {
+	if tag == "" {
+		return nil
+	}
+	if !imageTagRegexp.MatchString(tag) {
+		return fmt.Errorf("invalid image tag %q", tag)
+	}
+	return nil
+}
+
+var (
+	// extraArgNameRegexp matches flag names without leading dashes.
+	extraArgNameRegexp = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+	// commonProtectedFlags are the flags of all control plane components which point to the
+	// certificates and kubeconfigs mounted by Kubermatic or to the ports probed by Kubermatic.
+	commonProtectedFlags = []string{
+		"kubeconfig",
+		"authentication-kubeconfig",
+		"authorization-kubeconfig",
+		"client-ca-file",
+		"tls-cert-file",
+		"tls-private-key-file",
+		"port",
+		"secure-port",
+		"bind-address",
+	}
+
+	// cloudProviderProtectedFlags are the flags rendered from the cloud spec of the cluster, which
+	// must not diverge from the cloud provider Kubermatic configures for the cluster.
+	cloudProviderProtectedFlags = []string{
+		"cloud-provider",
+		"cloud-config",
+	}
+
+	// ApiserverProtectedFlags are the apiserver flags which cannot be set as extra args.
+	// A trailing "*" protects all flags with the prefix.
+	ApiserverProtectedFlags = append([]string{
+		"etcd-*",
+		"service-account-*",
+		"kubelet-client-*",
+		"proxy-client-*",
+		"authorization-mode",
+		"anonymous-auth",
+		"advertise-address",
+		"insecure-port",
+		"token-auth-file",
+		"basic-auth-file",
+		"requestheader-client-ca-file",
+		"encryption-provider-config",
+		"service-cluster-ip-range",
+	}, append(commonProtectedFlags, cloudProviderProtectedFlags...)...)
+
+	// ControllerManagerProtectedFlags are the controller-manager flags which cannot be set as extra args.
+	ControllerManagerProtectedFlags = append([]string{
+		"service-account-private-key-file",
+		"root-ca-file",
+		"cluster-signing-*",
+		"cluster-cidr",
+		"use-service-account-credentials",
+	}, append(commonProtectedFlags, cloudProviderProtectedFlags...)...)
+
+	// SchedulerProtectedFlags are the scheduler flags which cannot be set as extra args.
+	SchedulerProtectedFlags = append([]string{
+		"config",
+	}, commonProtectedFlags...)
+)
+
+// ValidateExtraArgs validates the extra command line flags of a control plane component,
+// rejecting malformed names and the protected flags Kubermatic relies on.
+func ValidateExtraArgs(args map[string]string, protectedFlags []string) error {
+	for name := range args {
+		if !extraArgNameRegexp.MatchString(name) {
+			return fmt.Errorf("invalid flag name %q, it must be given without leading dashes", name)
+		}
+		for _, protected := range protectedFlags {
+			if protected == name || (strings.HasSuffix(protected, "*") && strings.HasPrefix(name, strings.TrimSuffix(protected, "*"))) {
+				return fmt.Errorf("flag %q is managed by Kubermatic and cannot be overridden", name)
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateProjectedServiceAccountTokenSettings validates the settings of a projected
+// service account token. Empty settings are valid, as the legacy token is used then.
+func ValidateProjectedServiceAccountTokenSettings(s *kubermaticv1.ProjectedServiceAccountTokenSettings) error {
+	if s == nil || s.ExpirationSeconds == nil {
+		return nil
+	}
+	if *s.ExpirationSeconds < kubermaticv1.MinProjectedServiceAccountTokenExpirationSeconds {
+		return fmt.Errorf("token expiration must be at least %d seconds, got %d", kubermaticv1.MinProjectedServiceAccountTokenExpirationSeconds, *s.ExpirationSeconds)
+	}
+	return nil
+}
@@ -23,6 +23,8 @@ import (
 	"testing"
 
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/utils/pointer"
 )
 
@@ -231,3 +233,851 @@ func TestValidateLeaderElectionSettings(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateRootCASettings(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings *kubermaticv1.RootCASettings
+		wantErr  bool
+	}{
+		{
+			name:     "no settings",
+			settings: nil,
+			wantErr:  false,
+		},
+		{
+			name:     "empty settings",
+			settings: &kubermaticv1.RootCASettings{},
+			wantErr:  false,
+		},
+		{
+			name: "4096 bit RSA key",
+			settings: &kubermaticv1.RootCASettings{
+				KeyAlgorithm: kubermaticv1.KeyAlgorithmRSA,
+				KeySize:      4096,
+			},
+			wantErr: false,
+		},
+		{
+			name: "key size without algorithm",
+			settings: &kubermaticv1.RootCASettings{
+				KeySize: 3072,
+			},
+			wantErr: false,
+		},
+		{
+			name: "ECDSA key with default size",
+			settings: &kubermaticv1.RootCASettings{
+				KeyAlgorithm: kubermaticv1.KeyAlgorithmECDSA,
+			},
+			wantErr: false,
+		},
+		{
+			name: "384 bit ECDSA key",
+			settings: &kubermaticv1.RootCASettings{
+				KeyAlgorithm: kubermaticv1.KeyAlgorithmECDSA,
+				KeySize:      384,
+			},
+			wantErr: false,
+		},
+		{
+			name: "unsupported RSA key size",
+			settings: &kubermaticv1.RootCASettings{
+				KeyAlgorithm: kubermaticv1.KeyAlgorithmRSA,
+				KeySize:      1024,
+			},
+			wantErr: true,
+		},
+		{
+			name: "RSA key size for ECDSA key",
+			settings: &kubermaticv1.RootCASettings{
+				KeyAlgorithm: kubermaticv1.KeyAlgorithmECDSA,
+				KeySize:      2048,
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown algorithm",
+			settings: &kubermaticv1.RootCASettings{
+				KeyAlgorithm: "dsa",
+			},
+			wantErr: true,
+		},
+		{
+			name: "two year expiry",
+			settings: &kubermaticv1.RootCASettings{
+				Expiry: "17520h",
+			},
+			wantErr: false,
+		},
+		{
+			name: "malformed expiry",
+			settings: &kubermaticv1.RootCASettings{
+				Expiry: "2y",
+			},
+			wantErr: true,
+		},
+		{
+			name: "zero expiry",
+			settings: &kubermaticv1.RootCASettings{
+				Expiry: "0s",
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative expiry",
+			settings: &kubermaticv1.RootCASettings{
+				Expiry: "-24h",
+			},
+			wantErr: true,
+		},
+		{
+			name: "rotation 30 days before expiry",
+			settings: &kubermaticv1.RootCASettings{
+				RotateBefore: "720h",
+			},
+			wantErr: false,
+		},
+		{
+			name: "rotation threshold longer than expiry",
+			settings: &kubermaticv1.RootCASettings{
+				Expiry:       "720h",
+				RotateBefore: "1440h",
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative rotation threshold",
+			settings: &kubermaticv1.RootCASettings{
+				RotateBefore: "-1h",
+			},
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateRootCASettings(test.settings)
+
+			if test.wantErr == (err == nil) {
+				t.Errorf("Want error: %t, but got: \"%v\"", test.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateEtcdClusterSize(t *testing.T) {
+	tests := []struct {
+		name    string
+		size    int
+		wantErr bool
+	}{
+		{
+			name:    "no size configured",
+			size:    0,
+			wantErr: false,
+		},
+		{
+			name:    "single member",
+			size:    1,
+			wantErr: false,
+		},
+		{
+			name:    "five members",
+			size:    5,
+			wantErr: false,
+		},
+		{
+			name:    "even number of members",
+			size:    4,
+			wantErr: true,
+		},
+		{
+			name:    "too many members",
+			size:    9,
+			wantErr: true,
+		},
+		{
+			name:    "negative size",
+			size:    -1,
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateEtcdClusterSize(test.size)
+
+			if test.wantErr == (err == nil) {
+				t.Errorf("Want error: %t, but got: \"%v\"", test.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateEtcdDiskSize(t *testing.T) {
+	tests := []struct {
+		name    string
+		size    *resource.Quantity
+		wantErr bool
+	}{
+		{
+			name:    "no size configured",
+			size:    nil,
+			wantErr: false,
+		},
+		{
+			name:    "size within bounds",
+			size:    resource.NewQuantity(20*1024*1024*1024, resource.BinarySI),
+			wantErr: false,
+		},
+		{
+			name:    "size too small",
+			size:    resource.NewQuantity(512*1024*1024, resource.BinarySI),
+			wantErr: true,
+		},
+		{
+			name:    "size too large",
+			size:    resource.NewQuantity(1024*1024*1024*1024, resource.BinarySI),
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateEtcdDiskSize(test.size)
+
+			if test.wantErr == (err == nil) {
+				t.Errorf("Want error: %t, but got: \"%v\"", test.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateImageTag(t *testing.T) {
+	tests := []struct {
+		name    string
+		tag     string
+		wantErr bool
+	}{
+		{
+			name:    "no tag configured",
+			tag:     "",
+			wantErr: false,
+		},
+		{
+			name:    "version tag",
+			tag:     "v3.4.14",
+			wantErr: false,
+		},
+		{
+			name:    "tag with suffix",
+			tag:     "3.4.14-0_patched",
+			wantErr: false,
+		},
+		{
+			name:    "tag with a digest",
+			tag:     "v3.4.14@sha256:abcdef",
+			wantErr: true,
+		},
+		{
+			name:    "tag starting with a dot",
+			tag:     ".v3",
+			wantErr: true,
+		},
+		{
+			name:    "too long tag",
+			tag:     strings.Repeat("a", 129),
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateImageTag(test.tag)
+
+			if test.wantErr == (err == nil) {
+				t.Errorf("Want error: %t, but got: \"%v\"", test.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateExtraArgs(t *testing.T) {
+	tests := []struct {
+		name           string
+		args           map[string]string
+		protectedFlags []string
+		wantErr        bool
+	}{
+		{
+			name:           "no extra args",
+			protectedFlags: ApiserverProtectedFlags,
+			wantErr:        false,
+		},
+		{
+			name:           "unprotected flags",
+			args:           map[string]string{"v": "4", "feature-gates": "EphemeralContainers=true"},
+			protectedFlags: ApiserverProtectedFlags,
+			wantErr:        false,
+		},
+		{
+			name:           "flag with leading dashes",
+			args:           map[string]string{"--v": "4"},
+			protectedFlags: SchedulerProtectedFlags,
+			wantErr:        true,
+		},
+		{
+			name:           "protected flag",
+			args:           map[string]string{"authorization-mode": "AlwaysAllow"},
+			protectedFlags: ApiserverProtectedFlags,
+			wantErr:        true,
+		},
+		{
+			name:           "flag with a protected prefix",
+			args:           map[string]string{"etcd-servers": "http://localhost:2379"},
+			protectedFlags: ApiserverProtectedFlags,
+			wantErr:        true,
+		},
+		{
+			name:           "common protected flag",
+			args:           map[string]string{"kubeconfig": "/tmp/kubeconfig"},
+			protectedFlags: ControllerManagerProtectedFlags,
+			wantErr:        true,
+		},
+		{
+			name:           "cloud provider flag",
+			args:           map[string]string{"cloud-provider": "aws"},
+			protectedFlags: ControllerManagerProtectedFlags,
+			wantErr:        true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateExtraArgs(test.args, test.protectedFlags)
+
+			if test.wantErr == (err == nil) {
+				t.Errorf("Want error: %t, but got: \"%v\"", test.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateAPIServerExtraVolumes(t *testing.T) {
+	tests := []struct {
+		name    string
+		volumes []kubermaticv1.APIServerExtraVolume
+		wantErr bool
+	}{
+		{
+			name:    "no extra volumes",
+			wantErr: false,
+		},
+		{
+			name: "secret and config map",
+			volumes: []kubermaticv1.APIServerExtraVolume{
+				{Name: "webhook", MountPath: "/etc/webhook", Secret: "webhook-kubeconfig"},
+				{Name: "policies", MountPath: "/etc/policies", ConfigMap: "policies"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid name",
+			volumes: []kubermaticv1.APIServerExtraVolume{
+				{Name: "Webhook", MountPath: "/etc/webhook", Secret: "webhook-kubeconfig"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "duplicate name",
+			volumes: []kubermaticv1.APIServerExtraVolume{
+				{Name: "webhook", MountPath: "/etc/webhook", Secret: "webhook-kubeconfig"},
+				{Name: "webhook", MountPath: "/etc/policies", ConfigMap: "policies"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "relative mount path",
+			volumes: []kubermaticv1.APIServerExtraVolume{
+				{Name: "webhook", MountPath: "etc/webhook", Secret: "webhook-kubeconfig"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "duplicate mount path",
+			volumes: []kubermaticv1.APIServerExtraVolume{
+				{Name: "webhook", MountPath: "/etc/webhook", Secret: "webhook-kubeconfig"},
+				{Name: "policies", MountPath: "/etc/webhook/", ConfigMap: "policies"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "secret and config map set",
+			volumes: []kubermaticv1.APIServerExtraVolume{
+				{Name: "webhook", MountPath: "/etc/webhook", Secret: "webhook-kubeconfig", ConfigMap: "policies"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "neither secret nor config map set",
+			volumes: []kubermaticv1.APIServerExtraVolume{
+				{Name: "webhook", MountPath: "/etc/webhook"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateAPIServerExtraVolumes(test.volumes)
+
+			if test.wantErr == (err == nil) {
+				t.Errorf("Want error: %t, but got: \"%v\"", test.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateClusterNetwork(t *testing.T) {
+	tests := []struct {
+		name    string
+		network kubermaticv1.ClusterNetworkingConfig
+		wantErr bool
+	}{
+		{
+			name:    "defaults",
+			network: kubermaticv1.ClusterNetworkingConfig{},
+			wantErr: false,
+		},
+		{
+			name: "IPv4 single-stack",
+			network: kubermaticv1.ClusterNetworkingConfig{
+				IPFamilies: []kubermaticv1.IPFamily{kubermaticv1.IPFamilyIPv4},
+				Pods:       kubermaticv1.NetworkRanges{CIDRBlocks: []string{"172.25.0.0/16"}},
+				Services:   kubermaticv1.NetworkRanges{CIDRBlocks: []string{"10.240.16.0/20"}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "dual-stack",
+			network: kubermaticv1.ClusterNetworkingConfig{
+				IPFamilies: []kubermaticv1.IPFamily{kubermaticv1.IPFamilyIPv4, kubermaticv1.IPFamilyIPv6},
+				Pods:       kubermaticv1.NetworkRanges{CIDRBlocks: []string{"172.25.0.0/16", "fd01::/48"}},
+				Services:   kubermaticv1.NetworkRanges{CIDRBlocks: []string{"10.240.16.0/20", "fd02::/120"}},
+				CNIPlugin:  kubermaticv1.CNIPluginTypeCilium,
+			},
+			wantErr: false,
+		},
+		{
+			name: "dual-stack with canal",
+			network: kubermaticv1.ClusterNetworkingConfig{
+				IPFamilies: []kubermaticv1.IPFamily{kubermaticv1.IPFamilyIPv4, kubermaticv1.IPFamilyIPv6},
+			},
+			wantErr: true,
+		},
+		{
+			name: "IPv6 before IPv4",
+			network: kubermaticv1.ClusterNetworkingConfig{
+				IPFamilies: []kubermaticv1.IPFamily{kubermaticv1.IPFamilyIPv6, kubermaticv1.IPFamilyIPv4},
+				CNIPlugin:  kubermaticv1.CNIPluginTypeCilium,
+			},
+			wantErr: true,
+		},
+		{
+			name: "CIDR blocks not in the order of the IP families",
+			network: kubermaticv1.ClusterNetworkingConfig{
+				IPFamilies: []kubermaticv1.IPFamily{kubermaticv1.IPFamilyIPv4, kubermaticv1.IPFamilyIPv6},
+				Pods:       kubermaticv1.NetworkRanges{CIDRBlocks: []string{"fd01::/48", "172.25.0.0/16"}},
+				CNIPlugin:  kubermaticv1.CNIPluginTypeCilium,
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing CIDR block of an IP family",
+			network: kubermaticv1.ClusterNetworkingConfig{
+				IPFamilies: []kubermaticv1.IPFamily{kubermaticv1.IPFamilyIPv4, kubermaticv1.IPFamilyIPv6},
+				Services:   kubermaticv1.NetworkRanges{CIDRBlocks: []string{"10.240.16.0/20"}},
+				CNIPlugin:  kubermaticv1.CNIPluginTypeCilium,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid CIDR block",
+			network: kubermaticv1.ClusterNetworkingConfig{
+				Pods: kubermaticv1.NetworkRanges{CIDRBlocks: []string{"172.25.0.0"}},
+			},
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateClusterNetwork(test.network)
+
+			if test.wantErr == (err == nil) {
+				t.Errorf("Want error: %t, but got: \"%v\"", test.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateNetworkOverlap(t *testing.T) {
+	tests := []struct {
+		name     string
+		pods     []string
+		services []string
+		reserved []string
+		wantErr  bool
+	}{
+		{
+			name:    "defaulted networks",
+			wantErr: false,
+		},
+		{
+			name:     "disjoint networks",
+			pods:     []string{"172.25.0.0/16"},
+			services: []string{"10.240.16.0/20"},
+			reserved: []string{"192.168.0.0/16", "10.0.0.0/16"},
+			wantErr:  false,
+		},
+		{
+			name:     "pod network contains the service network",
+			pods:     []string{"10.0.0.0/8"},
+			services: []string{"10.240.16.0/20"},
+			wantErr:  true,
+		},
+		{
+			name:     "service network contains a reserved network",
+			pods:     []string{"172.25.0.0/16"},
+			services: []string{"10.240.0.0/16"},
+			reserved: []string{"10.240.16.0/24"},
+			wantErr:  true,
+		},
+		{
+			name:     "reserved network contains the pod network",
+			pods:     []string{"172.25.0.0/16"},
+			services: []string{"10.240.16.0/20"},
+			reserved: []string{"172.16.0.0/12"},
+			wantErr:  true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			network := kubermaticv1.ClusterNetworkingConfig{
+				Pods:     kubermaticv1.NetworkRanges{CIDRBlocks: test.pods},
+				Services: kubermaticv1.NetworkRanges{CIDRBlocks: test.services},
+			}
+			err := ValidateNetworkOverlap(network, test.reserved)
+
+			if test.wantErr == (err == nil) {
+				t.Errorf("Want error: %t, but got: \"%v\"", test.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateDefaultAddons(t *testing.T) {
+	available := sets.NewString("canal", "dashboard", "openvpn")
+
+	tests := []struct {
+		name            string
+		defaultAddons   []string
+		cniPlugin       kubermaticv1.CNIPluginType
+		availableAddons sets.String
+		wantErr         bool
+	}{
+		{
+			name:            "no default addons",
+			defaultAddons:   nil,
+			availableAddons: available,
+			wantErr:         false,
+		},
+		{
+			name:            "default CNI plugin included",
+			defaultAddons:   []string{"canal", "openvpn"},
+			availableAddons: available,
+			wantErr:         false,
+		},
+		{
+			name:            "default CNI plugin left out",
+			defaultAddons:   []string{"dashboard", "openvpn"},
+			availableAddons: available,
+			wantErr:         true,
+		},
+		{
+			name:            "selected CNI plugin included",
+			defaultAddons:   []string{"cilium", "openvpn"},
+			cniPlugin:       kubermaticv1.CNIPluginTypeCilium,
+			availableAddons: available,
+			wantErr:         false,
+		},
+		{
+			name:            "canal included while using cilium",
+			defaultAddons:   []string{"canal", "openvpn"},
+			cniPlugin:       kubermaticv1.CNIPluginTypeCilium,
+			availableAddons: available,
+			wantErr:         true,
+		},
+		{
+			name:            "unknown addon",
+			defaultAddons:   []string{"canal", "does-not-exist"},
+			availableAddons: available,
+			wantErr:         true,
+		},
+		{
+			name:            "CNI not installed as addon",
+			defaultAddons:   []string{"dashboard"},
+			availableAddons: sets.NewString("dashboard"),
+			wantErr:         false,
+		},
+		{
+			name:          "names not checked without configured addons",
+			defaultAddons: []string{"canal", "does-not-exist"},
+			wantErr:       false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateDefaultAddons(test.defaultAddons, test.cniPlugin, test.availableAddons)
+
+			if test.wantErr == (err == nil) {
+				t.Errorf("Want error: %t, but got: \"%v\"", test.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateDisabledAddons(t *testing.T) {
+	tests := []struct {
+		name            string
+		disabledAddons  []string
+		defaultAddons   []string
+		cniPlugin       kubermaticv1.CNIPluginType
+		availableAddons sets.String
+		wantErr         bool
+	}{
+		{
+			name:           "no disabled addons",
+			disabledAddons: nil,
+			wantErr:        false,
+		},
+		{
+			name:           "disabled dashboard",
+			disabledAddons: []string{"dashboard"},
+			wantErr:        false,
+		},
+		{
+			name:           "disabled default CNI plugin",
+			disabledAddons: []string{"dashboard", "canal"},
+			wantErr:        true,
+		},
+		{
+			name:           "disabled selected CNI plugin",
+			disabledAddons: []string{"cilium"},
+			cniPlugin:      kubermaticv1.CNIPluginTypeCilium,
+			wantErr:        true,
+		},
+		{
+			name:           "disabled canal while using cilium",
+			disabledAddons: []string{"canal"},
+			cniPlugin:      kubermaticv1.CNIPluginTypeCilium,
+			wantErr:        false,
+		},
+		{
+			name:            "disabled unknown addon",
+			disabledAddons:  []string{"does-not-exist"},
+			availableAddons: sets.NewString("canal", "dashboard"),
+			wantErr:         true,
+		},
+		{
+			name:           "combined with default addons",
+			disabledAddons: []string{"dashboard"},
+			defaultAddons:  []string{"canal", "openvpn"},
+			wantErr:        true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateDisabledAddons(test.disabledAddons, test.defaultAddons, test.cniPlugin, test.availableAddons)
+
+			if test.wantErr == (err == nil) {
+				t.Errorf("Want error: %t, but got: \"%v\"", test.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateProjectedServiceAccountTokenSettings(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings *kubermaticv1.ProjectedServiceAccountTokenSettings
+		wantErr  bool
+	}{
+		{
+			name:     "no settings",
+			settings: nil,
+			wantErr:  false,
+		},
+		{
+			name:     "default expiration",
+			settings: &kubermaticv1.ProjectedServiceAccountTokenSettings{Audience: "etcd-launcher"},
+			wantErr:  false,
+		},
+		{
+			name:     "minimum expiration",
+			settings: &kubermaticv1.ProjectedServiceAccountTokenSettings{ExpirationSeconds: pointer.Int64Ptr(600)},
+			wantErr:  false,
+		},
+		{
+			name:     "expiration too short",
+			settings: &kubermaticv1.ProjectedServiceAccountTokenSettings{ExpirationSeconds: pointer.Int64Ptr(300)},
+			wantErr:  true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateProjectedServiceAccountTokenSettings(test.settings)
+
+			if test.wantErr == (err == nil) {
+				t.Errorf("Want error: %t, but got: \"%v\"", test.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateOIDCSettings(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings kubermaticv1.OIDCSettings
+		wantErr  bool
+	}{
+		{
+			name:     "no settings",
+			settings: kubermaticv1.OIDCSettings{},
+			wantErr:  false,
+		},
+		{
+			name:     "valid settings",
+			settings: kubermaticv1.OIDCSettings{IssuerURL: "https://dex.example.com/dex", ClientID: "kubernetes", UsernameClaim: "email", GroupsClaim: "groups"},
+			wantErr:  false,
+		},
+		{
+			name:     "http issuer",
+			settings: kubermaticv1.OIDCSettings{IssuerURL: "http://dex.example.com/dex", ClientID: "kubernetes"},
+			wantErr:  true,
+		},
+		{
+			name:     "issuer without host",
+			settings: kubermaticv1.OIDCSettings{IssuerURL: "https:///dex", ClientID: "kubernetes"},
+			wantErr:  true,
+		},
+		{
+			name:     "missing client ID",
+			settings: kubermaticv1.OIDCSettings{IssuerURL: "https://dex.example.com/dex"},
+			wantErr:  true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateOIDCSettings(test.settings)
+
+			if test.wantErr == (err == nil) {
+				t.Errorf("Want error: %t, but got: \"%v\"", test.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateAuditLoggingSettings(t *testing.T) {
+	policy := `apiVersion: audit.k8s.io/v1
+kind: Policy
+rules:
+- level: RequestResponse
+`
+	tests := []struct {
+		name     string
+		settings *kubermaticv1.AuditLoggingSettings
+		wantErr  bool
+	}{
+		{
+			name:     "no settings",
+			settings: nil,
+			wantErr:  false,
+		},
+		{
+			name:     "inline policy",
+			settings: &kubermaticv1.AuditLoggingSettings{Enabled: true, Policy: policy},
+			wantErr:  false,
+		},
+		{
+			name:     "policy ConfigMap",
+			settings: &kubermaticv1.AuditLoggingSettings{Enabled: true, PolicyConfigMapName: "custom-audit-policy"},
+			wantErr:  false,
+		},
+		{
+			name:     "inline policy and policy ConfigMap",
+			settings: &kubermaticv1.AuditLoggingSettings{Enabled: true, Policy: policy, PolicyConfigMapName: "custom-audit-policy"},
+			wantErr:  true,
+		},
+		{
+			name:     "invalid YAML",
+			settings: &kubermaticv1.AuditLoggingSettings{Enabled: true, Policy: "rules: ["},
+			wantErr:  true,
+		},
+		{
+			name:     "wrong kind",
+			settings: &kubermaticv1.AuditLoggingSettings{Enabled: true, Policy: "apiVersion: v1\nkind: ConfigMap\n"},
+			wantErr:  true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateAuditLoggingSettings(test.settings)
+
+			if test.wantErr == (err == nil) {
+				t.Errorf("Want error: %t, but got: \"%v\"", test.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateSchedulerConfiguration(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  string
+		wantErr bool
+	}{
+		{
+			name:    "no configuration",
+			wantErr: false,
+		},
+		{
+			name: "scheduler profiles",
+			config: `apiVersion: kubescheduler.config.k8s.io/v1beta1
+kind: KubeSchedulerConfiguration
+profiles:
+- schedulerName: default-scheduler
+  plugins:
+    score:
+      disabled:
+      - name: NodeResourcesLeastAllocated
+      enabled:
+      - name: NodeResourcesMostAllocated
+`,
+			wantErr: false,
+		},
+		{
+			name:    "invalid YAML",
+			config:  "profiles: [",
+			wantErr: true,
+		},
+		{
+			name:    "scheduler policy",
+			config:  "apiVersion: v1\nkind: Policy\n",
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateSchedulerConfiguration(test.config)
+
+			if test.wantErr == (err == nil) {
+				t.Errorf("Want error: %t, but got: \"%v\"", test.wantErr, err)
+			}
+		})
+	}
+}
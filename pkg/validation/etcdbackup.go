@@ -0,0 +1,35 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"errors"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+)
+
+// ValidateEtcdBackupRetention validates the retention settings of an etcd backup config.
+// Both the number of kept backups and their maximum age must be positive if set.
+func ValidateEtcdBackupRetention(spec kubermaticv1.EtcdBackupConfigSpec) error {
+	if spec.Keep != nil && *spec.Keep <= 0 {
+		return errors.New("the number of kept backups must be positive")
+	}
+	if spec.MaxAge != nil && spec.MaxAge.Duration <= 0 {
+		return errors.New("the maximum age of backups must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2020 The Kubermatic Kubernetes Platform contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"testing"
+	"time"
+
+	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestValidateEtcdBackupRetention(t *testing.T) {
+	keep := func(n int) *int { return &n }
+
+	tests := []struct {
+		name    string
+		spec    kubermaticv1.EtcdBackupConfigSpec
+		wantErr bool
+	}{
+		{
+			name:    "no retention settings",
+			spec:    kubermaticv1.EtcdBackupConfigSpec{},
+			wantErr: false,
+		},
+		{
+			name:    "valid retention settings",
+			spec:    kubermaticv1.EtcdBackupConfigSpec{Keep: keep(10), MaxAge: &metav1.Duration{Duration: 72 * time.Hour}},
+			wantErr: false,
+		},
+		{
+			name:    "zero kept backups",
+			spec:    kubermaticv1.EtcdBackupConfigSpec{Keep: keep(0)},
+			wantErr: true,
+		},
+		{
+			name:    "negative maximum age",
+			spec:    kubermaticv1.EtcdBackupConfigSpec{MaxAge: &metav1.Duration{Duration: -time.Hour}},
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateEtcdBackupRetention(test.spec)
+
+			if test.wantErr == (err == nil) {
+				t.Errorf("Want error: %t, but got: \"%v\"", test.wantErr, err)
+			}
+		})
+	}
+}
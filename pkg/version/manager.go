@@ -40,9 +40,13 @@ type Manager struct {
 
 // Version is the object representing a Kubernetes version.
 type Version struct {
-	Version *semver.Version `json:"version"`
-	Default bool            `json:"default,omitempty"`
-	Type    string          `json:"type,omitempty"`
+	Version    *semver.Version `json:"version"`
+	Default    bool            `json:"default,omitempty"`
+	Deprecated bool            `json:"deprecated,omitempty"`
+	Type       string          `json:"type,omitempty"`
+	// AddonVersions pins the versions of the default addons installed into clusters
+	// of this version, keyed by the addon name.
+	AddonVersions map[string]string `json:"addonVersions,omitempty"`
 }
 
 // Update represents an update option for a cluster
@@ -246,3 +250,66 @@ func (m *Manager) GetPossibleUpdates(fromVersionRaw, clusterType string) ([]*Ver
 
 	return possibleVersions, nil
 }
+
+// ValidateUpdate returns an error if updating from one version to another is not covered by
+// the configured updates. Downgrades are never allowed, no matter the configured updates.
+func (m *Manager) ValidateUpdate(fromVersionRaw, toVersionRaw, clusterType string) error {
+	from, err := semver.NewVersion(fromVersionRaw)
+	if err != nil {
+		return fmt.Errorf("failed to parse version %s: %v", fromVersionRaw, err)
+	}
+	to, err := semver.NewVersion(toVersionRaw)
+	if err != nil {
+		return fmt.Errorf("failed to parse version %s: %v", toVersionRaw, err)
+	}
+
+	if to.Equal(from) {
+		return nil
+	}
+	if to.LessThan(from) {
+		return fmt.Errorf("downgrading from %s to %s is not allowed", from, to)
+	}
+
+	possibleVersions, err := m.GetPossibleUpdates(fromVersionRaw, clusterType)
+	if err != nil {
+		return err
+	}
+	for _, v := range possibleVersions {
+		if v.Version.Equal(to) {
+			if v.Deprecated {
+				return fmt.Errorf("updating to %s is not allowed, the version is deprecated%s", to, m.recommendation(clusterType))
+			}
+			return nil
+		}
+	}
+
+	return fmt.Errorf("updating from %s to %s is not allowed, no matching update is configured", from, to)
+}
+
+// ValidateNewCluster returns an error if new clusters must not be created with the given version.
+// Deprecated versions are rejected, existing clusters running them are not affected. Whether the
+// version is configured at all is up to the caller.
+func (m *Manager) ValidateNewCluster(versionRaw, clusterType string) error {
+	v, err := m.GetVersion(versionRaw, clusterType)
+	if err != nil {
+		if errors.Is(err, errVersionNotFound) {
+			return nil
+		}
+		return err
+	}
+	if v.Deprecated {
+		return fmt.Errorf("version %s is deprecated and cannot be used for new clusters%s", v.Version, m.recommendation(clusterType))
+	}
+	return nil
+}
+
+// recommendation returns a hint pointing to the default version, if it can be used instead of a
+// deprecated version.
+func (m *Manager) recommendation(clusterType string) string {
+	for _, v := range m.versions {
+		if v.Default && !v.Deprecated && v.Type == clusterType {
+			return fmt.Sprintf(", use %s instead", v.Version)
+		}
+	}
+	return ""
+}
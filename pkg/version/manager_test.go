@@ -85,3 +85,135 @@ func TestAutomaticNodeUpdate(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateUpdate(t *testing.T) {
+	m := &Manager{
+		updates: []*Update{
+			{From: "1.18.*", To: "1.18.*", Type: "kubernetes"},
+			{From: "1.18.*", To: "1.19.*", Type: "kubernetes"},
+		},
+		versions: []*Version{
+			{Version: semver.MustParse("1.18.8"), Type: "kubernetes"},
+			{Version: semver.MustParse("1.18.10"), Type: "kubernetes"},
+			{Version: semver.MustParse("1.18.12"), Type: "kubernetes", Deprecated: true},
+			{Version: semver.MustParse("1.19.2"), Type: "kubernetes"},
+			{Version: semver.MustParse("1.20.2"), Type: "kubernetes"},
+		},
+	}
+
+	testCases := []struct {
+		name        string
+		fromVersion string
+		toVersion   string
+		expectError bool
+	}{
+		{
+			name:        "Same version",
+			fromVersion: "1.18.8",
+			toVersion:   "1.18.8",
+		},
+		{
+			name:        "Patch update",
+			fromVersion: "1.18.8",
+			toVersion:   "1.18.10",
+		},
+		{
+			name:        "Minor update",
+			fromVersion: "1.18.10",
+			toVersion:   "1.19.2",
+		},
+		{
+			name:        "Skipping a minor version",
+			fromVersion: "1.18.10",
+			toVersion:   "1.20.2",
+			expectError: true,
+		},
+		{
+			name:        "Downgrade",
+			fromVersion: "1.18.10",
+			toVersion:   "1.18.8",
+			expectError: true,
+		},
+		{
+			name:        "Update without configured updates",
+			fromVersion: "1.19.2",
+			toVersion:   "1.20.2",
+			expectError: true,
+		},
+		{
+			name:        "Update to a deprecated version",
+			fromVersion: "1.18.10",
+			toVersion:   "1.18.12",
+			expectError: true,
+		},
+		{
+			name:        "Update away from a deprecated version",
+			fromVersion: "1.18.12",
+			toVersion:   "1.19.2",
+		},
+		{
+			name:        "Invalid version",
+			fromVersion: "1.18.8",
+			toVersion:   "latest",
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := m.ValidateUpdate(tc.fromVersion, tc.toVersion, "kubernetes")
+			if (err != nil) != tc.expectError {
+				t.Errorf("expected error: %v, got: %v", tc.expectError, err)
+			}
+		})
+	}
+}
+
+func TestValidateNewCluster(t *testing.T) {
+	m := &Manager{
+		versions: []*Version{
+			{Version: semver.MustParse("1.18.8"), Type: "kubernetes", Deprecated: true},
+			{Version: semver.MustParse("1.19.2"), Type: "kubernetes", Default: true},
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		version       string
+		expectedError string
+	}{
+		{
+			name:    "Supported version",
+			version: "1.19.2",
+		},
+		{
+			name:          "Deprecated version",
+			version:       "1.18.8",
+			expectedError: "version 1.18.8 is deprecated and cannot be used for new clusters, use 1.19.2 instead",
+		},
+		{
+			name:    "Unknown version",
+			version: "1.20.2",
+		},
+		{
+			name:          "Invalid version",
+			version:       "latest",
+			expectedError: "failed to parse version latest: Invalid Semantic Version",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := m.ValidateNewCluster(tc.version, "kubernetes")
+			if tc.expectedError == "" {
+				if err != nil {
+					t.Fatalf("expected no error, got: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tc.expectedError {
+				t.Errorf("expected error %q, got: %v", tc.expectedError, err)
+			}
+		})
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"reflect"
 
 	"github.com/go-logr/logr"
 
@@ -29,8 +30,10 @@ import (
 	"k8c.io/kubermatic/v2/pkg/validation"
 
 	admissionv1 "k8s.io/api/admission/v1"
+	storagev1 "k8s.io/api/storage/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	ctrlruntime "sigs.k8s.io/controller-runtime"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
@@ -43,13 +46,16 @@ type AdmissionHandler struct {
 	decoder  *admission.Decoder
 	features features.FeatureGate
 	client   ctrlruntimeclient.Client
+	// defaultAddons are the names of the default addons configured for the seed.
+	defaultAddons sets.String
 }
 
 // NewAdmissionHandler returns a new cluster validation AdmissionHandler.
-func NewAdmissionHandler(client ctrlruntimeclient.Client, features features.FeatureGate) *AdmissionHandler {
+func NewAdmissionHandler(client ctrlruntimeclient.Client, features features.FeatureGate, defaultAddons sets.String) *AdmissionHandler {
 	return &AdmissionHandler{
-		features: features,
-		client:   client,
+		features:      features,
+		client:        client,
+		defaultAddons: defaultAddons,
 	}
 }
 
@@ -108,6 +114,84 @@ func (h *AdmissionHandler) validateCreateOrUpdate(ctx context.Context, c *kuberm
 	if err := validation.ValidateLeaderElectionSettings(c.Spec.ComponentsOverride.Scheduler.LeaderElectionSettings); err != nil {
 		return fmt.Errorf("scheduler leader election settings are not valid: %w", err)
 	}
+	if err := validation.ValidateRootCASettings(c.Spec.RootCA); err != nil {
+		return fmt.Errorf("root CA settings are not valid: %w", err)
+	}
+	if err := validation.ValidateCertificateKeyAlgorithm(c.Spec.ComponentsOverride.Apiserver.CertificateKeyAlgorithm); err != nil {
+		return fmt.Errorf("apiserver certificate settings are not valid: %w", err)
+	}
+	if err := validation.ValidateCertSANs(c.Spec.ComponentsOverride.Apiserver.CertSANs); err != nil {
+		return fmt.Errorf("apiserver certificate SANs are not valid: %w", err)
+	}
+	if err := validation.ValidateKeySettings(c.Spec.ComponentsOverride.Apiserver.ServiceAccountKeyAlgorithm, c.Spec.ComponentsOverride.Apiserver.ServiceAccountKeySize); err != nil {
+		return fmt.Errorf("apiserver service account key settings are not valid: %w", err)
+	}
+	if err := validation.ValidateAPIServerServiceSettings(c.Spec.ComponentsOverride.Apiserver.Service); err != nil {
+		return fmt.Errorf("apiserver service settings are not valid: %w", err)
+	}
+	if s := c.Spec.ComponentsOverride.Apiserver.Service; s != nil && s.Type != "" && c.Spec.ExposeStrategy != kubermaticv1.ExposeStrategyNodePort {
+		return fmt.Errorf("the apiserver service type can only be set with the %s expose strategy", kubermaticv1.ExposeStrategyNodePort)
+	}
+	if s := c.Spec.ComponentsOverride.Apiserver.Service; s != nil && s.NodePort != 0 && c.Spec.ExposeStrategy == kubermaticv1.ExposeStrategyTunneling {
+		return fmt.Errorf("the apiserver NodePort can not be set with the %s expose strategy", kubermaticv1.ExposeStrategyTunneling)
+	}
+	if err := validation.ValidateAPIServerExtraVolumes(c.Spec.ComponentsOverride.Apiserver.ExtraVolumes); err != nil {
+		return fmt.Errorf("apiserver extra volumes are not valid: %w", err)
+	}
+	if err := validation.ValidateExtraArgs(c.Spec.ComponentsOverride.Apiserver.ExtraArgs, validation.ApiserverProtectedFlags); err != nil {
+		return fmt.Errorf("apiserver extra args are not valid: %w", err)
+	}
+	if err := validation.ValidateExtraArgs(c.Spec.ComponentsOverride.ControllerManager.ExtraArgs, validation.ControllerManagerProtectedFlags); err != nil {
+		return fmt.Errorf("controller manager extra args are not valid: %w", err)
+	}
+	if err := validation.ValidateSchedulerConfiguration(c.Spec.ComponentsOverride.Scheduler.Configuration); err != nil {
+		return fmt.Errorf("scheduler configuration is not valid: %w", err)
+	}
+	if err := validation.ValidateExtraArgs(c.Spec.ComponentsOverride.Scheduler.ExtraArgs, validation.SchedulerProtectedFlags); err != nil {
+		return fmt.Errorf("scheduler extra args are not valid: %w", err)
+	}
+	if err := validation.ValidateOIDCSettings(c.Spec.OIDC); err != nil {
+		return fmt.Errorf("OIDC settings are not valid: %w", err)
+	}
+	if err := validation.ValidateAuditLoggingSettings(c.Spec.AuditLogging); err != nil {
+		return fmt.Errorf("audit logging settings are not valid: %w", err)
+	}
+	if err := validation.ValidateEncryptionConfiguration(c.Spec.EncryptionConfiguration); err != nil {
+		return fmt.Errorf("encryption configuration is not valid: %w", err)
+	}
+	if err := validation.ValidateCNIPlugin(c.Spec.ClusterNetwork.CNIPlugin); err != nil {
+		return fmt.Errorf("cluster network settings are not valid: %w", err)
+	}
+	if err := validation.ValidateClusterNetwork(c.Spec.ClusterNetwork); err != nil {
+		return fmt.Errorf("cluster network settings are not valid: %w", err)
+	}
+	if err := validation.ValidateNetworkOverlap(c.Spec.ClusterNetwork, nil); err != nil {
+		return fmt.Errorf("cluster network settings are not valid: %w", err)
+	}
+	if err := validation.ValidateDefaultAddons(c.Spec.DefaultAddons, c.Spec.ClusterNetwork.CNIPlugin, h.defaultAddons); err != nil {
+		return fmt.Errorf("default addons are not valid: %w", err)
+	}
+	if err := validation.ValidateDisabledAddons(c.Spec.DisabledAddons, c.Spec.DefaultAddons, c.Spec.ClusterNetwork.CNIPlugin, h.defaultAddons); err != nil {
+		return fmt.Errorf("disabled addons are not valid: %w", err)
+	}
+	if err := validation.ValidateEtcdClusterSize(c.Spec.ComponentsOverride.Etcd.ClusterSize); err != nil {
+		return fmt.Errorf("etcd settings are not valid: %w", err)
+	}
+	if err := validation.ValidateImageTag(c.Spec.ComponentsOverride.Etcd.ImageTag); err != nil {
+		return fmt.Errorf("etcd settings are not valid: %w", err)
+	}
+	if err := validation.ValidateProjectedServiceAccountTokenSettings(c.Spec.ComponentsOverride.Etcd.ServiceAccountToken); err != nil {
+		return fmt.Errorf("etcd service account token settings are not valid: %w", err)
+	}
+	if err := validation.ValidateEtcdDiskSize(c.Spec.ComponentsOverride.Etcd.DiskSize); err != nil {
+		return fmt.Errorf("etcd settings are not valid: %w", err)
+	}
+	if err := h.validateEtcdStorageClass(ctx, c); err != nil {
+		return fmt.Errorf("etcd settings are not valid: %w", err)
+	}
+	if s := c.Spec.RestoreFromSnapshot; s != nil && s.BackupName == "" {
+		return errors.New("etcd snapshot to restore from must have a backup name")
+	}
 
 	if err := h.rejectUserSSHKeyAgentChanges(ctx, c); err != nil {
 		h.log.Info("cluster admission failed", "error", err)
@@ -134,13 +218,84 @@ func (h *AdmissionHandler) validateUpdateImmutability(req webhook.AdmissionReque
 		return fmt.Errorf("feature gate %q cannot be disabled once it's enabled", kubermaticv1.ClusterFeatureExternalCloudProvider)
 	}
 
+	// Secrets written with encryption enabled could not be read anymore without it.
+	if encryptionEnabled(oldCluster) && !encryptionEnabled(c) {
+		return errors.New("encryption of secrets cannot be disabled once it's enabled")
+	}
+
+	// Switching the CNI plugin would leave the nodes with two conflicting pod networks.
+	if cniPlugin(oldCluster) != cniPlugin(c) {
+		return errors.New("the CNI plugin cannot be changed after cluster creation")
+	}
+
+	// Pods and services keep their addresses, so the networks cannot be changed once they
+	// have been set on creation or defaulted by the cluster controller.
+	if networkRangesChanged(oldCluster.Spec.ClusterNetwork.Pods, c.Spec.ClusterNetwork.Pods) ||
+		networkRangesChanged(oldCluster.Spec.ClusterNetwork.Services, c.Spec.ClusterNetwork.Services) {
+		return errors.New("the pod and service networks cannot be changed after cluster creation")
+	}
+	if !reflect.DeepEqual(oldCluster.Spec.ClusterNetwork.IPFamilies, c.Spec.ClusterNetwork.IPFamilies) {
+		return errors.New("the IP families cannot be changed after cluster creation")
+	}
+
+	// The snapshot can only be removed after creation, the etcd of a running
+	// cluster must not be replaced by a snapshot this way.
+	if c.Spec.RestoreFromSnapshot != nil && !reflect.DeepEqual(oldCluster.Spec.RestoreFromSnapshot, c.Spec.RestoreFromSnapshot) {
+		return errors.New("restoring etcd from a snapshot can only be requested on cluster creation")
+	}
+
 	return nil
 }
 
+// cniPlugin returns the CNI plugin of the cluster, an empty value is the default canal plugin.
+func cniPlugin(c *kubermaticv1.Cluster) kubermaticv1.CNIPluginType {
+	if c.Spec.ClusterNetwork.CNIPlugin == "" {
+		return kubermaticv1.CNIPluginTypeCanal
+	}
+	return c.Spec.ClusterNetwork.CNIPlugin
+}
+
+// networkRangesChanged returns true if network ranges which have already been set are changed.
+func networkRangesChanged(oldRanges, newRanges kubermaticv1.NetworkRanges) bool {
+	return len(oldRanges.CIDRBlocks) > 0 && !reflect.DeepEqual(oldRanges.CIDRBlocks, newRanges.CIDRBlocks)
+}
+
+func encryptionEnabled(c *kubermaticv1.Cluster) bool {
+	return c.Spec.EncryptionConfiguration != nil && c.Spec.EncryptionConfiguration.Enabled
+}
+
 func (h *AdmissionHandler) SetupWebhookWithManager(mgr ctrlruntime.Manager) {
 	mgr.GetWebhookServer().Register("/validate-kubermatic-k8s-io-cluster", &webhook.Admission{Handler: h})
 }
 
+// validateEtcdStorageClass makes sure the storage class of the etcd volumes exists on the seed.
+// The class is only used when the volumes are created, so clusters which keep their storage
+// class are not rejected if it has been removed from the seed in the meantime.
+func (h *AdmissionHandler) validateEtcdStorageClass(ctx context.Context, cluster *kubermaticv1.Cluster) error {
+	storageClass := cluster.Spec.ComponentsOverride.Etcd.StorageClass
+	if h.client == nil || storageClass == "" {
+		return nil
+	}
+
+	oldCluster := &kubermaticv1.Cluster{}
+	if err := h.client.Get(ctx, types.NamespacedName{Name: cluster.Name}, oldCluster); err != nil {
+		if !kerrors.IsNotFound(err) {
+			return fmt.Errorf("failed to fetch cluster name=%s: %v", cluster.Name, err)
+		}
+	} else if oldCluster.Spec.ComponentsOverride.Etcd.StorageClass == storageClass {
+		return nil
+	}
+
+	if err := h.client.Get(ctx, types.NamespacedName{Name: storageClass}, &storagev1.StorageClass{}); err != nil {
+		if kerrors.IsNotFound(err) {
+			return fmt.Errorf("storage class %q does not exist", storageClass)
+		}
+		return fmt.Errorf("failed to fetch storage class %q: %v", storageClass, err)
+	}
+
+	return nil
+}
+
 func (h *AdmissionHandler) rejectUserSSHKeyAgentChanges(ctx context.Context, cluster *kubermaticv1.Cluster) error {
 	var (
 		oldCluster = &kubermaticv1.Cluster{}
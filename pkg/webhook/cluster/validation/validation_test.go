@@ -28,8 +28,10 @@ import (
 	"k8c.io/kubermatic/v2/pkg/features"
 
 	admissionv1 "k8s.io/api/admission/v1"
+	storagev1 "k8s.io/api/storage/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 	ctrlruntimefakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
@@ -46,11 +48,12 @@ func init() {
 
 func TestHandle(t *testing.T) {
 	tests := []struct {
-		name        string
-		req         webhook.AdmissionRequest
-		wantAllowed bool
-		features    features.FeatureGate
-		client      ctrlruntimeclient.Client
+		name          string
+		req           webhook.AdmissionRequest
+		wantAllowed   bool
+		features      features.FeatureGate
+		client        ctrlruntimeclient.Client
+		defaultAddons sets.String
 	}{
 		{
 			name: "Delete cluster success",
@@ -326,6 +329,293 @@ func TestHandle(t *testing.T) {
 				},
 			).Build(),
 		},
+		{
+			name: "Reject unknown CNI plugin",
+			req: webhook.AdmissionRequest{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation: admissionv1.Create,
+					RequestKind: &metav1.GroupVersionKind{
+						Group:   kubermaticv1.GroupName,
+						Version: kubermaticv1.GroupVersion,
+						Kind:    "Cluster",
+					},
+					Name: "foo",
+					Object: runtime.RawExtension{
+						Raw: rawClusterGen{Name: "foo", Namespace: "kubermatic", ExposeStrategy: "NodePort", CNIPlugin: "weave"}.Do(),
+					},
+				},
+			},
+			wantAllowed: false,
+		},
+		{
+			name: "Accept keeping the default CNI plugin",
+			req: webhook.AdmissionRequest{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation: admissionv1.Update,
+					RequestKind: &metav1.GroupVersionKind{
+						Group:   kubermaticv1.GroupName,
+						Version: kubermaticv1.GroupVersion,
+						Kind:    "Cluster",
+					},
+					Name: "foo",
+					Object: runtime.RawExtension{
+						Raw: rawClusterGen{Name: "foo", Namespace: "kubermatic", ExposeStrategy: "NodePort", CNIPlugin: "canal"}.Do(),
+					},
+					OldObject: runtime.RawExtension{
+						Raw: rawClusterGen{Name: "foo", Namespace: "kubermatic", ExposeStrategy: "NodePort"}.Do(),
+					},
+				},
+			},
+			wantAllowed: true,
+		},
+		{
+			name: "Reject changing the CNI plugin",
+			req: webhook.AdmissionRequest{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation: admissionv1.Update,
+					RequestKind: &metav1.GroupVersionKind{
+						Group:   kubermaticv1.GroupName,
+						Version: kubermaticv1.GroupVersion,
+						Kind:    "Cluster",
+					},
+					Name: "foo",
+					Object: runtime.RawExtension{
+						Raw: rawClusterGen{Name: "foo", Namespace: "kubermatic", ExposeStrategy: "NodePort", CNIPlugin: "cilium"}.Do(),
+					},
+					OldObject: runtime.RawExtension{
+						Raw: rawClusterGen{Name: "foo", Namespace: "kubermatic", ExposeStrategy: "NodePort"}.Do(),
+					},
+				},
+			},
+			wantAllowed: false,
+		},
+		{
+			name: "Reject requesting an etcd restore for an existing cluster",
+			req: webhook.AdmissionRequest{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation: admissionv1.Update,
+					RequestKind: &metav1.GroupVersionKind{
+						Group:   kubermaticv1.GroupName,
+						Version: kubermaticv1.GroupVersion,
+						Kind:    "Cluster",
+					},
+					Name: "foo",
+					Object: runtime.RawExtension{
+						Raw: rawClusterGen{Name: "foo", Namespace: "kubermatic", ExposeStrategy: "NodePort", RestoreFromSnapshot: "daily"}.Do(),
+					},
+					OldObject: runtime.RawExtension{
+						Raw: rawClusterGen{Name: "foo", Namespace: "kubermatic", ExposeStrategy: "NodePort"}.Do(),
+					},
+				},
+			},
+			wantAllowed: false,
+		},
+		{
+			name: "Accept removing the etcd restore request",
+			req: webhook.AdmissionRequest{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation: admissionv1.Update,
+					RequestKind: &metav1.GroupVersionKind{
+						Group:   kubermaticv1.GroupName,
+						Version: kubermaticv1.GroupVersion,
+						Kind:    "Cluster",
+					},
+					Name: "foo",
+					Object: runtime.RawExtension{
+						Raw: rawClusterGen{Name: "foo", Namespace: "kubermatic", ExposeStrategy: "NodePort"}.Do(),
+					},
+					OldObject: runtime.RawExtension{
+						Raw: rawClusterGen{Name: "foo", Namespace: "kubermatic", ExposeStrategy: "NodePort", RestoreFromSnapshot: "daily"}.Do(),
+					},
+				},
+			},
+			wantAllowed: true,
+		},
+		{
+			name: "Accept enabling the encryption of secrets",
+			req: webhook.AdmissionRequest{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation: admissionv1.Update,
+					RequestKind: &metav1.GroupVersionKind{
+						Group:   kubermaticv1.GroupName,
+						Version: kubermaticv1.GroupVersion,
+						Kind:    "Cluster",
+					},
+					Name: "foo",
+					Object: runtime.RawExtension{
+						Raw: rawClusterGen{Name: "foo", Namespace: "kubermatic", ExposeStrategy: "NodePort", EncryptionEnabled: true}.Do(),
+					},
+					OldObject: runtime.RawExtension{
+						Raw: rawClusterGen{Name: "foo", Namespace: "kubermatic", ExposeStrategy: "NodePort"}.Do(),
+					},
+				},
+			},
+			wantAllowed: true,
+		},
+		{
+			name: "Reject disabling the encryption of secrets",
+			req: webhook.AdmissionRequest{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation: admissionv1.Update,
+					RequestKind: &metav1.GroupVersionKind{
+						Group:   kubermaticv1.GroupName,
+						Version: kubermaticv1.GroupVersion,
+						Kind:    "Cluster",
+					},
+					Name: "foo",
+					Object: runtime.RawExtension{
+						Raw: rawClusterGen{Name: "foo", Namespace: "kubermatic", ExposeStrategy: "NodePort"}.Do(),
+					},
+					OldObject: runtime.RawExtension{
+						Raw: rawClusterGen{Name: "foo", Namespace: "kubermatic", ExposeStrategy: "NodePort", EncryptionEnabled: true}.Do(),
+					},
+				},
+			},
+			wantAllowed: false,
+		},
+		{
+			name: "Accept defaulting the pod network",
+			req: webhook.AdmissionRequest{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation: admissionv1.Update,
+					RequestKind: &metav1.GroupVersionKind{
+						Group:   kubermaticv1.GroupName,
+						Version: kubermaticv1.GroupVersion,
+						Kind:    "Cluster",
+					},
+					Name: "foo",
+					Object: runtime.RawExtension{
+						Raw: rawClusterGen{Name: "foo", Namespace: "kubermatic", ExposeStrategy: "NodePort", PodCIDR: "172.25.0.0/16"}.Do(),
+					},
+					OldObject: runtime.RawExtension{
+						Raw: rawClusterGen{Name: "foo", Namespace: "kubermatic", ExposeStrategy: "NodePort"}.Do(),
+					},
+				},
+			},
+			wantAllowed: true,
+		},
+		{
+			name: "Reject changing the pod network",
+			req: webhook.AdmissionRequest{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation: admissionv1.Update,
+					RequestKind: &metav1.GroupVersionKind{
+						Group:   kubermaticv1.GroupName,
+						Version: kubermaticv1.GroupVersion,
+						Kind:    "Cluster",
+					},
+					Name: "foo",
+					Object: runtime.RawExtension{
+						Raw: rawClusterGen{Name: "foo", Namespace: "kubermatic", ExposeStrategy: "NodePort", PodCIDR: "172.26.0.0/16"}.Do(),
+					},
+					OldObject: runtime.RawExtension{
+						Raw: rawClusterGen{Name: "foo", Namespace: "kubermatic", ExposeStrategy: "NodePort", PodCIDR: "172.25.0.0/16"}.Do(),
+					},
+				},
+			},
+			wantAllowed: false,
+		},
+		{
+			name: "Reject a cluster create request with a missing etcd storage class",
+			req: webhook.AdmissionRequest{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation: admissionv1.Create,
+					RequestKind: &metav1.GroupVersionKind{
+						Group:   kubermaticv1.GroupName,
+						Version: kubermaticv1.GroupVersion,
+						Kind:    "Cluster",
+					},
+					Name: "foo",
+					Object: runtime.RawExtension{
+						Raw: rawClusterGen{Name: "foo", Namespace: "kubermatic", ExposeStrategy: "NodePort", EtcdStorageClass: "fast-ssd"}.Do(),
+					},
+				},
+			},
+			wantAllowed: false,
+			client:      ctrlruntimefakeclient.NewClientBuilder().Build(),
+		},
+		{
+			name: "Accept a cluster create request with an existing etcd storage class",
+			req: webhook.AdmissionRequest{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation: admissionv1.Create,
+					RequestKind: &metav1.GroupVersionKind{
+						Group:   kubermaticv1.GroupName,
+						Version: kubermaticv1.GroupVersion,
+						Kind:    "Cluster",
+					},
+					Name: "foo",
+					Object: runtime.RawExtension{
+						Raw: rawClusterGen{Name: "foo", Namespace: "kubermatic", ExposeStrategy: "NodePort", EtcdStorageClass: "fast-ssd"}.Do(),
+					},
+				},
+			},
+			wantAllowed: true,
+			client: ctrlruntimefakeclient.NewClientBuilder().WithObjects(
+				&storagev1.StorageClass{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "fast-ssd",
+					},
+				},
+			).Build(),
+		},
+		{
+			name: "Accept a cluster create request with default addons including the CNI addon",
+			req: webhook.AdmissionRequest{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation: admissionv1.Create,
+					RequestKind: &metav1.GroupVersionKind{
+						Group:   kubermaticv1.GroupName,
+						Version: kubermaticv1.GroupVersion,
+						Kind:    "Cluster",
+					},
+					Name: "foo",
+					Object: runtime.RawExtension{
+						Raw: rawClusterGen{Name: "foo", Namespace: "kubermatic", ExposeStrategy: "NodePort", DefaultAddons: []string{"canal", "openvpn"}}.Do(),
+					},
+				},
+			},
+			wantAllowed:   true,
+			defaultAddons: sets.NewString("canal", "dashboard", "openvpn"),
+		},
+		{
+			name: "Reject a cluster create request with default addons leaving out the CNI addon",
+			req: webhook.AdmissionRequest{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation: admissionv1.Create,
+					RequestKind: &metav1.GroupVersionKind{
+						Group:   kubermaticv1.GroupName,
+						Version: kubermaticv1.GroupVersion,
+						Kind:    "Cluster",
+					},
+					Name: "foo",
+					Object: runtime.RawExtension{
+						Raw: rawClusterGen{Name: "foo", Namespace: "kubermatic", ExposeStrategy: "NodePort", DefaultAddons: []string{"openvpn"}}.Do(),
+					},
+				},
+			},
+			wantAllowed:   false,
+			defaultAddons: sets.NewString("canal", "dashboard", "openvpn"),
+		},
+		{
+			name: "Reject a cluster create request with an unknown default addon",
+			req: webhook.AdmissionRequest{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					Operation: admissionv1.Create,
+					RequestKind: &metav1.GroupVersionKind{
+						Group:   kubermaticv1.GroupName,
+						Version: kubermaticv1.GroupVersion,
+						Kind:    "Cluster",
+					},
+					Name: "foo",
+					Object: runtime.RawExtension{
+						Raw: rawClusterGen{Name: "foo", Namespace: "kubermatic", ExposeStrategy: "NodePort", DefaultAddons: []string{"canal", "does-not-exist"}}.Do(),
+					},
+				},
+			},
+			wantAllowed:   false,
+			defaultAddons: sets.NewString("canal", "dashboard", "openvpn"),
+		},
 	}
 	for _, tt := range tests {
 		d, err := admission.NewDecoder(testScheme)
@@ -333,10 +623,11 @@ func TestHandle(t *testing.T) {
 			t.Fatalf("error occurred while creating decoder: %v", err)
 		}
 		handler := AdmissionHandler{
-			log:      &logrtesting.NullLogger{},
-			decoder:  d,
-			features: tt.features,
-			client:   tt.client,
+			log:           &logrtesting.NullLogger{},
+			decoder:       d,
+			features:      tt.features,
+			client:        tt.client,
+			defaultAddons: tt.defaultAddons,
 		}
 		t.Run(tt.name, func(t *testing.T) {
 			if res := handler.Handle(context.TODO(), tt.req); res.Allowed != tt.wantAllowed {
@@ -353,6 +644,12 @@ type rawClusterGen struct {
 	ExposeStrategy        string
 	EnableUserSSHKey      bool
 	ExternalCloudProvider bool
+	CNIPlugin             string
+	RestoreFromSnapshot   string
+	EncryptionEnabled     bool
+	PodCIDR               string
+	EtcdStorageClass      string
+	DefaultAddons         []string
 }
 
 func (r rawClusterGen) Do() []byte {
@@ -365,7 +662,25 @@ func (r rawClusterGen) Do() []byte {
   },
   "spec": {
 	"exposeStrategy": "{{ .ExposeStrategy }}",
-	"enableUserSSHKey": {{ .EnableUserSSHKey }},
+	"clusterNetwork": {
+		"cniPlugin": "{{ .CNIPlugin }}"{{ if .PodCIDR }},
+		"pods": {
+			"cidrBlocks": ["{{ .PodCIDR }}"]
+		}{{ end }}
+	},
+	"enableUserSSHKey": {{ .EnableUserSSHKey }},{{ if .DefaultAddons }}
+	"defaultAddons": [{{ range $i, $addon := .DefaultAddons }}{{ if $i }}, {{ end }}"{{ $addon }}"{{ end }}],{{ end }}{{ if .EtcdStorageClass }}
+	"componentsOverride": {
+		"etcd": {
+			"storageClass": "{{ .EtcdStorageClass }}"
+		}
+	},{{ end }}{{ if .RestoreFromSnapshot }}
+	"restoreFromSnapshot": {
+		"backupName": "{{ .RestoreFromSnapshot }}"
+	},{{ end }}{{ if .EncryptionEnabled }}
+	"encryptionConfiguration": {
+		"enabled": true
+	},{{ end }}
 	"features": {
 		"externalCloudProvider": {{ .ExternalCloudProvider }}
 	}
@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 
+	"k8s.io/apimachinery/pkg/util/net"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 
 	kubermaticv1 "k8c.io/kubermatic/v2/pkg/crd/kubermatic/v1"
@@ -34,6 +35,7 @@ type ValidationHandlerBuilder struct {
 	seedName            string
 	singleSeedValidator *ensureSingleSeedValidatorWrapper
 	features            features.FeatureGate
+	seedNodePortRange   *net.PortRange
 }
 
 // Client sets the client to get API resources on the cluster the handler is
@@ -49,6 +51,13 @@ func (v *ValidationHandlerBuilder) FeatureGates(features features.FeatureGate) *
 	return v
 }
 
+// SeedNodePortRange sets the NodePort range of the Seed cluster the admission handler is used for,
+// the NodePort ranges of its datacenters must be within it.
+func (v *ValidationHandlerBuilder) SeedNodePortRange(portRange net.PortRange) *ValidationHandlerBuilder {
+	v.seedNodePortRange = &portRange
+	return v
+}
+
 // WorkerName sets the workerName value to be used to list clusters.
 // TODO(irozzo) check how this is useful.
 func (v *ValidationHandlerBuilder) WorkerName(workerName string) *ValidationHandlerBuilder {
@@ -93,6 +102,7 @@ func (v *ValidationHandlerBuilder) Build(ctx context.Context) (AdmissionHandler,
 		v.client,
 		seedClientGetter,
 		v.features,
+		v.seedNodePortRange,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("error occurred while creating seed validator: %v", err)
@@ -26,8 +26,10 @@ import (
 	"k8c.io/kubermatic/v2/pkg/features"
 	"k8c.io/kubermatic/v2/pkg/provider"
 	"k8c.io/kubermatic/v2/pkg/util/workerlabel"
+	"k8c.io/kubermatic/v2/pkg/validation"
 
 	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/util/net"
 	"k8s.io/apimachinery/pkg/util/sets"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -42,6 +44,7 @@ func newSeedValidator(
 	client ctrlruntimeclient.Client,
 	seedClientGetter provider.SeedClientGetter,
 	features features.FeatureGate,
+	seedNodePortRange *net.PortRange,
 ) (*validator, error) {
 	labelSelector, err := workerlabel.LabelSelector(workerName)
 	if err != nil {
@@ -49,11 +52,12 @@ func newSeedValidator(
 	}
 	listOpts := &ctrlruntimeclient.ListOptions{LabelSelector: labelSelector}
 	return &validator{
-		client:           client,
-		seedClientGetter: seedClientGetter,
-		lock:             &sync.Mutex{},
-		listOpts:         listOpts,
-		features:         features,
+		client:            client,
+		seedClientGetter:  seedClientGetter,
+		lock:              &sync.Mutex{},
+		listOpts:          listOpts,
+		features:          features,
+		seedNodePortRange: seedNodePortRange,
 	}, nil
 }
 
@@ -67,6 +71,8 @@ type validator struct {
 	// Can be used to insert a labelSelector
 	listOpts *ctrlruntimeclient.ListOptions
 	features features.FeatureGate
+	// NodePort range of the seed the validator is used for, nil in the master cluster
+	seedNodePortRange *net.PortRange
 }
 
 // Validate returns an error if the given seed does not pass all validation steps.
@@ -148,6 +154,24 @@ func (v *validator) validate(ctx context.Context, subject *kubermaticv1.Seed, se
 		if providerName == "" {
 			return fmt.Errorf("datacenter %q has no provider defined", dcName)
 		}
+		if dc.Spec.NodePortRange != "" {
+			portRange, err := net.ParsePortRange(dc.Spec.NodePortRange)
+			if err != nil {
+				return fmt.Errorf("datacenter %q has an invalid NodePort range: %v", dcName, err)
+			}
+			if r := v.seedNodePortRange; r != nil && (!r.Contains(portRange.Base) || !r.Contains(portRange.Base+portRange.Size-1)) {
+				return fmt.Errorf("datacenter %q has a NodePort range %s not within the NodePort range %s of the seed", dcName, portRange.String(), r.String())
+			}
+		}
+		if err := validation.ValidateCIDRBlocks(dc.Spec.ReservedCIDRBlocks); err != nil {
+			return fmt.Errorf("datacenter %q has invalid reserved CIDR blocks: %v", dcName, err)
+		}
+		if err := validation.ValidateAPIServerServiceSettings(dc.Spec.APIServerService); err != nil {
+			return fmt.Errorf("datacenter %q has invalid apiserver service settings: %v", dcName, err)
+		}
+		if s := dc.Spec.APIServerService; s != nil && s.NodePort != 0 {
+			return fmt.Errorf("datacenter %q must not request an apiserver NodePort, it can only be set per cluster", dcName)
+		}
 
 		if existingSeed == nil {
 			continue
@@ -27,6 +27,7 @@ import (
 
 	admissionv1 "k8s.io/api/admission/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/net"
 	"k8s.io/client-go/kubernetes/scheme"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -38,13 +39,14 @@ func TestValidate(t *testing.T) {
 	}
 
 	testCases := []struct {
-		name             string
-		seedToValidate   *kubermaticv1.Seed
-		existingSeeds    []*kubermaticv1.Seed
-		existingClusters []*kubermaticv1.Cluster
-		features         features.FeatureGate
-		isDelete         bool
-		errExpected      bool
+		name              string
+		seedToValidate    *kubermaticv1.Seed
+		existingSeeds     []*kubermaticv1.Seed
+		existingClusters  []*kubermaticv1.Cluster
+		features          features.FeatureGate
+		seedNodePortRange *net.PortRange
+		isDelete          bool
+		errExpected       bool
 	}{
 		{
 			name:           "Adding an empty seed should be possible",
@@ -442,6 +444,45 @@ func TestValidate(t *testing.T) {
 			},
 			features: features.FeatureGate{features.TunnelingExposeStrategy: true},
 		},
+		{
+			name: "Adding a datacenter with a NodePort range within the seed range should succeed",
+			seedToValidate: &kubermaticv1.Seed{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "new-seed",
+				},
+				Spec: kubermaticv1.SeedSpec{
+					Datacenters: map[string]kubermaticv1.Datacenter{
+						"dc1": {
+							Spec: kubermaticv1.DatacenterSpec{
+								Fake:          &kubermaticv1.DatacenterSpecFake{},
+								NodePortRange: "31000-31999",
+							},
+						},
+					},
+				},
+			},
+			seedNodePortRange: &net.PortRange{Base: 30000, Size: 2768},
+		},
+		{
+			name: "Adding a datacenter with a NodePort range outside of the seed range should fail",
+			seedToValidate: &kubermaticv1.Seed{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "new-seed",
+				},
+				Spec: kubermaticv1.SeedSpec{
+					Datacenters: map[string]kubermaticv1.Datacenter{
+						"dc1": {
+							Spec: kubermaticv1.DatacenterSpec{
+								Fake:          &kubermaticv1.DatacenterSpecFake{},
+								NodePortRange: "32000-32999",
+							},
+						},
+					},
+				},
+			},
+			seedNodePortRange: &net.PortRange{Base: 30000, Size: 2768},
+			errExpected:       true,
+		},
 		{
 			name: "Adding a seed with TunnelingExposeStrategy should fail when feature gate is not enabled",
 			seedToValidate: &kubermaticv1.Seed{
@@ -473,10 +514,11 @@ func TestValidate(t *testing.T) {
 				Build()
 
 			sv := &validator{
-				lock:     &sync.Mutex{},
-				listOpts: &ctrlruntimeclient.ListOptions{},
-				client:   client,
-				features: tc.features,
+				lock:              &sync.Mutex{},
+				listOpts:          &ctrlruntimeclient.ListOptions{},
+				client:            client,
+				features:          tc.features,
+				seedNodePortRange: tc.seedNodePortRange,
 				seedClientGetter: func(seed *kubermaticv1.Seed) (ctrlruntimeclient.Client, error) {
 					return client, nil
 				},